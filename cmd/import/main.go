@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dewciu/f1_api/pkg/config"
+	"github.com/dewciu/f1_api/pkg/database"
+	"github.com/dewciu/f1_api/pkg/importer"
+	"github.com/dewciu/f1_api/pkg/migrations"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Imports the CSV dump of the Ergast database or the JSON files of an OpenF1
+// export from a local directory:
+//
+//	import [-config app-config.yaml] [-source ergast] [-batch 5000] <directory>
+func main() {
+	configPath := flag.String("config", "app-config.yaml", "path to the configuration file")
+	source := flag.String("source", "ergast", "dataset in the directory, ergast or openf1")
+	batchSize := flag.Int("batch", importer.DefaultBatchSize, "number of rows upserted in a single transaction")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] <directory>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 || *batchSize < 1 || (*source != "ergast" && *source != importer.SourceOpenF1) {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := flag.Arg(0)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		logrus.Fatalf("%s is not a directory", dir)
+	}
+
+	config.CONFIG_PATH = *configPath
+	conf, err := config.GetConfig()
+	if err != nil {
+		logrus.Fatalf("Failed to get configuration: %v", err)
+	}
+
+	DB, err := database.Connect(conf)
+	if err != nil {
+		logrus.Fatalf("Failed to connect to DB: %v", err)
+	}
+	defer database.Disconnect(DB)
+
+	if err = migrations.Migrate(DB); err != nil {
+		logrus.Fatalf("Failed to migrate DB: %v", err)
+	}
+
+	var summary importer.Summary
+	if *source == importer.SourceOpenF1 {
+		summary, err = importOpenF1(DB, dir)
+	} else {
+		imp := importer.NewErgastImporter(DB, dir)
+		imp.BatchSize = *batchSize
+		summary, err = imp.Run()
+	}
+	summary.Print(os.Stdout)
+	if err != nil {
+		logrus.Errorf("Import stopped: %v", err)
+		database.Disconnect(DB)
+		os.Exit(1)
+	}
+}
+
+func importOpenF1(DB *gorm.DB, dir string) (importer.Summary, error) {
+	files, err := importer.OpenF1Dir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s holds no OpenF1 files", dir)
+	}
+	return importer.NewSessionImporter(DB).Import(importer.NewOpenF1Source(files))
+}
@@ -1,11 +1,19 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/dewciu/f1_api/pkg/config"
 	"github.com/dewciu/f1_api/pkg/database"
+	"github.com/dewciu/f1_api/pkg/live"
 	"github.com/dewciu/f1_api/pkg/migrations"
 	"github.com/dewciu/f1_api/pkg/routes"
 	"github.com/dewciu/f1_api/pkg/seeding"
@@ -14,6 +22,10 @@ import (
 	_ "github.com/dewciu/f1_api/docs"
 )
 
+// shutdownTimeout is the time requests in flight have to complete once the
+// server is asked to stop
+const shutdownTimeout = 10 * time.Second
+
 // @title F1 API
 // @version 1.0
 // @description This is an API for F1 application
@@ -51,7 +63,11 @@ func main() {
 		msg := fmt.Sprintf("Failed to connect to DB: %v", err)
 		panic(msg)
 	}
-	router := routes.SetupRouter(DB)
+	hub := live.NewHub(database.NewLiveRepository(DB), live.Options{
+		Buffer:           conf.Live.Buffer,
+		SnapshotInterval: time.Duration(conf.Live.SnapshotInterval) * time.Second,
+	})
+	router := routes.SetupRouter(DB, hub, conf)
 
 	defer database.Disconnect(DB)
 
@@ -65,6 +81,48 @@ func main() {
 		panic(msg)
 	}
 
-	hostname := fmt.Sprintf("%s:%d", conf.Server.Host, conf.Server.Port)
-	router.Run(hostname)
+	// simulations run in memory, those a previous run left unfinished will
+	// never finish
+	interrupted, err := database.NewSimulationRepository(DB).FailInterruptedSimulationsQuery()
+	if err != nil {
+		msg := fmt.Sprintf("Failed to recover interrupted simulations: %v", err)
+		panic(msg)
+	}
+	if interrupted > 0 {
+		logrus.Warnf("Marked %d simulations interrupted by the last shutdown as failed", interrupted)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// the hub restores sessions from their snapshots as the feed reaches
+	// them and takes a last snapshot once the context is done
+	var persisted sync.WaitGroup
+	persisted.Add(1)
+	go func() {
+		defer persisted.Done()
+		hub.Persist(ctx)
+	}()
+	if conf.Live.Feed != "" {
+		go hub.Follow(ctx, conf.Live.Feed, time.Duration(conf.Live.Retry)*time.Second)
+	}
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf("%s:%d", conf.Server.Host, conf.Server.Port),
+		Handler: router,
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.Errorf("Server failed: %v", err)
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	shutdown, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdown); err != nil {
+		logrus.Errorf("Failed to shut down the server: %v", err)
+	}
+	persisted.Wait()
 }
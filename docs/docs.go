@@ -57,14 +57,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/circuits": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves all users from the database, with optional filters",
+                "description": "Retrieves circuits from the database, with optional filters and pagination",
                 "consumes": [
                     "application/json"
                 ],
@@ -72,36 +72,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "circuits"
                 ],
-                "summary": "Get Users",
+                "summary": "Get Circuits",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User's E-mail",
-                        "name": "email",
+                        "description": "Circuit's country (ISO 3166-1 alpha-2)",
+                        "name": "country",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "User's username",
-                        "name": "username",
+                        "description": "Circuit's external reference",
+                        "name": "ref",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "User's ID",
-                        "name": "id",
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page offset",
+                        "name": "offset",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Returns list of users",
+                        "description": "Returns list of circuits",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/UserResponse"
+                                "$ref": "#/definitions/CircuitResponse"
                             }
                         }
                     }
@@ -113,7 +121,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Creates single user in database",
+                "description": "Creates single circuit in database",
                 "consumes": [
                     "application/json"
                 ],
@@ -121,38 +129,92 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "circuits"
                 ],
-                "summary": "Create User",
+                "summary": "Create Circuit",
                 "parameters": [
                     {
-                        "description": "User Object",
-                        "name": "User",
+                        "description": "Circuit Object",
+                        "name": "Circuit",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UserCreateModelValidator"
+                            "$ref": "#/definitions/CircuitCreateModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns Created Circuit",
+                        "schema": {
+                            "$ref": "#/definitions/CircuitResponse"
                         }
                     }
+                }
+            }
+        },
+        "/circuits/nearby": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves circuits within the radius of the point, ordered by haversine distance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "circuits"
+                ],
+                "summary": "Get nearby Circuits",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Latitude in decimal degrees",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude in decimal degrees",
+                        "name": "lng",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Search radius in kilometres",
+                        "name": "radius_km",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Returns Created User",
+                        "description": "Returns list of circuits with their distance",
                         "schema": {
-                            "$ref": "#/definitions/UserResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/NearbyCircuitResponse"
+                            }
                         }
                     }
                 }
             }
         },
-        "/users/{id}": {
+        "/circuits/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves a user from the database by ID",
+                "description": "Retrieves a circuit with its layouts from the database by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -160,13 +222,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "circuits"
                 ],
-                "summary": "Get User by ID",
+                "summary": "Get Circuit by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Circuit ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -174,9 +236,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Returns the user",
+                        "description": "Returns the circuit",
                         "schema": {
-                            "$ref": "#/definitions/UserResponse"
+                            "$ref": "#/definitions/CircuitResponse"
                         }
                     }
                 }
@@ -187,7 +249,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Updates a user in the database by ID",
+                "description": "Updates a circuit in the database by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -195,32 +257,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "circuits"
                 ],
-                "summary": "Update User by ID",
+                "summary": "Update Circuit by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Circuit ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User Object fields to update",
-                        "name": "User",
+                        "description": "Circuit Object fields to update",
+                        "name": "Circuit",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UserUpdateModelValidator"
+                            "$ref": "#/definitions/CircuitUpdateModelValidator"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Returns the updated user",
+                        "description": "Returns the updated circuit",
                         "schema": {
-                            "$ref": "#/definitions/UserResponse"
+                            "$ref": "#/definitions/CircuitResponse"
                         }
                     }
                 }
@@ -231,7 +293,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Deletes a user from the database by ID",
+                "description": "Deletes a circuit and its layouts from the database by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -239,13 +301,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "circuits"
                 ],
-                "summary": "Delete User by ID",
+                "summary": "Delete Circuit by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Circuit ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -258,14 +320,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/permissions": {
-            "get": {
+        "/circuits/{id}/layouts": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieves permission list for specific user by ID",
+                "description": "Adds a new layout version to the circuit, optionally with its GeoJSON geometry",
                 "consumes": [
                     "application/json"
                 ],
@@ -273,98 +335,7295 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "circuits"
                 ],
-                "summary": "Retrieve Permissions for the user by ID",
+                "summary": "Add Circuit layout",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Circuit ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Layout Object",
+                        "name": "Layout",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CircuitLayoutModelValidator"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Returns user's permissions",
+                    "201": {
+                        "description": "Returns Created Layout",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/PermissionResponse"
-                            }
+                            "$ref": "#/definitions/CircuitLayoutResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "LoginValidator": {
-            "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
-            "properties": {
-                "password": {
-                    "type": "string"
-                },
-                "username": {
-                    "type": "string"
-                }
-            }
         },
-        "PermissionResponse": {
-            "type": "object",
-            "properties": {
-                "endpoint": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "method": {
-                    "type": "string"
+        "/circuits/{id}/layouts/{layout}/map": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the stored track geometry of the circuit layout as a GeoJSON Feature",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/geo+json"
+                ],
+                "tags": [
+                    "circuits"
+                ],
+                "summary": "Get Circuit layout map",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Circuit ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Layout version",
+                        "name": "layout",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the layout as GeoJSON Feature",
+                        "schema": {
+                            "$ref": "#/definitions/CircuitMapResponse"
+                        }
+                    }
                 }
             }
         },
-        "TokenResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
-                    "type": "string"
+        "/constructors": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves constructors from the database, with optional filters and pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "constructors"
+                ],
+                "summary": "Get Constructors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Constructor's nationality (ISO 3166-1 alpha-2)",
+                        "name": "nationality",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Constructor's external reference",
+                        "name": "ref",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season the constructor was entered in",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns list of constructors",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ConstructorResponse"
+                            }
+                        }
+                    }
                 }
-            }
-        },
-        "UserCreateModelValidator": {
-            "type": "object",
-            "required": [
-                "email",
-                "password",
-                "username"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 8
-                },
-                "username": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 4
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates single constructor in database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "constructors"
+                ],
+                "summary": "Create Constructor",
+                "parameters": [
+                    {
+                        "description": "Constructor Object",
+                        "name": "Constructor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ConstructorCreateModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns Created Constructor",
+                        "schema": {
+                            "$ref": "#/definitions/ConstructorResponse"
+                        }
+                    }
                 }
             }
         },
-        "UserResponse": {
-            "type": "object",
-            "properties": {
-                "email": {
-                    "type": "string"
+        "/constructors/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a constructor from the database by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "constructors"
+                ],
+                "summary": "Get Constructor by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Constructor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the constructor",
+                        "schema": {
+                            "$ref": "#/definitions/ConstructorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates a constructor in the database by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "constructors"
+                ],
+                "summary": "Update Constructor by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Constructor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Constructor Object fields to update",
+                        "name": "Constructor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ConstructorUpdateModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the updated constructor",
+                        "schema": {
+                            "$ref": "#/definitions/ConstructorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a constructor from the database by ID, constructors with season entries cannot be deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "constructors"
+                ],
+                "summary": "Delete Constructor by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Constructor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/constructors/{id}/seasons/{year}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the constructor's entry for the year, with chassis, power unit and driver line-ups",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "constructors"
+                ],
+                "summary": "Get Constructor's season entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Constructor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the season entry",
+                        "schema": {
+                            "$ref": "#/definitions/SeasonEntryResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates the constructor's entry for the year or replaces the existing one, including its driver line-ups",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "constructors"
+                ],
+                "summary": "Create or replace Constructor's season entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Constructor ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Season entry with driver line-ups",
+                        "name": "SeasonEntry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SeasonEntryModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the saved season entry",
+                        "schema": {
+                            "$ref": "#/definitions/SeasonEntryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/drivers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves drivers from the database, with optional filters and pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drivers"
+                ],
+                "summary": "Get Drivers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Driver's nationality (ISO 3166-1 alpha-2)",
+                        "name": "nationality",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver's three-letter code",
+                        "name": "code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver's external reference",
+                        "name": "ref",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season the driver took part in",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns list of drivers",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/DriverResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates single driver in database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drivers"
+                ],
+                "summary": "Create Driver",
+                "parameters": [
+                    {
+                        "description": "Driver Object",
+                        "name": "Driver",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/DriverCreateModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns Created Driver",
+                        "schema": {
+                            "$ref": "#/definitions/DriverResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/drivers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a driver from the database by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drivers"
+                ],
+                "summary": "Get Driver by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the driver",
+                        "schema": {
+                            "$ref": "#/definitions/DriverResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates a driver in the database by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drivers"
+                ],
+                "summary": "Update Driver by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Driver Object fields to update",
+                        "name": "Driver",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/DriverUpdateModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the updated driver",
+                        "schema": {
+                            "$ref": "#/definitions/DriverResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a driver from the database by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drivers"
+                ],
+                "summary": "Delete Driver by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/drivers/{id}/results": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the results of the driver in calendar order, with optional season filter and pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "drivers"
+                ],
+                "summary": "Get Driver's results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns list of results",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/DriverResultResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/ergast/f1/{path}": {
+            "get": {
+                "description": "Answers Ergast paths such as /2023/5/results.json, /drivers/alonso/constructors.json, /current/last/results.json and /2023/driverStandings.json in the MRData envelope of the Ergast API, with limit, offset and total. Seasons may be current, rounds last or next, and seasons, races, results, sprint, qualifying, drivers, constructors, circuits, driverStandings and constructorStandings can be combined with the drivers, constructors, circuits, grid, results, qualifying and fastest filters. Only JSON is served",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ergast"
+                ],
+                "summary": "Ergast-compatible read API",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ergast path, e.g. 2023/5/results.json",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items, 30 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items skipped",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the Ergast response",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dewciu_f1_api_pkg_ergast.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/openf1": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Imports files exported in the OpenF1 schema, such as sessions.json, drivers.json, position.json, laps.json, stints.json, pit.json and race_control.json, optionally prefixed as in 9158_laps.json. Sessions are matched by their meeting key and drivers by their car number in the line-ups of the race. Records which cannot be mapped are queued for review instead of failing the import, records already imported are updated or skipped",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Import OpenF1 session data",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "OpenF1 JSON files",
+                        "name": "files",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns what became of the records of each file",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ImportFileResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Lists the imported records which could not be mapped in the order they were queued, with the reason and the record as read",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Get records queued for review",
+                "parameters": [
+                    {
+                        "enum": [
+                            "openf1"
+                        ],
+                        "type": "string",
+                        "description": "Source of the records",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the queued records",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ImportReviewResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/reviews/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes a reviewed record from the queue. A record still unmappable is queued again when its file is imported again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Dismiss a record queued for review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/live/{session}/state": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the full timing tower of the session, the current one while the session is live and the last snapshot after a restart. Late joiners apply the deltas of the subscription with a sequence above the one of the state",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "live"
+                ],
+                "summary": "Get live timing state",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "session",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the timing tower",
+                        "schema": {
+                            "$ref": "#/definitions/LiveStateResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/live/{session}/ws": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sends the timing of the session over a WebSocket, a state message with the full timing tower first and then a delta message with the changed fields of a driver for every update, numbered by their sequence. A subscriber falling too far behind is sent an evicted message and disconnected, it may subscribe again for a fresh state",
+                "tags": [
+                    "live"
+                ],
+                "summary": "Subscribe to live timing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "session",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switches to the WebSocket protocol",
+                        "schema": {
+                            "$ref": "#/definitions/LiveMessageResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a race with its circuit and session schedule by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the race",
+                        "schema": {
+                            "$ref": "#/definitions/RaceResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates the name, link or points scale of a race, the points scale is 0.5 for races awarding half points",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Update Race by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Race fields to update",
+                        "name": "Race",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RaceUpdateModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the updated race",
+                        "schema": {
+                            "$ref": "#/definitions/RaceResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a race and its sessions from the database by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Delete Race by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/races/{id}/decisions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the steward decisions of the race and its sprint in the order they were issued",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race steward decisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the decisions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/StewardDecisionResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a decision on a driver entered for the race. Time penalties and disqualifications amend the classification of the session, storing it as a new result version, and the standings follow",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Add Race steward decision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Steward decision",
+                        "name": "Decision",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/StewardDecisionModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns the created decision",
+                        "schema": {
+                            "$ref": "#/definitions/StewardDecisionResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/decisions/{decision}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Records that the decision was appealed, upheld or overturned, now unless at says otherwise. A penalty stands while under appeal, overturning it restores the classification",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Update Race steward decision status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Decision ID",
+                        "name": "decision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Decision status",
+                        "name": "Status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/StewardDecisionStatusValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the updated decision",
+                        "schema": {
+                            "$ref": "#/definitions/StewardDecisionResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/grid": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Builds the starting grid from the qualifying classification and the grid penalties, with an audit trail explaining every position change of each driver. On 2021 and 2022 sprint weekends the sprint classification sets the grid once it is stored",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race starting grid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the grid followed by pit lane starters and drivers who do not start",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/GridSlotResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/grid/penalties": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the grid penalties of the race in the order of infringement",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race grid penalties",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns list of grid penalties",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/GridPenaltyResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a grid drop, back-of-grid or pit lane start penalty of a driver entered for the race",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Add Race grid penalty",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Grid penalty",
+                        "name": "Penalty",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/GridPenaltyModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns Created Grid penalty",
+                        "schema": {
+                            "$ref": "#/definitions/GridPenaltyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/grid/penalties/{penalty}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Removes a grid penalty of the race",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Delete Race grid penalty",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Grid penalty ID",
+                        "name": "penalty",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/races/{id}/laps": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the lap times and positions of the race grouped by driver, drivers completing more laps first. Laps can be annotated with the weather observation nearest to the middle of the lap, a lap is wet when it reports rainfall",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race laps",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "driver",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "First lap",
+                        "name": "from_lap",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last lap",
+                        "name": "to_lap",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Leave out laps under the Safety Car, VSC or red flag",
+                        "name": "exclude_neutralized",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Annotate the laps with the nearest weather observation",
+                        "name": "weather",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Leave out laps run in the rain",
+                        "name": "exclude_wet",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the laps",
+                        "schema": {
+                            "$ref": "#/definitions/RaceLapsResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stores the lap times and positions of drivers entered for the race in bulk, laps already stored are replaced",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Submit Race laps",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Laps",
+                        "name": "Laps",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/LapsModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns the laps of the race",
+                        "schema": {
+                            "$ref": "#/definitions/RaceLapsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/laps/chart": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the position of every driver at the end of each lap, null where the driver has no time for the lap",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race lap chart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the lap chart",
+                        "schema": {
+                            "$ref": "#/definitions/LapChartResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/laps/fastest": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Ranks every driver by their fastest lap of the race with the gap to the fastest one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race fastest laps",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "driver",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "First lap",
+                        "name": "from_lap",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last lap",
+                        "name": "to_lap",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Leave out laps under the Safety Car, VSC or red flag",
+                        "name": "exclude_neutralized",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Leave out laps run in the rain",
+                        "name": "exclude_wet",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the fastest lap ranking",
+                        "schema": {
+                            "$ref": "#/definitions/FastestLapsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/laps/gaps": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the cumulative gap of every driver to the leader at the end of each lap in milliseconds, null from the first lap without a time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race gap chart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the gap chart",
+                        "schema": {
+                            "$ref": "#/definitions/GapChartResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/neutralizations": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Derives the Safety Car, Virtual Safety Car and red flag periods of the race from its race control events, with the laps run under them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race neutralizations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the neutralizations",
+                        "schema": {
+                            "$ref": "#/definitions/NeutralizationsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/pace": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Estimates the representative pace of every driver as the median of their clean laps, quickest first. The first lap, in-laps, out-laps, laps under the Safety Car, VSC or red flag and outliers of their stint are left out. Lap times are corrected to an empty tank by the fuel correction for every lap left to run, and a linear or quadratic degradation model in the age of the tyres is fitted to every stint. Times are in seconds",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race pace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Seconds gained for every lap of fuel burnt, 0.06 by default",
+                        "name": "fuel_correction",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "linear",
+                            "quadratic"
+                        ],
+                        "type": "string",
+                        "description": "Degradation model, linear by default",
+                        "name": "model",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Deviations from the median of a stint beyond which a lap is an outlier, 3 by default",
+                        "name": "outlier_threshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Leave out laps run in the rain",
+                        "name": "exclude_wet",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the pace of the drivers",
+                        "schema": {
+                            "$ref": "#/definitions/PaceResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/pit-stops": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the pit stops of the race in lap order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race pit stops",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the pit stops",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/PitStopResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replaces the pit stops of the race, every driver has to be entered for the round and their stops numbered from 1 in lap order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Submit Race pit stops",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pit stops",
+                        "name": "PitStops",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/PitStopsModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns the stored pit stops",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/PitStopResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/qualifying": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the qualifying classification with gaps to pole and to the car ahead, measured in the last segment each driver reached, and the 107% rule check against the fastest Q1 lap",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race qualifying",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "Q1",
+                            "Q2",
+                            "Q3"
+                        ],
+                        "type": "string",
+                        "description": "Only drivers who reached the segment",
+                        "name": "segment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only drivers whose lap in the segment is not slower, e.g. 1:30.000",
+                        "name": "max_time",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the qualifying classification",
+                        "schema": {
+                            "$ref": "#/definitions/QualifyingResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replaces the qualifying classification of the race, lap times are written as 1:23.456",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Submit Race qualifying",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Qualifying classification",
+                        "name": "Results",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/QualifyingResultsModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns the stored qualifying classification",
+                        "schema": {
+                            "$ref": "#/definitions/QualifyingResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/race-control": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the flags, Safety Car and VSC messages, track limits deletions, investigations and penalties of the race in time order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race control events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Event type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "driver",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the events",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/RaceControlEventResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds race control messages to the race, drivers they name have to be entered for the round",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Add Race control events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Race control events",
+                        "name": "Events",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RaceControlEventsModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns every event of the race",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/RaceControlEventResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/replay": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Plays a finished race back as server-sent events, as if it were live. Position and gap events follow the drivers completing their laps, pit events the end of their in-laps and race control events the time of the message, all in time order. The stream starts with a state event naming the viewer, which controls the playback through the control endpoint, and a snapshot event with the running order. A seek is followed by a new snapshot and the end event follows the last event of the race",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Stream race replay",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "default": 1,
+                        "description": "Speed multiplier",
+                        "name": "speed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Offset into the race in seconds",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Start paused",
+                        "name": "paused",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Streams the events",
+                        "schema": {
+                            "$ref": "#/definitions/ReplayEventResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/replay/ws": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Plays a finished race back over a WebSocket with the events of the server-sent stream, one JSON message each. The playback is controlled by sending ReplayControlValidator messages over the socket, a rejected control is answered with an error message",
+                "tags": [
+                    "races"
+                ],
+                "summary": "Replay race over WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "default": 1,
+                        "description": "Speed multiplier",
+                        "name": "speed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Offset into the race in seconds",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Start paused",
+                        "name": "paused",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switches to the WebSocket protocol",
+                        "schema": {
+                            "$ref": "#/definitions/ReplayEventResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/replay/{viewer}": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Pauses, resumes, seeks or changes the speed of the replay streamed to the viewer, which only the user who opened it may control. A seek takes an offset into the race in seconds and a change of speed the new multiplier. The stream reports the new state of the playback",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Control race replay",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Viewer ID",
+                        "name": "viewer",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Control",
+                        "name": "Control",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ReplayControlValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/races/{id}/results": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the classification of the race, classified drivers first followed by the unclassified ones. With as_of it is the classification as it stood at that time, amended by the steward decisions in force then",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time of the classification, e.g. 2023-03-05T18:00:00Z",
+                        "name": "as_of",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the classification",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ResultResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replaces the classification of the race, every driver has to be entered for the round and classified positions have to be contiguous",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Submit Race results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Race classification",
+                        "name": "Results",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SessionResultsModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns the stored classification",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ResultResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/results/versions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the history of the race classification, every submitted classification and every amendment by a steward decision with the time it took effect",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race result versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the versions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ResultVersionResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the session schedule of the race, start times are in UTC",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns list of sessions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SessionResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a session to the race weekend, it must not overlap other sessions nor leave the weekend",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Create Race session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Session Object",
+                        "name": "Session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SessionCreateModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns Created Session",
+                        "schema": {
+                            "$ref": "#/definitions/SessionResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/sessions/{session}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Reschedules the session or changes its status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Update Race session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "session",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Session fields to update",
+                        "name": "Session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SessionUpdateModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the updated session",
+                        "schema": {
+                            "$ref": "#/definitions/SessionResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/sprint": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the classification of the sprint of the race weekend, called Sprint Qualifying in 2021. With as_of it is the classification as it stood at that time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race sprint results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time of the classification, e.g. 2023-07-29T16:00:00Z",
+                        "name": "as_of",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the sprint classification",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ResultResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replaces the classification of the sprint, every driver has to be entered for the round and classified positions have to be contiguous. Standings score it with the sprint points of the season",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Submit Race sprint results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sprint classification",
+                        "name": "Results",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SessionResultsModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns the stored sprint classification",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/ResultResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/sprint/grid": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Builds the grid of the sprint from the session setting it in the season, qualifying in 2021 and 2022 and the Sprint Shootout from 2023, with the 107% rule applied. Grid penalties of the race are not served in the sprint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race sprint grid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the sprint grid followed by drivers who do not start",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/GridSlotResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/sprint/shootout": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the classification of the session setting the sprint grid from 2023, the Sprint Shootout, renamed Sprint Qualifying in 2024. Its segments SQ1 to SQ3 are stored as Q1 to Q3",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race sprint shootout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "Q1",
+                            "Q2",
+                            "Q3"
+                        ],
+                        "type": "string",
+                        "description": "Only drivers who reached the segment",
+                        "name": "segment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only drivers whose lap in the segment is not slower, e.g. 1:30.000",
+                        "name": "max_time",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the shootout classification",
+                        "schema": {
+                            "$ref": "#/definitions/QualifyingResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replaces the classification of the Sprint Shootout, lap times are written as 1:23.456 with SQ1 to SQ3 given as Q1 to Q3",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Submit Race sprint shootout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Shootout classification",
+                        "name": "Results",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/QualifyingResultsModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns the stored shootout classification",
+                        "schema": {
+                            "$ref": "#/definitions/QualifyingResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/stints": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the tyre stints of the race grouped by driver",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race stints",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the stints",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/DriverStintsResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replaces the tyre stints of the race, every driver has to be entered for the round and their stints numbered from 1 without overlapping",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Submit Race stints",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stints",
+                        "name": "Stints",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/StintsModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns the stored stints",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/DriverStintsResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/races/{id}/strategy": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves every driver's stint timeline and number of stops, the fastest pit stops and, when the race has laps, the undercuts and overcuts between drivers running within 5 seconds of each other who stopped within 5 laps",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "races"
+                ],
+                "summary": "Get Race strategy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Race ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the strategy",
+                        "schema": {
+                            "$ref": "#/definitions/StrategyResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves all championship seasons",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Get Seasons",
+                "responses": {
+                    "200": {
+                        "description": "Returns list of seasons",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SeasonResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates a championship season",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Create Season",
+                "parameters": [
+                    {
+                        "description": "Season Object",
+                        "name": "Season",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SeasonCreateModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns Created Season",
+                        "schema": {
+                            "$ref": "#/definitions/SeasonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{year}/entries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves every constructor's entry for the year, with driver line-ups",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Get season entries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the season entries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SeasonEntryResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{year}/head-to-head": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Compares two drivers over the rounds in which they drove for the same constructor by their line-ups, or every round they both took part in with any_pair. Counts who qualified and finished ahead, finishes ahead when both were classified, points including sprints and retirements by cause, with the mean qualifying gap of driver A to driver B in percent over the last segment both set a time in",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Get Season's head-to-head of two drivers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "driver_a",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "driver_b",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Compare every round both drivers took part in",
+                        "name": "any_pair",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the head-to-head",
+                        "schema": {
+                            "$ref": "#/definitions/HeadToHeadResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{year}/races": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the races of the season in round order, with circuits and session schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Get Season's race calendar",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns list of races",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/RaceResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Adds a race to the season calendar, weekends must not overlap other races of the season",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Create Race",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Race Object",
+                        "name": "Race",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/RaceCreateModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns Created Race",
+                        "schema": {
+                            "$ref": "#/definitions/RaceResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{year}/simulations": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the simulations of the season, the latest first, without their outcomes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Get Season simulations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the simulations",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/SimulationResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Fits a Plackett-Luce performance model with DNF probabilities to the season's race results and samples the remaining races and sprints in a background job. The same seed and settings give the same outcome. Poll the simulation until its status is finished. Responds with 429 while as many simulations as configured are running",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Create Season simulation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Simulation settings",
+                        "name": "Simulation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SimulationCreateModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Returns the pending simulation",
+                        "schema": {
+                            "$ref": "#/definitions/SimulationResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{year}/simulations/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a simulation with its status, once finished it includes every driver's title probability, expected points and championship position distribution",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Get Season simulation",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Simulation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the simulation",
+                        "schema": {
+                            "$ref": "#/definitions/SimulationResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{year}/standings/constructors": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Computes the constructors' championship from the stored results with the points system of the season, the standings are empty before 1958. Ties are broken by countback",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Get Season's constructors' standings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Standings after the round, the whole season by default",
+                        "name": "after_round",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the constructors' standings",
+                        "schema": {
+                            "$ref": "#/definitions/ConstructorStandingsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{year}/standings/contenders": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Computes the maximum achievable total of every driver and constructor over the remaining races, sprints and bonus points, whether they are eliminated and what the leader needs at the next round to clinch the title",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Get Season's title contenders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the contenders",
+                        "schema": {
+                            "$ref": "#/definitions/ContendersResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Computes the contenders as GetContenders does after applying hypothetical finishing orders to upcoming races",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Get Season's title contenders for hypothetical results",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Hypothetical finishing orders",
+                        "name": "Scenarios",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ContendersWhatIfValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the contenders",
+                        "schema": {
+                            "$ref": "#/definitions/ContendersResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{year}/standings/drivers": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Computes the drivers' championship from the stored results with the points system of the season, including dropped scores, half points, sprint points and the fastest lap bonus. Ties are broken by countback",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "seasons"
+                ],
+                "summary": "Get Season's drivers' standings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Season year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Standings after the round, the whole season by default",
+                        "name": "after_round",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the drivers' standings",
+                        "schema": {
+                            "$ref": "#/definitions/DriverStandingsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/next": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the session which is live or starts next according to the server clock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Get next Session",
+                "responses": {
+                    "200": {
+                        "description": "Returns the upcoming session with its race",
+                        "schema": {
+                            "$ref": "#/definitions/NextSessionResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{id}/telemetry": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the telemetry samples of a driver in the session in time order, of a lap or between two times. Given a number of points, the samples are downsampled to them with the Largest Triangle Three Buckets algorithm on the speed trace",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Get session telemetry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver ID",
+                        "name": "driver",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lap",
+                        "name": "lap",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First sample time, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last sample time, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of points",
+                        "name": "points",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the samples",
+                        "schema": {
+                            "$ref": "#/definitions/TelemetryResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stores car telemetry samples of drivers entered for the race, streamed as newline delimited JSON with a TelemetrySampleValidator per line. The stream is stored as it is read, samples already stored are skipped and a rejected line rolls back the whole stream",
+                "consumes": [
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Stream session telemetry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Samples, one JSON object per line",
+                        "name": "Samples",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/TelemetrySampleValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Returns the number of samples stored",
+                        "schema": {
+                            "$ref": "#/definitions/TelemetryCopyResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes all telemetry samples of the session at once",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Delete session telemetry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/sessions/{id}/telemetry/compare": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Aligns two laps of the session by distance along the track. Returns the speed and throttle of both laps interpolated on a common distance grid, the cumulative time driver B lost to driver A, and the driver faster through each mini-sector. The lap lengths are scaled to their mean, distances are integrated from the speed where samples have none",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Compare lap telemetry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver ID of lap A",
+                        "name": "driver_a",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lap A",
+                        "name": "lap_a",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Driver ID of lap B",
+                        "name": "driver_b",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Lap B",
+                        "name": "lap_b",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "default": 10,
+                        "description": "Grid spacing in metres",
+                        "name": "step",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 25,
+                        "description": "Number of mini-sectors",
+                        "name": "mini_sectors",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the comparison",
+                        "schema": {
+                            "$ref": "#/definitions/TelemetryComparisonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{id}/weather": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves the weather observations of the session in time order, optionally between two times. Observations are imported with the OpenF1 weather file of the session",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Get session weather",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First observation time, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last observation time, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the observations",
+                        "schema": {
+                            "$ref": "#/definitions/WeatherResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{id}/weather/summary": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Summarizes the weather of the session, the spread of the temperatures, humidity, pressure and wind speed, the mean wind direction and whether and how much of the session it rained",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Get session weather summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the summary",
+                        "schema": {
+                            "$ref": "#/definitions/WeatherSummaryResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves all users from the database, with optional filters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get Users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User's E-mail",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User's username",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "User's ID",
+                        "name": "id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns list of users",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/UserResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Creates single user in database",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create User",
+                "parameters": [
+                    {
+                        "description": "User Object",
+                        "name": "User",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UserCreateModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns Created User",
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves a user from the database by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get User by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the user",
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Updates a user in the database by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update User by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User Object fields to update",
+                        "name": "User",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UserUpdateModelValidator"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns the updated user",
+                        "schema": {
+                            "$ref": "#/definitions/UserResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a user from the database by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete User by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/users/{id}/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieves permission list for specific user by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Retrieve Permissions for the user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns user's permissions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/PermissionResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "Circuit": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "ergast_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "layouts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CircuitLayout"
+                    }
+                },
+                "location": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ref": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "CircuitCreateModelValidator": {
+            "type": "object",
+            "required": [
+                "country",
+                "latitude",
+                "location",
+                "longitude",
+                "name",
+                "ref"
+            ],
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "ergast_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "ref": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "CircuitLayout": {
+            "type": "object",
+            "properties": {
+                "circuit_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "first_year": {
+                    "type": "integer"
+                },
+                "geometry": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_year": {
+                    "type": "integer"
+                },
+                "length_meters": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "turns": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CircuitLayoutModelValidator": {
+            "type": "object",
+            "required": [
+                "direction",
+                "length_meters",
+                "name",
+                "turns"
+            ],
+            "properties": {
+                "direction": {
+                    "type": "string",
+                    "enum": [
+                        "clockwise",
+                        "anticlockwise"
+                    ]
+                },
+                "first_year": {
+                    "type": "integer",
+                    "minimum": 1950
+                },
+                "geometry": {
+                    "type": "object"
+                },
+                "last_year": {
+                    "type": "integer",
+                    "minimum": 1950
+                },
+                "length_meters": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "turns": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "CircuitLayoutResponse": {
+            "type": "object",
+            "properties": {
+                "direction": {
+                    "type": "string"
+                },
+                "first_year": {
+                    "type": "integer"
+                },
+                "last_year": {
+                    "type": "integer"
+                },
+                "length_meters": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "turns": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CircuitMapProperties": {
+            "type": "object",
+            "properties": {
+                "circuit": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "first_year": {
+                    "type": "integer"
+                },
+                "last_year": {
+                    "type": "integer"
+                },
+                "length_meters": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "turns": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CircuitMapResponse": {
+            "type": "object",
+            "properties": {
+                "geometry": {
+                    "type": "object"
+                },
+                "properties": {
+                    "$ref": "#/definitions/CircuitMapProperties"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "CircuitResponse": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "ergast_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "layouts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CircuitLayoutResponse"
+                    }
+                },
+                "location": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ref": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "CircuitUpdateModelValidator": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "location": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "ClinchResponse": {
+            "type": "object",
+            "properties": {
+                "any_result": {
+                    "type": "boolean"
+                },
+                "clinched": {
+                    "type": "boolean"
+                },
+                "leader": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "round": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ComparedLapResponse": {
+            "type": "object",
+            "properties": {
+                "driver_id": {
+                    "type": "string"
+                },
+                "lap": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ComparisonPointResponse": {
+            "type": "object",
+            "properties": {
+                "delta": {
+                    "description": "Delta is the time in seconds driver B lost to driver A up to the\ndistance, negative where driver B is ahead",
+                    "type": "number"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "speed_a": {
+                    "type": "number"
+                },
+                "speed_b": {
+                    "type": "number"
+                },
+                "throttle_a": {
+                    "type": "number"
+                },
+                "throttle_b": {
+                    "type": "number"
+                }
+            }
+        },
+        "CompoundPaceResponse": {
+            "type": "object",
+            "properties": {
+                "compound": {
+                    "type": "string"
+                },
+                "degradation": {
+                    "type": "number"
+                },
+                "stints": {
+                    "type": "integer"
+                }
+            }
+        },
+        "Constructor": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "ergast_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nationality": {
+                    "type": "string"
+                },
+                "ref": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "ConstructorContenderResponse": {
+            "type": "object",
+            "properties": {
+                "constructor": {
+                    "$ref": "#/definitions/ConstructorResponse"
+                },
+                "eliminated": {
+                    "type": "boolean"
+                },
+                "max_points": {
+                    "type": "number"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "sprint_points": {
+                    "type": "number"
+                },
+                "sprint_wins": {
+                    "type": "integer"
+                },
+                "wins": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ConstructorCreateModelValidator": {
+            "type": "object",
+            "required": [
+                "name",
+                "nationality",
+                "ref"
+            ],
+            "properties": {
+                "ergast_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "nationality": {
+                    "type": "string"
+                },
+                "ref": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "ConstructorResponse": {
+            "type": "object",
+            "properties": {
+                "ergast_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nationality": {
+                    "type": "string"
+                },
+                "ref": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "ConstructorStandingResponse": {
+            "type": "object",
+            "properties": {
+                "constructor": {
+                    "$ref": "#/definitions/ConstructorResponse"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "sprint_points": {
+                    "type": "number"
+                },
+                "sprint_wins": {
+                    "type": "integer"
+                },
+                "wins": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ConstructorStandingsResponse": {
+            "type": "object",
+            "properties": {
+                "after_round": {
+                    "type": "integer"
+                },
+                "standings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ConstructorStandingResponse"
+                    }
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ConstructorUpdateModelValidator": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "nationality": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "ContendersResponse": {
+            "type": "object",
+            "properties": {
+                "after_round": {
+                    "type": "integer"
+                },
+                "clinch": {
+                    "$ref": "#/definitions/ClinchResponse"
+                },
+                "constructors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ConstructorContenderResponse"
+                    }
+                },
+                "drivers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DriverContenderResponse"
+                    }
+                },
+                "remaining_rounds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ContendersWhatIfValidator": {
+            "type": "object",
+            "required": [
+                "races"
+            ],
+            "properties": {
+                "races": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/ScenarioValidator"
+                    }
+                }
+            }
+        },
+        "Driver": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "ergast_id": {
+                    "type": "integer"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "nationality": {
+                    "type": "string"
+                },
+                "permanent_number": {
+                    "type": "integer"
+                },
+                "ref": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "DriverContenderResponse": {
+            "type": "object",
+            "properties": {
+                "constructors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ConstructorResponse"
+                    }
+                },
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "eliminated": {
+                    "type": "boolean"
+                },
+                "gross_points": {
+                    "type": "number"
+                },
+                "max_points": {
+                    "type": "number"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "sprint_points": {
+                    "type": "number"
+                },
+                "sprint_wins": {
+                    "type": "integer"
+                },
+                "wins": {
+                    "type": "integer"
+                }
+            }
+        },
+        "DriverCreateModelValidator": {
+            "type": "object",
+            "required": [
+                "date_of_birth",
+                "first_name",
+                "last_name",
+                "nationality",
+                "ref"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "ergast_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "nationality": {
+                    "type": "string"
+                },
+                "permanent_number": {
+                    "type": "integer",
+                    "maximum": 99,
+                    "minimum": 1
+                },
+                "ref": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "seasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "DriverLapsResponse": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "laps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LapResponse"
+                    }
+                }
+            }
+        },
+        "DriverPaceResponse": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "excluded": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExcludedLapResponse"
+                    }
+                },
+                "gap": {
+                    "type": "number"
+                },
+                "laps": {
+                    "type": "integer"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "representative": {
+                    "type": "number"
+                },
+                "stints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PaceStintResponse"
+                    }
+                }
+            }
+        },
+        "DriverResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "ergast_id": {
+                    "type": "integer"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "nationality": {
+                    "type": "string"
+                },
+                "permanent_number": {
+                    "type": "integer"
+                },
+                "ref": {
+                    "type": "string"
+                },
+                "seasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "DriverResultResponse": {
+            "type": "object",
+            "properties": {
+                "car_number": {
+                    "type": "integer"
+                },
+                "classified": {
+                    "type": "boolean"
+                },
+                "constructor": {
+                    "$ref": "#/definitions/ConstructorResponse"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "fastest_lap": {
+                    "type": "integer"
+                },
+                "fastest_lap_rank": {
+                    "type": "integer"
+                },
+                "fastest_lap_time": {
+                    "type": "string"
+                },
+                "gap": {
+                    "type": "string"
+                },
+                "grid": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "laps": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "position_order": {
+                    "type": "integer"
+                },
+                "position_text": {
+                    "type": "string"
+                },
+                "race_id": {
+                    "type": "string"
+                },
+                "race_name": {
+                    "type": "string"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "session": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "time_millis": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "DriverStandingResponse": {
+            "type": "object",
+            "properties": {
+                "constructors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ConstructorResponse"
+                    }
+                },
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "gross_points": {
+                    "type": "number"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "sprint_points": {
+                    "type": "number"
+                },
+                "sprint_wins": {
+                    "type": "integer"
+                },
+                "wins": {
+                    "type": "integer"
+                }
+            }
+        },
+        "DriverStandingsResponse": {
+            "type": "object",
+            "properties": {
+                "after_round": {
+                    "type": "integer"
+                },
+                "standings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DriverStandingResponse"
+                    }
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "DriverStintsResponse": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "stints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/StintResponse"
+                    }
+                }
+            }
+        },
+        "DriverStrategyResponse": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "pit_stops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PitStopResponse"
+                    }
+                },
+                "stints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/StintResponse"
+                    }
+                },
+                "stops": {
+                    "type": "integer"
+                }
+            }
+        },
+        "DriverUpdateModelValidator": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "nationality": {
+                    "type": "string"
+                },
+                "permanent_number": {
+                    "type": "integer",
+                    "maximum": 99,
+                    "minimum": 1
+                },
+                "seasons": {
+                    "description": "Seasons replace the seasons of the driver when given",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExchangeResponse": {
+            "type": "object",
+            "properties": {
+                "early": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "early_lap": {
+                    "type": "integer"
+                },
+                "gain": {
+                    "type": "string"
+                },
+                "gap_after": {
+                    "type": "string"
+                },
+                "gap_before": {
+                    "type": "string"
+                },
+                "late": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "late_lap": {
+                    "type": "integer"
+                },
+                "outcome": {
+                    "type": "string"
+                }
+            }
+        },
+        "ExcludedLapResponse": {
+            "type": "object",
+            "properties": {
+                "lap": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "FastestLapResponse": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "gap": {
+                    "type": "string"
+                },
+                "lap": {
+                    "type": "integer"
+                },
+                "millis": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "FastestLapsResponse": {
+            "type": "object",
+            "properties": {
+                "race_id": {
+                    "type": "string"
+                },
+                "ranking": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/FastestLapResponse"
+                    }
+                }
+            }
+        },
+        "FastestStopsResponse": {
+            "type": "object",
+            "properties": {
+                "ranked_by": {
+                    "type": "string"
+                },
+                "ranking": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RankedStopResponse"
+                    }
+                }
+            }
+        },
+        "GapChartLineResponse": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "gaps_millis": {
+                    "description": "GapsMillis holds the gap to the leader at the end of every lap in\nmilliseconds",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "GapChartResponse": {
+            "type": "object",
+            "properties": {
+                "drivers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GapChartLineResponse"
+                    }
+                },
+                "laps": {
+                    "type": "integer"
+                },
+                "race_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "GridChangeResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "GridPenaltyModelValidator": {
+            "type": "object",
+            "required": [
+                "driver_id",
+                "infringed_at",
+                "type"
+            ],
+            "properties": {
+                "component": {
+                    "type": "string",
+                    "enum": [
+                        "power_unit",
+                        "gearbox",
+                        "other"
+                    ]
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "infringed_at": {
+                    "type": "string"
+                },
+                "places": {
+                    "type": "integer",
+                    "maximum": 99,
+                    "minimum": 0
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "grid_drop",
+                        "back_of_grid",
+                        "pit_lane"
+                    ]
+                }
+            }
+        },
+        "GridPenaltyResponse": {
+            "type": "object",
+            "properties": {
+                "component": {
+                    "type": "string"
+                },
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "infringed_at": {
+                    "type": "string"
+                },
+                "places": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "GridSlotResponse": {
+            "type": "object",
+            "properties": {
+                "audit": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/GridChangeResponse"
+                    }
+                },
+                "car_number": {
+                    "type": "integer"
+                },
+                "constructor": {
+                    "$ref": "#/definitions/ConstructorResponse"
+                },
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "grid": {
+                    "type": "integer"
+                },
+                "pit_lane": {
+                    "type": "boolean"
+                },
+                "qualified": {
+                    "type": "integer"
+                },
+                "starts": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "HeadToHeadDriverResponse": {
+            "type": "object",
+            "properties": {
+                "dnfs": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "finishes_ahead": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "qualifying": {
+                    "type": "integer"
+                },
+                "race": {
+                    "type": "integer"
+                }
+            }
+        },
+        "HeadToHeadResponse": {
+            "type": "object",
+            "properties": {
+                "driver_a": {
+                    "$ref": "#/definitions/HeadToHeadDriverResponse"
+                },
+                "driver_b": {
+                    "$ref": "#/definitions/HeadToHeadDriverResponse"
+                },
+                "qualifying_gap": {
+                    "description": "QualifyingGap is the mean gap of driver A to driver B in percent,\nnegative when driver A was quicker",
+                    "type": "number"
+                },
+                "rounds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/HeadToHeadRoundResponse"
+                    }
+                },
+                "teammates": {
+                    "type": "boolean"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "HeadToHeadRoundResponse": {
+            "type": "object",
+            "properties": {
+                "both_classified": {
+                    "type": "boolean"
+                },
+                "constructor_id": {
+                    "type": "string"
+                },
+                "qualifying_ahead": {
+                    "type": "string"
+                },
+                "qualifying_gap": {
+                    "type": "number"
+                },
+                "race_ahead": {
+                    "type": "string"
+                },
+                "race_id": {
+                    "type": "string"
+                },
+                "round": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ImportFileResponse": {
+            "type": "object",
+            "properties": {
+                "file": {
+                    "type": "string"
+                },
+                "imported": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "queued": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "ImportReviewResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "origin": {
+                    "type": "string"
+                },
+                "queued_at": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "record": {
+                    "type": "object"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "LapChartLineResponse": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "positions": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "LapChartResponse": {
+            "type": "object",
+            "properties": {
+                "drivers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LapChartLineResponse"
+                    }
+                },
+                "laps": {
+                    "type": "integer"
+                },
+                "race_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "LapResponse": {
+            "type": "object",
+            "properties": {
+                "lap": {
+                    "type": "integer"
+                },
+                "millis": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "weather": {
+                    "description": "Weather is the observation nearest to the lap, when asked for",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/WeatherObservationResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "LapValidator": {
+            "type": "object",
+            "required": [
+                "driver_id",
+                "lap",
+                "position",
+                "time"
+            ],
+            "properties": {
+                "driver_id": {
+                    "type": "string"
+                },
+                "lap": {
+                    "type": "integer",
+                    "maximum": 500,
+                    "minimum": 1
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "time": {
+                    "type": "string",
+                    "example": "1:32.238"
+                }
+            }
+        },
+        "LapsModelValidator": {
+            "type": "object",
+            "required": [
+                "laps"
+            ],
+            "properties": {
+                "laps": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/LapValidator"
+                    }
+                }
+            }
+        },
+        "LineUpResponse": {
+            "type": "object",
+            "properties": {
+                "car_number": {
+                    "type": "integer"
+                },
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "end_round": {
+                    "type": "integer"
+                },
+                "start_round": {
+                    "type": "integer"
+                }
+            }
+        },
+        "LineUpValidator": {
+            "type": "object",
+            "required": [
+                "driver_id",
+                "start_round"
+            ],
+            "properties": {
+                "car_number": {
+                    "type": "integer",
+                    "maximum": 99,
+                    "minimum": 0
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "end_round": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "start_round": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "LiveEntryResponse": {
+            "type": "object",
+            "properties": {
+                "best_lap": {
+                    "type": "string",
+                    "example": "1:30.965"
+                },
+                "compound": {
+                    "type": "string",
+                    "example": "MEDIUM"
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "gap": {
+                    "type": "string",
+                    "example": "1.254"
+                },
+                "in_pit": {
+                    "type": "boolean"
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "0.412"
+                },
+                "lap": {
+                    "type": "integer"
+                },
+                "last_lap": {
+                    "type": "string",
+                    "example": "1:31.447"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "tyre_age": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "string"
+                }
+            }
+        },
+        "LiveMessageResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "delta"
+                }
+            }
+        },
+        "LiveStateResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LiveEntryResponse"
+                    }
+                },
+                "sequence": {
+                    "description": "Sequence is the sequence of the last delta in the state",
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "string"
+                }
+            }
+        },
+        "LoginValidator": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "MiniSectorResponse": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "number"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "number"
+                },
+                "time_a": {
+                    "type": "number"
+                },
+                "time_b": {
+                    "type": "number"
+                },
+                "winner": {
+                    "description": "Winner is the driver faster through the mini-sector, null on a tie",
+                    "type": "string"
+                }
+            }
+        },
+        "NearbyCircuitResponse": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "distance_km": {
+                    "type": "number"
+                },
+                "ergast_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "layouts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CircuitLayoutResponse"
+                    }
+                },
+                "location": {
+                    "type": "string"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ref": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "NeutralizationResponse": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "end_lap": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "start_lap": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "NeutralizationsResponse": {
+            "type": "object",
+            "properties": {
+                "laps": {
+                    "description": "Laps lists the laps run at least partly under a neutralization",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/NeutralizationResponse"
+                    }
+                },
+                "race_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "NextSessionResponse": {
+            "type": "object",
+            "properties": {
+                "live": {
+                    "type": "boolean"
+                },
+                "race": {
+                    "$ref": "#/definitions/RaceResponse"
+                },
+                "session": {
+                    "$ref": "#/definitions/SessionResponse"
+                },
+                "starts_in_seconds": {
+                    "type": "integer"
+                }
+            }
+        },
+        "PaceLapResponse": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "corrected": {
+                    "type": "number"
+                },
+                "lap": {
+                    "type": "integer"
+                },
+                "residual": {
+                    "type": "number"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "PaceResponse": {
+            "type": "object",
+            "properties": {
+                "compounds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CompoundPaceResponse"
+                    }
+                },
+                "drivers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DriverPaceResponse"
+                    }
+                },
+                "fuel_correction": {
+                    "type": "number"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "outlier_threshold": {
+                    "type": "number"
+                },
+                "race_id": {
+                    "type": "string"
+                },
+                "race_laps": {
+                    "type": "integer"
+                }
+            }
+        },
+        "PaceStintResponse": {
+            "type": "object",
+            "properties": {
+                "compound": {
+                    "type": "string"
+                },
+                "degradation": {
+                    "type": "number"
+                },
+                "end_lap": {
+                    "type": "integer"
+                },
+                "fit": {
+                    "$ref": "#/definitions/StintFitResponse"
+                },
+                "laps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PaceLapResponse"
+                    }
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "start_lap": {
+                    "type": "integer"
+                }
+            }
+        },
+        "PermissionResponse": {
+            "type": "object",
+            "properties": {
+                "endpoint": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                }
+            }
+        },
+        "PitStopResponse": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lap": {
+                    "type": "integer"
+                },
+                "pit_lane": {
+                    "type": "string"
+                },
+                "stationary": {
+                    "type": "string"
+                },
+                "stop": {
+                    "type": "integer"
+                },
+                "time_of_day": {
+                    "type": "string"
+                }
+            }
+        },
+        "PitStopValidator": {
+            "type": "object",
+            "required": [
+                "driver_id",
+                "lap",
+                "stop"
+            ],
+            "properties": {
+                "driver_id": {
+                    "type": "string"
+                },
+                "lap": {
+                    "type": "integer",
+                    "maximum": 500,
+                    "minimum": 1
+                },
+                "pit_lane": {
+                    "type": "string",
+                    "example": "21.873"
+                },
+                "stationary": {
+                    "type": "string",
+                    "example": "2.4"
+                },
+                "stop": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "time_of_day": {
+                    "type": "string",
+                    "example": "14:35:12"
+                }
+            }
+        },
+        "PitStopsModelValidator": {
+            "type": "object",
+            "required": [
+                "pit_stops"
+            ],
+            "properties": {
+                "pit_stops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PitStopValidator"
+                    }
+                }
+            }
+        },
+        "QualifyingResponse": {
+            "type": "object",
+            "properties": {
+                "fastest_q1": {
+                    "type": "string",
+                    "example": "1:29.708"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/QualifyingResultResponse"
+                    }
+                },
+                "threshold_107": {
+                    "type": "string",
+                    "example": "1:35.787"
+                }
+            }
+        },
+        "QualifyingResultResponse": {
+            "type": "object",
+            "properties": {
+                "admitted": {
+                    "type": "boolean"
+                },
+                "car_number": {
+                    "type": "integer"
+                },
+                "constructor": {
+                    "$ref": "#/definitions/ConstructorResponse"
+                },
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "eliminated_in": {
+                    "type": "string"
+                },
+                "explanation": {
+                    "type": "string"
+                },
+                "gap_to_pole": {
+                    "type": "string",
+                    "example": "+0.138"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "interval": {
+                    "type": "string",
+                    "example": "+0.027"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "q1": {
+                    "type": "string",
+                    "example": "1:30.503"
+                },
+                "q1_deleted": {
+                    "type": "boolean"
+                },
+                "q2": {
+                    "type": "string",
+                    "example": "1:30.282"
+                },
+                "q2_deleted": {
+                    "type": "boolean"
+                },
+                "q3": {
+                    "type": "string",
+                    "example": "1:29.708"
+                },
+                "q3_deleted": {
+                    "type": "boolean"
+                },
+                "segment": {
+                    "type": "string"
+                },
+                "within_107": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "QualifyingResultValidator": {
+            "type": "object",
+            "required": [
+                "driver_id",
+                "position"
+            ],
+            "properties": {
+                "admitted": {
+                    "type": "boolean"
+                },
+                "car_number": {
+                    "type": "integer",
+                    "maximum": 99,
+                    "minimum": 0
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "eliminated_in": {
+                    "type": "string",
+                    "enum": [
+                        "Q1",
+                        "Q2"
+                    ]
+                },
+                "explanation": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "q1": {
+                    "type": "string",
+                    "example": "1:30.503"
+                },
+                "q1_deleted": {
+                    "type": "boolean"
+                },
+                "q2": {
+                    "type": "string",
+                    "example": "1:30.282"
+                },
+                "q2_deleted": {
+                    "type": "boolean"
+                },
+                "q3": {
+                    "type": "string",
+                    "example": "1:29.708"
+                },
+                "q3_deleted": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "QualifyingResultsModelValidator": {
+            "type": "object",
+            "required": [
+                "results"
+            ],
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/QualifyingResultValidator"
+                    }
+                }
+            }
+        },
+        "Race": {
+            "type": "object",
+            "properties": {
+                "circuit": {
+                    "$ref": "#/definitions/Circuit"
+                },
+                "circuit_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "meeting_key": {
+                    "description": "MeetingKey is the key of the race weekend in OpenF1 data",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "points_scale": {
+                    "description": "PointsScale multiplies the points awarded for the race, 0.5 for races\nawarding half points. Zero is treated as full points",
+                    "type": "number"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Session"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "RaceControlEventResponse": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lap": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "sector": {
+                    "type": "integer"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "RaceControlEventValidator": {
+            "type": "object",
+            "required": [
+                "time",
+                "type"
+            ],
+            "properties": {
+                "driver_id": {
+                    "type": "string"
+                },
+                "lap": {
+                    "type": "integer",
+                    "maximum": 500,
+                    "minimum": 1
+                },
+                "message": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "sector": {
+                    "type": "integer",
+                    "maximum": 3,
+                    "minimum": 1
+                },
+                "time": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "green_flag",
+                        "yellow_flag",
+                        "double_yellow_flag",
+                        "red_flag",
+                        "chequered_flag",
+                        "safety_car_deployed",
+                        "safety_car_ending",
+                        "vsc_deployed",
+                        "vsc_ending",
+                        "track_limits_deletion",
+                        "investigation",
+                        "penalty"
+                    ]
+                }
+            }
+        },
+        "RaceControlEventsModelValidator": {
+            "type": "object",
+            "required": [
+                "events"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/RaceControlEventValidator"
+                    }
+                }
+            }
+        },
+        "RaceCreateModelValidator": {
+            "type": "object",
+            "required": [
+                "circuit_id",
+                "date",
+                "name",
+                "round"
+            ],
+            "properties": {
+                "circuit_id": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "points_scale": {
+                    "type": "number",
+                    "maximum": 2
+                },
+                "round": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "RaceLapsResponse": {
+            "type": "object",
+            "properties": {
+                "drivers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DriverLapsResponse"
+                    }
+                },
+                "race_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "RaceResponse": {
+            "type": "object",
+            "properties": {
+                "circuit": {
+                    "$ref": "#/definitions/CircuitResponse"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "meeting_key": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "points_scale": {
+                    "type": "number"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SessionResponse"
+                    }
+                },
+                "sprint": {
+                    "description": "Sprint describes the format of sprint weekends",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/SprintFormatResponse"
+                        }
+                    ]
+                },
+                "url": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "RaceUpdateModelValidator": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "points_scale": {
+                    "type": "number",
+                    "maximum": 2
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "RankedStopResponse": {
+            "type": "object",
+            "properties": {
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "lap": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "stop": {
+                    "type": "integer"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "ReplayControlValidator": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "pause",
+                        "resume",
+                        "seek",
+                        "speed"
+                    ],
+                    "example": "seek"
+                },
+                "offset": {
+                    "description": "Offset is the offset into the race in seconds to seek to",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 1800
+                },
+                "speed": {
+                    "type": "number",
+                    "maximum": 100,
+                    "example": 4
+                }
+            }
+        },
+        "ReplayEventResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "offset": {
+                    "description": "Offset is the time into the race in seconds",
+                    "type": "number"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "ResultResponse": {
+            "type": "object",
+            "properties": {
+                "car_number": {
+                    "type": "integer"
+                },
+                "classified": {
+                    "type": "boolean"
+                },
+                "constructor": {
+                    "$ref": "#/definitions/ConstructorResponse"
+                },
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "fastest_lap": {
+                    "type": "integer"
+                },
+                "fastest_lap_rank": {
+                    "type": "integer"
+                },
+                "fastest_lap_time": {
+                    "type": "string"
+                },
+                "gap": {
+                    "type": "string"
+                },
+                "grid": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "laps": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "position_order": {
+                    "type": "integer"
+                },
+                "position_text": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "time_millis": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ResultValidator": {
+            "type": "object",
+            "required": [
+                "driver_id",
+                "status"
+            ],
+            "properties": {
+                "car_number": {
+                    "type": "integer",
+                    "maximum": 99,
+                    "minimum": 0
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "fastest_lap": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "fastest_lap_rank": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "fastest_lap_time": {
+                    "type": "string",
+                    "example": "1:23.456"
+                },
+                "gap": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "grid": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "laps": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "points": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "position": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "position_text": {
+                    "type": "string",
+                    "enum": [
+                        "R",
+                        "D",
+                        "W",
+                        "N",
+                        "E",
+                        "F"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "time_millis": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "ResultVersionResponse": {
+            "type": "object",
+            "properties": {
+                "decision_id": {
+                    "type": "string"
+                },
+                "document": {
+                    "type": "string"
+                },
+                "effective_at": {
+                    "type": "string"
+                },
+                "needs_review": {
+                    "description": "NeedsReview flags a time penalty on a lapped car, which keeps its place",
+                    "type": "boolean"
+                },
+                "status": {
+                    "description": "Status is the status the decision moved to, versions without a\ndecision hold a submitted classification",
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ScenarioValidator": {
+            "type": "object",
+            "required": [
+                "race",
+                "round"
+            ],
+            "properties": {
+                "fastest_lap": {
+                    "type": "string"
+                },
+                "race": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "round": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "sprint": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "Season": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "SeasonCreateModelValidator": {
+            "type": "object",
+            "required": [
+                "year"
+            ],
+            "properties": {
+                "url": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1950
+                }
+            }
+        },
+        "SeasonEntryModelValidator": {
+            "type": "object",
+            "required": [
+                "chassis_name",
+                "entrant_name",
+                "line_ups",
+                "power_unit"
+            ],
+            "properties": {
+                "chassis_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "entrant_name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "line_ups": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/LineUpValidator"
+                    }
+                },
+                "power_unit": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "SeasonEntryResponse": {
+            "type": "object",
+            "properties": {
+                "chassis_name": {
+                    "type": "string"
+                },
+                "constructor": {
+                    "$ref": "#/definitions/ConstructorResponse"
+                },
+                "entrant_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "line_ups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LineUpResponse"
+                    }
+                },
+                "power_unit": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "SeasonResponse": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "Session": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "race_id": {
+                    "type": "string"
+                },
+                "session_key": {
+                    "description": "SessionKey is the key of the session in OpenF1 data",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "SessionCreateModelValidator": {
+            "type": "object",
+            "required": [
+                "start_time",
+                "type"
+            ],
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "live",
+                        "finished",
+                        "cancelled"
+                    ]
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "FP1",
+                        "FP2",
+                        "FP3",
+                        "SPRINT_SHOOTOUT",
+                        "SPRINT",
+                        "QUALIFYING",
+                        "RACE"
+                    ]
+                }
+            }
+        },
+        "SessionResponse": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "session_key": {
+                    "description": "SessionKey is the key of the session in OpenF1 data",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "SessionResultsModelValidator": {
+            "type": "object",
+            "required": [
+                "results"
+            ],
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/ResultValidator"
+                    }
+                }
+            }
+        },
+        "SessionUpdateModelValidator": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "scheduled",
+                        "live",
+                        "finished",
+                        "cancelled"
+                    ]
+                }
+            }
+        },
+        "SimulationCreateModelValidator": {
+            "type": "object",
+            "properties": {
+                "decay": {
+                    "type": "number",
+                    "maximum": 1
+                },
+                "dnf_prior": {
+                    "type": "number",
+                    "maximum": 100
+                },
+                "overrides": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SimulationOverrideValidator"
+                    }
+                },
+                "runs": {
+                    "type": "integer",
+                    "maximum": 100000,
+                    "minimum": 1
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "strength_prior": {
+                    "type": "number",
+                    "maximum": 100
+                }
+            }
+        },
+        "SimulationDriverResponse": {
+            "type": "object",
+            "properties": {
+                "constructor": {
+                    "$ref": "#/definitions/ConstructorResponse"
+                },
+                "dnf_probability": {
+                    "type": "number"
+                },
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "expected_points": {
+                    "type": "number"
+                },
+                "points": {
+                    "type": "number"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "positions": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "strength": {
+                    "description": "Strength and DNFProbability are left out for drivers not taking part\nin the remaining races",
+                    "type": "number"
+                },
+                "title_probability": {
+                    "type": "number"
+                }
+            }
+        },
+        "SimulationOutcomeResponse": {
+            "type": "object",
+            "properties": {
+                "after_round": {
+                    "type": "integer"
+                },
+                "drivers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SimulationDriverResponse"
+                    }
+                },
+                "remaining_rounds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "SimulationOverride": {
+            "type": "object",
+            "properties": {
+                "dnf_probability": {
+                    "type": "number"
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "strength_factor": {
+                    "type": "number"
+                }
+            }
+        },
+        "SimulationOverrideValidator": {
+            "type": "object",
+            "required": [
+                "driver_id"
+            ],
+            "properties": {
+                "dnf_probability": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "strength_factor": {
+                    "type": "number",
+                    "maximum": 100
+                }
+            }
+        },
+        "SimulationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "decay": {
+                    "type": "number"
+                },
+                "dnf_prior": {
+                    "type": "number"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "outcome": {
+                    "$ref": "#/definitions/SimulationOutcomeResponse"
+                },
+                "overrides": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SimulationOverride"
+                    }
+                },
+                "runs": {
+                    "type": "integer"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "strength_prior": {
+                    "type": "number"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "SprintFormatResponse": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "type": "string"
+                },
+                "grid_session": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "sessions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sets_race_grid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "StewardDecisionModelValidator": {
+            "type": "object",
+            "required": [
+                "document",
+                "driver_id",
+                "infringement",
+                "penalty"
+            ],
+            "properties": {
+                "document": {
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "infringement": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "penalty": {
+                    "type": "string",
+                    "enum": [
+                        "time",
+                        "disqualification",
+                        "reprimand",
+                        "warning",
+                        "fine"
+                    ]
+                },
+                "session": {
+                    "type": "string",
+                    "enum": [
+                        "RACE",
+                        "SPRINT"
+                    ]
+                },
+                "value": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "StewardDecisionResponse": {
+            "type": "object",
+            "properties": {
+                "appealed_at": {
+                    "type": "string"
+                },
+                "document": {
+                    "type": "string"
+                },
+                "driver": {
+                    "$ref": "#/definitions/DriverResponse"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "infringement": {
+                    "type": "string"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "penalty": {
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "session": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "StewardDecisionStatusValidator": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "appealed",
+                        "upheld",
+                        "overturned"
+                    ]
+                }
+            }
+        },
+        "StintFitResponse": {
+            "type": "object",
+            "properties": {
+                "coefficients": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "r2": {
+                    "type": "number"
+                },
+                "residuals": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "rmse": {
+                    "type": "number"
+                }
+            }
+        },
+        "StintResponse": {
+            "type": "object",
+            "properties": {
+                "compound": {
+                    "type": "string"
+                },
+                "end_lap": {
+                    "type": "integer"
+                },
+                "laps": {
+                    "type": "integer"
+                },
+                "new": {
+                    "type": "boolean"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "start_lap": {
+                    "type": "integer"
+                }
+            }
+        },
+        "StintValidator": {
+            "type": "object",
+            "required": [
+                "compound",
+                "driver_id",
+                "end_lap",
+                "number",
+                "start_lap"
+            ],
+            "properties": {
+                "compound": {
+                    "type": "string",
+                    "enum": [
+                        "HYPERSOFT",
+                        "ULTRASOFT",
+                        "SUPERSOFT",
+                        "SOFT",
+                        "MEDIUM",
+                        "HARD",
+                        "SUPERHARD",
+                        "INTERMEDIATE",
+                        "WET",
+                        "UNKNOWN"
+                    ]
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "end_lap": {
+                    "type": "integer",
+                    "maximum": 500,
+                    "minimum": 1
+                },
+                "new": {
+                    "type": "boolean"
+                },
+                "number": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "start_lap": {
+                    "type": "integer",
+                    "maximum": 500,
+                    "minimum": 1
+                }
+            }
+        },
+        "StintsModelValidator": {
+            "type": "object",
+            "required": [
+                "stints"
+            ],
+            "properties": {
+                "stints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/StintValidator"
+                    }
+                }
+            }
+        },
+        "StrategyResponse": {
+            "type": "object",
+            "properties": {
+                "analysed": {
+                    "description": "Analysed is false when the race has no laps to compare the stops with",
+                    "type": "boolean"
+                },
+                "drivers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DriverStrategyResponse"
+                    }
+                },
+                "exchanges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExchangeResponse"
+                    }
+                },
+                "fastest_stops": {
+                    "$ref": "#/definitions/FastestStopsResponse"
+                },
+                "race_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "TelemetryComparisonResponse": {
+            "type": "object",
+            "properties": {
+                "a": {
+                    "$ref": "#/definitions/ComparedLapResponse"
+                },
+                "b": {
+                    "$ref": "#/definitions/ComparedLapResponse"
+                },
+                "delta": {
+                    "type": "number"
+                },
+                "length": {
+                    "description": "Length is the lap length in metres both laps are aligned on",
+                    "type": "number"
+                },
+                "mini_sectors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/MiniSectorResponse"
+                    }
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ComparisonPointResponse"
+                    }
+                },
+                "session_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "TelemetryCopyResponse": {
+            "type": "object",
+            "properties": {
+                "duplicates": {
+                    "description": "Duplicates counts the samples received which were already stored",
+                    "type": "integer"
+                },
+                "received": {
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "stored": {
+                    "type": "integer"
+                }
+            }
+        },
+        "TelemetryResponse": {
+            "type": "object",
+            "properties": {
+                "driver_id": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/TelemetrySampleResponse"
+                    }
+                },
+                "samples": {
+                    "description": "Samples counts the samples stored for the lap or time range, Points\nholds them downsampled when fewer points were requested",
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "TelemetrySampleResponse": {
+            "type": "object",
+            "properties": {
+                "brake": {
+                    "type": "boolean"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "drs": {
+                    "type": "boolean"
+                },
+                "gear": {
+                    "type": "integer"
+                },
+                "lap": {
+                    "type": "integer"
+                },
+                "rpm": {
+                    "type": "integer"
+                },
+                "speed": {
+                    "type": "integer"
+                },
+                "throttle": {
+                    "type": "integer"
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "TelemetrySampleValidator": {
+            "type": "object",
+            "required": [
+                "driver_id",
+                "lap",
+                "time"
+            ],
+            "properties": {
+                "brake": {
+                    "type": "boolean"
+                },
+                "distance": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "driver_id": {
+                    "type": "string"
+                },
+                "drs": {
+                    "type": "boolean"
+                },
+                "gear": {
+                    "type": "integer",
+                    "maximum": 8,
+                    "minimum": 0
+                },
+                "lap": {
+                    "type": "integer",
+                    "maximum": 500,
+                    "minimum": 1
+                },
+                "rpm": {
+                    "type": "integer",
+                    "maximum": 20000,
+                    "minimum": 0
+                },
+                "speed": {
+                    "type": "integer",
+                    "maximum": 400,
+                    "minimum": 0
+                },
+                "throttle": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "time": {
+                    "type": "string",
+                    "example": "2023-09-03T13:04:12.250Z"
+                }
+            }
+        },
+        "TokenResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "UserCreateModelValidator": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 8
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 4
+                }
+            }
+        },
+        "UserResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "string"
@@ -391,6 +7650,442 @@ const docTemplate = `{
                     "minLength": 4
                 }
             }
+        },
+        "WeatherObservationResponse": {
+            "type": "object",
+            "properties": {
+                "air_temperature": {
+                    "type": "number",
+                    "example": 27.4
+                },
+                "humidity": {
+                    "type": "number",
+                    "example": 41
+                },
+                "pressure": {
+                    "type": "number",
+                    "example": 996.8
+                },
+                "rainfall": {
+                    "type": "boolean"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "track_temperature": {
+                    "type": "number",
+                    "example": 43.9
+                },
+                "wind_direction": {
+                    "type": "integer",
+                    "example": 215
+                },
+                "wind_speed": {
+                    "type": "number",
+                    "example": 1.3
+                }
+            }
+        },
+        "WeatherRangeResponse": {
+            "type": "object",
+            "properties": {
+                "max": {
+                    "type": "number"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                }
+            }
+        },
+        "WeatherResponse": {
+            "type": "object",
+            "properties": {
+                "observations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/WeatherObservationResponse"
+                    }
+                },
+                "session_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "WeatherSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "air_temperature": {
+                    "$ref": "#/definitions/WeatherRangeResponse"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "humidity": {
+                    "$ref": "#/definitions/WeatherRangeResponse"
+                },
+                "observations": {
+                    "type": "integer"
+                },
+                "pressure": {
+                    "$ref": "#/definitions/WeatherRangeResponse"
+                },
+                "rainfall": {
+                    "type": "boolean"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "track_temperature": {
+                    "$ref": "#/definitions/WeatherRangeResponse"
+                },
+                "wet_share": {
+                    "description": "WetShare is the share of the observations reporting rainfall",
+                    "type": "number",
+                    "example": 0.25
+                },
+                "wind_direction": {
+                    "description": "WindDirection is the mean direction weighted by the wind speed",
+                    "type": "integer",
+                    "example": 215
+                },
+                "wind_speed": {
+                    "$ref": "#/definitions/WeatherRangeResponse"
+                }
+            }
+        },
+        "github_com_dewciu_f1_api_pkg_ergast.CircuitTable": {
+            "type": "object",
+            "properties": {
+                "Circuits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Circuit"
+                    }
+                },
+                "circuitId": {
+                    "type": "string"
+                },
+                "constructorId": {
+                    "type": "string"
+                },
+                "driverId": {
+                    "type": "string"
+                },
+                "fastest": {
+                    "type": "string"
+                },
+                "grid": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "round": {
+                    "type": "string"
+                },
+                "season": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_dewciu_f1_api_pkg_ergast.ConstructorStanding": {
+            "type": "object",
+            "properties": {
+                "Constructor": {
+                    "$ref": "#/definitions/Constructor"
+                },
+                "points": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "positionText": {
+                    "type": "string"
+                },
+                "wins": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_dewciu_f1_api_pkg_ergast.ConstructorTable": {
+            "type": "object",
+            "properties": {
+                "Constructors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Constructor"
+                    }
+                },
+                "circuitId": {
+                    "type": "string"
+                },
+                "constructorId": {
+                    "type": "string"
+                },
+                "driverId": {
+                    "type": "string"
+                },
+                "fastest": {
+                    "type": "string"
+                },
+                "grid": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "round": {
+                    "type": "string"
+                },
+                "season": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_dewciu_f1_api_pkg_ergast.DriverStanding": {
+            "type": "object",
+            "properties": {
+                "Constructors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Constructor"
+                    }
+                },
+                "Driver": {
+                    "$ref": "#/definitions/Driver"
+                },
+                "points": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "positionText": {
+                    "type": "string"
+                },
+                "wins": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_dewciu_f1_api_pkg_ergast.DriverTable": {
+            "type": "object",
+            "properties": {
+                "Drivers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Driver"
+                    }
+                },
+                "circuitId": {
+                    "type": "string"
+                },
+                "constructorId": {
+                    "type": "string"
+                },
+                "driverId": {
+                    "type": "string"
+                },
+                "fastest": {
+                    "type": "string"
+                },
+                "grid": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "round": {
+                    "type": "string"
+                },
+                "season": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_dewciu_f1_api_pkg_ergast.MRData": {
+            "type": "object",
+            "properties": {
+                "CircuitTable": {
+                    "$ref": "#/definitions/github_com_dewciu_f1_api_pkg_ergast.CircuitTable"
+                },
+                "ConstructorTable": {
+                    "$ref": "#/definitions/github_com_dewciu_f1_api_pkg_ergast.ConstructorTable"
+                },
+                "DriverTable": {
+                    "$ref": "#/definitions/github_com_dewciu_f1_api_pkg_ergast.DriverTable"
+                },
+                "RaceTable": {
+                    "$ref": "#/definitions/github_com_dewciu_f1_api_pkg_ergast.RaceTable"
+                },
+                "SeasonTable": {
+                    "$ref": "#/definitions/github_com_dewciu_f1_api_pkg_ergast.SeasonTable"
+                },
+                "StandingsTable": {
+                    "$ref": "#/definitions/github_com_dewciu_f1_api_pkg_ergast.StandingsTable"
+                },
+                "limit": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "string"
+                },
+                "series": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "xmlns": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_dewciu_f1_api_pkg_ergast.RaceTable": {
+            "type": "object",
+            "properties": {
+                "Races": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Race"
+                    }
+                },
+                "circuitId": {
+                    "type": "string"
+                },
+                "constructorId": {
+                    "type": "string"
+                },
+                "driverId": {
+                    "type": "string"
+                },
+                "fastest": {
+                    "type": "string"
+                },
+                "grid": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "round": {
+                    "type": "string"
+                },
+                "season": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_dewciu_f1_api_pkg_ergast.Response": {
+            "type": "object",
+            "properties": {
+                "MRData": {
+                    "$ref": "#/definitions/github_com_dewciu_f1_api_pkg_ergast.MRData"
+                }
+            }
+        },
+        "github_com_dewciu_f1_api_pkg_ergast.SeasonTable": {
+            "type": "object",
+            "properties": {
+                "Seasons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Season"
+                    }
+                },
+                "circuitId": {
+                    "type": "string"
+                },
+                "constructorId": {
+                    "type": "string"
+                },
+                "driverId": {
+                    "type": "string"
+                },
+                "fastest": {
+                    "type": "string"
+                },
+                "grid": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "round": {
+                    "type": "string"
+                },
+                "season": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_dewciu_f1_api_pkg_ergast.StandingsList": {
+            "type": "object",
+            "properties": {
+                "ConstructorStandings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_dewciu_f1_api_pkg_ergast.ConstructorStanding"
+                    }
+                },
+                "DriverStandings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_dewciu_f1_api_pkg_ergast.DriverStanding"
+                    }
+                },
+                "round": {
+                    "type": "string"
+                },
+                "season": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_dewciu_f1_api_pkg_ergast.StandingsTable": {
+            "type": "object",
+            "properties": {
+                "StandingsLists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_dewciu_f1_api_pkg_ergast.StandingsList"
+                    }
+                },
+                "circuitId": {
+                    "type": "string"
+                },
+                "constructorId": {
+                    "type": "string"
+                },
+                "driverId": {
+                    "type": "string"
+                },
+                "fastest": {
+                    "type": "string"
+                },
+                "grid": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "string"
+                },
+                "round": {
+                    "type": "string"
+                },
+                "season": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -36,3 +36,11 @@ func NewError(key string, err error) ValidationError {
 	res.Errors[key] = err.Error()
 	return res
 }
+
+type InvalidReferenceError struct {
+	Column string
+}
+
+func (s InvalidReferenceError) Error() string {
+	return fmt.Sprintf("referenced record does not exist, column: %s", s.Column)
+}
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultLimit = 30
+	MaxLimit     = 1000
+)
+
+type Pagination struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// GetPagination reads the limit and offset query parameters of the request,
+// falling back to DefaultLimit and 0 when they are not given.
+func GetPagination(c *gin.Context) (Pagination, error) {
+	p := Pagination{Limit: DefaultLimit}
+
+	if limit := c.Query("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 1 || l > MaxLimit {
+			return Pagination{}, fmt.Errorf("limit must be a number between 1 and %d", MaxLimit)
+		}
+		p.Limit = l
+	}
+
+	if offset := c.Query("offset"); offset != "" {
+		o, err := strconv.Atoi(offset)
+		if err != nil || o < 0 {
+			return Pagination{}, fmt.Errorf("offset must be a positive number")
+		}
+		p.Offset = o
+	}
+
+	return p, nil
+}
+
+// Paginate is a GORM scope applying the limit and offset to the query.
+func (p Pagination) Paginate(db *gorm.DB) *gorm.DB {
+	return db.Limit(p.Limit).Offset(p.Offset)
+}
@@ -5,6 +5,9 @@ import (
 	"github.com/gin-gonic/gin/binding"
 )
 
+// DateLayout is the layout of dates in requests and responses
+const DateLayout = "2006-01-02"
+
 func Bind(c *gin.Context, obj interface{}) error {
 	b := binding.Default(c.Request.Method, c.ContentType())
 	return c.ShouldBindWith(obj, b)
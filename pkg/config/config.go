@@ -23,6 +23,25 @@ type Config struct {
 		Mode              string `yaml:"mode"`
 		ApiSecret         string `yaml:"api_secret"`
 		TokenHourLifetime int    `yaml:"token_hour_lifetime"`
+		// AllowedOrigins are the origins of the pages which may open
+		// WebSockets, clients other than browsers send no origin
+		AllowedOrigins []string `yaml:"allowed_origins"`
+	}
+	Live struct {
+		// Feed is the source of the live timing, a tcp://, unix:// or file://
+		// URL, live timing is not ingested when empty
+		Feed string `yaml:"feed"`
+		// Retry is the number of seconds before the feed is opened again
+		Retry int `yaml:"retry"`
+		// Buffer is the number of deltas a subscriber may fall behind
+		Buffer int `yaml:"buffer"`
+		// SnapshotInterval is the number of seconds between two snapshots
+		SnapshotInterval int `yaml:"snapshot_interval"`
+	}
+	Simulations struct {
+		// Concurrency is the number of simulations run at the same time,
+		// further ones are refused until one finishes
+		Concurrency int `yaml:"concurrency"`
 	}
 }
 
@@ -0,0 +1,301 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	_ "github.com/dewciu/f1_api/docs"
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const GeoJSONContentType = "application/geo+json"
+
+type CircuitController struct {
+	DB          *gorm.DB
+	circuitRepo *d.CircuitRepository
+}
+
+func NewCircuitController(db *gorm.DB) *CircuitController {
+	circuitRepo := d.NewCircuitRepository(db)
+	return &CircuitController{DB: db, circuitRepo: circuitRepo}
+}
+
+// GetAllCircuits godoc
+// @Summary Get Circuits
+// @Description Retrieves circuits from the database, with optional filters and pagination
+// @Tags circuits
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param country query string false "Circuit's country (ISO 3166-1 alpha-2)"
+// @Param ref query string false "Circuit's external reference"
+// @Param limit query int false "Page size" default(30)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {array} CircuitResponse "Returns list of circuits"
+// @Router /circuits [get]
+func (cc *CircuitController) GetAllCircuits(c *gin.Context) {
+	pagination, err := common.GetPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("pagination", err))
+		return
+	}
+
+	circuits, err := cc.circuitRepo.GetCircuitsByFilterQuery(c, pagination)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.CircuitsSerializer{C: c, Circuits: circuits}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// CreateCircuit godoc
+// @Summary Create Circuit
+// @Description Creates single circuit in database
+// @Tags circuits
+// @Accept json
+// @Produce json
+// @Param Circuit body CircuitCreateModelValidator true "Circuit Object"
+// @Security ApiKeyAuth
+// @Success 201 {object} CircuitResponse "Returns Created Circuit"
+// @Router /circuits [post]
+func (cc *CircuitController) CreateCircuit(c *gin.Context) {
+	validator := v.CircuitCreateModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	circuit := validator.Circuit
+
+	err := cc.circuitRepo.CreateCircuitQuery(circuit)
+	if err != nil {
+		var er *common.AlreadyExistsError
+		if errors.As(err, &er) {
+			c.JSON(http.StatusConflict, common.NewError("circuit", er))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("database", err))
+		return
+	}
+
+	serializer := s.CircuitSerializer{C: c, Circuit: circuit}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
+
+// GetCircuitByID godoc
+// @Summary Get Circuit by ID
+// @Description Retrieves a circuit with its layouts from the database by ID
+// @Tags circuits
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Circuit ID"
+// @Success 200 {object} CircuitResponse "Returns the circuit"
+// @Router /circuits/{id} [get]
+func (cc *CircuitController) GetCircuitByID(c *gin.Context) {
+	id := c.Param("id")
+
+	circuit, err := cc.circuitRepo.GetCircuitByIdQuery(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("circuit", errors.New("circuit not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("circuit", err))
+		return
+	}
+
+	serializer := s.CircuitSerializer{C: c, Circuit: circuit}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// DeleteCircuitByID godoc
+// @Summary Delete Circuit by ID
+// @Description Deletes a circuit and its layouts from the database by ID
+// @Tags circuits
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Circuit ID"
+// @Success 204 "No Content"
+// @Router /circuits/{id} [delete]
+func (cc *CircuitController) DeleteCircuitByID(c *gin.Context) {
+	id := c.Param("id")
+
+	err := cc.circuitRepo.DeleteCircuitByIdQuery(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("circuit", errors.New("circuit not found")))
+			return
+		}
+		var er *common.InvalidReferenceError
+		if errors.As(err, &er) {
+			c.JSON(http.StatusConflict, common.NewError("circuit", errors.New("circuit is still referenced")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("circuit", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateCircuit godoc
+// @Summary Update Circuit by ID
+// @Description Updates a circuit in the database by ID
+// @Tags circuits
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Circuit ID"
+// @Param Circuit body CircuitUpdateModelValidator true "Circuit Object fields to update"
+// @Success 200 {object} CircuitResponse "Returns the updated circuit"
+// @Router /circuits/{id} [put]
+func (cc *CircuitController) UpdateCircuit(c *gin.Context) {
+	id := c.Param("id")
+	validator := v.CircuitUpdateModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	circuit, err := cc.circuitRepo.UpdateCircuitByIdQuery(id, validator)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("circuit", errors.New("circuit not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("circuit", err))
+		return
+	}
+
+	serializer := s.CircuitSerializer{C: c, Circuit: circuit}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// CreateCircuitLayout godoc
+// @Summary Add Circuit layout
+// @Description Adds a new layout version to the circuit, optionally with its GeoJSON geometry
+// @Tags circuits
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Circuit ID"
+// @Param Layout body CircuitLayoutModelValidator true "Layout Object"
+// @Success 201 {object} CircuitLayoutResponse "Returns Created Layout"
+// @Router /circuits/{id}/layouts [post]
+func (cc *CircuitController) CreateCircuitLayout(c *gin.Context) {
+	circuit, err := cc.circuitRepo.GetCircuitByIdQuery(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("circuit", errors.New("circuit not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("circuit", err))
+		return
+	}
+
+	validator := v.CircuitLayoutModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	layout := validator.CircuitLayout
+	layout.CircuitID = circuit.ID
+
+	layout, err = cc.circuitRepo.CreateCircuitLayoutQuery(layout)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("layout", err))
+		return
+	}
+
+	serializer := s.CircuitLayoutSerializer{C: c, CircuitLayout: layout}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
+
+// GetCircuitLayoutMap godoc
+// @Summary Get Circuit layout map
+// @Description Retrieves the stored track geometry of the circuit layout as a GeoJSON Feature
+// @Tags circuits
+// @Accept json
+// @Produce application/geo+json
+// @Security ApiKeyAuth
+// @Param id path string true "Circuit ID"
+// @Param layout path int true "Layout version"
+// @Success 200 {object} CircuitMapResponse "Returns the layout as GeoJSON Feature"
+// @Router /circuits/{id}/layouts/{layout}/map [get]
+func (cc *CircuitController) GetCircuitLayoutMap(c *gin.Context) {
+	id := c.Param("id")
+	version, err := strconv.Atoi(c.Param("layout"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("layout", errors.New("layout must be a version number")))
+		return
+	}
+
+	layout, err := cc.circuitRepo.GetCircuitLayoutQuery(id, version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("layout", errors.New("layout not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("layout", err))
+		return
+	}
+
+	if len(layout.Geometry) == 0 {
+		c.JSON(http.StatusNotFound, common.NewError("layout", errors.New("layout has no map")))
+		return
+	}
+
+	circuit, err := cc.circuitRepo.GetCircuitByIdQuery(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("circuit", err))
+		return
+	}
+
+	serializer := s.CircuitMapSerializer{C: c, Circuit: circuit.Ref, CircuitLayout: layout}
+	data, err := json.Marshal(serializer.Response())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("layout", err))
+		return
+	}
+	c.Data(http.StatusOK, GeoJSONContentType, data)
+}
+
+// GetNearbyCircuits godoc
+// @Summary Get nearby Circuits
+// @Description Retrieves circuits within the radius of the point, ordered by haversine distance
+// @Tags circuits
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param lat query number true "Latitude in decimal degrees"
+// @Param lng query number true "Longitude in decimal degrees"
+// @Param radius_km query number true "Search radius in kilometres"
+// @Success 200 {array} NearbyCircuitResponse "Returns list of circuits with their distance"
+// @Router /circuits/nearby [get]
+func (cc *CircuitController) GetNearbyCircuits(c *gin.Context) {
+	validator := v.NearbyCircuitsValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	circuits, err := cc.circuitRepo.GetCircuitsNearbyQuery(*validator.Lat, *validator.Lng, validator.RadiusKm)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.NearbyCircuitsSerializer{C: c, Circuits: circuits}
+	c.JSON(http.StatusOK, serializer.Response())
+}
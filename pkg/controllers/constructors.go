@@ -0,0 +1,293 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	_ "github.com/dewciu/f1_api/docs"
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ConstructorController struct {
+	DB              *gorm.DB
+	constructorRepo *d.ConstructorRepository
+}
+
+func NewConstructorController(db *gorm.DB) *ConstructorController {
+	constructorRepo := d.NewConstructorRepository(db)
+	return &ConstructorController{DB: db, constructorRepo: constructorRepo}
+}
+
+// GetAllConstructors godoc
+// @Summary Get Constructors
+// @Description Retrieves constructors from the database, with optional filters and pagination
+// @Tags constructors
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param nationality query string false "Constructor's nationality (ISO 3166-1 alpha-2)"
+// @Param ref query string false "Constructor's external reference"
+// @Param season query int false "Season the constructor was entered in"
+// @Param limit query int false "Page size" default(30)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {array} ConstructorResponse "Returns list of constructors"
+// @Router /constructors [get]
+func (cc *ConstructorController) GetAllConstructors(c *gin.Context) {
+	pagination, err := common.GetPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("pagination", err))
+		return
+	}
+
+	constructors, err := cc.constructorRepo.GetConstructorsByFilterQuery(c, pagination)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.ConstructorsSerializer{C: c, Constructors: constructors}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// CreateConstructor godoc
+// @Summary Create Constructor
+// @Description Creates single constructor in database
+// @Tags constructors
+// @Accept json
+// @Produce json
+// @Param Constructor body ConstructorCreateModelValidator true "Constructor Object"
+// @Security ApiKeyAuth
+// @Success 201 {object} ConstructorResponse "Returns Created Constructor"
+// @Router /constructors [post]
+func (cc *ConstructorController) CreateConstructor(c *gin.Context) {
+	validator := v.ConstructorCreateModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	constructor := validator.Constructor
+
+	err := cc.constructorRepo.CreateConstructorQuery(constructor)
+	if err != nil {
+		var er *common.AlreadyExistsError
+		if errors.As(err, &er) {
+			c.JSON(http.StatusConflict, common.NewError("constructor", er))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("database", err))
+		return
+	}
+
+	serializer := s.ConstructorSerializer{C: c, Constructor: constructor}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
+
+// GetConstructorByID godoc
+// @Summary Get Constructor by ID
+// @Description Retrieves a constructor from the database by ID
+// @Tags constructors
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Constructor ID"
+// @Success 200 {object} ConstructorResponse "Returns the constructor"
+// @Router /constructors/{id} [get]
+func (cc *ConstructorController) GetConstructorByID(c *gin.Context) {
+	id := c.Param("id")
+
+	constructor, err := cc.constructorRepo.GetConstructorByIdQuery(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("constructor", errors.New("constructor not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("constructor", err))
+		return
+	}
+
+	serializer := s.ConstructorSerializer{C: c, Constructor: constructor}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// DeleteConstructorByID godoc
+// @Summary Delete Constructor by ID
+// @Description Deletes a constructor from the database by ID, constructors with season entries cannot be deleted
+// @Tags constructors
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Constructor ID"
+// @Success 204 "No Content"
+// @Router /constructors/{id} [delete]
+func (cc *ConstructorController) DeleteConstructorByID(c *gin.Context) {
+	id := c.Param("id")
+
+	err := cc.constructorRepo.DeleteConstructorByIdQuery(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("constructor", errors.New("constructor not found")))
+			return
+		}
+		var er *common.InvalidReferenceError
+		if errors.As(err, &er) {
+			c.JSON(http.StatusConflict, common.NewError("constructor", errors.New("constructor has season entries")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("constructor", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateConstructor godoc
+// @Summary Update Constructor by ID
+// @Description Updates a constructor in the database by ID
+// @Tags constructors
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Constructor ID"
+// @Param Constructor body ConstructorUpdateModelValidator true "Constructor Object fields to update"
+// @Success 200 {object} ConstructorResponse "Returns the updated constructor"
+// @Router /constructors/{id} [put]
+func (cc *ConstructorController) UpdateConstructor(c *gin.Context) {
+	id := c.Param("id")
+	validator := v.ConstructorUpdateModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	constructor, err := cc.constructorRepo.UpdateConstructorByIdQuery(id, validator)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("constructor", errors.New("constructor not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("constructor", err))
+		return
+	}
+
+	serializer := s.ConstructorSerializer{C: c, Constructor: constructor}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// GetSeasonEntry godoc
+// @Summary Get Constructor's season entry
+// @Description Retrieves the constructor's entry for the year, with chassis, power unit and driver line-ups
+// @Tags constructors
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Constructor ID"
+// @Param year path int true "Season year"
+// @Success 200 {object} SeasonEntryResponse "Returns the season entry"
+// @Router /constructors/{id}/seasons/{year} [get]
+func (cc *ConstructorController) GetSeasonEntry(c *gin.Context) {
+	id := c.Param("id")
+	year, err := parseYearParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("year", err))
+		return
+	}
+
+	entry, err := cc.constructorRepo.GetSeasonEntryQuery(id, year)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("season_entry", errors.New("season entry not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("season_entry", err))
+		return
+	}
+
+	serializer := s.SeasonEntrySerializer{C: c, SeasonEntry: entry}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// SaveSeasonEntry godoc
+// @Summary Create or replace Constructor's season entry
+// @Description Creates the constructor's entry for the year or replaces the existing one, including its driver line-ups
+// @Tags constructors
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Constructor ID"
+// @Param year path int true "Season year"
+// @Param SeasonEntry body SeasonEntryModelValidator true "Season entry with driver line-ups"
+// @Success 200 {object} SeasonEntryResponse "Returns the saved season entry"
+// @Router /constructors/{id}/seasons/{year} [put]
+func (cc *ConstructorController) SaveSeasonEntry(c *gin.Context) {
+	year, err := parseYearParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("year", err))
+		return
+	}
+
+	constructor, err := cc.constructorRepo.GetConstructorByIdQuery(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("constructor", errors.New("constructor not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("constructor", err))
+		return
+	}
+
+	validator := v.SeasonEntryModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	entry := validator.SeasonEntry
+	entry.ConstructorID = constructor.ID
+	entry.Year = year
+
+	entry, err = cc.constructorRepo.SaveSeasonEntryQuery(entry)
+	if err != nil {
+		var er *common.InvalidReferenceError
+		if errors.As(err, &er) {
+			c.JSON(http.StatusBadRequest, common.NewError("season_entry", er))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("season_entry", err))
+		return
+	}
+
+	serializer := s.SeasonEntrySerializer{C: c, SeasonEntry: entry}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// GetSeasonEntries godoc
+// @Summary Get season entries
+// @Description Retrieves every constructor's entry for the year, with driver line-ups
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param year path int true "Season year"
+// @Success 200 {array} SeasonEntryResponse "Returns the season entries"
+// @Router /seasons/{year}/entries [get]
+func (cc *ConstructorController) GetSeasonEntries(c *gin.Context) {
+	year, err := parseYearParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("year", err))
+		return
+	}
+
+	entries, err := cc.constructorRepo.GetSeasonEntriesByYearQuery(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("season_entry", err))
+		return
+	}
+
+	serializer := s.SeasonEntriesSerializer{C: c, Entries: entries}
+	c.JSON(http.StatusOK, serializer.Response())
+}
@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	_ "github.com/dewciu/f1_api/docs"
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	m "github.com/dewciu/f1_api/pkg/models"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type DriverController struct {
+	DB         *gorm.DB
+	driverRepo *d.DriverRepository
+}
+
+func NewDriverController(db *gorm.DB) *DriverController {
+	driverRepo := d.NewDriverRepository(db)
+	return &DriverController{DB: db, driverRepo: driverRepo}
+}
+
+// GetAllDrivers godoc
+// @Summary Get Drivers
+// @Description Retrieves drivers from the database, with optional filters and pagination
+// @Tags drivers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param nationality query string false "Driver's nationality (ISO 3166-1 alpha-2)"
+// @Param code query string false "Driver's three-letter code"
+// @Param ref query string false "Driver's external reference"
+// @Param season query int false "Season the driver took part in"
+// @Param limit query int false "Page size" default(30)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {array} DriverResponse "Returns list of drivers"
+// @Router /drivers [get]
+func (dc *DriverController) GetAllDrivers(c *gin.Context) {
+	var drivers []m.Driver
+
+	validator := v.DriverFilterValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	pagination, err := common.GetPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("pagination", err))
+		return
+	}
+
+	drivers, err = dc.driverRepo.GetDriversByFilterQuery(validator, pagination)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.DriversSerializer{C: c, Drivers: drivers}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// CreateDriver godoc
+// @Summary Create Driver
+// @Description Creates single driver in database
+// @Tags drivers
+// @Accept json
+// @Produce json
+// @Param Driver body DriverCreateModelValidator true "Driver Object"
+// @Security ApiKeyAuth
+// @Success 201 {object} DriverResponse "Returns Created Driver"
+// @Router /drivers [post]
+func (dc *DriverController) CreateDriver(c *gin.Context) {
+	validator := v.DriverCreateModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	driver := validator.Driver
+
+	err := dc.driverRepo.CreateDriverQuery(driver)
+	if err != nil {
+		var er *common.AlreadyExistsError
+		if errors.As(err, &er) {
+			c.JSON(http.StatusConflict, common.NewError("driver", er))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("database", err))
+		return
+	}
+
+	serializer := s.DriverSerializer{C: c, Driver: driver}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
+
+// GetDriverByID godoc
+// @Summary Get Driver by ID
+// @Description Retrieves a driver from the database by ID
+// @Tags drivers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Driver ID"
+// @Success 200 {object} DriverResponse "Returns the driver"
+// @Router /drivers/{id} [get]
+func (dc *DriverController) GetDriverByID(c *gin.Context) {
+	id := c.Param("id")
+
+	driver, err := dc.driverRepo.GetDriverByIdQuery(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("driver", errors.New("driver not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("driver", err))
+		return
+	}
+
+	serializer := s.DriverSerializer{C: c, Driver: driver}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// DeleteDriverByID godoc
+// @Summary Delete Driver by ID
+// @Description Deletes a driver from the database by ID
+// @Tags drivers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Driver ID"
+// @Success 204 "No Content"
+// @Router /drivers/{id} [delete]
+func (dc *DriverController) DeleteDriverByID(c *gin.Context) {
+	id := c.Param("id")
+
+	err := dc.driverRepo.DeleteDriverByIdQuery(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("driver", errors.New("driver not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("driver", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateDriver godoc
+// @Summary Update Driver by ID
+// @Description Updates a driver in the database by ID
+// @Tags drivers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Driver ID"
+// @Param Driver body DriverUpdateModelValidator true "Driver Object fields to update"
+// @Success 200 {object} DriverResponse "Returns the updated driver"
+// @Router /drivers/{id} [put]
+func (dc *DriverController) UpdateDriver(c *gin.Context) {
+	id := c.Param("id")
+	validator := v.DriverUpdateModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	driver, err := dc.driverRepo.UpdateDriverByIdQuery(id, validator)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("driver", errors.New("driver not found")))
+			return
+		}
+		var er *common.AlreadyExistsError
+		if errors.As(err, &er) {
+			c.JSON(http.StatusConflict, common.NewError("driver", er))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("driver", err))
+		return
+	}
+
+	serializer := s.DriverSerializer{C: c, Driver: driver}
+	c.JSON(http.StatusOK, serializer.Response())
+}
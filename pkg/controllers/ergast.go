@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	"github.com/dewciu/f1_api/pkg/ergast"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ErgastController struct {
+	DB         *gorm.DB
+	ergastRepo *d.ErgastRepository
+}
+
+func NewErgastController(db *gorm.DB) *ErgastController {
+	ergastRepo := d.NewErgastRepository(db)
+	return &ErgastController{DB: db, ergastRepo: ergastRepo}
+}
+
+// requestURL returns the URL of the request without its query, as echoed in
+// the MRData envelope
+func requestURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host + c.Request.URL.Path
+}
+
+// GetErgast godoc
+// @Summary Ergast-compatible read API
+// @Description Answers Ergast paths such as /2023/5/results.json, /drivers/alonso/constructors.json, /current/last/results.json and /2023/driverStandings.json in the MRData envelope of the Ergast API, with limit, offset and total. Seasons may be current, rounds last or next, and seasons, races, results, sprint, qualifying, drivers, constructors, circuits, driverStandings and constructorStandings can be combined with the drivers, constructors, circuits, grid, results, qualifying and fastest filters. Only JSON is served
+// @Tags ergast
+// @Produce json
+// @Param path path string true "Ergast path, e.g. 2023/5/results.json"
+// @Param limit query int false "Number of items, 30 by default"
+// @Param offset query int false "Number of items skipped"
+// @Success 200 {object} ergast.Response "Returns the Ergast response"
+// @Router /ergast/f1/{path} [get]
+func (ec *ErgastController) GetErgast(c *gin.Context) {
+	q, err := ergast.ParsePath(c.Param("path"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("path", err))
+		return
+	}
+
+	p, err := common.GetPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("pagination", err))
+		return
+	}
+
+	if err := ec.ergastRepo.ResolveQuery(&q, time.Now().UTC()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("path", errors.New("no such season or round")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	data, err := ec.mrData(q, p, requestURL(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+	c.JSON(http.StatusOK, ergast.Response{MRData: data})
+}
+
+// mrData builds the envelope holding the page of the resource of the query
+func (ec *ErgastController) mrData(q ergast.Query, p common.Pagination, url string) (ergast.MRData, error) {
+	criteria := q.Criteria()
+
+	switch q.Resource {
+	case ergast.ResourceSeasons:
+		seasons, total, err := ec.ergastRepo.GetErgastSeasonsQuery(q, p)
+		data := ergast.NewMRData(url, p, total)
+		data.SeasonTable = &ergast.SeasonTable{Criteria: criteria, Seasons: []ergast.Season{}}
+		for _, season := range seasons {
+			data.SeasonTable.Seasons = append(data.SeasonTable.Seasons, ergast.SeasonOf(season))
+		}
+		return data, err
+
+	case ergast.ResourceRaces:
+		races, total, err := ec.ergastRepo.GetErgastRacesQuery(q, p)
+		data := ergast.NewMRData(url, p, total)
+		data.RaceTable = &ergast.RaceTable{Criteria: criteria, Races: []ergast.Race{}}
+		for _, race := range races {
+			data.RaceTable.Races = append(data.RaceTable.Races, ergast.ScheduleOf(race))
+		}
+		return data, err
+
+	case ergast.ResourceResults:
+		results, total, err := ec.ergastRepo.GetErgastResultsQuery(q, p)
+		data := ergast.NewMRData(url, p, total)
+		data.RaceTable = &ergast.RaceTable{Criteria: criteria, Races: ergast.ResultRaces(results)}
+		return data, err
+
+	case ergast.ResourceSprint:
+		results, total, err := ec.ergastRepo.GetErgastSprintResultsQuery(q, p)
+		data := ergast.NewMRData(url, p, total)
+		data.RaceTable = &ergast.RaceTable{Criteria: criteria, Races: ergast.SprintRaces(results)}
+		return data, err
+
+	case ergast.ResourceQualifying:
+		results, total, err := ec.ergastRepo.GetErgastQualifyingQuery(q, p)
+		data := ergast.NewMRData(url, p, total)
+		data.RaceTable = &ergast.RaceTable{Criteria: criteria, Races: ergast.QualifyingRaces(results)}
+		return data, err
+
+	case ergast.ResourceDrivers:
+		drivers, total, err := ec.ergastRepo.GetErgastDriversQuery(q, p)
+		data := ergast.NewMRData(url, p, total)
+		data.DriverTable = &ergast.DriverTable{Criteria: criteria, Drivers: []ergast.Driver{}}
+		for _, driver := range drivers {
+			data.DriverTable.Drivers = append(data.DriverTable.Drivers, ergast.DriverOf(driver))
+		}
+		return data, err
+
+	case ergast.ResourceConstructors:
+		constructors, total, err := ec.ergastRepo.GetErgastConstructorsQuery(q, p)
+		data := ergast.NewMRData(url, p, total)
+		data.ConstructorTable = &ergast.ConstructorTable{Criteria: criteria, Constructors: []ergast.Constructor{}}
+		for _, constructor := range constructors {
+			data.ConstructorTable.Constructors = append(data.ConstructorTable.Constructors, ergast.ConstructorOf(constructor))
+		}
+		return data, err
+
+	case ergast.ResourceCircuits:
+		circuits, total, err := ec.ergastRepo.GetErgastCircuitsQuery(q, p)
+		data := ergast.NewMRData(url, p, total)
+		data.CircuitTable = &ergast.CircuitTable{Criteria: criteria, Circuits: []ergast.Circuit{}}
+		for _, circuit := range circuits {
+			data.CircuitTable.Circuits = append(data.CircuitTable.Circuits, ergast.CircuitOf(circuit))
+		}
+		return data, err
+	}
+
+	table, err := ec.ergastRepo.GetErgastStandingsQuery(q)
+	if err != nil {
+		return ergast.MRData{}, err
+	}
+	return ergast.StandingsMRData(table, q, p, url), nil
+}
@@ -0,0 +1,121 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	"github.com/dewciu/f1_api/pkg/importer"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ImportController struct {
+	DB         *gorm.DB
+	importRepo *d.ImportRepository
+}
+
+func NewImportController(db *gorm.DB) *ImportController {
+	importRepo := d.NewImportRepository(db)
+	return &ImportController{DB: db, importRepo: importRepo}
+}
+
+// ImportOpenF1 godoc
+// @Summary Import OpenF1 session data
+// @Description Imports files exported in the OpenF1 schema, such as sessions.json, drivers.json, position.json, laps.json, stints.json, pit.json and race_control.json, optionally prefixed as in 9158_laps.json. Sessions are matched by their meeting key and drivers by their car number in the line-ups of the race. Records which cannot be mapped are queued for review instead of failing the import, records already imported are updated or skipped
+// @Tags imports
+// @Accept multipart/form-data
+// @Produce json
+// @Security ApiKeyAuth
+// @Param files formData file true "OpenF1 JSON files"
+// @Success 200 {array} ImportFileResponse "Returns what became of the records of each file"
+// @Router /imports/openf1 [post]
+func (ic *ImportController) ImportOpenF1(c *gin.Context) {
+	validator := v.ImportUploadValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	files := []importer.OpenF1File{}
+	for _, header := range validator.Files {
+		if _, ok := importer.OpenF1Kind(header.Filename); !ok {
+			c.JSON(http.StatusBadRequest, common.NewError("files", fmt.Errorf("%s is not an OpenF1 file", header.Filename)))
+			return
+		}
+		files = append(files, importer.OpenF1File{
+			Name: header.Filename,
+			Open: func() (io.ReadCloser, error) { return header.Open() },
+		})
+	}
+
+	summary, err := importer.NewSessionImporter(ic.DB).Import(importer.NewOpenF1Source(files))
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, common.NewError("import", err))
+		return
+	}
+
+	serializer := s.ImportSummarySerializer{C: c, Summary: summary}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// GetImportReviews godoc
+// @Summary Get records queued for review
+// @Description Lists the imported records which could not be mapped in the order they were queued, with the reason and the record as read
+// @Tags imports
+// @Produce json
+// @Security ApiKeyAuth
+// @Param source query string false "Source of the records" Enums(openf1)
+// @Param limit query int false "Page size" default(30)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {array} ImportReviewResponse "Returns the queued records"
+// @Router /imports/reviews [get]
+func (ic *ImportController) GetImportReviews(c *gin.Context) {
+	validator := v.ImportReviewFilterValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	pagination, err := common.GetPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("pagination", err))
+		return
+	}
+
+	reviews, err := ic.importRepo.GetImportReviewsQuery(validator.Source, pagination)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.ImportReviewsSerializer{C: c, Reviews: reviews}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// DeleteImportReview godoc
+// @Summary Dismiss a record queued for review
+// @Description Removes a reviewed record from the queue. A record still unmappable is queued again when its file is imported again
+// @Tags imports
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Review ID"
+// @Success 204 "No Content"
+// @Router /imports/reviews/{id} [delete]
+func (ic *ImportController) DeleteImportReview(c *gin.Context) {
+	err := ic.importRepo.DeleteImportReviewQuery(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("review", errors.New("review not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("review", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,301 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	_ "github.com/dewciu/f1_api/docs"
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/dewciu/f1_api/pkg/pace"
+	rc "github.com/dewciu/f1_api/pkg/racecontrol"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/dewciu/f1_api/pkg/weather"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type LapController struct {
+	DB              *gorm.DB
+	raceRepo        *d.RaceRepository
+	lapRepo         *d.LapRepository
+	raceControlRepo *d.RaceControlRepository
+	weatherRepo     *d.WeatherRepository
+	strategyRepo    *d.StrategyRepository
+}
+
+func NewLapController(db *gorm.DB) *LapController {
+	raceRepo := d.NewRaceRepository(db)
+	lapRepo := d.NewLapRepository(db)
+	raceControlRepo := d.NewRaceControlRepository(db)
+	weatherRepo := d.NewWeatherRepository(db)
+	strategyRepo := d.NewStrategyRepository(db)
+	return &LapController{DB: db, raceRepo: raceRepo, lapRepo: lapRepo, raceControlRepo: raceControlRepo, weatherRepo: weatherRepo, strategyRepo: strategyRepo}
+}
+
+// getRaceLaps loads the race from the id path parameter with its laps and
+// their drivers, writing the error response when it fails. The weather of
+// the laps is only loaded when the filter asks for it
+func (lc *LapController) getRaceLaps(c *gin.Context, filter v.LapsFilterValidator) (m.Race, []m.Lap, map[uuid.UUID]m.Driver, map[weather.LapKey]m.WeatherObservation, bool) {
+	race, ok := loadRace(c, lc.raceRepo)
+	if !ok {
+		return m.Race{}, nil, nil, nil, false
+	}
+
+	laps, err := lc.lapRepo.GetRaceLapsQuery(race.ID.String(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return m.Race{}, nil, nil, nil, false
+	}
+	if filter.ExcludeNeutralized {
+		events, err := lc.raceControlRepo.GetRaceControlEventsQuery(race.ID.String(), v.RaceControlFilterValidator{})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+			return m.Race{}, nil, nil, nil, false
+		}
+		laps = rc.ExcludeNeutralized(rc.Neutralizations(events), laps)
+	}
+	var conditions map[weather.LapKey]m.WeatherObservation
+	if filter.Weather || filter.ExcludeWet {
+		conditions, err = lc.getLapConditions(race, filter.Driver)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+			return m.Race{}, nil, nil, nil, false
+		}
+		if filter.ExcludeWet {
+			laps = weather.ExcludeWet(laps, conditions)
+		}
+	}
+	drivers, err := lc.lapRepo.GetLapDriversQuery(laps)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return m.Race{}, nil, nil, nil, false
+	}
+	return race, laps, drivers, conditions, true
+}
+
+// getLapConditions matches the laps of the race, of all drivers or one, to
+// the weather observations of the race session. All their laps are loaded,
+// as the laps are placed in time from the first one
+func (lc *LapController) getLapConditions(race m.Race, driver string) (map[weather.LapKey]m.WeatherObservation, error) {
+	session, err := lc.raceRepo.GetRaceSessionQuery(race.ID.String(), m.SessionRace)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return map[weather.LapKey]m.WeatherObservation{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	laps, err := lc.lapRepo.GetRaceLapsQuery(race.ID.String(), v.LapsFilterValidator{Driver: driver})
+	if err != nil {
+		return nil, err
+	}
+	observations, err := lc.weatherRepo.GetSessionWeatherQuery(session.ID.String(), v.WeatherFilterValidator{})
+	if err != nil {
+		return nil, err
+	}
+	return weather.LapConditions(session.StartTime, laps, observations), nil
+}
+
+// GetRaceLaps godoc
+// @Summary Get Race laps
+// @Description Retrieves the lap times and positions of the race grouped by driver, drivers completing more laps first. Laps can be annotated with the weather observation nearest to the middle of the lap, a lap is wet when it reports rainfall
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param driver query string false "Driver ID"
+// @Param from_lap query int false "First lap"
+// @Param to_lap query int false "Last lap"
+// @Param exclude_neutralized query bool false "Leave out laps under the Safety Car, VSC or red flag"
+// @Param weather query bool false "Annotate the laps with the nearest weather observation"
+// @Param exclude_wet query bool false "Leave out laps run in the rain"
+// @Success 200 {object} RaceLapsResponse "Returns the laps"
+// @Router /races/{id}/laps [get]
+func (lc *LapController) GetRaceLaps(c *gin.Context) {
+	validator := v.LapsFilterValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	race, laps, drivers, conditions, ok := lc.getRaceLaps(c, validator)
+	if !ok {
+		return
+	}
+
+	serializer := s.RaceLapsSerializer{C: c, RaceID: race.ID, Laps: laps, Drivers: drivers}
+	if validator.Weather {
+		serializer.Weather = conditions
+	}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// SaveRaceLaps godoc
+// @Summary Submit Race laps
+// @Description Stores the lap times and positions of drivers entered for the race in bulk, laps already stored are replaced
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param Laps body LapsModelValidator true "Laps"
+// @Success 201 {object} RaceLapsResponse "Returns the laps of the race"
+// @Router /races/{id}/laps [post]
+func (lc *LapController) SaveRaceLaps(c *gin.Context) {
+	race, ok := loadRace(c, lc.raceRepo)
+	if !ok {
+		return
+	}
+
+	validator := v.LapsModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	if err := lc.lapRepo.SaveRaceLapsQuery(race, validator.Models); err != nil {
+		writeResultError(c, err)
+		return
+	}
+
+	race, laps, drivers, _, ok := lc.getRaceLaps(c, v.LapsFilterValidator{})
+	if !ok {
+		return
+	}
+
+	serializer := s.RaceLapsSerializer{C: c, RaceID: race.ID, Laps: laps, Drivers: drivers}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
+
+// GetLapChart godoc
+// @Summary Get Race lap chart
+// @Description Retrieves the position of every driver at the end of each lap, null where the driver has no time for the lap
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Success 200 {object} LapChartResponse "Returns the lap chart"
+// @Router /races/{id}/laps/chart [get]
+func (lc *LapController) GetLapChart(c *gin.Context) {
+	race, laps, drivers, _, ok := lc.getRaceLaps(c, v.LapsFilterValidator{})
+	if !ok {
+		return
+	}
+
+	serializer := s.LapChartSerializer{C: c, RaceID: race.ID, Laps: laps, Drivers: drivers}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// GetGapChart godoc
+// @Summary Get Race gap chart
+// @Description Retrieves the cumulative gap of every driver to the leader at the end of each lap in milliseconds, null from the first lap without a time
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Success 200 {object} GapChartResponse "Returns the gap chart"
+// @Router /races/{id}/laps/gaps [get]
+func (lc *LapController) GetGapChart(c *gin.Context) {
+	race, laps, drivers, _, ok := lc.getRaceLaps(c, v.LapsFilterValidator{})
+	if !ok {
+		return
+	}
+
+	serializer := s.GapChartSerializer{C: c, RaceID: race.ID, Laps: laps, Drivers: drivers}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// GetFastestLaps godoc
+// @Summary Get Race fastest laps
+// @Description Ranks every driver by their fastest lap of the race with the gap to the fastest one
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param driver query string false "Driver ID"
+// @Param from_lap query int false "First lap"
+// @Param to_lap query int false "Last lap"
+// @Param exclude_neutralized query bool false "Leave out laps under the Safety Car, VSC or red flag"
+// @Param exclude_wet query bool false "Leave out laps run in the rain"
+// @Success 200 {object} FastestLapsResponse "Returns the fastest lap ranking"
+// @Router /races/{id}/laps/fastest [get]
+func (lc *LapController) GetFastestLaps(c *gin.Context) {
+	validator := v.LapsFilterValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	race, laps, drivers, _, ok := lc.getRaceLaps(c, validator)
+	if !ok {
+		return
+	}
+
+	serializer := s.FastestLapsSerializer{C: c, RaceID: race.ID, Laps: laps, Drivers: drivers}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// GetRacePace godoc
+// @Summary Get Race pace
+// @Description Estimates the representative pace of every driver as the median of their clean laps, quickest first. The first lap, in-laps, out-laps, laps under the Safety Car, VSC or red flag and outliers of their stint are left out. Lap times are corrected to an empty tank by the fuel correction for every lap left to run, and a linear or quadratic degradation model in the age of the tyres is fitted to every stint. Times are in seconds
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param fuel_correction query number false "Seconds gained for every lap of fuel burnt, 0.06 by default"
+// @Param model query string false "Degradation model, linear by default" Enums(linear, quadratic)
+// @Param outlier_threshold query number false "Deviations from the median of a stint beyond which a lap is an outlier, 3 by default"
+// @Param exclude_wet query bool false "Leave out laps run in the rain"
+// @Success 200 {object} PaceResponse "Returns the pace of the drivers"
+// @Router /races/{id}/pace [get]
+func (lc *LapController) GetRacePace(c *gin.Context) {
+	validator := v.PaceFilterValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	race, laps, drivers, _, ok := lc.getRaceLaps(c, v.LapsFilterValidator{})
+	if !ok {
+		return
+	}
+	stints, err := lc.strategyRepo.GetStintsQuery(race.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+	stops, err := lc.strategyRepo.GetPitStopsQuery(race.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+	events, err := lc.raceControlRepo.GetRaceControlEventsQuery(race.ID.String(), v.RaceControlFilterValidator{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	exclusions := pace.Exclusions{Neutralized: rc.NeutralizedLaps(rc.Neutralizations(events), laps)}
+	if validator.ExcludeWet {
+		conditions, err := lc.getLapConditions(race, "")
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+			return
+		}
+		exclusions.Wet = make(map[weather.LapKey]bool)
+		for key, observation := range conditions {
+			exclusions.Wet[key] = observation.Rainfall
+		}
+	}
+
+	serializer := s.PaceSerializer{C: c, RaceID: race.ID, Drivers: drivers, Pace: pace.Analyse(laps, stints, stops, exclusions, validator.Options)}
+	c.JSON(http.StatusOK, serializer.Response())
+}
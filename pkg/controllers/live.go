@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	"github.com/dewciu/f1_api/pkg/live"
+	m "github.com/dewciu/f1_api/pkg/models"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
+	"gorm.io/gorm"
+)
+
+// liveWriteTimeout is the time a subscriber has to take a message, one not
+// reading falls behind until the hub evicts it
+const liveWriteTimeout = 5 * time.Second
+
+type LiveController struct {
+	DB       *gorm.DB
+	raceRepo *d.RaceRepository
+	hub      *live.Hub
+	// origins are the origins allowed to open the WebSocket
+	origins []string
+}
+
+func NewLiveController(db *gorm.DB, hub *live.Hub, origins []string) *LiveController {
+	raceRepo := d.NewRaceRepository(db)
+	return &LiveController{DB: db, raceRepo: raceRepo, hub: hub, origins: origins}
+}
+
+// loadLiveSession loads the session from the session path parameter and its
+// timing into the hub, which restores it from the last snapshot when it has
+// none, writing the error response when either fails
+func (lc *LiveController) loadLiveSession(c *gin.Context) (m.Session, bool) {
+	session, err := lc.raceRepo.GetSessionByIdQuery(c.Param("session"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("session", errors.New("session not found")))
+			return m.Session{}, false
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("session", err))
+		return m.Session{}, false
+	}
+
+	if _, _, err := lc.hub.State(session.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("live", err))
+		return m.Session{}, false
+	}
+	return session, true
+}
+
+// GetLiveState godoc
+// @Summary Get live timing state
+// @Description Retrieves the full timing tower of the session, the current one while the session is live and the last snapshot after a restart. Late joiners apply the deltas of the subscription with a sequence above the one of the state
+// @Tags live
+// @Produce json
+// @Security ApiKeyAuth
+// @Param session path string true "Session ID"
+// @Success 200 {object} LiveStateResponse "Returns the timing tower"
+// @Router /live/{session}/state [get]
+func (lc *LiveController) GetLiveState(c *gin.Context) {
+	session, ok := lc.loadLiveSession(c)
+	if !ok {
+		return
+	}
+
+	state, ok, err := lc.hub.State(session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("live", err))
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusNotFound, common.NewError("live", errors.New("session has no live timing")))
+		return
+	}
+
+	serializer := s.LiveStateSerializer{C: c, State: state}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// SubscribeLive godoc
+// @Summary Subscribe to live timing
+// @Description Sends the timing of the session over a WebSocket, a state message with the full timing tower first and then a delta message with the changed fields of a driver for every update, numbered by their sequence. A subscriber falling too far behind is sent an evicted message and disconnected, it may subscribe again for a fresh state
+// @Tags live
+// @Security ApiKeyAuth
+// @Param session path string true "Session ID"
+// @Success 101 {object} LiveMessageResponse "Switches to the WebSocket protocol"
+// @Router /live/{session}/ws [get]
+func (lc *LiveController) SubscribeLive(c *gin.Context) {
+	session, ok := lc.loadLiveSession(c)
+	if !ok {
+		return
+	}
+
+	serveWebSocket(c, lc.origins, func(conn *websocket.Conn) {
+		state, subscription, err := lc.hub.Subscribe(session.ID)
+		if err != nil {
+			logrus.Errorf("Failed to subscribe to live timing: %v", err)
+			return
+		}
+		defer lc.hub.Unsubscribe(subscription)
+
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+		go discardMessages(conn, cancel)
+
+		serializer := s.LiveStateSerializer{C: c, State: state}
+		if sendLive(conn, s.LiveMessageResponse{Type: s.LiveMessageState, Data: serializer.Response()}) != nil {
+			return
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case delta, ok := <-subscription.C:
+				if !ok {
+					if err := subscription.Err(); err != nil {
+						sendLive(conn, s.LiveMessageResponse{Type: s.LiveMessageEvicted, Data: err.Error()})
+					}
+					return
+				}
+				serializer := s.LiveDeltaSerializer{C: c, Delta: delta}
+				if sendLive(conn, s.LiveMessageResponse{Type: s.LiveMessageDelta, Data: serializer.Response()}) != nil {
+					return
+				}
+			}
+		}
+	})
+}
+
+func sendLive(conn *websocket.Conn, message s.LiveMessageResponse) error {
+	conn.SetWriteDeadline(time.Now().Add(liveWriteTimeout))
+	return websocket.JSON.Send(conn, message)
+}
+
+// discardMessages reads the socket until the subscriber disconnects, the
+// subscription takes no messages
+func discardMessages(conn *websocket.Conn, disconnect context.CancelFunc) {
+	defer disconnect()
+	var message []byte
+	for {
+		if err := websocket.Message.Receive(conn, &message); err != nil {
+			return
+		}
+	}
+}
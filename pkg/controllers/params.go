@@ -0,0 +1,18 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errInvalidYear = errors.New("year must be a number")
+
+func parseYearParam(c *gin.Context) (int, error) {
+	year, err := strconv.Atoi(c.Param("year"))
+	if err != nil || year < 1950 {
+		return 0, errInvalidYear
+	}
+	return year, nil
+}
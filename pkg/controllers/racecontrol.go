@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"net/http"
+
+	_ "github.com/dewciu/f1_api/docs"
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	rc "github.com/dewciu/f1_api/pkg/racecontrol"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type RaceControlController struct {
+	DB              *gorm.DB
+	raceRepo        *d.RaceRepository
+	lapRepo         *d.LapRepository
+	raceControlRepo *d.RaceControlRepository
+}
+
+func NewRaceControlController(db *gorm.DB) *RaceControlController {
+	raceRepo := d.NewRaceRepository(db)
+	lapRepo := d.NewLapRepository(db)
+	raceControlRepo := d.NewRaceControlRepository(db)
+	return &RaceControlController{DB: db, raceRepo: raceRepo, lapRepo: lapRepo, raceControlRepo: raceControlRepo}
+}
+
+// GetRaceControl godoc
+// @Summary Get Race control events
+// @Description Retrieves the flags, Safety Car and VSC messages, track limits deletions, investigations and penalties of the race in time order
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param type query string false "Event type"
+// @Param driver query string false "Driver ID"
+// @Success 200 {array} RaceControlEventResponse "Returns the events"
+// @Router /races/{id}/race-control [get]
+func (cc *RaceControlController) GetRaceControl(c *gin.Context) {
+	validator := v.RaceControlFilterValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	race, ok := loadRace(c, cc.raceRepo)
+	if !ok {
+		return
+	}
+
+	events, err := cc.raceControlRepo.GetRaceControlEventsQuery(race.ID.String(), validator)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.RaceControlEventsSerializer{C: c, Events: events}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// CreateRaceControlEvents godoc
+// @Summary Add Race control events
+// @Description Adds race control messages to the race, drivers they name have to be entered for the round
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param Events body RaceControlEventsModelValidator true "Race control events"
+// @Success 201 {array} RaceControlEventResponse "Returns every event of the race"
+// @Router /races/{id}/race-control [post]
+func (cc *RaceControlController) CreateRaceControlEvents(c *gin.Context) {
+	race, ok := loadRace(c, cc.raceRepo)
+	if !ok {
+		return
+	}
+
+	validator := v.RaceControlEventsModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	if err := cc.raceControlRepo.CreateRaceControlEventsQuery(race, validator.Models); err != nil {
+		writeResultError(c, err)
+		return
+	}
+
+	events, err := cc.raceControlRepo.GetRaceControlEventsQuery(race.ID.String(), v.RaceControlFilterValidator{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.RaceControlEventsSerializer{C: c, Events: events}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
+
+// GetNeutralizations godoc
+// @Summary Get Race neutralizations
+// @Description Derives the Safety Car, Virtual Safety Car and red flag periods of the race from its race control events, with the laps run under them
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Success 200 {object} NeutralizationsResponse "Returns the neutralizations"
+// @Router /races/{id}/neutralizations [get]
+func (cc *RaceControlController) GetNeutralizations(c *gin.Context) {
+	race, ok := loadRace(c, cc.raceRepo)
+	if !ok {
+		return
+	}
+
+	events, err := cc.raceControlRepo.GetRaceControlEventsQuery(race.ID.String(), v.RaceControlFilterValidator{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+	laps, err := cc.lapRepo.GetRaceLapsQuery(race.ID.String(), v.LapsFilterValidator{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	// the race runs to the last lap stored or, without laps, mentioned by
+	// race control
+	lastLap := 0
+	for _, lap := range laps {
+		if lap.Number > lastLap {
+			lastLap = lap.Number
+		}
+	}
+	for _, event := range events {
+		if event.Lap != nil && *event.Lap > lastLap {
+			lastLap = *event.Lap
+		}
+	}
+
+	serializer := s.NeutralizationsSerializer{C: c, RaceID: race.ID, Periods: rc.Neutralizations(events), LastLap: lastLap}
+	c.JSON(http.StatusOK, serializer.Response())
+}
@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	_ "github.com/dewciu/f1_api/docs"
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	m "github.com/dewciu/f1_api/pkg/models"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type RaceController struct {
+	DB       *gorm.DB
+	raceRepo *d.RaceRepository
+	// Now returns the server clock, replaced in tests
+	Now func() time.Time
+}
+
+func NewRaceController(db *gorm.DB) *RaceController {
+	raceRepo := d.NewRaceRepository(db)
+	return &RaceController{DB: db, raceRepo: raceRepo, Now: time.Now}
+}
+
+// getRace loads the race from the id path parameter, writing the error
+// response when it cannot be found
+func (rc *RaceController) getRace(c *gin.Context) (m.Race, bool) {
+	race, err := rc.raceRepo.GetRaceByIdQuery(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("race", errors.New("race not found")))
+			return m.Race{}, false
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("race", err))
+		return m.Race{}, false
+	}
+	return race, true
+}
+
+func writeSessionError(c *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, common.NewError("session", errors.New("session not found")))
+		return
+	}
+	var calendarErr *v.CalendarError
+	if errors.As(err, &calendarErr) {
+		c.JSON(http.StatusBadRequest, common.NewError("calendar", calendarErr))
+		return
+	}
+	var alreadyExistsErr *common.AlreadyExistsError
+	if errors.As(err, &alreadyExistsErr) {
+		c.JSON(http.StatusConflict, common.NewError("session", alreadyExistsErr))
+		return
+	}
+	c.JSON(http.StatusInternalServerError, common.NewError("session", err))
+}
+
+// GetRaceByID godoc
+// @Summary Get Race by ID
+// @Description Retrieves a race with its circuit and session schedule by ID
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Success 200 {object} RaceResponse "Returns the race"
+// @Router /races/{id} [get]
+func (rc *RaceController) GetRaceByID(c *gin.Context) {
+	race, ok := rc.getRace(c)
+	if !ok {
+		return
+	}
+
+	serializer := s.RaceSerializer{C: c, Race: race}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// DeleteRaceByID godoc
+// @Summary Delete Race by ID
+// @Description Deletes a race and its sessions from the database by ID
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Success 204 "No Content"
+// @Router /races/{id} [delete]
+func (rc *RaceController) DeleteRaceByID(c *gin.Context) {
+	err := rc.raceRepo.DeleteRaceByIdQuery(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("race", errors.New("race not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("race", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateRace godoc
+// @Summary Update Race by ID
+// @Description Updates the name, link or points scale of a race, the points scale is 0.5 for races awarding half points
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param Race body RaceUpdateModelValidator true "Race fields to update"
+// @Success 200 {object} RaceResponse "Returns the updated race"
+// @Router /races/{id} [put]
+func (rc *RaceController) UpdateRace(c *gin.Context) {
+	validator := v.RaceUpdateModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	race, err := rc.raceRepo.UpdateRaceByIdQuery(c.Param("id"), validator)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("race", errors.New("race not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("race", err))
+		return
+	}
+
+	serializer := s.RaceSerializer{C: c, Race: race}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// GetRaceSessions godoc
+// @Summary Get Race sessions
+// @Description Retrieves the session schedule of the race, start times are in UTC
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Success 200 {array} SessionResponse "Returns list of sessions"
+// @Router /races/{id}/sessions [get]
+func (rc *RaceController) GetRaceSessions(c *gin.Context) {
+	race, ok := rc.getRace(c)
+	if !ok {
+		return
+	}
+
+	serializer := s.SessionsSerializer{C: c, Sessions: race.Sessions}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// CreateSession godoc
+// @Summary Create Race session
+// @Description Adds a session to the race weekend, it must not overlap other sessions nor leave the weekend
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param Session body SessionCreateModelValidator true "Session Object"
+// @Success 201 {object} SessionResponse "Returns Created Session"
+// @Router /races/{id}/sessions [post]
+func (rc *RaceController) CreateSession(c *gin.Context) {
+	race, ok := rc.getRace(c)
+	if !ok {
+		return
+	}
+
+	validator := v.SessionCreateModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	session, err := rc.raceRepo.CreateSessionQuery(race, validator.Session)
+	if err != nil {
+		writeSessionError(c, err)
+		return
+	}
+
+	serializer := s.SessionSerializer{C: c, Session: session}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
+
+// UpdateSession godoc
+// @Summary Update Race session
+// @Description Reschedules the session or changes its status
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param session path string true "Session ID"
+// @Param Session body SessionUpdateModelValidator true "Session fields to update"
+// @Success 200 {object} SessionResponse "Returns the updated session"
+// @Router /races/{id}/sessions/{session} [put]
+func (rc *RaceController) UpdateSession(c *gin.Context) {
+	race, ok := rc.getRace(c)
+	if !ok {
+		return
+	}
+
+	validator := v.SessionUpdateModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	session, err := rc.raceRepo.UpdateSessionQuery(race, c.Param("session"), validator)
+	if err != nil {
+		writeSessionError(c, err)
+		return
+	}
+
+	serializer := s.SessionSerializer{C: c, Session: session}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// GetNextSession godoc
+// @Summary Get next Session
+// @Description Retrieves the session which is live or starts next according to the server clock
+// @Tags sessions
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} NextSessionResponse "Returns the upcoming session with its race"
+// @Router /sessions/next [get]
+func (rc *RaceController) GetNextSession(c *gin.Context) {
+	now := rc.Now().UTC()
+
+	session, race, err := rc.raceRepo.GetNextSessionQuery(now)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("session", errors.New("no upcoming session")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("session", err))
+		return
+	}
+
+	serializer := s.NextSessionSerializer{C: c, Now: now, Session: session, Race: race}
+	c.JSON(http.StatusOK, serializer.Response())
+}
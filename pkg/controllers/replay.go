@@ -0,0 +1,234 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/dewciu/f1_api/pkg/replay"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/net/websocket"
+	"gorm.io/gorm"
+)
+
+type ReplayController struct {
+	DB              *gorm.DB
+	raceRepo        *d.RaceRepository
+	lapRepo         *d.LapRepository
+	strategyRepo    *d.StrategyRepository
+	raceControlRepo *d.RaceControlRepository
+	library         *replay.Library
+	// origins are the origins allowed to open the WebSocket
+	origins []string
+}
+
+func NewReplayController(db *gorm.DB, origins []string) *ReplayController {
+	raceRepo := d.NewRaceRepository(db)
+	lapRepo := d.NewLapRepository(db)
+	strategyRepo := d.NewStrategyRepository(db)
+	raceControlRepo := d.NewRaceControlRepository(db)
+	rc := &ReplayController{DB: db, raceRepo: raceRepo, lapRepo: lapRepo, strategyRepo: strategyRepo, raceControlRepo: raceControlRepo, origins: origins}
+	rc.library = replay.NewLibrary(rc.loadReplay, replay.SystemClock)
+	return rc
+}
+
+// loadReplay builds the replay of the race session, it is loaded once for all
+// viewers of the race
+func (rc *ReplayController) loadReplay(raceID uuid.UUID) (*replay.Replay, error) {
+	session, err := rc.raceRepo.GetRaceSessionQuery(raceID.String(), m.SessionRace)
+	if err != nil {
+		return nil, err
+	}
+	laps, err := rc.lapRepo.GetRaceLapsQuery(raceID.String(), v.LapsFilterValidator{})
+	if err != nil {
+		return nil, err
+	}
+	stops, err := rc.strategyRepo.GetPitStopsQuery(raceID.String())
+	if err != nil {
+		return nil, err
+	}
+	messages, err := rc.raceControlRepo.GetRaceControlEventsQuery(raceID.String(), v.RaceControlFilterValidator{})
+	if err != nil {
+		return nil, err
+	}
+	return replay.Build(raceID, session.StartTime, laps, stops, messages), nil
+}
+
+// openReplay opens a player of the finished race from the id path parameter
+// owned by the requesting user, writing the error response when it cannot be
+// played back
+func (rc *ReplayController) openReplay(c *gin.Context, start replay.Control) (*replay.Player, bool) {
+	race, ok := loadRace(c, rc.raceRepo)
+	if !ok {
+		return nil, false
+	}
+
+	session, err := rc.raceRepo.GetRaceSessionQuery(race.ID.String(), m.SessionRace)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("session", errors.New("race has no "+m.SessionRace+" session")))
+			return nil, false
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("session", err))
+		return nil, false
+	}
+	if session.Status != m.SessionFinished {
+		c.JSON(http.StatusConflict, common.NewError("replay", errors.New("only a finished race can be replayed")))
+		return nil, false
+	}
+
+	player, err := rc.library.Open(race.ID, c.GetString("req_user_id"), start)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("replay", err))
+		return nil, false
+	}
+	return player, true
+}
+
+// StreamReplay godoc
+// @Summary Stream race replay
+// @Description Plays a finished race back as server-sent events, as if it were live. Position and gap events follow the drivers completing their laps, pit events the end of their in-laps and race control events the time of the message, all in time order. The stream starts with a state event naming the viewer, which controls the playback through the control endpoint, and a snapshot event with the running order. A seek is followed by a new snapshot and the end event follows the last event of the race
+// @Tags races
+// @Produce text/event-stream
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param speed query number false "Speed multiplier" default(1)
+// @Param from query number false "Offset into the race in seconds"
+// @Param paused query bool false "Start paused"
+// @Success 200 {object} ReplayEventResponse "Streams the events"
+// @Router /races/{id}/replay [get]
+func (rc *ReplayController) StreamReplay(c *gin.Context) {
+	validator := v.ReplayStreamValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	player, ok := rc.openReplay(c, validator.Start())
+	if !ok {
+		return
+	}
+	defer rc.library.Close(player)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	player.Run(c.Request.Context(), func(event replay.Event) error {
+		serializer := s.ReplayEventSerializer{C: c, Start: player.Start(), Event: event}
+		c.SSEvent(event.Type, serializer.Response())
+		c.Writer.Flush()
+		return c.Request.Context().Err()
+	})
+}
+
+// ControlReplay godoc
+// @Summary Control race replay
+// @Description Pauses, resumes, seeks or changes the speed of the replay streamed to the viewer, which only the user who opened it may control. A seek takes an offset into the race in seconds and a change of speed the new multiplier. The stream reports the new state of the playback
+// @Tags races
+// @Accept json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param viewer path string true "Viewer ID"
+// @Param Control body ReplayControlValidator true "Control"
+// @Success 204 "No Content"
+// @Router /races/{id}/replay/{viewer} [post]
+func (rc *ReplayController) ControlReplay(c *gin.Context) {
+	validator := v.ReplayControlValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	viewer, err := uuid.Parse(c.Param("viewer"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, common.NewError("replay", errors.New("viewer not found")))
+		return
+	}
+	player, ok := rc.library.Player(viewer, c.GetString("req_user_id"))
+	if !ok || player.RaceID().String() != c.Param("id") {
+		c.JSON(http.StatusNotFound, common.NewError("replay", errors.New("viewer not found")))
+		return
+	}
+
+	if err := player.Control(validator.Control()); err != nil {
+		c.JSON(http.StatusNotFound, common.NewError("replay", errors.New("viewer not found")))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ReplayWebSocket godoc
+// @Summary Replay race over WebSocket
+// @Description Plays a finished race back over a WebSocket with the events of the server-sent stream, one JSON message each. The playback is controlled by sending ReplayControlValidator messages over the socket, a rejected control is answered with an error message
+// @Tags races
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param speed query number false "Speed multiplier" default(1)
+// @Param from query number false "Offset into the race in seconds"
+// @Param paused query bool false "Start paused"
+// @Success 101 {object} ReplayEventResponse "Switches to the WebSocket protocol"
+// @Router /races/{id}/replay/ws [get]
+func (rc *ReplayController) ReplayWebSocket(c *gin.Context) {
+	validator := v.ReplayStreamValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	player, ok := rc.openReplay(c, validator.Start())
+	if !ok {
+		return
+	}
+	defer rc.library.Close(player)
+
+	serveWebSocket(c, rc.origins, func(conn *websocket.Conn) {
+		ctx, cancel := context.WithCancel(c.Request.Context())
+		defer cancel()
+		go receiveReplayControls(conn, player, cancel)
+
+		player.Run(ctx, func(event replay.Event) error {
+			serializer := s.ReplayEventSerializer{C: c, Start: player.Start(), Event: event}
+			conn.SetWriteDeadline(time.Now().Add(replayWriteTimeout))
+			return websocket.JSON.Send(conn, serializer.Response())
+		})
+	})
+}
+
+// replayWriteTimeout drops a viewer not reading the events
+const replayWriteTimeout = 10 * time.Second
+
+// receiveReplayControls passes the controls sent over the socket to the
+// player until the viewer disconnects
+func receiveReplayControls(conn *websocket.Conn, player *replay.Player, disconnect context.CancelFunc) {
+	defer disconnect()
+	for {
+		validator := v.ReplayControlValidator{}
+		if err := websocket.JSON.Receive(conn, &validator); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				websocket.JSON.Send(conn, gin.H{"error": err.Error()})
+				continue
+			}
+			return
+		}
+		if err := validator.Validate(); err != nil {
+			websocket.JSON.Send(conn, gin.H{"error": err})
+			continue
+		}
+		if player.Control(validator.Control()) != nil {
+			return
+		}
+	}
+}
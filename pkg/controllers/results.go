@@ -0,0 +1,541 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	_ "github.com/dewciu/f1_api/docs"
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	"github.com/dewciu/f1_api/pkg/grid"
+	m "github.com/dewciu/f1_api/pkg/models"
+	q "github.com/dewciu/f1_api/pkg/qualifying"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	"github.com/dewciu/f1_api/pkg/sprint"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ResultController struct {
+	DB          *gorm.DB
+	raceRepo    *d.RaceRepository
+	resultRepo  *d.ResultRepository
+	driverRepo  *d.DriverRepository
+	gridRepo    *d.GridRepository
+	stewardRepo *d.StewardRepository
+}
+
+func NewResultController(db *gorm.DB) *ResultController {
+	raceRepo := d.NewRaceRepository(db)
+	resultRepo := d.NewResultRepository(db)
+	driverRepo := d.NewDriverRepository(db)
+	gridRepo := d.NewGridRepository(db)
+	stewardRepo := d.NewStewardRepository(db)
+	return &ResultController{DB: db, raceRepo: raceRepo, resultRepo: resultRepo, driverRepo: driverRepo, gridRepo: gridRepo, stewardRepo: stewardRepo}
+}
+
+func (rc *ResultController) getRace(c *gin.Context) (m.Race, bool) {
+	return loadRace(c, rc.raceRepo)
+}
+
+// loadRace loads the race from the id path parameter, writing the error
+// response when it cannot be found
+func loadRace(c *gin.Context, raceRepo *d.RaceRepository) (m.Race, bool) {
+	race, err := raceRepo.GetRaceByIdQuery(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("race", errors.New("race not found")))
+			return m.Race{}, false
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("race", err))
+		return m.Race{}, false
+	}
+	return race, true
+}
+
+// getRaceSession loads the race from the id path parameter and its session of
+// the given type, writing the error response when either cannot be found
+func (rc *ResultController) getRaceSession(c *gin.Context, sessionType string) (m.Race, m.Session, bool) {
+	race, ok := rc.getRace(c)
+	if !ok {
+		return m.Race{}, m.Session{}, false
+	}
+
+	session, err := rc.raceRepo.GetRaceSessionQuery(race.ID.String(), sessionType)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("session", errors.New("race has no "+sessionType+" session")))
+			return m.Race{}, m.Session{}, false
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("session", err))
+		return m.Race{}, m.Session{}, false
+	}
+
+	return race, session, true
+}
+
+func writeResultError(c *gin.Context, err error) {
+	var classificationErr *v.ClassificationError
+	if errors.As(err, &classificationErr) {
+		c.JSON(http.StatusBadRequest, common.NewError("classification", classificationErr))
+		return
+	}
+	var referenceErr *common.InvalidReferenceError
+	if errors.As(err, &referenceErr) {
+		c.JSON(http.StatusBadRequest, common.NewError("result", referenceErr))
+		return
+	}
+	c.JSON(http.StatusInternalServerError, common.NewError("database", err))
+}
+
+// getSessionResults writes the classification of the session of the given
+// type, as it stood at the as_of query parameter when given
+func (rc *ResultController) getSessionResults(c *gin.Context, sessionType string) {
+	validator := v.ResultsFilterValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	_, session, ok := rc.getRaceSession(c, sessionType)
+	if !ok {
+		return
+	}
+
+	var results []m.Result
+	var err error
+	if validator.ParsedAsOf != nil {
+		results, err = rc.stewardRepo.GetSessionResultsAtQuery(session.ID.String(), *validator.ParsedAsOf)
+	} else {
+		results, err = rc.resultRepo.GetSessionResultsQuery(session.ID.String())
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("results", errors.New("session had no classification at that time")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.ResultsSerializer{C: c, Results: results}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// saveSessionResults replaces the classification of the session of the
+// given type with the submitted one
+func (rc *ResultController) saveSessionResults(c *gin.Context, sessionType string) {
+	race, session, ok := rc.getRaceSession(c, sessionType)
+	if !ok {
+		return
+	}
+
+	validator := v.SessionResultsModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	results, err := rc.resultRepo.SaveSessionResultsQuery(race, session, validator.Models)
+	if err != nil {
+		writeResultError(c, err)
+		return
+	}
+
+	serializer := s.ResultsSerializer{C: c, Results: results}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
+
+// getQualifying writes the qualifying classification of the session of the
+// given type
+func (rc *ResultController) getQualifying(c *gin.Context, sessionType string) {
+	validator := v.QualifyingFilterValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	_, session, ok := rc.getRaceSession(c, sessionType)
+	if !ok {
+		return
+	}
+
+	results, err := rc.resultRepo.GetQualifyingResultsQuery(session.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	report := q.Evaluate(results).Filter(validator.Segment, validator.ParsedMaxTime)
+
+	serializer := s.QualifyingSerializer{C: c, Report: report}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// saveQualifying replaces the qualifying classification of the session of
+// the given type with the submitted one
+func (rc *ResultController) saveQualifying(c *gin.Context, sessionType string) {
+	race, session, ok := rc.getRaceSession(c, sessionType)
+	if !ok {
+		return
+	}
+
+	validator := v.QualifyingResultsModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	results, err := rc.resultRepo.SaveQualifyingResultsQuery(race, session, validator.Models)
+	if err != nil {
+		writeResultError(c, err)
+		return
+	}
+
+	serializer := s.QualifyingSerializer{C: c, Report: q.Evaluate(results)}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
+
+// GetRaceResults godoc
+// @Summary Get Race results
+// @Description Retrieves the classification of the race, classified drivers first followed by the unclassified ones. With as_of it is the classification as it stood at that time, amended by the steward decisions in force then
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param as_of query string false "Time of the classification, e.g. 2023-03-05T18:00:00Z"
+// @Success 200 {array} ResultResponse "Returns the classification"
+// @Router /races/{id}/results [get]
+func (rc *ResultController) GetRaceResults(c *gin.Context) {
+	rc.getSessionResults(c, m.SessionRace)
+}
+
+// SaveRaceResults godoc
+// @Summary Submit Race results
+// @Description Replaces the classification of the race, every driver has to be entered for the round and classified positions have to be contiguous
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param Results body SessionResultsModelValidator true "Race classification"
+// @Success 201 {array} ResultResponse "Returns the stored classification"
+// @Router /races/{id}/results [post]
+func (rc *ResultController) SaveRaceResults(c *gin.Context) {
+	rc.saveSessionResults(c, m.SessionRace)
+}
+
+// GetSprintResults godoc
+// @Summary Get Race sprint results
+// @Description Retrieves the classification of the sprint of the race weekend, called Sprint Qualifying in 2021. With as_of it is the classification as it stood at that time
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param as_of query string false "Time of the classification, e.g. 2023-07-29T16:00:00Z"
+// @Success 200 {array} ResultResponse "Returns the sprint classification"
+// @Router /races/{id}/sprint [get]
+func (rc *ResultController) GetSprintResults(c *gin.Context) {
+	rc.getSessionResults(c, m.SessionSprint)
+}
+
+// SaveSprintResults godoc
+// @Summary Submit Race sprint results
+// @Description Replaces the classification of the sprint, every driver has to be entered for the round and classified positions have to be contiguous. Standings score it with the sprint points of the season
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param Results body SessionResultsModelValidator true "Sprint classification"
+// @Success 201 {array} ResultResponse "Returns the stored sprint classification"
+// @Router /races/{id}/sprint [post]
+func (rc *ResultController) SaveSprintResults(c *gin.Context) {
+	rc.saveSessionResults(c, m.SessionSprint)
+}
+
+// GetRaceQualifying godoc
+// @Summary Get Race qualifying
+// @Description Retrieves the qualifying classification with gaps to pole and to the car ahead, measured in the last segment each driver reached, and the 107% rule check against the fastest Q1 lap
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param segment query string false "Only drivers who reached the segment" Enums(Q1, Q2, Q3)
+// @Param max_time query string false "Only drivers whose lap in the segment is not slower, e.g. 1:30.000"
+// @Success 200 {object} QualifyingResponse "Returns the qualifying classification"
+// @Router /races/{id}/qualifying [get]
+func (rc *ResultController) GetRaceQualifying(c *gin.Context) {
+	rc.getQualifying(c, m.SessionQualifying)
+}
+
+// SaveRaceQualifying godoc
+// @Summary Submit Race qualifying
+// @Description Replaces the qualifying classification of the race, lap times are written as 1:23.456
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param Results body QualifyingResultsModelValidator true "Qualifying classification"
+// @Success 201 {object} QualifyingResponse "Returns the stored qualifying classification"
+// @Router /races/{id}/qualifying [post]
+func (rc *ResultController) SaveRaceQualifying(c *gin.Context) {
+	rc.saveQualifying(c, m.SessionQualifying)
+}
+
+// GetSprintShootout godoc
+// @Summary Get Race sprint shootout
+// @Description Retrieves the classification of the session setting the sprint grid from 2023, the Sprint Shootout, renamed Sprint Qualifying in 2024. Its segments SQ1 to SQ3 are stored as Q1 to Q3
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param segment query string false "Only drivers who reached the segment" Enums(Q1, Q2, Q3)
+// @Param max_time query string false "Only drivers whose lap in the segment is not slower, e.g. 1:30.000"
+// @Success 200 {object} QualifyingResponse "Returns the shootout classification"
+// @Router /races/{id}/sprint/shootout [get]
+func (rc *ResultController) GetSprintShootout(c *gin.Context) {
+	rc.getQualifying(c, m.SessionSprintShootout)
+}
+
+// SaveSprintShootout godoc
+// @Summary Submit Race sprint shootout
+// @Description Replaces the classification of the Sprint Shootout, lap times are written as 1:23.456 with SQ1 to SQ3 given as Q1 to Q3
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param Results body QualifyingResultsModelValidator true "Shootout classification"
+// @Success 201 {object} QualifyingResponse "Returns the stored shootout classification"
+// @Router /races/{id}/sprint/shootout [post]
+func (rc *ResultController) SaveSprintShootout(c *gin.Context) {
+	rc.saveQualifying(c, m.SessionSprintShootout)
+}
+
+// GetRaceGrid godoc
+// @Summary Get Race starting grid
+// @Description Builds the starting grid from the qualifying classification and the grid penalties, with an audit trail explaining every position change of each driver. On 2021 and 2022 sprint weekends the sprint classification sets the grid once it is stored
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Success 200 {array} GridSlotResponse "Returns the grid followed by pit lane starters and drivers who do not start"
+// @Router /races/{id}/grid [get]
+func (rc *ResultController) GetRaceGrid(c *gin.Context) {
+	race, session, ok := rc.getRaceSession(c, m.SessionQualifying)
+	if !ok {
+		return
+	}
+
+	results, err := rc.resultRepo.GetQualifyingResultsQuery(session.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+	penalties, err := rc.gridRepo.GetGridPenaltiesQuery(race.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	var sprintResults []m.Result
+	if format, ok := sprint.ForSeason(race.Year); ok && format.SetsRaceGrid {
+		for _, session := range race.Sessions {
+			if session.Type != m.SessionSprint {
+				continue
+			}
+			sprintResults, err = rc.resultRepo.GetSessionResultsQuery(session.ID.String())
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+				return
+			}
+		}
+	}
+
+	startingGrid := grid.Build(results, penalties)
+	if len(sprintResults) > 0 {
+		startingGrid = grid.BuildFromSprint(results, sprintResults, penalties)
+	}
+
+	serializer := s.StartingGridSerializer{C: c, StartingGrid: startingGrid}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// GetSprintGrid godoc
+// @Summary Get Race sprint grid
+// @Description Builds the grid of the sprint from the session setting it in the season, qualifying in 2021 and 2022 and the Sprint Shootout from 2023, with the 107% rule applied. Grid penalties of the race are not served in the sprint
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Success 200 {array} GridSlotResponse "Returns the sprint grid followed by drivers who do not start"
+// @Router /races/{id}/sprint/grid [get]
+func (rc *ResultController) GetSprintGrid(c *gin.Context) {
+	race, ok := rc.getRace(c)
+	if !ok {
+		return
+	}
+
+	format, ok := sprint.ForSeason(race.Year)
+	if !ok || !sprint.IsSprintWeekend(race) {
+		c.JSON(http.StatusNotFound, common.NewError("session", errors.New("race has no "+m.SessionSprint+" session")))
+		return
+	}
+
+	_, session, ok := rc.getRaceSession(c, format.GridSession)
+	if !ok {
+		return
+	}
+
+	results, err := rc.resultRepo.GetQualifyingResultsQuery(session.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.StartingGridSerializer{C: c, StartingGrid: grid.Build(results, nil)}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// GetGridPenalties godoc
+// @Summary Get Race grid penalties
+// @Description Retrieves the grid penalties of the race in the order of infringement
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Success 200 {array} GridPenaltyResponse "Returns list of grid penalties"
+// @Router /races/{id}/grid/penalties [get]
+func (rc *ResultController) GetGridPenalties(c *gin.Context) {
+	race, ok := rc.getRace(c)
+	if !ok {
+		return
+	}
+
+	penalties, err := rc.gridRepo.GetGridPenaltiesQuery(race.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.GridPenaltiesSerializer{C: c, Penalties: penalties}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// CreateGridPenalty godoc
+// @Summary Add Race grid penalty
+// @Description Adds a grid drop, back-of-grid or pit lane start penalty of a driver entered for the race
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param Penalty body GridPenaltyModelValidator true "Grid penalty"
+// @Success 201 {object} GridPenaltyResponse "Returns Created Grid penalty"
+// @Router /races/{id}/grid/penalties [post]
+func (rc *ResultController) CreateGridPenalty(c *gin.Context) {
+	race, ok := rc.getRace(c)
+	if !ok {
+		return
+	}
+
+	validator := v.GridPenaltyModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	penalty, err := rc.gridRepo.CreateGridPenaltyQuery(race, validator.GridPenalty)
+	if err != nil {
+		writeResultError(c, err)
+		return
+	}
+
+	serializer := s.GridPenaltySerializer{C: c, GridPenalty: penalty}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
+
+// DeleteGridPenalty godoc
+// @Summary Delete Race grid penalty
+// @Description Removes a grid penalty of the race
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param penalty path string true "Grid penalty ID"
+// @Success 204 "No Content"
+// @Router /races/{id}/grid/penalties/{penalty} [delete]
+func (rc *ResultController) DeleteGridPenalty(c *gin.Context) {
+	err := rc.gridRepo.DeleteGridPenaltyQuery(c.Param("id"), c.Param("penalty"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("penalty", errors.New("grid penalty not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("penalty", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetDriverResults godoc
+// @Summary Get Driver's results
+// @Description Retrieves the results of the driver in calendar order, with optional season filter and pagination
+// @Tags drivers
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Driver ID"
+// @Param season query int false "Season year"
+// @Param limit query int false "Page size" default(30)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {array} DriverResultResponse "Returns list of results"
+// @Router /drivers/{id}/results [get]
+func (rc *ResultController) GetDriverResults(c *gin.Context) {
+	pagination, err := common.GetPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("pagination", err))
+		return
+	}
+
+	var season *int
+	if value := c.Query("season"); value != "" {
+		year, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, common.NewError("season", errors.New("season must be a year")))
+			return
+		}
+		season = &year
+	}
+
+	driver, err := rc.driverRepo.GetDriverByIdQuery(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("driver", errors.New("driver not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("driver", err))
+		return
+	}
+
+	results, err := rc.resultRepo.GetDriverResultsQuery(driver.ID.String(), season, pagination)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.DriverResultsSerializer{C: c, Results: results}
+	c.JSON(http.StatusOK, serializer.Response())
+}
@@ -0,0 +1,163 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	_ "github.com/dewciu/f1_api/docs"
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type SeasonController struct {
+	DB         *gorm.DB
+	seasonRepo *d.SeasonRepository
+	raceRepo   *d.RaceRepository
+}
+
+func NewSeasonController(db *gorm.DB) *SeasonController {
+	seasonRepo := d.NewSeasonRepository(db)
+	raceRepo := d.NewRaceRepository(db)
+	return &SeasonController{DB: db, seasonRepo: seasonRepo, raceRepo: raceRepo}
+}
+
+// GetAllSeasons godoc
+// @Summary Get Seasons
+// @Description Retrieves all championship seasons
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {array} SeasonResponse "Returns list of seasons"
+// @Router /seasons [get]
+func (sc *SeasonController) GetAllSeasons(c *gin.Context) {
+	seasons, err := sc.seasonRepo.GetAllSeasonsQuery()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.SeasonsSerializer{C: c, Seasons: seasons}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// CreateSeason godoc
+// @Summary Create Season
+// @Description Creates a championship season
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param Season body SeasonCreateModelValidator true "Season Object"
+// @Success 201 {object} SeasonResponse "Returns Created Season"
+// @Router /seasons [post]
+func (sc *SeasonController) CreateSeason(c *gin.Context) {
+	validator := v.SeasonCreateModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	season := validator.Season
+
+	err := sc.seasonRepo.CreateSeasonQuery(season)
+	if err != nil {
+		var er *common.AlreadyExistsError
+		if errors.As(err, &er) {
+			c.JSON(http.StatusConflict, common.NewError("season", er))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("database", err))
+		return
+	}
+
+	serializer := s.SeasonSerializer{C: c, Season: season}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
+
+// GetSeasonRaces godoc
+// @Summary Get Season's race calendar
+// @Description Retrieves the races of the season in round order, with circuits and session schedule
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param year path int true "Season year"
+// @Success 200 {array} RaceResponse "Returns list of races"
+// @Router /seasons/{year}/races [get]
+func (sc *SeasonController) GetSeasonRaces(c *gin.Context) {
+	year, err := parseYearParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("year", err))
+		return
+	}
+
+	races, err := sc.raceRepo.GetRacesBySeasonQuery(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.RacesSerializer{C: c, Races: races}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// CreateRace godoc
+// @Summary Create Race
+// @Description Adds a race to the season calendar, weekends must not overlap other races of the season
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param year path int true "Season year"
+// @Param Race body RaceCreateModelValidator true "Race Object"
+// @Success 201 {object} RaceResponse "Returns Created Race"
+// @Router /seasons/{year}/races [post]
+func (sc *SeasonController) CreateRace(c *gin.Context) {
+	year, err := parseYearParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("year", err))
+		return
+	}
+
+	validator := v.RaceCreateModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	race := validator.Race
+	race.Year = year
+
+	race, err = sc.raceRepo.CreateRaceQuery(race)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("season", errors.New("season not found")))
+			return
+		}
+		var calendarErr *v.CalendarError
+		if errors.As(err, &calendarErr) {
+			c.JSON(http.StatusBadRequest, common.NewError("calendar", calendarErr))
+			return
+		}
+		var alreadyExistsErr *common.AlreadyExistsError
+		if errors.As(err, &alreadyExistsErr) {
+			c.JSON(http.StatusConflict, common.NewError("race", alreadyExistsErr))
+			return
+		}
+		var referenceErr *common.InvalidReferenceError
+		if errors.As(err, &referenceErr) {
+			c.JSON(http.StatusBadRequest, common.NewError("race", referenceErr))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("database", err))
+		return
+	}
+
+	serializer := s.RaceSerializer{C: c, Race: race}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
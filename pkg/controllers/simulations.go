@@ -0,0 +1,275 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	_ "github.com/dewciu/f1_api/docs"
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	m "github.com/dewciu/f1_api/pkg/models"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	sim "github.com/dewciu/f1_api/pkg/simulation"
+	st "github.com/dewciu/f1_api/pkg/standings"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// DefaultSimulationConcurrency is the number of simulations run at the same
+// time when the configuration sets none
+const DefaultSimulationConcurrency = 2
+
+type SimulationController struct {
+	DB             *gorm.DB
+	raceRepo       *d.RaceRepository
+	resultRepo     *d.ResultRepository
+	seasonRepo     *d.SeasonRepository
+	simulationRepo *d.SimulationRepository
+	// slots limits the simulations running at the same time, a simulation
+	// takes a slot before it is stored and frees it once it ends
+	slots chan struct{}
+}
+
+func NewSimulationController(db *gorm.DB, concurrency int) *SimulationController {
+	if concurrency <= 0 {
+		concurrency = DefaultSimulationConcurrency
+	}
+	raceRepo := d.NewRaceRepository(db)
+	resultRepo := d.NewResultRepository(db)
+	seasonRepo := d.NewSeasonRepository(db)
+	simulationRepo := d.NewSimulationRepository(db)
+	return &SimulationController{DB: db, raceRepo: raceRepo, resultRepo: resultRepo, seasonRepo: seasonRepo, simulationRepo: simulationRepo, slots: make(chan struct{}, concurrency)}
+}
+
+// withDefaults fills in the model settings the simulation leaves out with the
+// defaults of the model
+func withDefaults(simulation m.Simulation) m.Simulation {
+	defaults := sim.DefaultConfig()
+	if simulation.Decay == 0 {
+		simulation.Decay = defaults.Decay
+	}
+	if simulation.StrengthPrior == 0 {
+		simulation.StrengthPrior = defaults.StrengthPrior
+	}
+	if simulation.DNFPrior == 0 {
+		simulation.DNFPrior = defaults.DNFPrior
+	}
+	return simulation
+}
+
+// simulationConfig rebuilds the model configuration stored with the simulation
+func simulationConfig(simulation m.Simulation) (sim.Config, error) {
+	config := sim.Config{
+		Decay:         simulation.Decay,
+		StrengthPrior: simulation.StrengthPrior,
+		DNFPrior:      simulation.DNFPrior,
+		Overrides:     make(map[uuid.UUID]sim.Override),
+	}
+
+	overrides := []m.SimulationOverride{}
+	if len(simulation.Overrides) > 0 {
+		if err := json.Unmarshal(simulation.Overrides, &overrides); err != nil {
+			return sim.Config{}, err
+		}
+	}
+	for _, override := range overrides {
+		config.Overrides[override.DriverID] = sim.Override{
+			StrengthFactor: override.StrengthFactor,
+			DNFProbability: override.DNFProbability,
+		}
+	}
+	return config, nil
+}
+
+// run samples the season in the background and stores the outcome, freeing
+// the slot taken for the simulation once it ends
+func (sc *SimulationController) run(simulation m.Simulation, races []m.Race, results []m.Result, model sim.Model) {
+	defer func() { <-sc.slots }()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err := fmt.Errorf("simulation panicked: %v", r)
+			logrus.Error(err)
+			if err := sc.simulationRepo.FailSimulationQuery(&simulation, err); err != nil {
+				logrus.Error(err)
+			}
+		}
+	}()
+
+	if err := sc.simulationRepo.StartSimulationQuery(&simulation); err != nil {
+		logrus.Error(err)
+		return
+	}
+
+	system := st.SystemForSeason(simulation.Year)
+	outcome := sim.Run(simulation.Year, system, races, results, model, simulation.Runs, simulation.Seed)
+	serializer := s.SimulationOutcomeSerializer{Outcome: outcome}
+	data, err := json.Marshal(serializer.Response())
+	if err == nil {
+		err = sc.simulationRepo.FinishSimulationQuery(&simulation, data)
+	}
+	if err != nil {
+		logrus.Error(err)
+		if err := sc.simulationRepo.FailSimulationQuery(&simulation, err); err != nil {
+			logrus.Error(err)
+		}
+	}
+}
+
+// CreateSimulation godoc
+// @Summary Create Season simulation
+// @Description Fits a Plackett-Luce performance model with DNF probabilities to the season's race results and samples the remaining races and sprints in a background job. The same seed and settings give the same outcome. Poll the simulation until its status is finished. Responds with 429 while as many simulations as configured are running
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param year path int true "Season year"
+// @Param Simulation body SimulationCreateModelValidator true "Simulation settings"
+// @Success 202 {object} SimulationResponse "Returns the pending simulation"
+// @Router /seasons/{year}/simulations [post]
+func (sc *SimulationController) CreateSimulation(c *gin.Context) {
+	year, err := parseYearParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("year", err))
+		return
+	}
+
+	validator := v.SimulationCreateModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	if _, err := sc.seasonRepo.GetSeasonByYearQuery(year); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("season", errors.New("season not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	races, err := sc.raceRepo.GetRacesBySeasonQuery(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+	results, err := sc.resultRepo.GetSeasonResultsQuery(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	simulation := withDefaults(validator.Simulation)
+	simulation.Year = year
+	config, err := simulationConfig(simulation)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+	model, err := sim.Fit(races, results, config)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("simulation", err))
+		return
+	}
+	for driver := range config.Overrides {
+		found := false
+		for _, d := range model.Drivers {
+			found = found || d.Driver.ID == driver
+		}
+		if !found {
+			c.JSON(http.StatusBadRequest, common.NewError("overrides", fmt.Errorf("driver %s does not take part in the remaining races", driver)))
+			return
+		}
+	}
+
+	select {
+	case sc.slots <- struct{}{}:
+	default:
+		c.JSON(http.StatusTooManyRequests, common.NewError("simulation", errors.New("too many simulations are running, try again later")))
+		return
+	}
+	simulation, err = sc.simulationRepo.CreateSimulationQuery(simulation)
+	if err != nil {
+		<-sc.slots
+		c.JSON(http.StatusInternalServerError, common.NewError("database", err))
+		return
+	}
+
+	go sc.run(simulation, races, results, model)
+
+	serializer := s.SimulationSerializer{C: c, Simulation: simulation}
+	c.JSON(http.StatusAccepted, serializer.Response())
+}
+
+// GetSeasonSimulations godoc
+// @Summary Get Season simulations
+// @Description Retrieves the simulations of the season, the latest first, without their outcomes
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param year path int true "Season year"
+// @Param limit query int false "Page size" default(30)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {array} SimulationResponse "Returns the simulations"
+// @Router /seasons/{year}/simulations [get]
+func (sc *SimulationController) GetSeasonSimulations(c *gin.Context) {
+	year, err := parseYearParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("year", err))
+		return
+	}
+
+	pagination, err := common.GetPagination(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("pagination", err))
+		return
+	}
+
+	simulations, err := sc.simulationRepo.GetSimulationsBySeasonQuery(year, pagination)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.SimulationsSerializer{C: c, Simulations: simulations}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// GetSimulation godoc
+// @Summary Get Season simulation
+// @Description Retrieves a simulation with its status, once finished it includes every driver's title probability, expected points and championship position distribution
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param year path int true "Season year"
+// @Param id path string true "Simulation ID"
+// @Success 200 {object} SimulationResponse "Returns the simulation"
+// @Router /seasons/{year}/simulations/{id} [get]
+func (sc *SimulationController) GetSimulation(c *gin.Context) {
+	year, err := parseYearParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("year", err))
+		return
+	}
+
+	simulation, err := sc.simulationRepo.GetSimulationQuery(year, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("simulation", errors.New("simulation not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("simulation", err))
+		return
+	}
+
+	serializer := s.SimulationSerializer{C: c, Simulation: simulation}
+	c.JSON(http.StatusOK, serializer.Response())
+}
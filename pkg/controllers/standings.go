@@ -0,0 +1,262 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	_ "github.com/dewciu/f1_api/docs"
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	h2h "github.com/dewciu/f1_api/pkg/headtohead"
+	m "github.com/dewciu/f1_api/pkg/models"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	st "github.com/dewciu/f1_api/pkg/standings"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type StandingsController struct {
+	DB              *gorm.DB
+	raceRepo        *d.RaceRepository
+	resultRepo      *d.ResultRepository
+	driverRepo      *d.DriverRepository
+	constructorRepo *d.ConstructorRepository
+}
+
+func NewStandingsController(db *gorm.DB) *StandingsController {
+	raceRepo := d.NewRaceRepository(db)
+	resultRepo := d.NewResultRepository(db)
+	driverRepo := d.NewDriverRepository(db)
+	constructorRepo := d.NewConstructorRepository(db)
+	return &StandingsController{DB: db, raceRepo: raceRepo, resultRepo: resultRepo, driverRepo: driverRepo, constructorRepo: constructorRepo}
+}
+
+// computeStandings computes the tables of the season from the year path
+// parameter and the after_round query parameter, writing the error response
+// when they are invalid
+func (sc *StandingsController) computeStandings(c *gin.Context) (st.Table, bool) {
+	year, err := parseYearParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("year", err))
+		return st.Table{}, false
+	}
+
+	validator := v.StandingsFilterValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return st.Table{}, false
+	}
+
+	races, err := sc.raceRepo.GetRacesBySeasonQuery(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return st.Table{}, false
+	}
+	results, err := sc.resultRepo.GetSeasonResultsQuery(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return st.Table{}, false
+	}
+
+	return st.Compute(year, st.SystemForSeason(year), races, results, validator.AfterRound), true
+}
+
+// GetDriverStandings godoc
+// @Summary Get Season's drivers' standings
+// @Description Computes the drivers' championship from the stored results with the points system of the season, including dropped scores, half points, sprint points and the fastest lap bonus. Ties are broken by countback
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param year path int true "Season year"
+// @Param after_round query int false "Standings after the round, the whole season by default"
+// @Success 200 {object} DriverStandingsResponse "Returns the drivers' standings"
+// @Router /seasons/{year}/standings/drivers [get]
+func (sc *StandingsController) GetDriverStandings(c *gin.Context) {
+	table, ok := sc.computeStandings(c)
+	if !ok {
+		return
+	}
+
+	serializer := s.DriverStandingsSerializer{C: c, Table: table}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// GetConstructorStandings godoc
+// @Summary Get Season's constructors' standings
+// @Description Computes the constructors' championship from the stored results with the points system of the season, the standings are empty before 1958. Ties are broken by countback
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param year path int true "Season year"
+// @Param after_round query int false "Standings after the round, the whole season by default"
+// @Success 200 {object} ConstructorStandingsResponse "Returns the constructors' standings"
+// @Router /seasons/{year}/standings/constructors [get]
+func (sc *StandingsController) GetConstructorStandings(c *gin.Context) {
+	table, ok := sc.computeStandings(c)
+	if !ok {
+		return
+	}
+
+	serializer := s.ConstructorStandingsSerializer{C: c, Table: table}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// contest computes the contenders of the season from the year path
+// parameter, writing the error response when it fails
+func (sc *StandingsController) contest(c *gin.Context, scenarios []st.Scenario) (st.Contenders, bool) {
+	year, err := parseYearParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("year", err))
+		return st.Contenders{}, false
+	}
+
+	races, err := sc.raceRepo.GetRacesBySeasonQuery(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return st.Contenders{}, false
+	}
+	results, err := sc.resultRepo.GetSeasonResultsQuery(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return st.Contenders{}, false
+	}
+
+	contenders, err := st.Contest(year, st.SystemForSeason(year), races, results, scenarios)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("scenario", err))
+		return st.Contenders{}, false
+	}
+	return contenders, true
+}
+
+// GetContenders godoc
+// @Summary Get Season's title contenders
+// @Description Computes the maximum achievable total of every driver and constructor over the remaining races, sprints and bonus points, whether they are eliminated and what the leader needs at the next round to clinch the title
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param year path int true "Season year"
+// @Success 200 {object} ContendersResponse "Returns the contenders"
+// @Router /seasons/{year}/standings/contenders [get]
+func (sc *StandingsController) GetContenders(c *gin.Context) {
+	contenders, ok := sc.contest(c, nil)
+	if !ok {
+		return
+	}
+
+	serializer := s.ContendersSerializer{C: c, Contenders: contenders}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// GetContendersWhatIf godoc
+// @Summary Get Season's title contenders for hypothetical results
+// @Description Computes the contenders as GetContenders does after applying hypothetical finishing orders to upcoming races
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param year path int true "Season year"
+// @Param Scenarios body ContendersWhatIfValidator true "Hypothetical finishing orders"
+// @Success 200 {object} ContendersResponse "Returns the contenders"
+// @Router /seasons/{year}/standings/contenders [post]
+func (sc *StandingsController) GetContendersWhatIf(c *gin.Context) {
+	validator := v.ContendersWhatIfValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	contenders, ok := sc.contest(c, validator.Scenarios)
+	if !ok {
+		return
+	}
+
+	serializer := s.ContendersSerializer{C: c, Contenders: contenders}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// loadDriver loads the driver of the id, writing the error response when it
+// fails
+func (sc *StandingsController) loadDriver(c *gin.Context, id string) (m.Driver, bool) {
+	driver, err := sc.driverRepo.GetDriverByIdQuery(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("driver", errors.New("driver not found")))
+			return m.Driver{}, false
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("driver", err))
+		return m.Driver{}, false
+	}
+	return driver, true
+}
+
+// GetHeadToHead godoc
+// @Summary Get Season's head-to-head of two drivers
+// @Description Compares two drivers over the rounds in which they drove for the same constructor by their line-ups, or every round they both took part in with any_pair. Counts who qualified and finished ahead, finishes ahead when both were classified, points including sprints and retirements by cause, with the mean qualifying gap of driver A to driver B in percent over the last segment both set a time in
+// @Tags seasons
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param year path int true "Season year"
+// @Param driver_a query string true "Driver ID"
+// @Param driver_b query string true "Driver ID"
+// @Param any_pair query bool false "Compare every round both drivers took part in"
+// @Success 200 {object} HeadToHeadResponse "Returns the head-to-head"
+// @Router /seasons/{year}/head-to-head [get]
+func (sc *StandingsController) GetHeadToHead(c *gin.Context) {
+	year, err := parseYearParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, common.NewError("year", err))
+		return
+	}
+
+	validator := v.HeadToHeadFilterValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	driverA, ok := sc.loadDriver(c, validator.DriverA)
+	if !ok {
+		return
+	}
+	driverB, ok := sc.loadDriver(c, validator.DriverB)
+	if !ok {
+		return
+	}
+
+	races, err := sc.raceRepo.GetRacesBySeasonQuery(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+	results, err := sc.resultRepo.GetSeasonResultsQuery(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+	qualifying, err := sc.resultRepo.GetSeasonQualifyingQuery(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+	entries, err := sc.constructorRepo.GetSeasonEntriesByYearQuery(year)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+	season := h2h.Season{Races: races, Results: results, Qualifying: qualifying, Entries: entries}
+
+	serializer := s.HeadToHeadSerializer{
+		C:          c,
+		Year:       year,
+		DriverA:    driverA,
+		DriverB:    driverB,
+		HeadToHead: h2h.Compare(season, driverA.ID, driverB.ID, validator.AnyPair),
+	}
+	c.JSON(http.StatusOK, serializer.Response())
+}
@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	_ "github.com/dewciu/f1_api/docs"
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	m "github.com/dewciu/f1_api/pkg/models"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type StewardController struct {
+	DB          *gorm.DB
+	raceRepo    *d.RaceRepository
+	stewardRepo *d.StewardRepository
+}
+
+func NewStewardController(db *gorm.DB) *StewardController {
+	raceRepo := d.NewRaceRepository(db)
+	stewardRepo := d.NewStewardRepository(db)
+	return &StewardController{DB: db, raceRepo: raceRepo, stewardRepo: stewardRepo}
+}
+
+// GetStewardDecisions godoc
+// @Summary Get Race steward decisions
+// @Description Retrieves the steward decisions of the race and its sprint in the order they were issued
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Success 200 {array} StewardDecisionResponse "Returns the decisions"
+// @Router /races/{id}/decisions [get]
+func (sc *StewardController) GetStewardDecisions(c *gin.Context) {
+	race, ok := loadRace(c, sc.raceRepo)
+	if !ok {
+		return
+	}
+
+	decisions, err := sc.stewardRepo.GetRaceStewardDecisionsQuery(race.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.StewardDecisionsSerializer{C: c, Decisions: decisions}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// CreateStewardDecision godoc
+// @Summary Add Race steward decision
+// @Description Adds a decision on a driver entered for the race. Time penalties and disqualifications amend the classification of the session, storing it as a new result version, and the standings follow
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param Decision body StewardDecisionModelValidator true "Steward decision"
+// @Success 201 {object} StewardDecisionResponse "Returns the created decision"
+// @Router /races/{id}/decisions [post]
+func (sc *StewardController) CreateStewardDecision(c *gin.Context) {
+	race, ok := loadRace(c, sc.raceRepo)
+	if !ok {
+		return
+	}
+
+	validator := v.StewardDecisionModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	session, err := sc.raceRepo.GetRaceSessionQuery(race.ID.String(), validator.SessionType)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("session", errors.New("race has no "+validator.SessionType+" session")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("session", err))
+		return
+	}
+
+	decision, err := sc.stewardRepo.CreateStewardDecisionQuery(race, session, validator.Decision)
+	if err != nil {
+		writeResultError(c, err)
+		return
+	}
+
+	serializer := s.StewardDecisionSerializer{C: c, StewardDecision: decision}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
+
+// UpdateStewardDecisionStatus godoc
+// @Summary Update Race steward decision status
+// @Description Records that the decision was appealed, upheld or overturned, now unless at says otherwise. A penalty stands while under appeal, overturning it restores the classification
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param decision path string true "Decision ID"
+// @Param Status body StewardDecisionStatusValidator true "Decision status"
+// @Success 200 {object} StewardDecisionResponse "Returns the updated decision"
+// @Router /races/{id}/decisions/{decision} [put]
+func (sc *StewardController) UpdateStewardDecisionStatus(c *gin.Context) {
+	race, ok := loadRace(c, sc.raceRepo)
+	if !ok {
+		return
+	}
+
+	validator := v.StewardDecisionStatusValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	decision, err := sc.stewardRepo.GetStewardDecisionQuery(race.ID.String(), c.Param("decision"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("decision", errors.New("decision not found")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("decision", err))
+		return
+	}
+
+	decision, err = sc.stewardRepo.UpdateStewardDecisionStatusQuery(race, decision, validator.Status, validator.ParsedAt)
+	if err != nil {
+		writeResultError(c, err)
+		return
+	}
+
+	serializer := s.StewardDecisionSerializer{C: c, StewardDecision: decision}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// GetResultVersions godoc
+// @Summary Get Race result versions
+// @Description Retrieves the history of the race classification, every submitted classification and every amendment by a steward decision with the time it took effect
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Success 200 {array} ResultVersionResponse "Returns the versions"
+// @Router /races/{id}/results/versions [get]
+func (sc *StewardController) GetResultVersions(c *gin.Context) {
+	race, ok := loadRace(c, sc.raceRepo)
+	if !ok {
+		return
+	}
+
+	session, err := sc.raceRepo.GetRaceSessionQuery(race.ID.String(), m.SessionRace)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("session", errors.New("race has no "+m.SessionRace+" session")))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("session", err))
+		return
+	}
+
+	versions, err := sc.stewardRepo.GetResultVersionsQuery(session.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.ResultVersionsSerializer{C: c, Versions: versions}
+	c.JSON(http.StatusOK, serializer.Response())
+}
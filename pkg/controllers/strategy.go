@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"net/http"
+
+	_ "github.com/dewciu/f1_api/docs"
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	m "github.com/dewciu/f1_api/pkg/models"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	"github.com/dewciu/f1_api/pkg/strategy"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type StrategyController struct {
+	DB           *gorm.DB
+	raceRepo     *d.RaceRepository
+	resultRepo   *d.ResultRepository
+	lapRepo      *d.LapRepository
+	strategyRepo *d.StrategyRepository
+}
+
+func NewStrategyController(db *gorm.DB) *StrategyController {
+	raceRepo := d.NewRaceRepository(db)
+	resultRepo := d.NewResultRepository(db)
+	lapRepo := d.NewLapRepository(db)
+	strategyRepo := d.NewStrategyRepository(db)
+	return &StrategyController{DB: db, raceRepo: raceRepo, resultRepo: resultRepo, lapRepo: lapRepo, strategyRepo: strategyRepo}
+}
+
+// GetPitStops godoc
+// @Summary Get Race pit stops
+// @Description Retrieves the pit stops of the race in lap order
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Success 200 {array} PitStopResponse "Returns the pit stops"
+// @Router /races/{id}/pit-stops [get]
+func (sc *StrategyController) GetPitStops(c *gin.Context) {
+	race, ok := loadRace(c, sc.raceRepo)
+	if !ok {
+		return
+	}
+
+	stops, err := sc.strategyRepo.GetPitStopsQuery(race.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.PitStopsSerializer{C: c, PitStops: stops}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// SavePitStops godoc
+// @Summary Submit Race pit stops
+// @Description Replaces the pit stops of the race, every driver has to be entered for the round and their stops numbered from 1 in lap order
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param PitStops body PitStopsModelValidator true "Pit stops"
+// @Success 201 {array} PitStopResponse "Returns the stored pit stops"
+// @Router /races/{id}/pit-stops [post]
+func (sc *StrategyController) SavePitStops(c *gin.Context) {
+	race, ok := loadRace(c, sc.raceRepo)
+	if !ok {
+		return
+	}
+
+	validator := v.PitStopsModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	stops, err := sc.strategyRepo.SavePitStopsQuery(race, validator.Models)
+	if err != nil {
+		writeResultError(c, err)
+		return
+	}
+
+	serializer := s.PitStopsSerializer{C: c, PitStops: stops}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
+
+// GetStints godoc
+// @Summary Get Race stints
+// @Description Retrieves the tyre stints of the race grouped by driver
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Success 200 {array} DriverStintsResponse "Returns the stints"
+// @Router /races/{id}/stints [get]
+func (sc *StrategyController) GetStints(c *gin.Context) {
+	race, ok := loadRace(c, sc.raceRepo)
+	if !ok {
+		return
+	}
+
+	stints, err := sc.strategyRepo.GetStintsQuery(race.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.StintsSerializer{C: c, Stints: stints}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// SaveStints godoc
+// @Summary Submit Race stints
+// @Description Replaces the tyre stints of the race, every driver has to be entered for the round and their stints numbered from 1 without overlapping
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Param Stints body StintsModelValidator true "Stints"
+// @Success 201 {array} DriverStintsResponse "Returns the stored stints"
+// @Router /races/{id}/stints [post]
+func (sc *StrategyController) SaveStints(c *gin.Context) {
+	race, ok := loadRace(c, sc.raceRepo)
+	if !ok {
+		return
+	}
+
+	validator := v.StintsModelValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	stints, err := sc.strategyRepo.SaveStintsQuery(race, validator.Models)
+	if err != nil {
+		writeResultError(c, err)
+		return
+	}
+
+	serializer := s.StintsSerializer{C: c, Stints: stints}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
+
+// GetRaceStrategy godoc
+// @Summary Get Race strategy
+// @Description Retrieves every driver's stint timeline and number of stops, the fastest pit stops and, when the race has laps, the undercuts and overcuts between drivers running within 5 seconds of each other who stopped within 5 laps
+// @Tags races
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Race ID"
+// @Success 200 {object} StrategyResponse "Returns the strategy"
+// @Router /races/{id}/strategy [get]
+func (sc *StrategyController) GetRaceStrategy(c *gin.Context) {
+	race, ok := loadRace(c, sc.raceRepo)
+	if !ok {
+		return
+	}
+
+	stints, err := sc.strategyRepo.GetStintsQuery(race.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+	stops, err := sc.strategyRepo.GetPitStopsQuery(race.ID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+	laps, err := sc.lapRepo.GetRaceLapsQuery(race.ID.String(), v.LapsFilterValidator{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	results := []m.Result{}
+	for _, session := range race.Sessions {
+		if session.Type == m.SessionRace {
+			results, err = sc.resultRepo.GetSessionResultsQuery(session.ID.String())
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+				return
+			}
+		}
+	}
+
+	serializer := s.StrategySerializer{C: c, RaceID: race.ID, Strategy: strategy.Analyse(stints, stops, laps, results)}
+	c.JSON(http.StatusOK, serializer.Response())
+}
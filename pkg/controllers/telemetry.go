@@ -0,0 +1,214 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	m "github.com/dewciu/f1_api/pkg/models"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	"github.com/dewciu/f1_api/pkg/telemetry"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TelemetryController struct {
+	DB            *gorm.DB
+	raceRepo      *d.RaceRepository
+	telemetryRepo *d.TelemetryRepository
+}
+
+func NewTelemetryController(db *gorm.DB) *TelemetryController {
+	raceRepo := d.NewRaceRepository(db)
+	telemetryRepo := d.NewTelemetryRepository(db)
+	return &TelemetryController{DB: db, raceRepo: raceRepo, telemetryRepo: telemetryRepo}
+}
+
+// loadSession loads the session from the id path parameter with its race,
+// writing the error response when it cannot be found
+func loadSession(c *gin.Context, raceRepo *d.RaceRepository) (m.Race, m.Session, bool) {
+	session, err := raceRepo.GetSessionByIdQuery(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, common.NewError("session", errors.New("session not found")))
+			return m.Race{}, m.Session{}, false
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("session", err))
+		return m.Race{}, m.Session{}, false
+	}
+
+	race, err := raceRepo.GetRaceByIdQuery(session.RaceID.String())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("race", err))
+		return m.Race{}, m.Session{}, false
+	}
+	return race, session, true
+}
+
+// SaveTelemetry godoc
+// @Summary Stream session telemetry
+// @Description Stores car telemetry samples of drivers entered for the race, streamed as newline delimited JSON with a TelemetrySampleValidator per line. The stream is stored as it is read, samples already stored are skipped and a rejected line rolls back the whole stream
+// @Tags sessions
+// @Accept application/x-ndjson
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Session ID"
+// @Param Samples body TelemetrySampleValidator true "Samples, one JSON object per line"
+// @Success 201 {object} TelemetryCopyResponse "Returns the number of samples stored"
+// @Router /sessions/{id}/telemetry [post]
+func (tc *TelemetryController) SaveTelemetry(c *gin.Context) {
+	race, session, ok := loadSession(c, tc.raceRepo)
+	if !ok {
+		return
+	}
+
+	reader := v.NewTelemetryReader(c.Request.Body)
+	copied, err := tc.telemetryRepo.SaveSessionTelemetryQuery(c.Request.Context(), race, session, reader)
+	if err != nil {
+		var lineErr *v.TelemetryLineError
+		if errors.As(err, &lineErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": lineErr})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, common.NewError("telemetry", err))
+		return
+	}
+
+	serializer := s.TelemetryCopySerializer{C: c, SessionID: session.ID, Copy: copied}
+	c.JSON(http.StatusCreated, serializer.Response())
+}
+
+// GetTelemetry godoc
+// @Summary Get session telemetry
+// @Description Retrieves the telemetry samples of a driver in the session in time order, of a lap or between two times. Given a number of points, the samples are downsampled to them with the Largest Triangle Three Buckets algorithm on the speed trace
+// @Tags sessions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Session ID"
+// @Param driver query string true "Driver ID"
+// @Param lap query int false "Lap"
+// @Param from query string false "First sample time, RFC 3339"
+// @Param to query string false "Last sample time, RFC 3339"
+// @Param points query int false "Maximum number of points"
+// @Success 200 {object} TelemetryResponse "Returns the samples"
+// @Router /sessions/{id}/telemetry [get]
+func (tc *TelemetryController) GetTelemetry(c *gin.Context) {
+	validator := v.TelemetryFilterValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	_, session, ok := loadSession(c, tc.raceRepo)
+	if !ok {
+		return
+	}
+
+	samples, err := tc.telemetryRepo.GetSessionTelemetryQuery(session.ID, validator)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.TelemetrySerializer{
+		C:         c,
+		SessionID: session.ID,
+		DriverID:  uuid.MustParse(validator.Driver),
+		Samples:   len(samples),
+		Points:    telemetry.Downsample(samples, validator.Points),
+	}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// DeleteTelemetry godoc
+// @Summary Delete session telemetry
+// @Description Deletes all telemetry samples of the session at once
+// @Tags sessions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Session ID"
+// @Success 204 "No Content"
+// @Router /sessions/{id}/telemetry [delete]
+func (tc *TelemetryController) DeleteTelemetry(c *gin.Context) {
+	_, session, ok := loadSession(c, tc.raceRepo)
+	if !ok {
+		return
+	}
+
+	if err := tc.telemetryRepo.DeleteSessionTelemetryQuery(session.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("telemetry", err))
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CompareTelemetry godoc
+// @Summary Compare lap telemetry
+// @Description Aligns two laps of the session by distance along the track. Returns the speed and throttle of both laps interpolated on a common distance grid, the cumulative time driver B lost to driver A, and the driver faster through each mini-sector. The lap lengths are scaled to their mean, distances are integrated from the speed where samples have none
+// @Tags sessions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Session ID"
+// @Param driver_a query string true "Driver ID of lap A"
+// @Param lap_a query int true "Lap A"
+// @Param driver_b query string true "Driver ID of lap B"
+// @Param lap_b query int true "Lap B"
+// @Param step query number false "Grid spacing in metres" default(10)
+// @Param mini_sectors query int false "Number of mini-sectors" default(25)
+// @Success 200 {object} TelemetryComparisonResponse "Returns the comparison"
+// @Router /sessions/{id}/telemetry/compare [get]
+func (tc *TelemetryController) CompareTelemetry(c *gin.Context) {
+	validator := v.TelemetryCompareValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	_, session, ok := loadSession(c, tc.raceRepo)
+	if !ok {
+		return
+	}
+
+	a, ok := tc.getLapTelemetry(c, session, validator.DriverA, validator.LapA)
+	if !ok {
+		return
+	}
+	b, ok := tc.getLapTelemetry(c, session, validator.DriverB, validator.LapB)
+	if !ok {
+		return
+	}
+
+	comparison, err := telemetry.Compare(a, b, telemetry.CompareOptions{Step: validator.Step, MiniSectors: validator.MiniSectors})
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, common.NewError("telemetry", err))
+		return
+	}
+
+	serializer := s.TelemetryComparisonSerializer{
+		C:          c,
+		SessionID:  session.ID,
+		A:          s.ComparedLapResponse{DriverID: uuid.MustParse(validator.DriverA), Lap: validator.LapA},
+		B:          s.ComparedLapResponse{DriverID: uuid.MustParse(validator.DriverB), Lap: validator.LapB},
+		Comparison: comparison,
+	}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// getLapTelemetry loads the samples of a lap of the driver, writing the
+// error response when there are none
+func (tc *TelemetryController) getLapTelemetry(c *gin.Context, session m.Session, driver string, lap int) ([]m.TelemetrySample, bool) {
+	samples, err := tc.telemetryRepo.GetSessionTelemetryQuery(session.ID, v.TelemetryFilterValidator{Driver: driver, Lap: lap})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return nil, false
+	}
+	if len(samples) == 0 {
+		c.JSON(http.StatusNotFound, common.NewError("telemetry", fmt.Errorf("driver %s has no telemetry for lap %d", driver, lap)))
+		return nil, false
+	}
+	return samples, true
+}
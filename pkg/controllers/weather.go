@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	d "github.com/dewciu/f1_api/pkg/database"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/dewciu/f1_api/pkg/weather"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type WeatherController struct {
+	DB          *gorm.DB
+	raceRepo    *d.RaceRepository
+	weatherRepo *d.WeatherRepository
+}
+
+func NewWeatherController(db *gorm.DB) *WeatherController {
+	raceRepo := d.NewRaceRepository(db)
+	weatherRepo := d.NewWeatherRepository(db)
+	return &WeatherController{DB: db, raceRepo: raceRepo, weatherRepo: weatherRepo}
+}
+
+// GetSessionWeather godoc
+// @Summary Get session weather
+// @Description Retrieves the weather observations of the session in time order, optionally between two times. Observations are imported with the OpenF1 weather file of the session
+// @Tags sessions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Session ID"
+// @Param from query string false "First observation time, RFC 3339"
+// @Param to query string false "Last observation time, RFC 3339"
+// @Success 200 {object} WeatherResponse "Returns the observations"
+// @Router /sessions/{id}/weather [get]
+func (wc *WeatherController) GetSessionWeather(c *gin.Context) {
+	validator := v.WeatherFilterValidator{}
+	if err := validator.Bind(c); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err})
+		return
+	}
+
+	_, session, ok := loadSession(c, wc.raceRepo)
+	if !ok {
+		return
+	}
+
+	observations, err := wc.weatherRepo.GetSessionWeatherQuery(session.ID.String(), validator)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.WeatherSerializer{C: c, SessionID: session.ID, Observations: observations}
+	c.JSON(http.StatusOK, serializer.Response())
+}
+
+// GetWeatherSummary godoc
+// @Summary Get session weather summary
+// @Description Summarizes the weather of the session, the spread of the temperatures, humidity, pressure and wind speed, the mean wind direction and whether and how much of the session it rained
+// @Tags sessions
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id path string true "Session ID"
+// @Success 200 {object} WeatherSummaryResponse "Returns the summary"
+// @Router /sessions/{id}/weather/summary [get]
+func (wc *WeatherController) GetWeatherSummary(c *gin.Context) {
+	_, session, ok := loadSession(c, wc.raceRepo)
+	if !ok {
+		return
+	}
+
+	observations, err := wc.weatherRepo.GetSessionWeatherQuery(session.ID.String(), v.WeatherFilterValidator{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, common.NewError("server", err))
+		return
+	}
+
+	serializer := s.WeatherSummarySerializer{C: c, SessionID: session.ID, Summary: weather.Summarize(observations)}
+	c.JSON(http.StatusOK, serializer.Response())
+}
@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// serveWebSocket upgrades the request to a WebSocket served by the handler.
+// Browsers send the origin of the page opening the socket, it has to be one
+// of the allowed origins so that other sites cannot use the socket on behalf
+// of the user. Clients other than browsers send no origin and are accepted
+func serveWebSocket(c *gin.Context, origins []string, handler websocket.Handler) {
+	server := websocket.Server{
+		Handshake: func(_ *websocket.Config, r *http.Request) error {
+			origin := r.Header.Get("Origin")
+			if origin == "" || slices.Contains(origins, origin) {
+				return nil
+			}
+			return fmt.Errorf("origin %s is not allowed", origin)
+		},
+		Handler: handler,
+	}
+	server.ServeHTTP(c.Writer, c.Request)
+}
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"sort"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	"github.com/dewciu/f1_api/pkg/geo"
+	m "github.com/dewciu/f1_api/pkg/models"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CircuitRepository struct {
+	DB *gorm.DB
+}
+
+func NewCircuitRepository(db *gorm.DB) *CircuitRepository {
+	return &CircuitRepository{DB: db}
+}
+
+// CircuitDistance is a circuit together with its distance from a searched point
+type CircuitDistance struct {
+	Circuit    m.Circuit
+	DistanceKm float64
+}
+
+func (repo *CircuitRepository) CreateCircuitQuery(circuit m.Circuit) error {
+	return translateError(repo.DB.Create(&circuit).Error)
+}
+
+func (repo *CircuitRepository) GetCircuitsByFilterQuery(c *gin.Context, p common.Pagination) ([]m.Circuit, error) {
+	var circuits []m.Circuit
+	query := repo.DB
+
+	if country := c.Query("country"); country != "" {
+		query = query.Where("country = ?", country)
+	}
+	if ref := c.Query("ref"); ref != "" {
+		query = query.Where("ref = ?", ref)
+	}
+
+	err := query.Scopes(p.Paginate).Order("name").Find(&circuits).Error
+	if err != nil {
+		return []m.Circuit{}, err
+	}
+
+	return circuits, nil
+}
+
+func (repo *CircuitRepository) GetCircuitByIdQuery(id string) (m.Circuit, error) {
+	var circuit m.Circuit
+	err := repo.DB.
+		Preload("Layouts", func(db *gorm.DB) *gorm.DB {
+			return db.Omit("geometry").Order("version")
+		}).
+		Where("id = ?", id).
+		First(&circuit).Error
+	if err != nil {
+		return m.Circuit{}, err
+	}
+	return circuit, nil
+}
+
+func (repo *CircuitRepository) DeleteCircuitByIdQuery(id string) error {
+	r := repo.DB.Where("id = ?", id).Delete(&m.Circuit{})
+	if r.Error != nil {
+		return translateError(r.Error)
+	}
+	if r.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (repo *CircuitRepository) UpdateCircuitByIdQuery(id string, circuitToUpdate v.CircuitUpdateModelValidator) (m.Circuit, error) {
+	var circuit m.Circuit
+
+	if err := repo.DB.Where("id = ?", id).First(&circuit).Error; err != nil {
+		return m.Circuit{}, err
+	}
+
+	if err := repo.DB.Model(&circuit).Updates(circuitToUpdate).Error; err != nil {
+		return m.Circuit{}, translateError(err)
+	}
+
+	return repo.GetCircuitByIdQuery(id)
+}
+
+// CreateCircuitLayoutQuery stores the layout as the next version of the circuit
+func (repo *CircuitRepository) CreateCircuitLayoutQuery(layout m.CircuitLayout) (m.CircuitLayout, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock the circuit so concurrent requests cannot take the same version
+		var circuit m.Circuit
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", layout.CircuitID).First(&circuit).Error
+		if err != nil {
+			return err
+		}
+
+		var version int
+		err = tx.Model(&m.CircuitLayout{}).
+			Where("circuit_id = ?", layout.CircuitID).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&version).Error
+		if err != nil {
+			return err
+		}
+
+		layout.Version = version + 1
+		return tx.Create(&layout).Error
+	})
+	if err != nil {
+		return m.CircuitLayout{}, translateError(err)
+	}
+	return layout, nil
+}
+
+func (repo *CircuitRepository) GetCircuitLayoutQuery(circuitID string, version int) (m.CircuitLayout, error) {
+	var layout m.CircuitLayout
+	err := repo.DB.Where("circuit_id = ? AND version = ?", circuitID, version).First(&layout).Error
+	if err != nil {
+		return m.CircuitLayout{}, err
+	}
+	return layout, nil
+}
+
+// GetCircuitsNearbyQuery returns the circuits within radiusKm of the point,
+// ordered from the closest one
+func (repo *CircuitRepository) GetCircuitsNearbyQuery(lat, lng, radiusKm float64) ([]CircuitDistance, error) {
+	var circuits []m.Circuit
+	minLat, maxLat, minLng, maxLng := geo.BoundingBox(lat, lng, radiusKm)
+
+	query := repo.DB.Where("latitude BETWEEN ? AND ?", minLat, maxLat)
+	// The box crosses the antimeridian only when it spans the whole globe,
+	// in which case longitude does not narrow the search
+	if minLng > -180 || maxLng < 180 {
+		query = query.Where("longitude BETWEEN ? AND ?", minLng, maxLng)
+	}
+
+	if err := query.Find(&circuits).Error; err != nil {
+		return []CircuitDistance{}, err
+	}
+
+	nearby := []CircuitDistance{}
+	for _, circuit := range circuits {
+		distance := geo.Haversine(lat, lng, circuit.Latitude, circuit.Longitude)
+		if distance <= radiusKm {
+			nearby = append(nearby, CircuitDistance{Circuit: circuit, DistanceKm: distance})
+		}
+	}
+
+	sort.Slice(nearby, func(i, j int) bool {
+		return nearby[i].DistanceKm < nearby[j].DistanceKm
+	})
+
+	return nearby, nil
+}
@@ -0,0 +1,150 @@
+package database
+
+import (
+	"github.com/dewciu/f1_api/pkg/common"
+	m "github.com/dewciu/f1_api/pkg/models"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ConstructorRepository struct {
+	DB *gorm.DB
+}
+
+func NewConstructorRepository(db *gorm.DB) *ConstructorRepository {
+	return &ConstructorRepository{DB: db}
+}
+
+func (repo *ConstructorRepository) CreateConstructorQuery(constructor m.Constructor) error {
+	return translateError(repo.DB.Create(&constructor).Error)
+}
+
+func (repo *ConstructorRepository) GetConstructorsByFilterQuery(c *gin.Context, p common.Pagination) ([]m.Constructor, error) {
+	var constructors []m.Constructor
+	query := repo.DB
+
+	if nationality := c.Query("nationality"); nationality != "" {
+		query = query.Where("nationality = ?", nationality)
+	}
+	if ref := c.Query("ref"); ref != "" {
+		query = query.Where("ref = ?", ref)
+	}
+	if season := c.Query("season"); season != "" {
+		query = query.Where("id IN (?)", repo.DB.Model(&m.SeasonEntry{}).Select("constructor_id").Where("year = ?", season))
+	}
+
+	err := query.Scopes(p.Paginate).Order("name").Find(&constructors).Error
+	if err != nil {
+		return []m.Constructor{}, err
+	}
+
+	return constructors, nil
+}
+
+func (repo *ConstructorRepository) GetConstructorByIdQuery(id string) (m.Constructor, error) {
+	var constructor m.Constructor
+	err := repo.DB.Where("id = ?", id).First(&constructor).Error
+	if err != nil {
+		return m.Constructor{}, err
+	}
+	return constructor, nil
+}
+
+func (repo *ConstructorRepository) DeleteConstructorByIdQuery(id string) error {
+	r := repo.DB.Where("id = ?", id).Delete(&m.Constructor{})
+	if r.Error != nil {
+		return translateError(r.Error)
+	}
+	if r.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (repo *ConstructorRepository) UpdateConstructorByIdQuery(id string, constructorToUpdate v.ConstructorUpdateModelValidator) (m.Constructor, error) {
+	var constructor m.Constructor
+
+	if err := repo.DB.Where("id = ?", id).First(&constructor).Error; err != nil {
+		return m.Constructor{}, err
+	}
+
+	if err := repo.DB.Model(&constructor).Updates(constructorToUpdate).Error; err != nil {
+		return m.Constructor{}, translateError(err)
+	}
+
+	return repo.GetConstructorByIdQuery(id)
+}
+
+func preloadEntry(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Constructor").
+		Preload("LineUps", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_round, car_number")
+		}).
+		Preload("LineUps.Driver")
+}
+
+func (repo *ConstructorRepository) GetSeasonEntryQuery(constructorID string, year int) (m.SeasonEntry, error) {
+	var entry m.SeasonEntry
+	err := repo.DB.Scopes(preloadEntry).
+		Where("constructor_id = ? AND year = ?", constructorID, year).
+		First(&entry).Error
+	if err != nil {
+		return m.SeasonEntry{}, err
+	}
+	return entry, nil
+}
+
+func (repo *ConstructorRepository) GetSeasonEntriesByYearQuery(year int) ([]m.SeasonEntry, error) {
+	var entries []m.SeasonEntry
+	err := repo.DB.Scopes(preloadEntry).
+		Joins("JOIN constructors ON constructors.id = season_entries.constructor_id").
+		Where("season_entries.year = ?", year).
+		Order("constructors.name").
+		Find(&entries).Error
+	return entries, err
+}
+
+// SaveSeasonEntryQuery creates the constructor's entry for the year or replaces
+// the existing one together with its driver line-ups.
+func (repo *ConstructorRepository) SaveSeasonEntryQuery(entry m.SeasonEntry) (m.SeasonEntry, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		var existing m.SeasonEntry
+		r := tx.Where("constructor_id = ? AND year = ?", entry.ConstructorID, entry.Year).Limit(1).Find(&existing)
+		if r.Error != nil {
+			return r.Error
+		}
+
+		if r.RowsAffected > 0 {
+			entry.ID = existing.ID
+			if err := tx.Where("season_entry_id = ?", existing.ID).Delete(&m.LineUp{}).Error; err != nil {
+				return err
+			}
+			err := tx.Model(&existing).Updates(map[string]interface{}{
+				"entrant_name": entry.EntrantName,
+				"chassis_name": entry.ChassisName,
+				"power_unit":   entry.PowerUnit,
+			}).Error
+			if err != nil {
+				return err
+			}
+		} else if err := tx.Omit(clause.Associations).Create(&entry).Error; err != nil {
+			return err
+		}
+
+		if len(entry.LineUps) == 0 {
+			return nil
+		}
+		for i := range entry.LineUps {
+			entry.LineUps[i].SeasonEntryID = entry.ID
+		}
+		return tx.Omit(clause.Associations).Create(&entry.LineUps).Error
+	})
+	if err != nil {
+		return m.SeasonEntry{}, translateError(err)
+	}
+
+	return repo.GetSeasonEntryQuery(entry.ConstructorID.String(), entry.Year)
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"github.com/dewciu/f1_api/pkg/common"
+	m "github.com/dewciu/f1_api/pkg/models"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DriverRepository struct {
+	DB *gorm.DB
+}
+
+func NewDriverRepository(db *gorm.DB) *DriverRepository {
+	return &DriverRepository{DB: db}
+}
+
+func (repo *DriverRepository) CreateDriverQuery(driver m.Driver) error {
+	return translateError(repo.DB.Create(&driver).Error)
+}
+
+func preloadDriverSeasons(db *gorm.DB) *gorm.DB {
+	return db.Preload("Seasons", func(db *gorm.DB) *gorm.DB {
+		return db.Order("year")
+	})
+}
+
+func (repo *DriverRepository) GetDriversByFilterQuery(filter v.DriverFilterValidator, p common.Pagination) ([]m.Driver, error) {
+	var drivers []m.Driver
+	query := repo.DB.Scopes(preloadDriverSeasons)
+
+	if filter.Nationality != "" {
+		query = query.Where("nationality = ?", filter.Nationality)
+	}
+	if filter.Code != "" {
+		query = query.Where("code = ?", filter.Code)
+	}
+	if filter.Ref != "" {
+		query = query.Where("ref = ?", filter.Ref)
+	}
+	if filter.Season != "" {
+		query = query.Where("id IN (?) OR id IN (?)",
+			repo.DB.Model(&m.DriverSeason{}).
+				Select("driver_id").
+				Where("year = ?", filter.Season),
+			repo.DB.Model(&m.LineUp{}).
+				Select("line_ups.driver_id").
+				Joins("JOIN season_entries ON season_entries.id = line_ups.season_entry_id").
+				Where("season_entries.year = ?", filter.Season))
+	}
+
+	err := query.Scopes(p.Paginate).Order("last_name, first_name").Find(&drivers).Error
+	if err != nil {
+		return []m.Driver{}, err
+	}
+
+	return drivers, nil
+}
+
+func (repo *DriverRepository) GetDriverByIdQuery(id string) (m.Driver, error) {
+	var driver m.Driver
+	err := repo.DB.Scopes(preloadDriverSeasons).Where("id = ?", id).First(&driver).Error
+	if err != nil {
+		return m.Driver{}, err
+	}
+	return driver, nil
+}
+
+func (repo *DriverRepository) DeleteDriverByIdQuery(id string) error {
+	r := repo.DB.Where("id = ?", id).Delete(&m.Driver{})
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (repo *DriverRepository) UpdateDriverByIdQuery(id string, driverToUpdate v.DriverUpdateModelValidator) (m.Driver, error) {
+	var driver m.Driver
+
+	if err := repo.DB.Where("id = ?", id).First(&driver).Error; err != nil {
+		return m.Driver{}, err
+	}
+
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&driver).Updates(driverToUpdate).Error; err != nil {
+			return translateError(err)
+		}
+		if driverToUpdate.Seasons == nil {
+			return nil
+		}
+		if err := tx.Where("driver_id = ?", driver.ID).Delete(&m.DriverSeason{}).Error; err != nil {
+			return err
+		}
+		if len(driverToUpdate.Seasons) == 0 {
+			return nil
+		}
+		seasons := make([]m.DriverSeason, 0, len(driverToUpdate.Seasons))
+		for _, year := range driverToUpdate.Seasons {
+			seasons = append(seasons, m.DriverSeason{DriverID: driver.ID, Year: year})
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&seasons).Error
+	})
+	if err != nil {
+		return m.Driver{}, err
+	}
+
+	return repo.GetDriverByIdQuery(id)
+}
@@ -0,0 +1,373 @@
+package database
+
+import (
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	"github.com/dewciu/f1_api/pkg/ergast"
+	m "github.com/dewciu/f1_api/pkg/models"
+	st "github.com/dewciu/f1_api/pkg/standings"
+	"gorm.io/gorm"
+)
+
+type ErgastRepository struct {
+	DB *gorm.DB
+}
+
+func NewErgastRepository(db *gorm.DB) *ErgastRepository {
+	return &ErgastRepository{DB: db}
+}
+
+// lastRaced returns the last round of the season with race results, 0 when
+// none was raced yet
+func (repo *ErgastRepository) lastRaced(year int) (int, error) {
+	var round int
+	err := repo.DB.Model(&m.Result{}).
+		Joins("JOIN races ON races.id = results.race_id").
+		Joins("JOIN sessions ON sessions.id = results.session_id").
+		Where("races.year = ? AND sessions.type = ?", year, m.SessionRace).
+		Select("COALESCE(MAX(races.round), 0)").
+		Scan(&round).Error
+	return round, err
+}
+
+// ResolveQuery replaces the current season with the latest season which
+// started by now, the last round with the last round raced and the next round
+// with the one following it. Returns gorm.ErrRecordNotFound when there is no
+// such season or round
+func (repo *ErgastRepository) ResolveQuery(q *ergast.Query, now time.Time) error {
+	if q.Current {
+		err := repo.DB.Model(&m.Race{}).
+			Where("year <= ?", now.Year()).
+			Select("COALESCE(MAX(year), 0)").
+			Scan(&q.Season).Error
+		if err != nil {
+			return err
+		}
+		if q.Season == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		q.Current = false
+	}
+
+	if !q.Last && !q.Next {
+		return nil
+	}
+	last, err := repo.lastRaced(q.Season)
+	if err != nil {
+		return err
+	}
+	if q.Last {
+		q.Round = last
+	} else {
+		err = repo.DB.Model(&m.Race{}).
+			Where("year = ? AND round > ?", q.Season, last).
+			Select("COALESCE(MIN(round), 0)").
+			Scan(&q.Round).Error
+		if err != nil {
+			return err
+		}
+	}
+	q.Last, q.Next = false, false
+	if q.Round == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// filterRaces limits a query joined with races to the season, round and
+// circuit of the Ergast query
+func (repo *ErgastRepository) filterRaces(q ergast.Query) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if q.Season != 0 {
+			db = db.Where("races.year = ?", q.Season)
+		}
+		if q.Round != 0 {
+			db = db.Where("races.round = ?", q.Round)
+		}
+		if ref, ok := q.Filters[ergast.ResourceCircuits]; ok {
+			db = db.Where("races.circuit_id IN (?)", repo.DB.Model(&m.Circuit{}).Select("id").Where("ref = ?", ref))
+		}
+		return db
+	}
+}
+
+// filterEntrants limits the results of the table to the driver and
+// constructor of the Ergast query
+func (repo *ErgastRepository) filterEntrants(q ergast.Query, table string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if ref, ok := q.Filters[ergast.ResourceDrivers]; ok {
+			db = db.Where(table+".driver_id IN (?)", repo.DB.Model(&m.Driver{}).Select("id").Where("ref = ?", ref))
+		}
+		if ref, ok := q.Filters[ergast.ResourceConstructors]; ok {
+			db = db.Where(table+".constructor_id IN (?)", repo.DB.Model(&m.Constructor{}).Select("id").Where("ref = ?", ref))
+		}
+		return db
+	}
+}
+
+// raceResults returns the race results matching the Ergast query, joined
+// with their races
+func (repo *ErgastRepository) raceResults(q ergast.Query) *gorm.DB {
+	db := repo.DB.Model(&m.Result{}).
+		Joins("JOIN races ON races.id = results.race_id").
+		Joins("JOIN sessions ON sessions.id = results.session_id").
+		Where("sessions.type = ?", m.SessionRace).
+		Scopes(repo.filterRaces(q), repo.filterEntrants(q, "results"))
+
+	if position := q.Position(ergast.FilterGrid); position != 0 {
+		db = db.Where("results.grid = ?", position)
+	}
+	if position := q.Position(ergast.ResourceResults); position != 0 {
+		db = db.Where("results.position = ?", position)
+	}
+	if position := q.Position(ergast.FilterFastest); position != 0 {
+		db = db.Where("results.fastest_lap_rank = ?", position)
+	}
+	if position := q.Position(ergast.ResourceQualifying); position != 0 {
+		db = db.Where("EXISTS (?)", repo.DB.Model(&m.QualifyingResult{}).
+			Select("1").
+			Joins("JOIN sessions q ON q.id = qualifying_results.session_id").
+			Where("q.type = ? AND qualifying_results.position = ?", m.SessionQualifying, position).
+			Where("qualifying_results.race_id = results.race_id AND qualifying_results.driver_id = results.driver_id"))
+	}
+	return db
+}
+
+// byRaceResults limits the results of the table to drivers whose race results
+// match the grid, finishing and fastest lap positions of the Ergast query
+func (repo *ErgastRepository) byRaceResults(q ergast.Query, table string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		filtered := false
+		for _, filter := range []string{ergast.FilterGrid, ergast.ResourceResults, ergast.FilterFastest} {
+			_, ok := q.Filters[filter]
+			filtered = filtered || ok
+		}
+		if !filtered {
+			return db
+		}
+		return db.Where("("+table+".race_id, "+table+".driver_id) IN (?)",
+			repo.raceResults(q).Select("results.race_id, results.driver_id"))
+	}
+}
+
+// GetErgastResultsQuery returns the page of the race results matching the
+// query in calendar order and the number of all of them
+func (repo *ErgastRepository) GetErgastResultsQuery(q ergast.Query, p common.Pagination) ([]m.Result, int64, error) {
+	var total int64
+	if err := repo.raceResults(q).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var results []m.Result
+	err := repo.raceResults(q).
+		Scopes(preloadResult, p.Paginate).
+		Preload("Race.Circuit").
+		Preload("Race.Sessions").
+		Order("races.year, races.round, results.position_order").
+		Find(&results).Error
+	return results, total, err
+}
+
+func (repo *ErgastRepository) sprintResults(q ergast.Query) *gorm.DB {
+	db := repo.DB.Model(&m.Result{}).
+		Joins("JOIN races ON races.id = results.race_id").
+		Joins("JOIN sessions ON sessions.id = results.session_id").
+		Where("sessions.type = ?", m.SessionSprint).
+		Scopes(repo.filterRaces(q), repo.filterEntrants(q, "results"), repo.byRaceResults(q, "results"))
+	if position := q.Position(ergast.ResourceSprint); position != 0 {
+		db = db.Where("results.position = ?", position)
+	}
+	return db
+}
+
+// GetErgastSprintResultsQuery returns the page of the sprint results matching
+// the query in calendar order and the number of all of them
+func (repo *ErgastRepository) GetErgastSprintResultsQuery(q ergast.Query, p common.Pagination) ([]m.Result, int64, error) {
+	var total int64
+	if err := repo.sprintResults(q).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var results []m.Result
+	err := repo.sprintResults(q).
+		Scopes(preloadResult, p.Paginate).
+		Preload("Race.Circuit").
+		Preload("Race.Sessions").
+		Order("races.year, races.round, results.position_order").
+		Find(&results).Error
+	return results, total, err
+}
+
+func (repo *ErgastRepository) qualifyingResults(q ergast.Query) *gorm.DB {
+	db := repo.DB.Model(&m.QualifyingResult{}).
+		Joins("JOIN races ON races.id = qualifying_results.race_id").
+		Joins("JOIN sessions ON sessions.id = qualifying_results.session_id").
+		Where("sessions.type = ?", m.SessionQualifying).
+		Scopes(repo.filterRaces(q), repo.filterEntrants(q, "qualifying_results"), repo.byRaceResults(q, "qualifying_results"))
+	if position := q.Position(ergast.ResourceQualifying); position != 0 {
+		db = db.Where("qualifying_results.position = ?", position)
+	}
+	return db
+}
+
+// GetErgastQualifyingQuery returns the page of the qualifying results
+// matching the query in calendar order and the number of all of them
+func (repo *ErgastRepository) GetErgastQualifyingQuery(q ergast.Query, p common.Pagination) ([]m.QualifyingResult, int64, error) {
+	var total int64
+	if err := repo.qualifyingResults(q).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var results []m.QualifyingResult
+	err := repo.qualifyingResults(q).
+		Scopes(preloadResult, p.Paginate).
+		Preload("Race.Circuit").
+		Preload("Race.Sessions").
+		Order("races.year, races.round, qualifying_results.position").
+		Find(&results).Error
+	return results, total, err
+}
+
+func (repo *ErgastRepository) races(q ergast.Query) *gorm.DB {
+	db := repo.DB.Model(&m.Race{}).Scopes(repo.filterRaces(q))
+	if q.FiltersResults() {
+		db = db.Where("races.id IN (?)", repo.raceResults(q).Select("results.race_id"))
+	}
+	return db
+}
+
+// GetErgastRacesQuery returns the page of the races matching the query in
+// calendar order and the number of all of them
+func (repo *ErgastRepository) GetErgastRacesQuery(q ergast.Query, p common.Pagination) ([]m.Race, int64, error) {
+	var total int64
+	if err := repo.races(q).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var races []m.Race
+	err := repo.races(q).Scopes(preloadRace, p.Paginate).Order("races.year, races.round").Find(&races).Error
+	return races, total, err
+}
+
+// participants limits the table to the rows with the ref of the filter, and
+// to the ones whose column appears in the race results matching the rest of
+// the query
+func (repo *ErgastRepository) participants(q ergast.Query, filter, table, column string) *gorm.DB {
+	db := repo.DB.Table(table)
+	if ref, ok := q.Filters[filter]; ok {
+		db = db.Where(table+".ref = ?", ref)
+	}
+	others := q.Without(filter)
+	if others.LimitsRaces() || others.FiltersResults() {
+		db = db.Where(table+".id IN (?)", repo.raceResults(others).Select(column))
+	}
+	return db
+}
+
+// GetErgastDriversQuery returns the page of the drivers matching the query
+// ordered by ref and the number of all of them
+func (repo *ErgastRepository) GetErgastDriversQuery(q ergast.Query, p common.Pagination) ([]m.Driver, int64, error) {
+	query := func() *gorm.DB {
+		return repo.participants(q, ergast.ResourceDrivers, "drivers", "results.driver_id")
+	}
+
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var drivers []m.Driver
+	err := query().Scopes(p.Paginate).Order("drivers.ref").Find(&drivers).Error
+	return drivers, total, err
+}
+
+// GetErgastConstructorsQuery returns the page of the constructors matching the
+// query ordered by ref and the number of all of them
+func (repo *ErgastRepository) GetErgastConstructorsQuery(q ergast.Query, p common.Pagination) ([]m.Constructor, int64, error) {
+	query := func() *gorm.DB {
+		return repo.participants(q, ergast.ResourceConstructors, "constructors", "results.constructor_id")
+	}
+
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var constructors []m.Constructor
+	err := query().Scopes(p.Paginate).Order("constructors.ref").Find(&constructors).Error
+	return constructors, total, err
+}
+
+// GetErgastCircuitsQuery returns the page of the circuits matching the query
+// ordered by ref and the number of all of them. Circuits of a season are the
+// ones on its calendar, raced or not
+func (repo *ErgastRepository) GetErgastCircuitsQuery(q ergast.Query, p common.Pagination) ([]m.Circuit, int64, error) {
+	query := func() *gorm.DB {
+		db := repo.DB.Model(&m.Circuit{})
+		if ref, ok := q.Filters[ergast.ResourceCircuits]; ok {
+			db = db.Where("circuits.ref = ?", ref)
+		}
+		others := q.Without(ergast.ResourceCircuits)
+		if others.LimitsRaces() || others.FiltersResults() {
+			db = db.Where("circuits.id IN (?)", repo.races(others).Select("races.circuit_id"))
+		}
+		return db
+	}
+
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var circuits []m.Circuit
+	err := query().Scopes(p.Paginate).Order("circuits.ref").Find(&circuits).Error
+	return circuits, total, err
+}
+
+// GetErgastSeasonsQuery returns the page of the seasons matching the query in
+// order and the number of all of them
+func (repo *ErgastRepository) GetErgastSeasonsQuery(q ergast.Query, p common.Pagination) ([]m.Season, int64, error) {
+	query := func() *gorm.DB {
+		db := repo.DB.Model(&m.Season{})
+		if q.LimitsRaces() || q.FiltersResults() {
+			db = db.Where("seasons.year IN (?)", repo.races(q).Select("races.year"))
+		}
+		return db
+	}
+
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var seasons []m.Season
+	err := query().Scopes(p.Paginate).Order("seasons.year").Find(&seasons).Error
+	return seasons, total, err
+}
+
+// GetErgastStandingsQuery computes the standings of the season of the query
+// after its round, or after the last round raced
+func (repo *ErgastRepository) GetErgastStandingsQuery(q ergast.Query) (st.Table, error) {
+	round := q.Round
+	if round == 0 {
+		last, err := repo.lastRaced(q.Season)
+		if err != nil {
+			return st.Table{}, err
+		}
+		round = last
+	}
+
+	races, err := (&RaceRepository{DB: repo.DB}).GetRacesBySeasonQuery(q.Season)
+	if err != nil {
+		return st.Table{}, err
+	}
+	results, err := (&ResultRepository{DB: repo.DB}).GetSeasonResultsQuery(q.Season)
+	if err != nil {
+		return st.Table{}, err
+	}
+
+	table := st.Compute(q.Season, st.SystemForSeason(q.Season), races, results, round)
+	table.AfterRound = round
+	return table, nil
+}
@@ -0,0 +1,32 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
+)
+
+// translateError converts unique constraint violations into AlreadyExistsError
+// and foreign key violations into InvalidReferenceError, any other error is
+// returned untouched.
+func translateError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	column := common.GetColumnFromUniqueErrorDetails(pgErr.Detail)
+	switch pgErr.Code {
+	case uniqueViolationCode:
+		return &common.AlreadyExistsError{Column: column}
+	case foreignKeyViolationCode:
+		return &common.InvalidReferenceError{Column: column}
+	}
+	return err
+}
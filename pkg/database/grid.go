@@ -0,0 +1,61 @@
+package database
+
+import (
+	m "github.com/dewciu/f1_api/pkg/models"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GridRepository struct {
+	DB *gorm.DB
+}
+
+func NewGridRepository(db *gorm.DB) *GridRepository {
+	return &GridRepository{DB: db}
+}
+
+// CreateGridPenaltyQuery stores the penalty of a driver entered for the race
+func (repo *GridRepository) CreateGridPenaltyQuery(race m.Race, penalty m.GridPenalty) (m.GridPenalty, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		_, entered, err := raceEntrants(tx, race)
+		if err != nil {
+			return err
+		}
+		if err := v.ValidateEntrant(penalty.DriverID, entered); err != nil {
+			return err
+		}
+
+		penalty.RaceID = race.ID
+		if err := tx.Omit(clause.Associations).Create(&penalty).Error; err != nil {
+			return err
+		}
+		return tx.Preload("Driver").First(&penalty, "id = ?", penalty.ID).Error
+	})
+	if err != nil {
+		return m.GridPenalty{}, translateError(err)
+	}
+	return penalty, nil
+}
+
+// GetGridPenaltiesQuery returns the penalties of the race in the order of
+// infringement
+func (repo *GridRepository) GetGridPenaltiesQuery(raceID string) ([]m.GridPenalty, error) {
+	var penalties []m.GridPenalty
+	err := repo.DB.Preload("Driver").
+		Where("race_id = ?", raceID).
+		Order("infringed_at, created_at").
+		Find(&penalties).Error
+	return penalties, err
+}
+
+func (repo *GridRepository) DeleteGridPenaltyQuery(raceID string, id string) error {
+	r := repo.DB.Where("id = ? AND race_id = ?", id, raceID).Delete(&m.GridPenalty{})
+	if r.Error != nil {
+		return translateError(r.Error)
+	}
+	if r.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"github.com/dewciu/f1_api/pkg/common"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"gorm.io/gorm"
+)
+
+type ImportRepository struct {
+	DB *gorm.DB
+}
+
+func NewImportRepository(db *gorm.DB) *ImportRepository {
+	return &ImportRepository{DB: db}
+}
+
+// GetImportReviewsQuery returns the records queued for review in the order
+// they were queued, optionally of one source
+func (repo *ImportRepository) GetImportReviewsQuery(source string, p common.Pagination) ([]m.ImportReview, error) {
+	reviews := []m.ImportReview{}
+	query := repo.DB.Scopes(p.Paginate)
+	if source != "" {
+		query = query.Where("source = ?", source)
+	}
+	err := query.Order("created_at, origin").Find(&reviews).Error
+	return reviews, err
+}
+
+// DeleteImportReviewQuery removes a reviewed record from the queue
+func (repo *ImportRepository) DeleteImportReviewQuery(id string) error {
+	r := repo.DB.Where("id = ?", id).Delete(&m.ImportReview{})
+	if r.Error != nil {
+		return r.Error
+	}
+	if r.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package database
+
+import (
+	m "github.com/dewciu/f1_api/pkg/models"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// lapBatchSize keeps every insert statement well below the Postgres limit of
+// 65535 parameters
+const lapBatchSize = 2000
+
+type LapRepository struct {
+	DB *gorm.DB
+}
+
+func NewLapRepository(db *gorm.DB) *LapRepository {
+	return &LapRepository{DB: db}
+}
+
+// SaveRaceLapsQuery stores the laps of drivers entered for the race in
+// batches, replacing the position and time of laps already stored
+func (repo *LapRepository) SaveRaceLapsQuery(race m.Race, laps []m.Lap) error {
+	return repo.DB.Transaction(func(tx *gorm.DB) error {
+		_, entered, err := raceEntrants(tx, race)
+		if err != nil {
+			return err
+		}
+
+		for i := range laps {
+			if err := v.ValidateEntrant(laps[i].DriverID, entered); err != nil {
+				return err
+			}
+			laps[i].RaceID = race.ID
+		}
+
+		return tx.Omit(clause.Associations).
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "race_id"}, {Name: "driver_id"}, {Name: "lap"}},
+				DoUpdates: clause.AssignmentColumns([]string{"position", "time"}),
+			}).
+			CreateInBatches(&laps, lapBatchSize).Error
+	})
+}
+
+// GetRaceLapsQuery returns the laps of the race in a single query, ordered
+// by driver and lap, optionally of one driver and a range of laps
+func (repo *LapRepository) GetRaceLapsQuery(raceID string, filter v.LapsFilterValidator) ([]m.Lap, error) {
+	laps := []m.Lap{}
+	query := repo.DB.Where("race_id = ?", raceID)
+	if filter.Driver != "" {
+		query = query.Where("driver_id = ?", filter.Driver)
+	}
+	if filter.FromLap > 0 {
+		query = query.Where("lap >= ?", filter.FromLap)
+	}
+	if filter.ToLap > 0 {
+		query = query.Where("lap <= ?", filter.ToLap)
+	}
+	err := query.Order("driver_id, lap").Find(&laps).Error
+	return laps, err
+}
+
+// GetLapDriversQuery returns the drivers of the laps by their ID, loaded in
+// a single query
+func (repo *LapRepository) GetLapDriversQuery(laps []m.Lap) (map[uuid.UUID]m.Driver, error) {
+	ids := []uuid.UUID{}
+	seen := make(map[uuid.UUID]bool)
+	for _, lap := range laps {
+		if !seen[lap.DriverID] {
+			seen[lap.DriverID] = true
+			ids = append(ids, lap.DriverID)
+		}
+	}
+
+	drivers := make(map[uuid.UUID]m.Driver)
+	if len(ids) == 0 {
+		return drivers, nil
+	}
+
+	var found []m.Driver
+	if err := repo.DB.Where("id IN ?", ids).Find(&found).Error; err != nil {
+		return nil, err
+	}
+	for _, driver := range found {
+		drivers[driver.ID] = driver
+	}
+	return drivers, nil
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/dewciu/f1_api/pkg/live"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LiveRepository struct {
+	DB *gorm.DB
+}
+
+func NewLiveRepository(db *gorm.DB) *LiveRepository {
+	return &LiveRepository{DB: db}
+}
+
+// SaveSnapshot replaces the timing snapshot of the session, a snapshot older
+// than the stored one is ignored. It persists the snapshots of a live.Hub
+func (repo *LiveRepository) SaveSnapshot(state live.State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	snapshot := m.TimingSnapshot{SessionID: state.SessionID, Sequence: int64(state.Sequence), State: data}
+	return repo.DB.Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "session_id"}},
+			Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "timing_snapshots.sequence < excluded.sequence"}}},
+			DoUpdates: clause.AssignmentColumns([]string{"sequence", "state", "updated_at"}),
+		}).
+		Create(&snapshot).Error
+}
+
+// GetTimingSnapshotQuery returns the last persisted timing tower of the
+// session
+func (repo *LiveRepository) GetTimingSnapshotQuery(sessionID string) (live.State, error) {
+	var snapshot m.TimingSnapshot
+	if err := repo.DB.Where("session_id = ?", sessionID).First(&snapshot).Error; err != nil {
+		return live.State{}, err
+	}
+	state := live.State{}
+	err := json.Unmarshal(snapshot.State, &state)
+	return state, err
+}
+
+// LoadSnapshot returns the last persisted timing tower of the session, false
+// when it has none. It restores the sessions of a live.Hub
+func (repo *LiveRepository) LoadSnapshot(sessionID uuid.UUID) (live.State, bool, error) {
+	state, err := repo.GetTimingSnapshotQuery(sessionID.String())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return live.State{}, false, nil
+	}
+	if err != nil {
+		return live.State{}, false, err
+	}
+	return state, true, nil
+}
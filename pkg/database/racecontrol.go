@@ -0,0 +1,50 @@
+package database
+
+import (
+	m "github.com/dewciu/f1_api/pkg/models"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RaceControlRepository struct {
+	DB *gorm.DB
+}
+
+func NewRaceControlRepository(db *gorm.DB) *RaceControlRepository {
+	return &RaceControlRepository{DB: db}
+}
+
+// CreateRaceControlEventsQuery adds the events to the race, drivers they
+// name have to be entered for the race
+func (repo *RaceControlRepository) CreateRaceControlEventsQuery(race m.Race, events []m.RaceControlEvent) error {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		drivers := []uuid.UUID{}
+		for i := range events {
+			events[i].RaceID = race.ID
+			if events[i].DriverID != nil {
+				drivers = append(drivers, *events[i].DriverID)
+			}
+		}
+		if err := validateRaceDrivers(tx, race, drivers); err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(&events).Error
+	})
+	return translateError(err)
+}
+
+// GetRaceControlEventsQuery returns the events of the race in time order
+func (repo *RaceControlRepository) GetRaceControlEventsQuery(raceID string, filter v.RaceControlFilterValidator) ([]m.RaceControlEvent, error) {
+	events := []m.RaceControlEvent{}
+	query := repo.DB.Preload("Driver").Where("race_id = ?", raceID)
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Driver != "" {
+		query = query.Where("driver_id = ?", filter.Driver)
+	}
+	err := query.Order("time").Find(&events).Error
+	return events, err
+}
@@ -0,0 +1,212 @@
+package database
+
+import (
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RaceRepository struct {
+	DB *gorm.DB
+}
+
+func NewRaceRepository(db *gorm.DB) *RaceRepository {
+	return &RaceRepository{DB: db}
+}
+
+func preloadRace(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Circuit").
+		Preload("Sessions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("start_time")
+		})
+}
+
+func (repo *RaceRepository) GetRacesBySeasonQuery(year int) ([]m.Race, error) {
+	var races []m.Race
+	err := repo.DB.Scopes(preloadRace).Where("year = ?", year).Order("round").Find(&races).Error
+	return races, err
+}
+
+func (repo *RaceRepository) GetRaceByIdQuery(id string) (m.Race, error) {
+	var race m.Race
+	err := repo.DB.Scopes(preloadRace).Where("id = ?", id).First(&race).Error
+	if err != nil {
+		return m.Race{}, err
+	}
+	return race, nil
+}
+
+func (repo *RaceRepository) GetRaceByRoundQuery(year, round int) (m.Race, error) {
+	var race m.Race
+	err := repo.DB.Scopes(preloadRace).Where("year = ? AND round = ?", year, round).First(&race).Error
+	if err != nil {
+		return m.Race{}, err
+	}
+	return race, nil
+}
+
+func (repo *RaceRepository) DeleteRaceByIdQuery(id string) error {
+	r := repo.DB.Where("id = ?", id).Delete(&m.Race{})
+	if r.Error != nil {
+		return translateError(r.Error)
+	}
+	if r.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (repo *RaceRepository) UpdateRaceByIdQuery(id string, raceToUpdate v.RaceUpdateModelValidator) (m.Race, error) {
+	var race m.Race
+	if err := repo.DB.Where("id = ?", id).First(&race).Error; err != nil {
+		return m.Race{}, err
+	}
+
+	updates := map[string]interface{}{}
+	if raceToUpdate.Name != "" {
+		updates["name"] = raceToUpdate.Name
+	}
+	if raceToUpdate.URL != "" {
+		updates["url"] = raceToUpdate.URL
+	}
+	if raceToUpdate.PointsScale != nil {
+		updates["points_scale"] = *raceToUpdate.PointsScale
+	}
+
+	if err := repo.DB.Model(&race).Updates(updates).Error; err != nil {
+		return m.Race{}, translateError(err)
+	}
+
+	return repo.GetRaceByIdQuery(id)
+}
+
+// lockCalendar locks the season row so calendar changes of the same season
+// are validated one at a time, then returns the races of the season
+func lockCalendar(tx *gorm.DB, year int) ([]m.Race, error) {
+	var season m.Season
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("year = ?", year).First(&season).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var races []m.Race
+	err = tx.Preload("Sessions").Where("year = ?", year).Find(&races).Error
+	return races, err
+}
+
+// CreateRaceQuery adds the race to its season calendar, the calendar with the
+// new race has to pass validation
+func (repo *RaceRepository) CreateRaceQuery(race m.Race) (m.Race, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		races, err := lockCalendar(tx, race.Year)
+		if err != nil {
+			return err
+		}
+
+		if err := v.ValidateCalendar(append(races, race)); err != nil {
+			return err
+		}
+
+		return tx.Omit(clause.Associations).Create(&race).Error
+	})
+	if err != nil {
+		return m.Race{}, translateError(err)
+	}
+
+	return repo.GetRaceByIdQuery(race.ID.String())
+}
+
+// saveSession validates the calendar with the session added to or replaced
+// in its race, then stores it
+func (repo *RaceRepository) saveSession(race m.Race, session m.Session, create bool) (m.Session, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		races, err := lockCalendar(tx, race.Year)
+		if err != nil {
+			return err
+		}
+
+		for i := range races {
+			if races[i].ID != race.ID {
+				continue
+			}
+			sessions := []m.Session{}
+			for _, s := range races[i].Sessions {
+				if s.ID != session.ID {
+					sessions = append(sessions, s)
+				}
+			}
+			races[i].Sessions = append(sessions, session)
+		}
+
+		if err := v.ValidateCalendar(races); err != nil {
+			return err
+		}
+
+		if create {
+			return tx.Create(&session).Error
+		}
+		return tx.Save(&session).Error
+	})
+	if err != nil {
+		return m.Session{}, translateError(err)
+	}
+	return session, nil
+}
+
+func (repo *RaceRepository) CreateSessionQuery(race m.Race, session m.Session) (m.Session, error) {
+	session.RaceID = race.ID
+	return repo.saveSession(race, session, true)
+}
+
+func (repo *RaceRepository) UpdateSessionQuery(race m.Race, sessionID string, sessionToUpdate v.SessionUpdateModelValidator) (m.Session, error) {
+	var session m.Session
+	err := repo.DB.Where("id = ? AND race_id = ?", sessionID, race.ID).First(&session).Error
+	if err != nil {
+		return m.Session{}, err
+	}
+
+	return repo.saveSession(race, sessionToUpdate.Apply(session), false)
+}
+
+func (repo *RaceRepository) GetSessionByIdQuery(id string) (m.Session, error) {
+	var session m.Session
+	err := repo.DB.Where("id = ?", id).First(&session).Error
+	if err != nil {
+		return m.Session{}, err
+	}
+	return session, nil
+}
+
+// GetRaceSessionQuery returns the session of the given type of the race
+func (repo *RaceRepository) GetRaceSessionQuery(raceID string, sessionType string) (m.Session, error) {
+	var session m.Session
+	err := repo.DB.Where("race_id = ? AND type = ?", raceID, sessionType).First(&session).Error
+	if err != nil {
+		return m.Session{}, err
+	}
+	return session, nil
+}
+
+// GetNextSessionQuery returns the session which is live or starts next at the
+// given time, together with its race
+func (repo *RaceRepository) GetNextSessionQuery(now time.Time) (m.Session, m.Race, error) {
+	var session m.Session
+	err := repo.DB.
+		Where("status IN ?", []string{m.SessionScheduled, m.SessionLive}).
+		Where("end_time > ?", now).
+		Order("start_time").
+		First(&session).Error
+	if err != nil {
+		return m.Session{}, m.Race{}, err
+	}
+
+	race, err := repo.GetRaceByIdQuery(session.RaceID.String())
+	if err != nil {
+		return m.Session{}, m.Race{}, err
+	}
+	return session, race, nil
+}
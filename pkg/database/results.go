@@ -0,0 +1,217 @@
+package database
+
+import (
+	"sort"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	m "github.com/dewciu/f1_api/pkg/models"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Entrant is a driver entered for a race through the line-up of a season
+// entry
+type Entrant struct {
+	DriverID      uuid.UUID
+	ConstructorID uuid.UUID
+	CarNumber     int
+}
+
+type ResultRepository struct {
+	DB *gorm.DB
+}
+
+func NewResultRepository(db *gorm.DB) *ResultRepository {
+	return &ResultRepository{DB: db}
+}
+
+func preloadResult(db *gorm.DB) *gorm.DB {
+	return db.Preload("Driver").Preload("Constructor")
+}
+
+// GetRaceEntrantsQuery returns the drivers whose line-up covers the round of
+// the race
+func (repo *ResultRepository) GetRaceEntrantsQuery(race m.Race) ([]Entrant, error) {
+	var entrants []Entrant
+	err := repo.DB.Table("line_ups").
+		Select("line_ups.driver_id, season_entries.constructor_id, line_ups.car_number").
+		Joins("JOIN season_entries ON season_entries.id = line_ups.season_entry_id").
+		Where("season_entries.year = ?", race.Year).
+		Where("line_ups.start_round <= ?", race.Round).
+		Where("line_ups.end_round IS NULL OR line_ups.end_round >= ?", race.Round).
+		Scan(&entrants).Error
+	return entrants, err
+}
+
+// orderClassification assigns the position order, classified drivers come
+// first by position, the others follow by completed laps
+func orderClassification(results []m.Result) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Position != nil && b.Position != nil {
+			return *a.Position < *b.Position
+		}
+		if a.Position != nil || b.Position != nil {
+			return a.Position != nil
+		}
+		return a.Laps > b.Laps
+	})
+	for i := range results {
+		results[i].PositionOrder = i + 1
+	}
+}
+
+// raceEntrants returns the entrants of the race by driver
+func raceEntrants(tx *gorm.DB, race m.Race) (map[uuid.UUID]Entrant, map[uuid.UUID]bool, error) {
+	entrants, err := (&ResultRepository{DB: tx}).GetRaceEntrantsQuery(race)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byDriver := make(map[uuid.UUID]Entrant)
+	entered := make(map[uuid.UUID]bool)
+	for _, entrant := range entrants {
+		byDriver[entrant.DriverID] = entrant
+		entered[entrant.DriverID] = true
+	}
+	return byDriver, entered, nil
+}
+
+// SaveSessionResultsQuery replaces the classification of the session, the
+// results are validated against the race entrants and stored in a single
+// transaction with a new result version
+func (repo *ResultRepository) SaveSessionResultsQuery(race m.Race, session m.Session, results []m.Result) ([]m.Result, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		byDriver, entered, err := raceEntrants(tx, race)
+		if err != nil {
+			return err
+		}
+
+		if err := v.ValidateClassification(results, entered); err != nil {
+			return err
+		}
+
+		orderClassification(results)
+		for i := range results {
+			entrant := byDriver[results[i].DriverID]
+			results[i].RaceID = race.ID
+			results[i].SessionID = session.ID
+			results[i].ConstructorID = entrant.ConstructorID
+			if results[i].CarNumber == 0 {
+				results[i].CarNumber = entrant.CarNumber
+			}
+		}
+
+		if err := tx.Where("session_id = ?", session.ID).Delete(&m.Result{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(&results).Error; err != nil {
+			return err
+		}
+		return recordSubmission(tx, session)
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return repo.GetSessionResultsQuery(session.ID.String())
+}
+
+func (repo *ResultRepository) GetSessionResultsQuery(sessionID string) ([]m.Result, error) {
+	var results []m.Result
+	err := repo.DB.Scopes(preloadResult).
+		Where("session_id = ?", sessionID).
+		Order("position_order").
+		Find(&results).Error
+	return results, err
+}
+
+// GetDriverResultsQuery returns the results of the driver in calendar order,
+// optionally limited to a season
+func (repo *ResultRepository) GetDriverResultsQuery(driverID string, year *int, p common.Pagination) ([]m.Result, error) {
+	var results []m.Result
+	query := repo.DB.Scopes(preloadResult, p.Paginate).
+		Preload("Race").
+		Preload("Session").
+		Joins("JOIN races ON races.id = results.race_id").
+		Joins("JOIN sessions ON sessions.id = results.session_id").
+		Where("results.driver_id = ?", driverID)
+	if year != nil {
+		query = query.Where("races.year = ?", *year)
+	}
+	err := query.Order("races.year, races.round, sessions.start_time").Find(&results).Error
+	return results, err
+}
+
+// SaveQualifyingResultsQuery replaces the qualifying classification of the
+// session in a single transaction, like SaveSessionResultsQuery
+func (repo *ResultRepository) SaveQualifyingResultsQuery(race m.Race, session m.Session, results []m.QualifyingResult) ([]m.QualifyingResult, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		byDriver, entered, err := raceEntrants(tx, race)
+		if err != nil {
+			return err
+		}
+
+		if err := v.ValidateQualifying(results, entered); err != nil {
+			return err
+		}
+
+		for i := range results {
+			entrant := byDriver[results[i].DriverID]
+			results[i].RaceID = race.ID
+			results[i].SessionID = session.ID
+			results[i].ConstructorID = entrant.ConstructorID
+			if results[i].CarNumber == 0 {
+				results[i].CarNumber = entrant.CarNumber
+			}
+		}
+
+		if err := tx.Where("session_id = ?", session.ID).Delete(&m.QualifyingResult{}).Error; err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(&results).Error
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	return repo.GetQualifyingResultsQuery(session.ID.String())
+}
+
+func (repo *ResultRepository) GetQualifyingResultsQuery(sessionID string) ([]m.QualifyingResult, error) {
+	var results []m.QualifyingResult
+	err := repo.DB.Scopes(preloadResult).
+		Where("session_id = ?", sessionID).
+		Order("position").
+		Find(&results).Error
+	return results, err
+}
+
+// GetSeasonResultsQuery returns the race and sprint results of the season
+// with their session, driver and constructor
+func (repo *ResultRepository) GetSeasonResultsQuery(year int) ([]m.Result, error) {
+	var results []m.Result
+	err := repo.DB.Scopes(preloadResult).
+		Preload("Session").
+		Joins("JOIN races ON races.id = results.race_id").
+		Joins("JOIN sessions ON sessions.id = results.session_id").
+		Where("races.year = ? AND sessions.type IN ?", year, []string{m.SessionRace, m.SessionSprint}).
+		Order("races.round, results.position_order").
+		Find(&results).Error
+	return results, err
+}
+
+// GetSeasonQualifyingQuery returns the results of the qualifying sessions of
+// the season, sprint shootouts excluded
+func (repo *ResultRepository) GetSeasonQualifyingQuery(year int) ([]m.QualifyingResult, error) {
+	var results []m.QualifyingResult
+	err := repo.DB.Scopes(preloadResult).
+		Joins("JOIN races ON races.id = qualifying_results.race_id").
+		Joins("JOIN sessions ON sessions.id = qualifying_results.session_id").
+		Where("races.year = ? AND sessions.type = ?", year, m.SessionQualifying).
+		Order("races.round, qualifying_results.position").
+		Find(&results).Error
+	return results, err
+}
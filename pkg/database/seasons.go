@@ -0,0 +1,33 @@
+package database
+
+import (
+	m "github.com/dewciu/f1_api/pkg/models"
+	"gorm.io/gorm"
+)
+
+type SeasonRepository struct {
+	DB *gorm.DB
+}
+
+func NewSeasonRepository(db *gorm.DB) *SeasonRepository {
+	return &SeasonRepository{DB: db}
+}
+
+func (repo *SeasonRepository) GetAllSeasonsQuery() ([]m.Season, error) {
+	var seasons []m.Season
+	err := repo.DB.Order("year").Find(&seasons).Error
+	return seasons, err
+}
+
+func (repo *SeasonRepository) GetSeasonByYearQuery(year int) (m.Season, error) {
+	var season m.Season
+	err := repo.DB.Where("year = ?", year).First(&season).Error
+	if err != nil {
+		return m.Season{}, err
+	}
+	return season, nil
+}
+
+func (repo *SeasonRepository) CreateSeasonQuery(season m.Season) error {
+	return translateError(repo.DB.Create(&season).Error)
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrSimulationInterrupted fails the simulations whose job was lost when the
+// server stopped
+var ErrSimulationInterrupted = errors.New("simulation was interrupted by a restart of the server")
+
+type SimulationRepository struct {
+	DB *gorm.DB
+}
+
+func NewSimulationRepository(db *gorm.DB) *SimulationRepository {
+	return &SimulationRepository{DB: db}
+}
+
+func (repo *SimulationRepository) CreateSimulationQuery(simulation m.Simulation) (m.Simulation, error) {
+	err := repo.DB.Omit(clause.Associations).Create(&simulation).Error
+	return simulation, translateError(err)
+}
+
+func (repo *SimulationRepository) GetSimulationQuery(year int, id string) (m.Simulation, error) {
+	var simulation m.Simulation
+	err := repo.DB.Where("year = ? AND id = ?", year, id).First(&simulation).Error
+	return simulation, err
+}
+
+// GetSimulationsBySeasonQuery returns the simulations of the season, the
+// latest first, without their outcomes
+func (repo *SimulationRepository) GetSimulationsBySeasonQuery(year int, p common.Pagination) ([]m.Simulation, error) {
+	var simulations []m.Simulation
+	err := repo.DB.Scopes(p.Paginate).
+		Omit("outcome").
+		Where("year = ?", year).
+		Order("created_at DESC").
+		Find(&simulations).Error
+	return simulations, err
+}
+
+func (repo *SimulationRepository) StartSimulationQuery(simulation *m.Simulation) error {
+	now := time.Now()
+	simulation.Status = m.SimulationRunning
+	simulation.StartedAt = &now
+	return repo.DB.Model(simulation).Select("updated_at", "status", "started_at").Updates(simulation).Error
+}
+
+func (repo *SimulationRepository) FinishSimulationQuery(simulation *m.Simulation, outcome m.JSON) error {
+	now := time.Now()
+	simulation.Status = m.SimulationFinished
+	simulation.FinishedAt = &now
+	simulation.Outcome = outcome
+	return repo.DB.Model(simulation).Select("updated_at", "status", "finished_at", "outcome").Updates(simulation).Error
+}
+
+func (repo *SimulationRepository) FailSimulationQuery(simulation *m.Simulation, cause error) error {
+	now := time.Now()
+	simulation.Status = m.SimulationFailed
+	simulation.FinishedAt = &now
+	simulation.Error = cause.Error()
+	return repo.DB.Model(simulation).Select("updated_at", "status", "finished_at", "error").Updates(simulation).Error
+}
+
+// FailInterruptedSimulationsQuery fails the simulations left pending or
+// running by a previous run of the server, their jobs only live in memory
+// and no longer exist. It returns the number of simulations failed
+func (repo *SimulationRepository) FailInterruptedSimulationsQuery() (int64, error) {
+	result := repo.DB.Model(&m.Simulation{}).
+		Where("status IN ?", []string{m.SimulationPending, m.SimulationRunning}).
+		Updates(map[string]interface{}{
+			"status":      m.SimulationFailed,
+			"finished_at": time.Now(),
+			"error":       ErrSimulationInterrupted.Error(),
+		})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,295 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	st "github.com/dewciu/f1_api/pkg/standings"
+	"github.com/dewciu/f1_api/pkg/stewards"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StewardRepository struct {
+	DB *gorm.DB
+}
+
+func NewStewardRepository(db *gorm.DB) *StewardRepository {
+	return &StewardRepository{DB: db}
+}
+
+// lockSession locks the session so concurrent requests cannot take the same
+// result version
+func lockSession(tx *gorm.DB, session m.Session) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", session.ID).First(&m.Session{}).Error
+}
+
+// createResultVersion stores the classification as the next version of the
+// session
+func createResultVersion(tx *gorm.DB, session m.Session, version m.ResultVersion, results []m.Result) error {
+	data, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	var last int
+	err = tx.Model(&m.ResultVersion{}).
+		Where("session_id = ?", session.ID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&last).Error
+	if err != nil {
+		return err
+	}
+
+	version.SessionID = session.ID
+	version.Version = last + 1
+	version.Results = data
+	return tx.Omit(clause.Associations).Create(&version).Error
+}
+
+// recordSubmission stores the classification of the session as submitted.
+// The first one takes effect at the end of the session, so decisions issued
+// after the chequered flag amend it, later ones take effect now
+func recordSubmission(tx *gorm.DB, session m.Session) error {
+	if err := lockSession(tx, session); err != nil {
+		return err
+	}
+
+	var count int64
+	if err := tx.Model(&m.ResultVersion{}).Where("session_id = ?", session.ID).Count(&count).Error; err != nil {
+		return err
+	}
+	effectiveAt := time.Now().UTC()
+	if count == 0 && session.EndTime.Before(effectiveAt) {
+		effectiveAt = session.EndTime.UTC()
+	}
+
+	results, err := (&ResultRepository{DB: tx}).GetSessionResultsQuery(session.ID.String())
+	if err != nil {
+		return err
+	}
+	return createResultVersion(tx, session, m.ResultVersion{EffectiveAt: effectiveAt}, results)
+}
+
+// amendSession stores the classification of the session after the decision
+// moved to the status at the time as a new version, refreshes the versions
+// taking effect later and replaces the stored results with the classification
+// in force now, which the standings are computed from
+func amendSession(tx *gorm.DB, race m.Race, session m.Session, decision m.StewardDecision, status string, at time.Time) error {
+	if err := lockSession(tx, session); err != nil {
+		return err
+	}
+
+	var versions []m.ResultVersion
+	if err := tx.Where("session_id = ?", session.ID).Order("version").Find(&versions).Error; err != nil {
+		return err
+	}
+	// results stored before versions were kept become the first version
+	if len(versions) == 0 {
+		if err := recordSubmission(tx, session); err != nil {
+			return err
+		}
+		if err := tx.Where("session_id = ?", session.ID).Order("version").Find(&versions).Error; err != nil {
+			return err
+		}
+	}
+
+	if status == m.DecisionIssued {
+		classified := make(map[uuid.UUID]bool)
+		var submittedAt time.Time
+		if base, ok := stewards.Base(versions, at); ok {
+			results, err := stewards.Snapshot(base)
+			if err != nil {
+				return err
+			}
+			for _, result := range results {
+				classified[result.DriverID] = true
+			}
+			submittedAt = base.EffectiveAt
+		}
+		if err := v.ValidateAmendment(decision, classified, submittedAt); err != nil {
+			return err
+		}
+	}
+
+	var decisions []m.StewardDecision
+	if err := tx.Where("session_id = ?", session.ID).Find(&decisions).Error; err != nil {
+		return err
+	}
+
+	system := st.SystemForSeason(race.Year)
+	classification := func(at time.Time) ([]m.Result, error) {
+		results, _, err := stewards.ClassificationAt(versions, decisions, at, system, session.Type, race.PointsScale)
+		return results, err
+	}
+
+	results, err := classification(at)
+	if err != nil {
+		return err
+	}
+	needsReview, err := stewards.NeedsReviewAt(versions, decisions, at)
+	if err != nil {
+		return err
+	}
+	version := m.ResultVersion{EffectiveAt: at, DecisionID: &decision.ID, Status: status, NeedsReview: needsReview}
+	if err := createResultVersion(tx, session, version, results); err != nil {
+		return err
+	}
+
+	for _, later := range versions {
+		if later.DecisionID == nil || !later.EffectiveAt.After(at) {
+			continue
+		}
+		results, err := classification(later.EffectiveAt)
+		if err != nil {
+			return err
+		}
+		if later.Results, err = json.Marshal(results); err != nil {
+			return err
+		}
+		if later.NeedsReview, err = stewards.NeedsReviewAt(versions, decisions, later.EffectiveAt); err != nil {
+			return err
+		}
+		if err := tx.Model(&later).Select("updated_at", "results", "needs_review").Updates(&later).Error; err != nil {
+			return err
+		}
+	}
+
+	current, err := classification(time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if err := tx.Where("session_id = ?", session.ID).Delete(&m.Result{}).Error; err != nil {
+		return err
+	}
+	if len(current) == 0 {
+		return nil
+	}
+	return tx.Omit(clause.Associations).Create(&current).Error
+}
+
+// CreateStewardDecisionQuery stores the decision on a driver entered for the
+// race, time penalties and disqualifications amend the classification of the
+// session
+func (repo *StewardRepository) CreateStewardDecisionQuery(race m.Race, session m.Session, decision m.StewardDecision) (m.StewardDecision, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		_, entered, err := raceEntrants(tx, race)
+		if err != nil {
+			return err
+		}
+		if err := v.ValidateEntrant(decision.DriverID, entered); err != nil {
+			return err
+		}
+
+		decision.RaceID = race.ID
+		decision.SessionID = session.ID
+		if err := tx.Omit(clause.Associations).Create(&decision).Error; err != nil {
+			return err
+		}
+
+		if stewards.Amends(decision.Penalty) {
+			return amendSession(tx, race, session, decision, m.DecisionIssued, decision.IssuedAt)
+		}
+		return nil
+	})
+	if err != nil {
+		return m.StewardDecision{}, translateError(err)
+	}
+	return repo.GetStewardDecisionQuery(race.ID.String(), decision.ID.String())
+}
+
+// UpdateStewardDecisionStatusQuery moves the decision to the status at the
+// time, amending the classification again when the penalty changes it
+func (repo *StewardRepository) UpdateStewardDecisionStatusQuery(race m.Race, decision m.StewardDecision, status string, at time.Time) (m.StewardDecision, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		if err := v.ValidateDecisionStatus(decision, status, at); err != nil {
+			return err
+		}
+
+		decision.Status = status
+		if status == m.DecisionAppealed {
+			decision.AppealedAt = &at
+		} else {
+			decision.ResolvedAt = &at
+		}
+		err := tx.Model(&decision).
+			Select("updated_at", "status", "appealed_at", "resolved_at").
+			Updates(&decision).Error
+		if err != nil {
+			return err
+		}
+
+		if stewards.Amends(decision.Penalty) {
+			return amendSession(tx, race, decision.Session, decision, status, at)
+		}
+		return nil
+	})
+	if err != nil {
+		return m.StewardDecision{}, translateError(err)
+	}
+	return repo.GetStewardDecisionQuery(race.ID.String(), decision.ID.String())
+}
+
+func preloadDecision(db *gorm.DB) *gorm.DB {
+	return db.Preload("Driver").Preload("Session")
+}
+
+// GetRaceStewardDecisionsQuery returns the decisions of the race in the order
+// they were issued
+func (repo *StewardRepository) GetRaceStewardDecisionsQuery(raceID string) ([]m.StewardDecision, error) {
+	var decisions []m.StewardDecision
+	err := repo.DB.Scopes(preloadDecision).
+		Where("race_id = ?", raceID).
+		Order("issued_at, document").
+		Find(&decisions).Error
+	return decisions, err
+}
+
+func (repo *StewardRepository) GetStewardDecisionQuery(raceID string, id string) (m.StewardDecision, error) {
+	var decision m.StewardDecision
+	err := repo.DB.Scopes(preloadDecision).
+		Where("id = ? AND race_id = ?", id, raceID).
+		First(&decision).Error
+	return decision, err
+}
+
+// GetResultVersionsQuery returns the versions of the classification of the
+// session in the order they were stored, without their results
+func (repo *StewardRepository) GetResultVersionsQuery(sessionID string) ([]m.ResultVersion, error) {
+	var versions []m.ResultVersion
+	err := repo.DB.Omit("results").
+		Preload("Decision").
+		Where("session_id = ?", sessionID).
+		Order("version").
+		Find(&versions).Error
+	return versions, err
+}
+
+// GetSessionResultsAtQuery returns the classification of the session as it
+// stood at the time. Sessions without versions have only ever had their
+// stored results, sessions whose first version took effect later had no
+// classification yet and return gorm.ErrRecordNotFound
+func (repo *StewardRepository) GetSessionResultsAtQuery(sessionID string, at time.Time) ([]m.Result, error) {
+	var version m.ResultVersion
+	err := repo.DB.Where("session_id = ? AND effective_at <= ?", sessionID, at).
+		Order("effective_at DESC, version DESC").
+		First(&version).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		var count int64
+		if err := repo.DB.Model(&m.ResultVersion{}).Where("session_id = ?", sessionID).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return (&ResultRepository{DB: repo.DB}).GetSessionResultsQuery(sessionID)
+		}
+		return nil, gorm.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stewards.Snapshot(version)
+}
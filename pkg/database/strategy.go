@@ -0,0 +1,101 @@
+package database
+
+import (
+	m "github.com/dewciu/f1_api/pkg/models"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StrategyRepository struct {
+	DB *gorm.DB
+}
+
+func NewStrategyRepository(db *gorm.DB) *StrategyRepository {
+	return &StrategyRepository{DB: db}
+}
+
+// validateRaceDrivers checks every driver is entered for the race
+func validateRaceDrivers(tx *gorm.DB, race m.Race, drivers []uuid.UUID) error {
+	_, entered, err := raceEntrants(tx, race)
+	if err != nil {
+		return err
+	}
+	for _, driver := range drivers {
+		if err := v.ValidateEntrant(driver, entered); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SavePitStopsQuery replaces the pit stops of the race
+func (repo *StrategyRepository) SavePitStopsQuery(race m.Race, stops []m.PitStop) ([]m.PitStop, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		drivers := []uuid.UUID{}
+		for i := range stops {
+			stops[i].RaceID = race.ID
+			drivers = append(drivers, stops[i].DriverID)
+		}
+		if err := validateRaceDrivers(tx, race, drivers); err != nil {
+			return err
+		}
+
+		if err := tx.Where("race_id = ?", race.ID).Delete(&m.PitStop{}).Error; err != nil {
+			return err
+		}
+		if len(stops) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(&stops).Error
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return repo.GetPitStopsQuery(race.ID.String())
+}
+
+func (repo *StrategyRepository) GetPitStopsQuery(raceID string) ([]m.PitStop, error) {
+	stops := []m.PitStop{}
+	err := repo.DB.Preload("Driver").
+		Where("race_id = ?", raceID).
+		Order("lap, stop").
+		Find(&stops).Error
+	return stops, err
+}
+
+// SaveStintsQuery replaces the stints of the race
+func (repo *StrategyRepository) SaveStintsQuery(race m.Race, stints []m.Stint) ([]m.Stint, error) {
+	err := repo.DB.Transaction(func(tx *gorm.DB) error {
+		drivers := []uuid.UUID{}
+		for i := range stints {
+			stints[i].RaceID = race.ID
+			drivers = append(drivers, stints[i].DriverID)
+		}
+		if err := validateRaceDrivers(tx, race, drivers); err != nil {
+			return err
+		}
+
+		if err := tx.Where("race_id = ?", race.ID).Delete(&m.Stint{}).Error; err != nil {
+			return err
+		}
+		if len(stints) == 0 {
+			return nil
+		}
+		return tx.Omit(clause.Associations).Create(&stints).Error
+	})
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return repo.GetStintsQuery(race.ID.String())
+}
+
+func (repo *StrategyRepository) GetStintsQuery(raceID string) ([]m.Stint, error) {
+	stints := []m.Stint{}
+	err := repo.DB.Preload("Driver").
+		Where("race_id = ?", raceID).
+		Order("driver_id, number").
+		Find(&stints).Error
+	return stints, err
+}
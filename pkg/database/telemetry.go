@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+)
+
+type TelemetryRepository struct {
+	DB *gorm.DB
+}
+
+func NewTelemetryRepository(db *gorm.DB) *TelemetryRepository {
+	return &TelemetryRepository{DB: db}
+}
+
+// TelemetryCopy counts the samples of a stream, Stored leaves out the
+// samples already stored
+type TelemetryCopy struct {
+	Received int64
+	Stored   int64
+}
+
+// telemetryPartition names the partition holding the samples of the session
+func telemetryPartition(sessionID uuid.UUID) string {
+	return "telemetry_samples_" + strings.ReplaceAll(sessionID.String(), "-", "")
+}
+
+// telemetrySource feeds the samples of the reader to COPY
+type telemetrySource struct {
+	reader  *v.TelemetryReader
+	session uuid.UUID
+	sample  m.TelemetrySample
+}
+
+func (ts *telemetrySource) Next() bool {
+	sample, ok := ts.reader.Next()
+	sample.SessionID = ts.session
+	ts.sample = sample
+	return ok
+}
+
+func (ts *telemetrySource) Values() ([]interface{}, error) {
+	return ts.sample.Values(), nil
+}
+
+func (ts *telemetrySource) Err() error {
+	return ts.reader.Err
+}
+
+// SaveSessionTelemetryQuery streams the samples of the reader into the
+// partition of the session with COPY. The samples are copied to a staging
+// table first, so that samples already stored are skipped instead of failing
+// the stream. A rejected line rolls back the whole stream, together with the
+// partition when the stream created it
+func (repo *TelemetryRepository) SaveSessionTelemetryQuery(ctx context.Context, race m.Race, session m.Session, reader *v.TelemetryReader) (TelemetryCopy, error) {
+	_, entered, err := raceEntrants(repo.DB, race)
+	if err != nil {
+		return TelemetryCopy{}, err
+	}
+	reader.Entered = entered
+
+	partition := pgx.Identifier{telemetryPartition(session.ID)}.Sanitize()
+	var copied TelemetryCopy
+	err = withPgxConn(ctx, repo.DB, func(conn *pgx.Conn) error {
+		return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			// streams of the same session wait for each other, so that only
+			// the first one creates the partition
+			_, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", partition)
+			if err != nil {
+				return err
+			}
+			_, err = tx.Exec(ctx, fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s PARTITION OF telemetry_samples FOR VALUES IN ('%s')", partition, session.ID))
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Exec(ctx, "CREATE TEMPORARY TABLE telemetry_staging (LIKE telemetry_samples) ON COMMIT DROP")
+			if err != nil {
+				return err
+			}
+
+			copied.Received, err = tx.CopyFrom(ctx, pgx.Identifier{"telemetry_staging"}, m.TelemetryColumns, &telemetrySource{reader: reader, session: session.ID})
+			if err != nil {
+				return err
+			}
+
+			columns := strings.Join(m.TelemetryColumns, ", ")
+			tag, err := tx.Exec(ctx, fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM telemetry_staging ON CONFLICT DO NOTHING", partition, columns, columns))
+			copied.Stored = tag.RowsAffected()
+			return err
+		})
+	})
+	if reader.Err != nil {
+		return TelemetryCopy{}, reader.Err
+	}
+	return copied, err
+}
+
+// withPgxConn runs f on a pgx connection of the pool, for COPY which
+// database/sql cannot express
+func withPgxConn(ctx context.Context, db *gorm.DB, f func(*pgx.Conn) error) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn interface{}) error {
+		stdlibConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.New("database driver does not support COPY")
+		}
+		return f(stdlibConn.Conn())
+	})
+}
+
+// GetSessionTelemetryQuery returns the samples of the driver in the session
+// in time order, of a lap or between two times when given
+func (repo *TelemetryRepository) GetSessionTelemetryQuery(sessionID uuid.UUID, filter v.TelemetryFilterValidator) ([]m.TelemetrySample, error) {
+	samples := []m.TelemetrySample{}
+	query := repo.DB.Where("session_id = ? AND driver_id = ?", sessionID, filter.Driver)
+	if filter.Lap > 0 {
+		query = query.Where("lap = ?", filter.Lap)
+	}
+	if filter.ParsedFrom != nil {
+		query = query.Where("time >= ?", *filter.ParsedFrom)
+	}
+	if filter.ParsedTo != nil {
+		query = query.Where("time <= ?", *filter.ParsedTo)
+	}
+	err := query.Order("time").Find(&samples).Error
+	return samples, err
+}
+
+// DeleteSessionTelemetryQuery drops the partition of the session with all
+// its samples
+func (repo *TelemetryRepository) DeleteSessionTelemetryQuery(sessionID uuid.UUID) error {
+	partition := pgx.Identifier{telemetryPartition(sessionID)}.Sanitize()
+	return repo.DB.Exec("DROP TABLE IF EXISTS " + partition).Error
+}
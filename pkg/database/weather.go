@@ -0,0 +1,30 @@
+package database
+
+import (
+	m "github.com/dewciu/f1_api/pkg/models"
+	v "github.com/dewciu/f1_api/pkg/validators"
+	"gorm.io/gorm"
+)
+
+type WeatherRepository struct {
+	DB *gorm.DB
+}
+
+func NewWeatherRepository(db *gorm.DB) *WeatherRepository {
+	return &WeatherRepository{DB: db}
+}
+
+// GetSessionWeatherQuery returns the weather observations of the session in
+// time order, optionally between two times
+func (repo *WeatherRepository) GetSessionWeatherQuery(sessionID string, filter v.WeatherFilterValidator) ([]m.WeatherObservation, error) {
+	observations := []m.WeatherObservation{}
+	query := repo.DB.Where("session_id = ?", sessionID)
+	if filter.ParsedFrom != nil {
+		query = query.Where("time >= ?", *filter.ParsedFrom)
+	}
+	if filter.ParsedTo != nil {
+		query = query.Where("time <= ?", *filter.ParsedTo)
+	}
+	err := query.Order("time").Find(&observations).Error
+	return observations, err
+}
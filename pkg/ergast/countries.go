@@ -0,0 +1,139 @@
+package ergast
+
+// country holds the names Ergast used for a country, which are not ISO names,
+// and for the nationality of its drivers and constructors
+type country struct {
+	code        string
+	name        string
+	nationality string
+}
+
+var countries = []country{
+	{"AE", "UAE", "Emirati"},
+	{"AR", "Argentina", "Argentine"},
+	{"AT", "Austria", "Austrian"},
+	{"AU", "Australia", "Australian"},
+	{"AZ", "Azerbaijan", "Azerbaijani"},
+	{"BE", "Belgium", "Belgian"},
+	{"BH", "Bahrain", "Bahraini"},
+	{"BR", "Brazil", "Brazilian"},
+	{"CA", "Canada", "Canadian"},
+	{"CH", "Switzerland", "Swiss"},
+	{"CL", "Chile", "Chilean"},
+	{"CN", "China", "Chinese"},
+	{"CO", "Colombia", "Colombian"},
+	{"CZ", "Czech Republic", "Czech"},
+	{"DE", "Germany", "German"},
+	{"DK", "Denmark", "Danish"},
+	{"ES", "Spain", "Spanish"},
+	{"FI", "Finland", "Finnish"},
+	{"FR", "France", "French"},
+	{"GB", "UK", "British"},
+	{"HK", "Hong Kong", "Hong Kong"},
+	{"HU", "Hungary", "Hungarian"},
+	{"ID", "Indonesia", "Indonesian"},
+	{"IE", "Ireland", "Irish"},
+	{"IN", "India", "Indian"},
+	{"IT", "Italy", "Italian"},
+	{"JP", "Japan", "Japanese"},
+	{"KR", "Korea", "Korean"},
+	{"LI", "Liechtenstein", "Liechtensteiner"},
+	{"MA", "Morocco", "Moroccan"},
+	{"MC", "Monaco", "Monegasque"},
+	{"MX", "Mexico", "Mexican"},
+	{"MY", "Malaysia", "Malaysian"},
+	{"NL", "Netherlands", "Dutch"},
+	{"NZ", "New Zealand", "New Zealander"},
+	{"PL", "Poland", "Polish"},
+	{"PT", "Portugal", "Portuguese"},
+	{"QA", "Qatar", "Qatari"},
+	{"RU", "Russia", "Russian"},
+	{"SA", "Saudi Arabia", "Saudi"},
+	{"SE", "Sweden", "Swedish"},
+	{"SG", "Singapore", "Singaporean"},
+	{"TH", "Thailand", "Thai"},
+	{"TR", "Turkey", "Turkish"},
+	{"US", "USA", "American"},
+	{"UY", "Uruguay", "Uruguayan"},
+	{"VE", "Venezuela", "Venezuelan"},
+	{"VN", "Vietnam", "Vietnamese"},
+	{"ZA", "South Africa", "South African"},
+	{"ZW", "Zimbabwe", "Rhodesian"},
+}
+
+var byCode = func() map[string]country {
+	codes := make(map[string]country, len(countries))
+	for _, c := range countries {
+		codes[c.code] = c
+	}
+	return codes
+}()
+
+// CountryName returns the Ergast name of the country with the ISO 3166-1
+// alpha-2 code, the code itself when it is unknown
+func CountryName(code string) string {
+	if c, ok := byCode[code]; ok {
+		return c.name
+	}
+	return code
+}
+
+// Nationality returns the Ergast nationality of drivers and constructors of
+// the country with the ISO 3166-1 alpha-2 code, the code itself when it is
+// unknown
+func Nationality(code string) string {
+	if c, ok := byCode[code]; ok {
+		return c.nationality
+	}
+	return code
+}
+
+// countryAliases and nationalityAliases hold the other spellings found in
+// the Ergast data, including nationalities of drivers born in one country and
+// racing under the flag of another
+var (
+	countryAliases = map[string]string{
+		"United States":        "US",
+		"United Kingdom":       "GB",
+		"Great Britain":        "GB",
+		"South Korea":          "KR",
+		"United Arab Emirates": "AE",
+	}
+	nationalityAliases = map[string]string{
+		"American-Italian":  "US",
+		"Argentine-Italian": "AR",
+		"Argentinian":       "AR",
+		"East German":       "DE",
+		"West German":       "DE",
+	}
+)
+
+var byName, byNationality = func() (map[string]string, map[string]string) {
+	names := make(map[string]string, len(countries)+len(countryAliases))
+	nationalities := make(map[string]string, len(countries)+len(nationalityAliases))
+	for _, c := range countries {
+		names[c.name] = c.code
+		nationalities[c.nationality] = c.code
+	}
+	for name, code := range countryAliases {
+		names[name] = code
+	}
+	for nationality, code := range nationalityAliases {
+		nationalities[nationality] = code
+	}
+	return names, nationalities
+}()
+
+// CountryCode returns the ISO 3166-1 alpha-2 code of the country with the
+// Ergast name
+func CountryCode(name string) (string, bool) {
+	code, ok := byName[name]
+	return code, ok
+}
+
+// NationalityCode returns the ISO 3166-1 alpha-2 code of the country of the
+// Ergast nationality
+func NationalityCode(nationality string) (string, bool) {
+	code, ok := byNationality[nationality]
+	return code, ok
+}
@@ -0,0 +1,298 @@
+package ergast
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	m "github.com/dewciu/f1_api/pkg/models"
+	st "github.com/dewciu/f1_api/pkg/standings"
+	"github.com/google/uuid"
+)
+
+// The samples in testdata are Ergast responses without the average speed of
+// fastest laps, which is not stored
+
+func date(value string) time.Time {
+	t, _ := time.Parse("2006-01-02", value)
+	return t
+}
+
+func ptr(n int) *int {
+	return &n
+}
+
+var (
+	verstappen = m.Driver{Model: m.Model{ID: uuid.New()}, Ref: "max_verstappen", PermanentNumber: ptr(33), Code: "VER",
+		FirstName: "Max", LastName: "Verstappen", DateOfBirth: date("1997-09-30"), Nationality: "NL",
+		URL: "http://en.wikipedia.org/wiki/Max_Verstappen"}
+	perez = m.Driver{Model: m.Model{ID: uuid.New()}, Ref: "perez", PermanentNumber: ptr(11), Code: "PER",
+		FirstName: "Sergio", LastName: "Pérez", DateOfBirth: date("1990-01-26"), Nationality: "MX",
+		URL: "http://en.wikipedia.org/wiki/Sergio_P%C3%A9rez"}
+	alonso = m.Driver{Model: m.Model{ID: uuid.New()}, Ref: "alonso", PermanentNumber: ptr(14), Code: "ALO",
+		FirstName: "Fernando", LastName: "Alonso", DateOfBirth: date("1981-07-29"), Nationality: "ES",
+		URL: "http://en.wikipedia.org/wiki/Fernando_Alonso"}
+
+	redBull     = constructor("red_bull", "Red Bull", "AT", "http://en.wikipedia.org/wiki/Red_Bull_Racing")
+	astonMartin = constructor("aston_martin", "Aston Martin", "GB", "http://en.wikipedia.org/wiki/Aston_Martin_in_Formula_One")
+)
+
+func constructor(ref, name, nationality, url string) m.Constructor {
+	return m.Constructor{Model: m.Model{ID: uuid.New()}, Ref: ref, Name: name, Nationality: nationality, URL: url}
+}
+
+func race(year, round int, name, url string, circuit m.Circuit, sessions ...m.Session) m.Race {
+	r := m.Race{Model: m.Model{ID: uuid.New()}, Year: year, Round: round, Name: name, URL: url, Circuit: circuit, Sessions: sessions}
+	for _, session := range sessions {
+		if session.Type == m.SessionRace {
+			r.Date = date(session.StartTime.Format("2006-01-02"))
+		}
+	}
+	return r
+}
+
+func session(sessionType, start string) m.Session {
+	t, _ := time.Parse(time.RFC3339, start)
+	return m.Session{Model: m.Model{ID: uuid.New()}, Type: sessionType, StartTime: t}
+}
+
+type finish struct {
+	driver      m.Driver
+	constructor m.Constructor
+	number      int
+	grid        int
+	laps        int
+	millis      int64
+	gap         string
+	points      float64
+	fastestLap  int
+	fastestTime string
+	fastestRank int
+}
+
+func results(r m.Race, finishes ...finish) []m.Result {
+	results := []m.Result{}
+	for i, f := range finishes {
+		position := i + 1
+		millis := f.millis
+		fastest, _ := m.ParseLapTime(f.fastestTime)
+		results = append(results, m.Result{
+			RaceID: r.ID, Race: r, Session: m.Session{Type: m.SessionRace},
+			DriverID: f.driver.ID, Driver: f.driver, ConstructorID: f.constructor.ID, Constructor: f.constructor,
+			CarNumber: f.number, Grid: f.grid, Position: &position, PositionText: "", PositionOrder: position,
+			Classified: true, Laps: f.laps, TimeMillis: &millis, Gap: f.gap, Status: m.StatusFinished,
+			Points: f.points, FastestLap: ptr(f.fastestLap), FastestLapTime: &fastest, FastestLapRank: ptr(f.fastestRank),
+		})
+	}
+	return results
+}
+
+// compare checks the response against the sample, the URL of the sample is
+// the one of the Ergast API
+func compare(t *testing.T, sample string, build func(url string) MRData) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want Response
+	if err := json.Unmarshal(data, &want); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := json.Marshal(Response{MRData: build(want.MRData.URL)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotJSON, wantJSON interface{}
+	json.Unmarshal(got, &gotJSON)
+	json.Unmarshal(data, &wantJSON)
+	if !reflect.DeepEqual(gotJSON, wantJSON) {
+		t.Errorf("response differs from %s:\n%s", sample, got)
+	}
+}
+
+func parse(t *testing.T, path string) Query {
+	t.Helper()
+	q, err := ParsePath(path)
+	if err != nil {
+		t.Fatalf("parsing %s: %v", path, err)
+	}
+	return q
+}
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path     string
+		season   int
+		round    int
+		resource string
+		filters  map[string]string
+	}{
+		{"/2023/5/results.json", 2023, 5, ResourceResults, map[string]string{}},
+		{"/drivers/alonso/constructors.json", 0, 0, ResourceConstructors, map[string]string{ResourceDrivers: "alonso"}},
+		{"/drivers/alonso", 0, 0, ResourceDrivers, map[string]string{ResourceDrivers: "alonso"}},
+		{"/2023.json", 2023, 0, ResourceRaces, map[string]string{}},
+		{"", 0, 0, ResourceSeasons, map[string]string{}},
+		{"/2021/driverStandings/1.json", 2021, 0, ResourceDriverStandings, map[string]string{ResourceDriverStandings: "1"}},
+		{"/circuits/monza/grid/1/results/1/drivers", 0, 0, ResourceDrivers,
+			map[string]string{ResourceCircuits: "monza", FilterGrid: "1", ResourceResults: "1"}},
+	}
+	for _, tt := range tests {
+		q := parse(t, tt.path)
+		if q.Season != tt.season || q.Round != tt.round || q.Resource != tt.resource || !reflect.DeepEqual(q.Filters, tt.filters) {
+			t.Errorf("%s parsed as %+v", tt.path, q)
+		}
+	}
+
+	q := parse(t, "/current/last/results.json")
+	if !q.Current || !q.Last || q.Resource != ResourceResults {
+		t.Errorf("current/last parsed as %+v", q)
+	}
+	if q := parse(t, "/current/next.json"); !q.Next || q.Resource != ResourceRaces {
+		t.Errorf("current/next parsed as %+v", q)
+	}
+
+	invalid := []string{
+		"/2023/5/laps.json",
+		"/2023/results.xml",
+		"/1949/results.json",
+		"/2023/grid/pole/results.json",
+		"/2023/grid",
+		"/driverStandings.json",
+		"/2023/driverStandings/1/results.json",
+		"/drivers/alonso/drivers/hamilton/results.json",
+	}
+	for _, path := range invalid {
+		if _, err := ParsePath(path); err == nil {
+			t.Errorf("%s parsed without an error", path)
+		}
+	}
+}
+
+func TestResultsContract(t *testing.T) {
+	miami := m.Circuit{Ref: "miami", Name: "Miami International Autodrome", Location: "Miami", Country: "US",
+		Latitude: 25.9581, Longitude: -80.2389, URL: "http://en.wikipedia.org/wiki/Miami_International_Autodrome"}
+	r := race(2023, 5, "Miami Grand Prix", "https://en.wikipedia.org/wiki/2023_Miami_Grand_Prix", miami,
+		session(m.SessionQualifying, "2023-05-06T20:00:00Z"), session(m.SessionRace, "2023-05-07T19:30:00Z"))
+	page := results(r,
+		finish{verstappen, redBull, 1, 9, 57, 5258241, "", 26, 50, "1:29.708", 1},
+		finish{perez, redBull, 11, 1, 57, 5263625, "+5.384", 18, 56, "1:30.203", 2},
+		finish{alonso, astonMartin, 14, 2, 57, 5284546, "+26.305", 15, 57, "1:30.966", 5},
+	)
+
+	q := parse(t, "/2023/5/results.json")
+	compare(t, "2023_5_results.json", func(url string) MRData {
+		data := NewMRData(url, common.Pagination{Limit: 3}, 20)
+		data.RaceTable = &RaceTable{Criteria: q.Criteria(), Races: ResultRaces(page)}
+		return data
+	})
+}
+
+func TestCurrentLastResultsContract(t *testing.T) {
+	yasMarina := m.Circuit{Ref: "yas_marina", Name: "Yas Marina Circuit", Location: "Abu Dhabi", Country: "AE",
+		Latitude: 24.4672, Longitude: 54.6031, URL: "http://en.wikipedia.org/wiki/Yas_Marina_Circuit"}
+	r := race(2023, 22, "Abu Dhabi Grand Prix", "https://en.wikipedia.org/wiki/2023_Abu_Dhabi_Grand_Prix", yasMarina,
+		session(m.SessionRace, "2023-11-26T13:00:00Z"))
+	page := results(r, finish{verstappen, redBull, 1, 1, 58, 5222624, "", 26, 44, "1:26.993", 1})
+
+	// the repository resolves current and last to the season and round
+	q := parse(t, "/current/last/results.json")
+	q.Season, q.Round, q.Current, q.Last = 2023, 22, false, false
+
+	compare(t, "current_last_results.json", func(url string) MRData {
+		data := NewMRData(url, common.Pagination{Limit: 1}, 20)
+		data.RaceTable = &RaceTable{Criteria: q.Criteria(), Races: ResultRaces(page)}
+		return data
+	})
+}
+
+func TestDriverConstructorsContract(t *testing.T) {
+	constructors := []m.Constructor{
+		constructor("alpine", "Alpine F1 Team", "FR", "http://en.wikipedia.org/wiki/Alpine_F1_Team"),
+		astonMartin,
+		constructor("ferrari", "Ferrari", "IT", "http://en.wikipedia.org/wiki/Scuderia_Ferrari"),
+		constructor("mclaren", "McLaren", "GB", "http://en.wikipedia.org/wiki/McLaren"),
+		constructor("minardi", "Minardi", "IT", "http://en.wikipedia.org/wiki/Minardi"),
+		constructor("renault", "Renault", "FR", "http://en.wikipedia.org/wiki/Renault_in_Formula_One"),
+	}
+
+	q := parse(t, "/drivers/alonso/constructors.json")
+	compare(t, "drivers_alonso_constructors.json", func(url string) MRData {
+		data := NewMRData(url, common.Pagination{Limit: common.DefaultLimit}, int64(len(constructors)))
+		data.ConstructorTable = &ConstructorTable{Criteria: q.Criteria(), Constructors: []Constructor{}}
+		for _, c := range constructors {
+			data.ConstructorTable.Constructors = append(data.ConstructorTable.Constructors, ConstructorOf(c))
+		}
+		return data
+	})
+}
+
+func TestDriverStandingsContract(t *testing.T) {
+	bahrain := m.Circuit{Ref: "bahrain", Name: "Bahrain International Circuit", Country: "BH"}
+	r := race(2023, 1, "Bahrain Grand Prix", "", bahrain, session(m.SessionRace, "2023-03-05T15:00:00Z"))
+	season := results(r,
+		finish{verstappen, redBull, 1, 1, 57, 5636736, "", 0, 0, "1:36.236", 6},
+		finish{perez, redBull, 11, 2, 57, 5648723, "+11.987", 0, 0, "1:36.344", 7},
+		finish{alonso, astonMartin, 14, 5, 57, 5675373, "+38.637", 0, 0, "1:36.156", 5},
+	)
+	table := st.Compute(2023, st.SystemForSeason(2023), []m.Race{r}, season, 1)
+
+	q := parse(t, "/2023/1/driverStandings.json")
+	compare(t, "2023_1_driverStandings.json", func(url string) MRData {
+		return StandingsMRData(table, q, common.Pagination{Limit: 3}, url)
+	})
+
+	leader := StandingsMRData(table, parse(t, "/2023/1/constructors/aston_martin/driverStandings.json"), common.Pagination{Limit: 3}, "")
+	if list := leader.StandingsTable.StandingsLists; len(list) != 1 || len(list[0].DriverStandings) != 1 || list[0].DriverStandings[0].Driver.DriverID != "alonso" {
+		t.Errorf("aston_martin standings are %+v", list)
+	}
+	if none := StandingsMRData(table, parse(t, "/2023/1/driverStandings/4.json"), common.Pagination{Limit: 3}, ""); none.Total != "0" || len(none.StandingsTable.StandingsLists) != 0 {
+		t.Errorf("got %s standings in P4", none.Total)
+	}
+}
+
+func TestScheduleContract(t *testing.T) {
+	shanghai := m.Circuit{Ref: "shanghai", Name: "Shanghai International Circuit", Location: "Shanghai", Country: "CN",
+		Latitude: 31.3389, Longitude: 121.22, URL: "http://en.wikipedia.org/wiki/Shanghai_International_Circuit"}
+	r := race(2024, 5, "Chinese Grand Prix", "https://en.wikipedia.org/wiki/2024_Chinese_Grand_Prix", shanghai,
+		session(m.SessionFP1, "2024-04-19T03:30:00Z"),
+		session(m.SessionSprintShootout, "2024-04-19T07:30:00Z"),
+		session(m.SessionSprint, "2024-04-20T03:00:00Z"),
+		session(m.SessionQualifying, "2024-04-20T07:00:00Z"),
+		session(m.SessionRace, "2024-04-21T07:00:00Z"),
+	)
+
+	q := parse(t, "/2024/5/races.json")
+	compare(t, "2024_5_races.json", func(url string) MRData {
+		data := NewMRData(url, common.Pagination{Limit: common.DefaultLimit}, 1)
+		data.RaceTable = &RaceTable{Criteria: q.Criteria(), Races: []Race{ScheduleOf(r)}}
+		return data
+	})
+}
+
+func TestCountryCodes(t *testing.T) {
+	for name, want := range map[string]string{"UK": "GB", "USA": "US", "United States": "US", "Korea": "KR", "UAE": "AE"} {
+		if code, ok := CountryCode(name); !ok || code != want {
+			t.Errorf("CountryCode(%q) = %q, %v, want %q", name, code, ok, want)
+		}
+	}
+	for nationality, want := range map[string]string{"British": "GB", "Dutch": "NL", "East German": "DE", "American-Italian": "US", "Rhodesian": "ZW"} {
+		if code, ok := NationalityCode(nationality); !ok || code != want {
+			t.Errorf("NationalityCode(%q) = %q, %v, want %q", nationality, code, ok, want)
+		}
+	}
+	if _, ok := NationalityCode("Martian"); ok {
+		t.Error("an unknown nationality has a code")
+	}
+	for _, c := range countries {
+		if code, _ := CountryCode(CountryName(c.code)); code != c.code {
+			t.Errorf("%s does not round trip through its name", c.code)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Package ergast speaks the URL scheme and JSON shape of the Ergast API,
+// which many scripts and community tools still depend on. It parses Ergast
+// paths into queries and builds the MRData responses from the models of the
+// project.
+package ergast
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Resources and filters of an Ergast path. Every resource except seasons and
+// races can also be used as a filter followed by its value
+const (
+	ResourceSeasons              = "seasons"
+	ResourceRaces                = "races"
+	ResourceResults              = "results"
+	ResourceSprint               = "sprint"
+	ResourceQualifying           = "qualifying"
+	ResourceDrivers              = "drivers"
+	ResourceConstructors         = "constructors"
+	ResourceCircuits             = "circuits"
+	ResourceDriverStandings      = "driverStandings"
+	ResourceConstructorStandings = "constructorStandings"
+	FilterGrid                   = "grid"
+	FilterFastest                = "fastest"
+)
+
+const (
+	seasonCurrent = "current"
+	roundLast     = "last"
+	roundNext     = "next"
+)
+
+var resources = map[string]bool{
+	ResourceSeasons:              true,
+	ResourceRaces:                true,
+	ResourceResults:              true,
+	ResourceSprint:               true,
+	ResourceQualifying:           true,
+	ResourceDrivers:              true,
+	ResourceConstructors:         true,
+	ResourceCircuits:             true,
+	ResourceDriverStandings:      true,
+	ResourceConstructorStandings: true,
+}
+
+// filters maps the filters to whether their value is a position, the others
+// take a ref
+var filters = map[string]bool{
+	ResourceDrivers:              false,
+	ResourceConstructors:         false,
+	ResourceCircuits:             false,
+	ResourceResults:              true,
+	ResourceSprint:               true,
+	ResourceQualifying:           true,
+	ResourceDriverStandings:      true,
+	ResourceConstructorStandings: true,
+	FilterGrid:                   true,
+	FilterFastest:                true,
+}
+
+// Query is a parsed Ergast path such as /2023/5/drivers/alonso/results
+type Query struct {
+	// Season is 0 when the path is not limited to a season, Current is set
+	// for the current season until the season is resolved
+	Season  int
+	Current bool
+	// Round is 0 when the path is not limited to a round, Last and Next are
+	// set for the last and the next round until the round is resolved
+	Round int
+	Last  bool
+	Next  bool
+	// Filters holds the refs of drivers, constructors and circuits and the
+	// positions of the other filters
+	Filters  map[string]string
+	Resource string
+}
+
+// ParsePath parses the path after /ergast/f1. Paths are answered in JSON
+// with or without the .json extension, XML is not served
+func ParsePath(path string) (Query, error) {
+	q := Query{Filters: map[string]string{}}
+
+	path = strings.Trim(path, "/")
+	if strings.HasSuffix(path, ".xml") {
+		return Query{}, errors.New("only JSON responses are served")
+	}
+	path = strings.TrimSuffix(path, ".json")
+
+	segments := []string{}
+	if path != "" {
+		segments = strings.Split(path, "/")
+	}
+
+	if len(segments) > 0 {
+		if segments[0] == seasonCurrent {
+			q.Current = true
+			segments = segments[1:]
+		} else if season, err := strconv.Atoi(segments[0]); err == nil {
+			if season < 1950 {
+				return Query{}, fmt.Errorf("season %d is not a championship season", season)
+			}
+			q.Season = season
+			segments = segments[1:]
+		}
+	}
+
+	if (q.Season != 0 || q.Current) && len(segments) > 0 {
+		switch segments[0] {
+		case roundLast:
+			q.Last = true
+			segments = segments[1:]
+		case roundNext:
+			q.Next = true
+			segments = segments[1:]
+		default:
+			if round, err := strconv.Atoi(segments[0]); err == nil {
+				if round < 1 {
+					return Query{}, errors.New("round must be a positive number")
+				}
+				q.Round = round
+				segments = segments[1:]
+			}
+		}
+	}
+
+	for len(segments) > 0 {
+		key := segments[0]
+		if !resources[key] && !filters[key] {
+			return Query{}, fmt.Errorf("unknown resource %q", key)
+		}
+
+		if len(segments) == 1 {
+			if !resources[key] {
+				return Query{}, fmt.Errorf("%s is a filter and needs a value", key)
+			}
+			q.Resource = key
+			break
+		}
+
+		if _, ok := filters[key]; !ok {
+			return Query{}, fmt.Errorf("%s cannot be filtered by", key)
+		}
+		if _, ok := q.Filters[key]; ok {
+			return Query{}, fmt.Errorf("%s is filtered more than once", key)
+		}
+		value := segments[1]
+		if filters[key] {
+			if position, err := strconv.Atoi(value); err != nil || position < 1 {
+				return Query{}, fmt.Errorf("%s must be followed by a positive number", key)
+			}
+		}
+		q.Filters[key] = value
+
+		// a resource followed by its value is the last segment, as in
+		// /drivers/alonso
+		if len(segments) == 2 && resources[key] {
+			q.Resource = key
+		}
+		segments = segments[2:]
+	}
+
+	if q.Resource == "" {
+		q.Resource = ResourceSeasons
+		if q.Season != 0 || q.Current {
+			q.Resource = ResourceRaces
+		}
+	}
+
+	standings := q.Resource == ResourceDriverStandings || q.Resource == ResourceConstructorStandings
+	if standings && q.Season == 0 && !q.Current {
+		return Query{}, errors.New("standings need a season")
+	}
+	// these positions only filter their own resource
+	for _, filter := range []string{ResourceSprint, ResourceDriverStandings, ResourceConstructorStandings} {
+		if _, ok := q.Filters[filter]; ok && q.Resource != filter {
+			return Query{}, fmt.Errorf("%s can only filter %s", filter, filter)
+		}
+	}
+
+	return q, nil
+}
+
+// Position returns the position given to the filter, 0 when it is not
+// filtered by
+func (q Query) Position(filter string) int {
+	position, _ := strconv.Atoi(q.Filters[filter])
+	return position
+}
+
+// FiltersResults reports whether the query selects races, drivers or
+// constructors by their race results rather than by the calendar only
+func (q Query) FiltersResults() bool {
+	for _, filter := range []string{ResourceDrivers, ResourceConstructors, ResourceResults, ResourceQualifying, FilterGrid, FilterFastest} {
+		if _, ok := q.Filters[filter]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Criteria echoes the query in the table of the response, the season and
+// round have to be resolved
+func (q Query) Criteria() Criteria {
+	criteria := Criteria{
+		DriverID:      q.Filters[ResourceDrivers],
+		ConstructorID: q.Filters[ResourceConstructors],
+		CircuitID:     q.Filters[ResourceCircuits],
+		Grid:          q.Filters[FilterGrid],
+		Fastest:       q.Filters[FilterFastest],
+	}
+	if q.Season != 0 {
+		criteria.Season = strconv.Itoa(q.Season)
+	}
+	if q.Round != 0 {
+		criteria.Round = strconv.Itoa(q.Round)
+	}
+	for _, filter := range []string{ResourceResults, ResourceSprint, ResourceQualifying, ResourceDriverStandings, ResourceConstructorStandings} {
+		if position, ok := q.Filters[filter]; ok {
+			criteria.Position = position
+		}
+	}
+	return criteria
+}
+
+// Without returns the query without the filter
+func (q Query) Without(filter string) Query {
+	filters := make(map[string]string, len(q.Filters))
+	for key, value := range q.Filters {
+		if key != filter {
+			filters[key] = value
+		}
+	}
+	q.Filters = filters
+	return q
+}
+
+// LimitsRaces reports whether the query selects a part of the calendar
+func (q Query) LimitsRaces() bool {
+	_, circuit := q.Filters[ResourceCircuits]
+	return q.Season != 0 || q.Round != 0 || circuit
+}
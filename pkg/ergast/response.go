@@ -0,0 +1,432 @@
+package ergast
+
+import (
+	"strconv"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	m "github.com/dewciu/f1_api/pkg/models"
+	st "github.com/dewciu/f1_api/pkg/standings"
+	"github.com/google/uuid"
+)
+
+const (
+	Xmlns  = "http://ergast.com/mrd/1.5"
+	Series = "f1"
+)
+
+// Response is the envelope of every Ergast response, every value in it is a
+// string as in the original API
+type Response struct {
+	MRData MRData `json:"MRData"`
+}
+
+type MRData struct {
+	Xmlns            string            `json:"xmlns"`
+	Series           string            `json:"series"`
+	URL              string            `json:"url"`
+	Limit            string            `json:"limit"`
+	Offset           string            `json:"offset"`
+	Total            string            `json:"total"`
+	SeasonTable      *SeasonTable      `json:"SeasonTable,omitempty"`
+	RaceTable        *RaceTable        `json:"RaceTable,omitempty"`
+	DriverTable      *DriverTable      `json:"DriverTable,omitempty"`
+	ConstructorTable *ConstructorTable `json:"ConstructorTable,omitempty"`
+	CircuitTable     *CircuitTable     `json:"CircuitTable,omitempty"`
+	StandingsTable   *StandingsTable   `json:"StandingsTable,omitempty"`
+}
+
+// Criteria are the filters of the request echoed in its table
+type Criteria struct {
+	Season        string `json:"season,omitempty"`
+	Round         string `json:"round,omitempty"`
+	DriverID      string `json:"driverId,omitempty"`
+	ConstructorID string `json:"constructorId,omitempty"`
+	CircuitID     string `json:"circuitId,omitempty"`
+	Grid          string `json:"grid,omitempty"`
+	Fastest       string `json:"fastest,omitempty"`
+	Position      string `json:"position,omitempty"`
+}
+
+type SeasonTable struct {
+	Criteria
+	Seasons []Season `json:"Seasons"`
+}
+
+type RaceTable struct {
+	Criteria
+	Races []Race `json:"Races"`
+}
+
+type DriverTable struct {
+	Criteria
+	Drivers []Driver `json:"Drivers"`
+}
+
+type ConstructorTable struct {
+	Criteria
+	Constructors []Constructor `json:"Constructors"`
+}
+
+type CircuitTable struct {
+	Criteria
+	Circuits []Circuit `json:"Circuits"`
+}
+
+type StandingsTable struct {
+	Criteria
+	StandingsLists []StandingsList `json:"StandingsLists"`
+}
+
+type Season struct {
+	Season string `json:"season"`
+	URL    string `json:"url"`
+}
+
+type Location struct {
+	Lat      string `json:"lat"`
+	Long     string `json:"long"`
+	Locality string `json:"locality"`
+	Country  string `json:"country"`
+}
+
+type Circuit struct {
+	CircuitID   string   `json:"circuitId"`
+	URL         string   `json:"url"`
+	CircuitName string   `json:"circuitName"`
+	Location    Location `json:"Location"`
+}
+
+type Driver struct {
+	DriverID        string `json:"driverId"`
+	PermanentNumber string `json:"permanentNumber,omitempty"`
+	Code            string `json:"code,omitempty"`
+	URL             string `json:"url"`
+	GivenName       string `json:"givenName"`
+	FamilyName      string `json:"familyName"`
+	DateOfBirth     string `json:"dateOfBirth"`
+	Nationality     string `json:"nationality"`
+}
+
+type Constructor struct {
+	ConstructorID string `json:"constructorId"`
+	URL           string `json:"url"`
+	Name          string `json:"name"`
+	Nationality   string `json:"nationality"`
+}
+
+// Schedule is the start of a session of the race weekend
+type Schedule struct {
+	Date string `json:"date"`
+	Time string `json:"time"`
+}
+
+type Race struct {
+	Season            string             `json:"season"`
+	Round             string             `json:"round"`
+	URL               string             `json:"url"`
+	RaceName          string             `json:"raceName"`
+	Circuit           Circuit            `json:"Circuit"`
+	Date              string             `json:"date"`
+	Time              string             `json:"time,omitempty"`
+	FirstPractice     *Schedule          `json:"FirstPractice,omitempty"`
+	SecondPractice    *Schedule          `json:"SecondPractice,omitempty"`
+	ThirdPractice     *Schedule          `json:"ThirdPractice,omitempty"`
+	Qualifying        *Schedule          `json:"Qualifying,omitempty"`
+	SprintQualifying  *Schedule          `json:"SprintQualifying,omitempty"`
+	Sprint            *Schedule          `json:"Sprint,omitempty"`
+	Results           []Result           `json:"Results,omitempty"`
+	SprintResults     []Result           `json:"SprintResults,omitempty"`
+	QualifyingResults []QualifyingResult `json:"QualifyingResults,omitempty"`
+}
+
+type Time struct {
+	Millis string `json:"millis,omitempty"`
+	Time   string `json:"time"`
+}
+
+type FastestLap struct {
+	Rank string `json:"rank,omitempty"`
+	Lap  string `json:"lap"`
+	Time Time   `json:"Time"`
+}
+
+type Result struct {
+	Number       string      `json:"number"`
+	Position     string      `json:"position"`
+	PositionText string      `json:"positionText"`
+	Points       string      `json:"points"`
+	Driver       Driver      `json:"Driver"`
+	Constructor  Constructor `json:"Constructor"`
+	Grid         string      `json:"grid"`
+	Laps         string      `json:"laps"`
+	Status       string      `json:"status"`
+	Time         *Time       `json:"Time,omitempty"`
+	FastestLap   *FastestLap `json:"FastestLap,omitempty"`
+}
+
+type QualifyingResult struct {
+	Number      string      `json:"number"`
+	Position    string      `json:"position"`
+	Driver      Driver      `json:"Driver"`
+	Constructor Constructor `json:"Constructor"`
+	Q1          string      `json:"Q1,omitempty"`
+	Q2          string      `json:"Q2,omitempty"`
+	Q3          string      `json:"Q3,omitempty"`
+}
+
+type StandingsList struct {
+	Season               string                `json:"season"`
+	Round                string                `json:"round"`
+	DriverStandings      []DriverStanding      `json:"DriverStandings,omitempty"`
+	ConstructorStandings []ConstructorStanding `json:"ConstructorStandings,omitempty"`
+}
+
+type DriverStanding struct {
+	Position     string        `json:"position"`
+	PositionText string        `json:"positionText"`
+	Points       string        `json:"points"`
+	Wins         string        `json:"wins"`
+	Driver       Driver        `json:"Driver"`
+	Constructors []Constructor `json:"Constructors"`
+}
+
+type ConstructorStanding struct {
+	Position     string      `json:"position"`
+	PositionText string      `json:"positionText"`
+	Points       string      `json:"points"`
+	Wins         string      `json:"wins"`
+	Constructor  Constructor `json:"Constructor"`
+}
+
+// NewMRData returns the envelope of the page of a response with total items
+func NewMRData(url string, p common.Pagination, total int64) MRData {
+	return MRData{
+		Xmlns:  Xmlns,
+		Series: Series,
+		URL:    url,
+		Limit:  strconv.Itoa(p.Limit),
+		Offset: strconv.Itoa(p.Offset),
+		Total:  strconv.FormatInt(total, 10),
+	}
+}
+
+func number(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func SeasonOf(season m.Season) Season {
+	return Season{Season: strconv.Itoa(season.Year), URL: season.URL}
+}
+
+func CircuitOf(circuit m.Circuit) Circuit {
+	return Circuit{
+		CircuitID:   circuit.Ref,
+		URL:         circuit.URL,
+		CircuitName: circuit.Name,
+		Location: Location{
+			Lat:      number(circuit.Latitude),
+			Long:     number(circuit.Longitude),
+			Locality: circuit.Location,
+			Country:  CountryName(circuit.Country),
+		},
+	}
+}
+
+func DriverOf(driver m.Driver) Driver {
+	response := Driver{
+		DriverID:    driver.Ref,
+		Code:        driver.Code,
+		URL:         driver.URL,
+		GivenName:   driver.FirstName,
+		FamilyName:  driver.LastName,
+		Nationality: Nationality(driver.Nationality),
+	}
+	if driver.PermanentNumber != nil {
+		response.PermanentNumber = strconv.Itoa(*driver.PermanentNumber)
+	}
+	if !driver.DateOfBirth.IsZero() {
+		response.DateOfBirth = driver.DateOfBirth.Format("2006-01-02")
+	}
+	return response
+}
+
+func ConstructorOf(constructor m.Constructor) Constructor {
+	return Constructor{
+		ConstructorID: constructor.Ref,
+		URL:           constructor.URL,
+		Name:          constructor.Name,
+		Nationality:   Nationality(constructor.Nationality),
+	}
+}
+
+// RaceOf returns the race without its sessions, as it heads its results.
+// The race needs its circuit and sessions loaded
+func RaceOf(race m.Race) Race {
+	response := Race{
+		Season:   strconv.Itoa(race.Year),
+		Round:    strconv.Itoa(race.Round),
+		URL:      race.URL,
+		RaceName: race.Name,
+		Circuit:  CircuitOf(race.Circuit),
+		Date:     race.Date.Format("2006-01-02"),
+	}
+	for _, session := range race.Sessions {
+		if session.Type == m.SessionRace {
+			response.Time = session.StartTime.UTC().Format("15:04:05Z")
+		}
+	}
+	return response
+}
+
+// ScheduleOf returns the race with the start of every session of its
+// weekend, as listed in the calendar
+func ScheduleOf(race m.Race) Race {
+	response := RaceOf(race)
+	for _, session := range race.Sessions {
+		schedule := &Schedule{
+			Date: session.StartTime.UTC().Format("2006-01-02"),
+			Time: session.StartTime.UTC().Format("15:04:05Z"),
+		}
+		switch session.Type {
+		case m.SessionFP1:
+			response.FirstPractice = schedule
+		case m.SessionFP2:
+			response.SecondPractice = schedule
+		case m.SessionFP3:
+			response.ThirdPractice = schedule
+		case m.SessionQualifying:
+			response.Qualifying = schedule
+		case m.SessionSprintShootout:
+			response.SprintQualifying = schedule
+		case m.SessionSprint:
+			response.Sprint = schedule
+		}
+	}
+	return response
+}
+
+func ResultOf(result m.Result) Result {
+	response := Result{
+		Number:       strconv.Itoa(result.CarNumber),
+		Position:     strconv.Itoa(result.PositionOrder),
+		PositionText: result.PositionText,
+		Points:       number(result.Points),
+		Driver:       DriverOf(result.Driver),
+		Constructor:  ConstructorOf(result.Constructor),
+		Grid:         strconv.Itoa(result.Grid),
+		Laps:         strconv.Itoa(result.Laps),
+		Status:       result.Status,
+	}
+	if response.PositionText == "" && result.Position != nil {
+		response.PositionText = strconv.Itoa(*result.Position)
+	}
+
+	if result.TimeMillis != nil {
+		// the winner's time is the race time, the others the gap to it
+		time := m.LapTimeFromMillis(*result.TimeMillis).String()
+		if result.Gap != "" {
+			time = result.Gap
+		}
+		response.Time = &Time{Millis: strconv.FormatInt(*result.TimeMillis, 10), Time: time}
+	}
+
+	if result.FastestLapTime != nil {
+		response.FastestLap = &FastestLap{Time: Time{Time: result.FastestLapTime.String()}}
+		if result.FastestLap != nil {
+			response.FastestLap.Lap = strconv.Itoa(*result.FastestLap)
+		}
+		if result.FastestLapRank != nil {
+			response.FastestLap.Rank = strconv.Itoa(*result.FastestLapRank)
+		}
+	}
+
+	return response
+}
+
+func QualifyingResultOf(result m.QualifyingResult) QualifyingResult {
+	response := QualifyingResult{
+		Number:      strconv.Itoa(result.CarNumber),
+		Position:    strconv.Itoa(result.Position),
+		Driver:      DriverOf(result.Driver),
+		Constructor: ConstructorOf(result.Constructor),
+	}
+	for _, segment := range []struct {
+		time  *m.LapTime
+		field *string
+	}{{result.Q1, &response.Q1}, {result.Q2, &response.Q2}, {result.Q3, &response.Q3}} {
+		if segment.time != nil {
+			*segment.field = segment.time.String()
+		}
+	}
+	return response
+}
+
+// racesOf groups items in calendar order under their races, starting a new
+// race whenever the race of the item changes
+func racesOf(count int, race func(i int) (uuid.UUID, m.Race), add func(race *Race, i int)) []Race {
+	races := []Race{}
+	var current uuid.UUID
+	for i := 0; i < count; i++ {
+		id, r := race(i)
+		if len(races) == 0 || id != current {
+			races = append(races, RaceOf(r))
+			current = id
+		}
+		add(&races[len(races)-1], i)
+	}
+	return races
+}
+
+// ResultRaces groups race results in calendar order under their races, the
+// results need their race with its circuit and sessions loaded
+func ResultRaces(results []m.Result) []Race {
+	return racesOf(len(results), func(i int) (uuid.UUID, m.Race) {
+		return results[i].RaceID, results[i].Race
+	}, func(race *Race, i int) {
+		race.Results = append(race.Results, ResultOf(results[i]))
+	})
+}
+
+// SprintRaces groups sprint results in calendar order under their races
+func SprintRaces(results []m.Result) []Race {
+	return racesOf(len(results), func(i int) (uuid.UUID, m.Race) {
+		return results[i].RaceID, results[i].Race
+	}, func(race *Race, i int) {
+		race.SprintResults = append(race.SprintResults, ResultOf(results[i]))
+	})
+}
+
+// QualifyingRaces groups qualifying results in calendar order under their
+// races
+func QualifyingRaces(results []m.QualifyingResult) []Race {
+	return racesOf(len(results), func(i int) (uuid.UUID, m.Race) {
+		return results[i].RaceID, results[i].Race
+	}, func(race *Race, i int) {
+		race.QualifyingResults = append(race.QualifyingResults, QualifyingResultOf(results[i]))
+	})
+}
+
+func DriverStandingOf(standing st.DriverStanding) DriverStanding {
+	response := DriverStanding{
+		Position:     strconv.Itoa(standing.Position),
+		PositionText: strconv.Itoa(standing.Position),
+		Points:       number(standing.Points),
+		Wins:         strconv.Itoa(standing.Wins),
+		Driver:       DriverOf(standing.Driver),
+		Constructors: []Constructor{},
+	}
+	for _, constructor := range standing.Constructors {
+		response.Constructors = append(response.Constructors, ConstructorOf(constructor))
+	}
+	return response
+}
+
+func ConstructorStandingOf(standing st.ConstructorStanding) ConstructorStanding {
+	return ConstructorStanding{
+		Position:     strconv.Itoa(standing.Position),
+		PositionText: strconv.Itoa(standing.Position),
+		Points:       number(standing.Points),
+		Wins:         strconv.Itoa(standing.Wins),
+		Constructor:  ConstructorOf(standing.Constructor),
+	}
+}
@@ -0,0 +1,93 @@
+package ergast
+
+import (
+	"strconv"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	st "github.com/dewciu/f1_api/pkg/standings"
+)
+
+// Page returns the items of the page
+func Page[T any](items []T, p common.Pagination) []T {
+	if p.Offset >= len(items) {
+		return []T{}
+	}
+	end := p.Offset + p.Limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[p.Offset:end]
+}
+
+// DriverStandings returns the drivers' standings of the table matching the
+// driver, constructor and position of the query
+func DriverStandings(table st.Table, q Query) []DriverStanding {
+	standings := []DriverStanding{}
+	for _, standing := range table.Drivers {
+		if ref, ok := q.Filters[ResourceDrivers]; ok && standing.Driver.Ref != ref {
+			continue
+		}
+		if ref, ok := q.Filters[ResourceConstructors]; ok {
+			drove := false
+			for _, constructor := range standing.Constructors {
+				drove = drove || constructor.Ref == ref
+			}
+			if !drove {
+				continue
+			}
+		}
+		if position := q.Position(ResourceDriverStandings); position != 0 && standing.Position != position {
+			continue
+		}
+		standings = append(standings, DriverStandingOf(standing))
+	}
+	return standings
+}
+
+// ConstructorStandings returns the constructors' standings of the table
+// matching the constructor and position of the query
+func ConstructorStandings(table st.Table, q Query) []ConstructorStanding {
+	standings := []ConstructorStanding{}
+	for _, standing := range table.Constructors {
+		if ref, ok := q.Filters[ResourceConstructors]; ok && standing.Constructor.Ref != ref {
+			continue
+		}
+		if position := q.Position(ResourceConstructorStandings); position != 0 && standing.Position != position {
+			continue
+		}
+		standings = append(standings, ConstructorStandingOf(standing))
+	}
+	return standings
+}
+
+// StandingsListOf returns the list of the standings of the table after its
+// round
+func StandingsListOf(table st.Table) StandingsList {
+	return StandingsList{Season: strconv.Itoa(table.Year), Round: strconv.Itoa(table.AfterRound)}
+}
+
+// StandingsMRData builds the envelope holding the page of the drivers' or
+// constructors' standings of the table, as the resource of the query asks
+func StandingsMRData(table st.Table, q Query, p common.Pagination, url string) MRData {
+	list := StandingsListOf(table)
+	var total int
+	if q.Resource == ResourceDriverStandings {
+		standings := DriverStandings(table, q)
+		total = len(standings)
+		list.DriverStandings = Page(standings, p)
+	} else {
+		standings := ConstructorStandings(table, q)
+		total = len(standings)
+		list.ConstructorStandings = Page(standings, p)
+	}
+
+	criteria := q.Criteria()
+	criteria.Round = list.Round
+
+	data := NewMRData(url, p, int64(total))
+	data.StandingsTable = &StandingsTable{Criteria: criteria, StandingsLists: []StandingsList{}}
+	if total > 0 {
+		data.StandingsTable.StandingsLists = append(data.StandingsTable.StandingsLists, list)
+	}
+	return data
+}
@@ -0,0 +1,47 @@
+package geo
+
+import "math"
+
+const EarthRadiusKm = 6371.0
+
+func toRadians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// Haversine returns the great-circle distance in kilometres between two points
+// given in decimal degrees.
+func Haversine(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BoundingBox returns the latitude and longitude ranges enclosing every point
+// within radiusKm of the origin. It is meant as a cheap prefilter before the
+// exact Haversine distance is checked.
+func BoundingBox(lat, lng, radiusKm float64) (minLat, maxLat, minLng, maxLng float64) {
+	dLat := radiusKm / EarthRadiusKm * 180 / math.Pi
+
+	minLat = math.Max(lat-dLat, -90)
+	maxLat = math.Min(lat+dLat, 90)
+
+	// Close to the poles the longitude range covers the whole globe
+	if minLat <= -90 || maxLat >= 90 {
+		return minLat, maxLat, -180, 180
+	}
+
+	// The circle is widest north of its centre in the northern hemisphere and
+	// south of it in the southern one, so scaling by the centre latitude
+	// would cut off its sides at high latitudes
+	dLng := math.Asin(math.Sin(radiusKm/EarthRadiusKm)/math.Cos(toRadians(lat))) * 180 / math.Pi
+	minLng, maxLng = lng-dLng, lng+dLng
+	if minLng < -180 || maxLng > 180 {
+		return minLat, maxLat, -180, 180
+	}
+
+	return minLat, maxLat, minLng, maxLng
+}
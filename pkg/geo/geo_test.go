@@ -0,0 +1,90 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHaversine(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lng1, lat2, lng2 float64
+		km                     float64
+	}{
+		{"London to Paris", 51.5074, -0.1278, 48.8566, 2.3522, 343.56},
+		{"New York to Los Angeles", 40.7128, -74.0060, 34.0522, -118.2437, 3935.75},
+		{"Sydney to Melbourne", -33.8688, 151.2093, -37.8136, 144.9631, 713.43},
+		{"Tokyo to Sydney", 35.6762, 139.6503, -33.8688, 151.2093, 7825.82},
+		{"across the antimeridian", 0, 179.5, 0, -179.5, 111.19},
+		{"pole to pole", 90, 0, -90, 0, math.Pi * EarthRadiusKm},
+		{"antipodes", 0, 0, 0, 180, math.Pi * EarthRadiusKm},
+		{"same point", 43.7347, 7.4206, 43.7347, 7.4206, 0},
+	}
+	for _, tt := range tests {
+		got := Haversine(tt.lat1, tt.lng1, tt.lat2, tt.lng2)
+		if math.Abs(got-tt.km) > 0.01 {
+			t.Errorf("%s is %.2f km, want %.2f", tt.name, got, tt.km)
+		}
+		if back := Haversine(tt.lat2, tt.lng2, tt.lat1, tt.lng1); math.Abs(back-got) > 1e-9 {
+			t.Errorf("%s is %.2f km one way and %.2f km back", tt.name, got, back)
+		}
+	}
+}
+
+// destination returns the point at the given distance from the origin along
+// the initial bearing in degrees
+func destination(lat, lng, km, bearing float64) (float64, float64) {
+	d := km / EarthRadiusKm
+	phi, lambda, theta := toRadians(lat), toRadians(lng), toRadians(bearing)
+	phi2 := math.Asin(math.Sin(phi)*math.Cos(d) + math.Cos(phi)*math.Sin(d)*math.Cos(theta))
+	lambda2 := lambda + math.Atan2(math.Sin(theta)*math.Sin(d)*math.Cos(phi), math.Cos(d)-math.Sin(phi)*math.Sin(phi2))
+	lng2 := math.Mod(lambda2*180/math.Pi+540, 360) - 180
+	return phi2 * 180 / math.Pi, lng2
+}
+
+func TestBoundingBox(t *testing.T) {
+	tests := []struct {
+		name          string
+		lat, lng, km  float64
+		fullLongitude bool
+	}{
+		{"Monza", 45.6156, 9.2811, 50, false},
+		{"Interlagos", -23.7036, -46.6997, 500, false},
+		{"on the equator", 0, 0, 1000, false},
+		{"crossing the antimeridian", -17.7134, 178.065, 300, true},
+		{"crossing the antimeridian westwards", 64.8378, -179.9, 100, true},
+		{"near the north pole", 85, 30, 600, true},
+		{"near the south pole", -88, -120, 100, false},
+		{"reaching the south pole", -89.5, -120, 100, true},
+		{"at high latitude", 78.2232, 15.6267, 400, false},
+	}
+	for _, tt := range tests {
+		minLat, maxLat, minLng, maxLng := BoundingBox(tt.lat, tt.lng, tt.km)
+		if tt.fullLongitude != (minLng == -180 && maxLng == 180) {
+			t.Errorf("%s spans longitudes %.3f to %.3f", tt.name, minLng, maxLng)
+		}
+		if minLat < -90 || maxLat > 90 || minLat > tt.lat || maxLat < tt.lat {
+			t.Errorf("%s spans latitudes %.3f to %.3f", tt.name, minLat, maxLat)
+		}
+
+		// every point of the circle, slightly inside it, is within the box
+		for bearing := 0.0; bearing < 360; bearing += 0.5 {
+			lat, lng := destination(tt.lat, tt.lng, tt.km*0.999, bearing)
+			if lat < minLat || lat > maxLat || lng < minLng || lng > maxLng {
+				t.Errorf("%s: point %.4f, %.4f at bearing %v is outside the box %.4f..%.4f, %.4f..%.4f",
+					tt.name, lat, lng, bearing, minLat, maxLat, minLng, maxLng)
+				break
+			}
+		}
+	}
+}
+
+func TestBoundingBoxPole(t *testing.T) {
+	minLat, maxLat, minLng, maxLng := BoundingBox(90, 0, 100)
+	if maxLat != 90 || minLng != -180 || maxLng != 180 {
+		t.Errorf("box around the pole is %v..%v, %v..%v", minLat, maxLat, minLng, maxLng)
+	}
+	if want := 90 - 100/EarthRadiusKm*180/math.Pi; math.Abs(minLat-want) > 1e-9 {
+		t.Errorf("box around the pole reaches down to %v, want %v", minLat, want)
+	}
+}
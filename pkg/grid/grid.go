@@ -0,0 +1,331 @@
+// Package grid builds the starting grid from the qualifying classification
+// and the grid penalties of the race.
+//
+// The penalties are applied as the sporting regulations prescribe:
+//   - drivers outside the 107% rule start only when admitted by the stewards,
+//     from the back of the grid in qualifying order
+//   - back-of-grid penalties, including power unit drops above
+//     models.MaxPowerUnitPlaces, place drivers behind the others in the order
+//     of infringement, ahead of admitted non-qualifiers
+//   - grid drops are applied one by one in the order of infringement, a drop
+//     that cannot be fully served places the driver last among the drivers
+//     without back-of-grid penalties
+//   - pit lane starters leave the grid, the cars behind them move up
+package grid
+
+import (
+	"fmt"
+	"sort"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	q "github.com/dewciu/f1_api/pkg/qualifying"
+	"github.com/google/uuid"
+)
+
+// Change is a step of the audit trail of a driver, positions are 0 when the
+// driver is not on the grid
+type Change struct {
+	From   int
+	To     int
+	Reason string
+}
+
+type Slot struct {
+	m.QualifyingResult
+	// Grid is the grid slot, 0 for pit lane starters and drivers who do not
+	// start
+	Grid    int
+	PitLane bool
+	Starts  bool
+	Audit   []Change
+}
+
+type StartingGrid struct {
+	// Slots holds the grid in starting order followed by the pit lane
+	// starters and the drivers who do not start
+	Slots []Slot
+}
+
+type builder struct {
+	slots map[uuid.UUID]*Slot
+	order []uuid.UUID
+}
+
+func label(result m.QualifyingResult) string {
+	if result.Driver.Code != "" {
+		return result.Driver.Code
+	}
+	if result.Driver.LastName != "" {
+		return result.Driver.LastName
+	}
+	return result.DriverID.String()
+}
+
+func (b *builder) positions() map[uuid.UUID]int {
+	positions := make(map[uuid.UUID]int, len(b.order))
+	for i, driver := range b.order {
+		positions[driver] = i + 1
+	}
+	return positions
+}
+
+// apply runs a step moving the subject and records the change of the
+// subject with its own reason, and of every other driver whose position
+// changed with the reason given for them
+func (b *builder) apply(subject uuid.UUID, reason, othersReason string, step func()) {
+	before := b.positions()
+	step()
+	after := b.positions()
+
+	b.slots[subject].Audit = append(b.slots[subject].Audit, Change{From: before[subject], To: after[subject], Reason: reason})
+	for _, driver := range b.order {
+		if driver != subject && before[driver] != after[driver] {
+			b.slots[driver].Audit = append(b.slots[driver].Audit, Change{From: before[driver], To: after[driver], Reason: othersReason})
+		}
+	}
+}
+
+func (b *builder) index(driver uuid.UUID) int {
+	for i, d := range b.order {
+		if d == driver {
+			return i
+		}
+	}
+	return -1
+}
+
+func (b *builder) move(driver uuid.UUID, to int) {
+	from := b.index(driver)
+	order := append(b.order[:from:from], b.order[from+1:]...)
+	b.order = append(order[:to:to], append([]uuid.UUID{driver}, order[to:]...)...)
+}
+
+func (b *builder) remove(driver uuid.UUID) {
+	from := b.index(driver)
+	b.order = append(b.order[:from:from], b.order[from+1:]...)
+}
+
+func sortByInfringement(penalties []m.GridPenalty) []m.GridPenalty {
+	sorted := append([]m.GridPenalty{}, penalties...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].InfringedAt.Before(sorted[j].InfringedAt)
+	})
+	return sorted
+}
+
+func penaltyReason(penalty m.GridPenalty) string {
+	if penalty.Reason != "" {
+		return penalty.Reason
+	}
+	return penalty.Component
+}
+
+// Build computes the starting grid from the qualifying results, ordered by
+// position, and the grid penalties of the race. Penalties of drivers without
+// a qualifying result are ignored
+func Build(results []m.QualifyingResult, penalties []m.GridPenalty) StartingGrid {
+	return build(results, penalties, true, func(result m.QualifyingResult) string {
+		return fmt.Sprintf("Qualified P%d", result.Position)
+	})
+}
+
+// BuildFromSprint computes the grid of a race set by the sprint, as in 2021
+// and 2022. Drivers line up in the order of the sprint classification,
+// followed by the drivers without a sprint result in qualifying order, and
+// the grid penalties of the race are applied as in Build. The 107% rule was
+// already applied to the sprint grid
+func BuildFromSprint(results []m.QualifyingResult, sprint []m.Result, penalties []m.GridPenalty) StartingGrid {
+	finished := make(map[uuid.UUID]m.Result)
+	for _, result := range sprint {
+		finished[result.DriverID] = result
+	}
+
+	ordered := append([]m.QualifyingResult{}, results...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		a, aok := finished[ordered[i].DriverID]
+		b, bok := finished[ordered[j].DriverID]
+		if aok != bok {
+			return aok
+		}
+		if aok {
+			return a.PositionOrder < b.PositionOrder
+		}
+		return ordered[i].Position < ordered[j].Position
+	})
+
+	return build(ordered, penalties, false, func(result m.QualifyingResult) string {
+		sprint, ok := finished[result.DriverID]
+		switch {
+		case !ok:
+			return fmt.Sprintf("No sprint result, qualified P%d", result.Position)
+		case sprint.Position == nil:
+			return fmt.Sprintf("Not classified in the sprint, lines up by sprint order P%d", sprint.PositionOrder)
+		}
+		return fmt.Sprintf("Finished the sprint P%d", *sprint.Position)
+	})
+}
+
+// build computes the grid from the results in starting order, origin
+// explains the starting position of a driver
+func build(results []m.QualifyingResult, penalties []m.GridPenalty, check107 bool, origin func(m.QualifyingResult) string) StartingGrid {
+	report := q.Evaluate(results)
+	b := &builder{slots: make(map[uuid.UUID]*Slot)}
+	admitted := []uuid.UUID{}
+	excluded := []uuid.UUID{}
+
+	for _, evaluation := range report.Results {
+		result := evaluation.QualifyingResult
+		slot := &Slot{QualifyingResult: result, Starts: true}
+		b.slots[result.DriverID] = slot
+
+		switch {
+		case evaluation.Within107 || !check107:
+			b.order = append(b.order, result.DriverID)
+			slot.Audit = append(slot.Audit, Change{To: len(b.order), Reason: origin(result)})
+		case result.Admitted:
+			admitted = append(admitted, result.DriverID)
+		default:
+			slot.Starts = false
+			excluded = append(excluded, result.DriverID)
+			slot.Audit = append(slot.Audit, Change{Reason: "Outside 107% of the fastest Q1 time and not admitted by the stewards"})
+		}
+	}
+
+	for _, driver := range admitted {
+		b.order = append(b.order, driver)
+		slot := b.slots[driver]
+		slot.Audit = append(slot.Audit, Change{To: len(b.order), Reason: fmt.Sprintf("Qualified P%d outside 107%%, admitted by the stewards to start from the back", slot.Position)})
+	}
+
+	// back-of-grid and pit lane penalties override grid drops, power unit
+	// drops above the limit turn into a back-of-grid penalty
+	backOfGrid := []m.GridPenalty{}
+	pitLane := []m.GridPenalty{}
+	drops := []m.GridPenalty{}
+	powerUnitPlaces := make(map[uuid.UUID]int)
+	sentBack := make(map[uuid.UUID]bool)
+	startsFromPitLane := make(map[uuid.UUID]bool)
+
+	for _, penalty := range sortByInfringement(penalties) {
+		slot, ok := b.slots[penalty.DriverID]
+		if !ok || !slot.Starts {
+			continue
+		}
+		switch penalty.Type {
+		case m.GridPenaltyPitLane:
+			if !startsFromPitLane[penalty.DriverID] {
+				startsFromPitLane[penalty.DriverID] = true
+				pitLane = append(pitLane, penalty)
+			}
+		case m.GridPenaltyBackOfGrid:
+			if !sentBack[penalty.DriverID] {
+				sentBack[penalty.DriverID] = true
+				backOfGrid = append(backOfGrid, penalty)
+			}
+		case m.GridPenaltyDrop:
+			if penalty.Component == m.ComponentPowerUnit {
+				powerUnitPlaces[penalty.DriverID] += penalty.Places
+				if powerUnitPlaces[penalty.DriverID] > m.MaxPowerUnitPlaces && !sentBack[penalty.DriverID] {
+					sentBack[penalty.DriverID] = true
+					penalty.Reason = fmt.Sprintf("more than %d places of power unit penalties", m.MaxPowerUnitPlaces)
+					backOfGrid = append(backOfGrid, penalty)
+					continue
+				}
+			}
+			drops = append(drops, penalty)
+		}
+	}
+
+	// pit lane starters leave the grid first, their penalties for grid
+	// places are not served
+	for _, penalty := range pitLane {
+		driver := penalty.DriverID
+		b.apply(driver,
+			fmt.Sprintf("Starts from the pit lane for %s", penaltyReason(penalty)),
+			fmt.Sprintf("Moved up as %s starts from the pit lane", label(b.slots[driver].QualifyingResult)),
+			func() { b.remove(driver) })
+		b.slots[driver].PitLane = true
+	}
+
+	// admitted non-qualifiers remaining on the grid form its last rows
+	isAdmitted := make(map[uuid.UUID]bool)
+	admittedOnGrid := 0
+	for _, driver := range admitted {
+		isAdmitted[driver] = true
+		if !startsFromPitLane[driver] {
+			admittedOnGrid++
+		}
+	}
+
+	// notServed records a penalty which does not move the driver
+	notServed := func(driver uuid.UUID, reason string) {
+		position := 0
+		if !startsFromPitLane[driver] {
+			position = b.index(driver) + 1
+		}
+		b.slots[driver].Audit = append(b.slots[driver].Audit, Change{From: position, To: position, Reason: reason})
+	}
+
+	// back-of-grid drivers line up behind the others in the order of
+	// infringement, ahead of the admitted non-qualifiers
+	sentBackCount := 0
+	for _, penalty := range backOfGrid {
+		driver := penalty.DriverID
+		reason := fmt.Sprintf("Back of the grid for %s", penaltyReason(penalty))
+		switch {
+		case startsFromPitLane[driver]:
+			notServed(driver, reason+" not applied, starting from the pit lane")
+			continue
+		case isAdmitted[driver]:
+			notServed(driver, reason+", already starting from the back")
+			continue
+		}
+
+		to := len(b.order) - admittedOnGrid - 1
+		b.apply(driver, reason,
+			fmt.Sprintf("Moved up as %s goes to the back of the grid", label(b.slots[driver].QualifyingResult)),
+			func() { b.move(driver, to) })
+		sentBackCount++
+	}
+
+	// grid drops are served among the drivers without back-of-grid
+	// penalties, in the order of infringement
+	served := len(b.order) - admittedOnGrid - sentBackCount
+	for _, penalty := range drops {
+		driver := penalty.DriverID
+		drop := fmt.Sprintf("%d place drop for %s", penalty.Places, penaltyReason(penalty))
+		switch {
+		case startsFromPitLane[driver]:
+			notServed(driver, drop+" not applied, starting from the pit lane")
+			continue
+		case sentBack[driver] || isAdmitted[driver]:
+			notServed(driver, drop+" not applied, already starting from the back")
+			continue
+		}
+
+		to := b.index(driver) + penalty.Places
+		reason := fmt.Sprintf("Dropped %d places for %s", penalty.Places, penaltyReason(penalty))
+		if to > served-1 {
+			to = served - 1
+			reason += ", not fully served"
+		}
+		b.apply(driver, reason,
+			fmt.Sprintf("Moved up as %s dropped %d places", label(b.slots[driver].QualifyingResult), penalty.Places),
+			func() { b.move(driver, to) })
+	}
+
+	grid := StartingGrid{Slots: make([]Slot, 0, len(b.slots))}
+	for i, driver := range b.order {
+		slot := b.slots[driver]
+		slot.Grid = i + 1
+		grid.Slots = append(grid.Slots, *slot)
+	}
+	for _, penalty := range pitLane {
+		grid.Slots = append(grid.Slots, *b.slots[penalty.DriverID])
+	}
+	for _, driver := range excluded {
+		grid.Slots = append(grid.Slots, *b.slots[driver])
+	}
+
+	return grid
+}
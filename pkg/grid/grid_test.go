@@ -0,0 +1,163 @@
+package grid
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+func qualifying(n int) []m.QualifyingResult {
+	results := []m.QualifyingResult{}
+	for i := 1; i <= n; i++ {
+		q1 := m.LapTimeFromMillis(int64(90000 + i*100))
+		result := m.QualifyingResult{DriverID: uuid.New(), Position: i, Q1: &q1}
+		result.Driver.Code = fmt.Sprintf("D%02d", i)
+		results = append(results, result)
+	}
+	return results
+}
+
+func order(grid StartingGrid) []string {
+	codes := []string{}
+	for _, slot := range grid.Slots {
+		code := slot.Driver.Code
+		switch {
+		case slot.PitLane:
+			code += "(PL)"
+		case !slot.Starts:
+			code += "(DNS)"
+		}
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+func TestBuild(t *testing.T) {
+	at := time.Date(2023, 9, 1, 12, 0, 0, 0, time.UTC)
+	hour := func(h int) time.Time { return at.Add(time.Duration(h) * time.Hour) }
+
+	tests := []struct {
+		name      string
+		penalties func(r []m.QualifyingResult) []m.GridPenalty
+		adjust    func(r []m.QualifyingResult)
+		want      []string
+	}{
+		{
+			name:      "qualifying order without penalties",
+			penalties: func(r []m.QualifyingResult) []m.GridPenalty { return nil },
+			want:      []string{"D01", "D02", "D03", "D04", "D05", "D06"},
+		},
+		{
+			name: "grid drops are applied in order of infringement",
+			penalties: func(r []m.QualifyingResult) []m.GridPenalty {
+				return []m.GridPenalty{
+					{DriverID: r[1].DriverID, Type: m.GridPenaltyDrop, Places: 1, InfringedAt: hour(2)},
+					{DriverID: r[0].DriverID, Type: m.GridPenaltyDrop, Places: 2, InfringedAt: hour(1)},
+				}
+			},
+			want: []string{"D03", "D02", "D01", "D04", "D05", "D06"},
+		},
+		{
+			name: "back-of-grid drivers line up in order of infringement behind drops not fully served",
+			penalties: func(r []m.QualifyingResult) []m.GridPenalty {
+				return []m.GridPenalty{
+					{DriverID: r[0].DriverID, Type: m.GridPenaltyBackOfGrid, InfringedAt: hour(2)},
+					{DriverID: r[1].DriverID, Type: m.GridPenaltyBackOfGrid, InfringedAt: hour(1)},
+					{DriverID: r[2].DriverID, Type: m.GridPenaltyDrop, Places: 10, InfringedAt: hour(3)},
+				}
+			},
+			want: []string{"D04", "D05", "D06", "D03", "D02", "D01"},
+		},
+		{
+			name: "power unit drops above the limit send the driver to the back",
+			penalties: func(r []m.QualifyingResult) []m.GridPenalty {
+				return []m.GridPenalty{
+					{DriverID: r[0].DriverID, Type: m.GridPenaltyDrop, Component: m.ComponentPowerUnit, Places: 10, InfringedAt: hour(1)},
+					{DriverID: r[0].DriverID, Type: m.GridPenaltyDrop, Component: m.ComponentPowerUnit, Places: 10, InfringedAt: hour(2)},
+					{DriverID: r[1].DriverID, Type: m.GridPenaltyDrop, Component: m.ComponentGearbox, Places: 2, InfringedAt: hour(3)},
+				}
+			},
+			want: []string{"D03", "D04", "D02", "D05", "D06", "D01"},
+		},
+		{
+			name: "pit lane starters leave the grid and non-qualifiers start only when admitted",
+			penalties: func(r []m.QualifyingResult) []m.GridPenalty {
+				return []m.GridPenalty{
+					{DriverID: r[0].DriverID, Type: m.GridPenaltyPitLane, InfringedAt: hour(1)},
+					{DriverID: r[0].DriverID, Type: m.GridPenaltyDrop, Places: 3, InfringedAt: hour(2)},
+					{DriverID: r[1].DriverID, Type: m.GridPenaltyBackOfGrid, InfringedAt: hour(3)},
+				}
+			},
+			adjust: func(r []m.QualifyingResult) {
+				slow := m.LapTimeFromMillis(99000)
+				r[4].Q1 = &slow
+				r[4].Admitted = true
+				r[5].Q1 = nil
+			},
+			want: []string{"D03", "D04", "D02", "D05", "D01(PL)", "D06(DNS)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := qualifying(6)
+			if tt.adjust != nil {
+				tt.adjust(results)
+			}
+
+			grid := Build(results, tt.penalties(results))
+
+			if got := order(grid); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got grid %v, want %v", got, tt.want)
+			}
+			for i, slot := range grid.Slots {
+				if slot.Starts && !slot.PitLane && slot.Grid != i+1 {
+					t.Errorf("%s has grid slot %d, want %d", slot.Driver.Code, slot.Grid, i+1)
+				}
+				last := slot.Audit[len(slot.Audit)-1]
+				if slot.Starts && last.To != slot.Grid {
+					t.Errorf("%s audit trail ends at %d, want %d", slot.Driver.Code, last.To, slot.Grid)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildFromSprint(t *testing.T) {
+	results := qualifying(5)
+	position := func(p int) *int { return &p }
+
+	// D03 won the sprint from D01, D05 retired after D04 and D02 did not take
+	// part in it
+	sprint := []m.Result{
+		{DriverID: results[2].DriverID, Position: position(1), PositionOrder: 1},
+		{DriverID: results[0].DriverID, Position: position(2), PositionOrder: 2},
+		{DriverID: results[3].DriverID, Position: position(3), PositionOrder: 3},
+		{DriverID: results[4].DriverID, PositionText: m.PositionRetired, PositionOrder: 4},
+	}
+	penalties := []m.GridPenalty{
+		{DriverID: results[2].DriverID, Type: m.GridPenaltyDrop, Places: 1, InfringedAt: time.Date(2022, 4, 23, 15, 0, 0, 0, time.UTC)},
+	}
+
+	grid := BuildFromSprint(results, sprint, penalties)
+
+	want := []string{"D01", "D03", "D04", "D05", "D02"}
+	if got := order(grid); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got grid %v, want %v", got, want)
+	}
+
+	reasons := map[string]string{
+		"D03": "Finished the sprint P1",
+		"D05": "Not classified in the sprint, lines up by sprint order P4",
+		"D02": "No sprint result, qualified P2",
+	}
+	for _, slot := range grid.Slots {
+		if reason, ok := reasons[slot.Driver.Code]; ok && slot.Audit[0].Reason != reason {
+			t.Errorf("%s starts from %q, want %q", slot.Driver.Code, slot.Audit[0].Reason, reason)
+		}
+	}
+}
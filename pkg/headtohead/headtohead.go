@@ -0,0 +1,261 @@
+// Package headtohead compares two drivers over the rounds of a season, by
+// default only those in which they drove for the same constructor.
+package headtohead
+
+import (
+	"sort"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+// UnknownCause is the cause of retirements stored without a status
+const UnknownCause = "Unknown"
+
+// Season holds what the comparison is made from. Results are the race and
+// sprint results of the season with their session, Qualifying the results
+// of the qualifying sessions
+type Season struct {
+	Races      []m.Race
+	Results    []m.Result
+	Qualifying []m.QualifyingResult
+	Entries    []m.SeasonEntry
+}
+
+// Tally is what a driver scored against the other. Points include sprints,
+// DNFs count the unclassified race results by their status
+type Tally struct {
+	Qualifying    int
+	Race          int
+	FinishesAhead int
+	Points        float64
+	DNFs          map[string]int
+}
+
+// Round is a compared round, the Ahead fields hold the driver ahead and are
+// nil when the drivers cannot be compared. QualifyingGap is the gap of the
+// first driver to the second in percent, negative when they were quicker.
+// ConstructorID is nil when comparing drivers of different teams
+type Round struct {
+	Round           int
+	RaceID          uuid.UUID
+	ConstructorID   *uuid.UUID
+	QualifyingAhead *uuid.UUID
+	QualifyingGap   *float64
+	RaceAhead       *uuid.UUID
+	// BothClassified tells whether RaceAhead counts as a finish ahead
+	BothClassified bool
+}
+
+type HeadToHead struct {
+	DriverA uuid.UUID
+	DriverB uuid.UUID
+	// Teammates is false when any pair of drivers is compared
+	Teammates bool
+	Rounds    []Round
+	A         Tally
+	B         Tally
+	// QualifyingGap is the mean of the qualifying gaps of the rounds, nil
+	// when there are none
+	QualifyingGap *float64
+}
+
+// round holds the results of the two drivers in a round
+type round struct {
+	race       map[uuid.UUID]m.Result
+	sprint     map[uuid.UUID]m.Result
+	qualifying map[uuid.UUID]m.QualifyingResult
+}
+
+// Compare compares the drivers over the rounds they took part in together,
+// either as teammates or, when anyPair is set, whatever their team
+func Compare(season Season, driverA, driverB uuid.UUID, anyPair bool) HeadToHead {
+	result := HeadToHead{
+		DriverA:   driverA,
+		DriverB:   driverB,
+		Teammates: !anyPair,
+		Rounds:    []Round{},
+		A:         Tally{DNFs: make(map[string]int)},
+		B:         Tally{DNFs: make(map[string]int)},
+	}
+
+	rounds := make(map[uuid.UUID]*round)
+	of := func(raceID uuid.UUID) *round {
+		r, ok := rounds[raceID]
+		if !ok {
+			r = &round{race: map[uuid.UUID]m.Result{}, sprint: map[uuid.UUID]m.Result{}, qualifying: map[uuid.UUID]m.QualifyingResult{}}
+			rounds[raceID] = r
+		}
+		return r
+	}
+	for _, res := range season.Results {
+		if res.DriverID != driverA && res.DriverID != driverB {
+			continue
+		}
+		switch res.Session.Type {
+		case m.SessionRace:
+			of(res.RaceID).race[res.DriverID] = res
+		case m.SessionSprint:
+			of(res.RaceID).sprint[res.DriverID] = res
+		}
+	}
+	for _, q := range season.Qualifying {
+		if q.DriverID == driverA || q.DriverID == driverB {
+			of(q.RaceID).qualifying[q.DriverID] = q
+		}
+	}
+
+	lineUps := lineUpsOf(season.Entries, driverA, driverB)
+	races := append([]m.Race(nil), season.Races...)
+	sort.Slice(races, func(i, j int) bool { return races[i].Round < races[j].Round })
+
+	var gapSum float64
+	gaps := 0
+	for _, race := range races {
+		r, ok := rounds[race.ID]
+		if !ok || !r.took(driverA) || !r.took(driverB) {
+			continue
+		}
+		compared := Round{Round: race.Round, RaceID: race.ID}
+		if !anyPair {
+			a, okA := r.constructor(lineUps, driverA, race.Round)
+			b, okB := r.constructor(lineUps, driverB, race.Round)
+			if !okA || !okB || a != b {
+				continue
+			}
+			compared.ConstructorID = &a
+		}
+
+		if qa, qb, ok := r.qualifyingOf(driverA, driverB); ok {
+			compared.QualifyingAhead = ahead(qa.Position < qb.Position, driverA, driverB, &result.A.Qualifying, &result.B.Qualifying)
+			if gap, ok := qualifyingGap(qa, qb); ok {
+				compared.QualifyingGap = &gap
+				gapSum += gap
+				gaps++
+			}
+		}
+		if ra, rb, ok := r.raceOf(driverA, driverB); ok {
+			compared.RaceAhead = ahead(ra.PositionOrder < rb.PositionOrder, driverA, driverB, &result.A.Race, &result.B.Race)
+			compared.BothClassified = ra.Classified && rb.Classified
+			if compared.BothClassified {
+				if ra.PositionOrder < rb.PositionOrder {
+					result.A.FinishesAhead++
+				} else {
+					result.B.FinishesAhead++
+				}
+			}
+		}
+		score(&result.A, r, driverA)
+		score(&result.B, r, driverB)
+		result.Rounds = append(result.Rounds, compared)
+	}
+
+	if gaps > 0 {
+		mean := gapSum / float64(gaps)
+		result.QualifyingGap = &mean
+	}
+	return result
+}
+
+type entryLineUp struct {
+	constructor uuid.UUID
+	lineUp      m.LineUp
+}
+
+// lineUpsOf returns the line-ups of the drivers with the constructor of
+// their entry
+func lineUpsOf(entries []m.SeasonEntry, drivers ...uuid.UUID) map[uuid.UUID][]entryLineUp {
+	lineUps := make(map[uuid.UUID][]entryLineUp)
+	for _, entry := range entries {
+		for _, lineUp := range entry.LineUps {
+			for _, driver := range drivers {
+				if lineUp.DriverID == driver {
+					lineUps[driver] = append(lineUps[driver], entryLineUp{constructor: entry.ConstructorID, lineUp: lineUp})
+				}
+			}
+		}
+	}
+	return lineUps
+}
+
+// took reports whether the driver has a race or qualifying result in the
+// round
+func (r *round) took(driver uuid.UUID) bool {
+	_, raced := r.race[driver]
+	_, qualified := r.qualifying[driver]
+	return raced || qualified
+}
+
+// constructor returns the constructor the driver drove for in the round,
+// from the line-up covering the round or, for drivers without line-ups in
+// the season, from their results
+func (r *round) constructor(lineUps map[uuid.UUID][]entryLineUp, driver uuid.UUID, number int) (uuid.UUID, bool) {
+	if entries, ok := lineUps[driver]; ok {
+		for _, entry := range entries {
+			if entry.lineUp.CoversRound(number) {
+				return entry.constructor, true
+			}
+		}
+		return uuid.Nil, false
+	}
+	if res, ok := r.race[driver]; ok {
+		return res.ConstructorID, true
+	}
+	if q, ok := r.qualifying[driver]; ok {
+		return q.ConstructorID, true
+	}
+	return uuid.Nil, false
+}
+
+func (r *round) qualifyingOf(a, b uuid.UUID) (m.QualifyingResult, m.QualifyingResult, bool) {
+	qa, okA := r.qualifying[a]
+	qb, okB := r.qualifying[b]
+	return qa, qb, okA && okB
+}
+
+func (r *round) raceOf(a, b uuid.UUID) (m.Result, m.Result, bool) {
+	ra, okA := r.race[a]
+	rb, okB := r.race[b]
+	return ra, rb, okA && okB
+}
+
+// ahead counts the round for the driver ahead and returns them
+func ahead(first bool, a, b uuid.UUID, countA, countB *int) *uuid.UUID {
+	if first {
+		*countA++
+		return &a
+	}
+	*countB++
+	return &b
+}
+
+// qualifyingGap returns the gap of a to b in percent over the last segment
+// in which both set a time
+func qualifyingGap(a, b m.QualifyingResult) (float64, bool) {
+	for _, segment := range []string{m.SegmentQ3, m.SegmentQ2, m.SegmentQ1} {
+		timeA, timeB := a.Time(segment), b.Time(segment)
+		if timeA != nil && timeB != nil && *timeB > 0 {
+			return (float64(*timeA) - float64(*timeB)) / float64(*timeB) * 100, true
+		}
+	}
+	return 0, false
+}
+
+// score adds the points and retirements of the driver in the round
+func score(tally *Tally, r *round, driver uuid.UUID) {
+	if res, ok := r.sprint[driver]; ok {
+		tally.Points += res.Points
+	}
+	res, ok := r.race[driver]
+	if !ok {
+		return
+	}
+	tally.Points += res.Points
+	if !res.Classified {
+		cause := res.Status
+		if cause == "" {
+			cause = UnknownCause
+		}
+		tally.DNFs[cause]++
+	}
+}
@@ -0,0 +1,140 @@
+package headtohead
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+var (
+	alpha, beta = uuid.New(), uuid.New()
+	teamX       = uuid.New()
+	teamY       = uuid.New()
+	// first drives for X, second for Y and then X from round 3, third leaves
+	// X after round 2
+	first, second, third = uuid.New(), uuid.New(), uuid.New()
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func lapTime(seconds float64) *m.LapTime {
+	t := m.LapTime(time.Duration(seconds * float64(time.Second)))
+	return &t
+}
+
+// season returns a round per classification, the first driver listed
+// winning the race and the qualifying
+func season(classifications [][]uuid.UUID) Season {
+	s := Season{Entries: []m.SeasonEntry{
+		{ConstructorID: teamX, LineUps: []m.LineUp{
+			{DriverID: first, StartRound: 1},
+			{DriverID: third, StartRound: 1, EndRound: intPtr(2)},
+			{DriverID: second, StartRound: 3},
+		}},
+		{ConstructorID: teamY, LineUps: []m.LineUp{
+			{DriverID: second, StartRound: 1, EndRound: intPtr(2)},
+			{DriverID: third, StartRound: 3},
+		}},
+	}}
+	for i, order := range classifications {
+		race := m.Race{Model: m.Model{ID: uuid.New()}, Round: i + 1}
+		s.Races = append(s.Races, race)
+		for position, driver := range order {
+			s.Results = append(s.Results, m.Result{
+				RaceID:        race.ID,
+				Session:       m.Session{Type: m.SessionRace},
+				DriverID:      driver,
+				PositionOrder: position + 1,
+				Classified:    true,
+				Status:        m.StatusFinished,
+				Points:        float64(10 - position),
+			})
+			s.Qualifying = append(s.Qualifying, m.QualifyingResult{
+				RaceID:   race.ID,
+				DriverID: driver,
+				Position: position + 1,
+				Q1:       lapTime(80 + float64(position)),
+			})
+		}
+	}
+	return s
+}
+
+func TestCompareTeammates(t *testing.T) {
+	s := season([][]uuid.UUID{
+		{first, second, third},
+		{second, first, third},
+		{second, first, third},
+		{first, second, third},
+	})
+	// second retires in round 4, both set a Q3 time in round 3
+	s.Results[10].Classified, s.Results[10].Status = false, "Engine"
+	s.Qualifying[6].Q3, s.Qualifying[7].Q3 = lapTime(99), lapTime(100)
+
+	h := Compare(s, first, second, false)
+	if len(h.Rounds) != 2 || h.Rounds[0].Round != 3 || *h.Rounds[0].ConstructorID != teamX {
+		t.Fatalf("compared rounds %+v, want rounds 3 and 4 for team X", h.Rounds)
+	}
+	if h.A.Race != 1 || h.B.Race != 1 || h.A.Qualifying != 1 || h.B.Qualifying != 1 {
+		t.Errorf("head-to-head is %+v against %+v", h.A, h.B)
+	}
+	if h.A.FinishesAhead != 0 || h.B.FinishesAhead != 1 || h.Rounds[1].BothClassified {
+		t.Errorf("finishes ahead %d to %d", h.A.FinishesAhead, h.B.FinishesAhead)
+	}
+	if h.A.Points != 19 || h.B.Points != 19 {
+		t.Errorf("points %v to %v", h.A.Points, h.B.Points)
+	}
+	if h.B.DNFs["Engine"] != 1 || len(h.A.DNFs) != 0 {
+		t.Errorf("DNFs %v and %v", h.A.DNFs, h.B.DNFs)
+	}
+
+	// round 3 compares the Q3 times, 100 to 99, round 4 the Q1 times, 80 to 81
+	want := ((100.0/99-1)*100 + (80.0/81-1)*100) / 2
+	if h.QualifyingGap == nil || math.Abs(*h.QualifyingGap-want) > 1e-9 {
+		t.Errorf("qualifying gap %v, want %v", h.QualifyingGap, want)
+	}
+}
+
+func TestCompareAnyPair(t *testing.T) {
+	s := season([][]uuid.UUID{
+		{first, second, third},
+		{second, first, third},
+	})
+	if h := Compare(s, first, second, false); len(h.Rounds) != 0 {
+		t.Errorf("compared %d rounds of drivers of different teams", len(h.Rounds))
+	}
+
+	h := Compare(s, first, second, true)
+	if h.Teammates || len(h.Rounds) != 2 || h.Rounds[0].ConstructorID != nil {
+		t.Fatalf("compared rounds %+v", h.Rounds)
+	}
+	if h.A.Race != 1 || h.B.Race != 1 || h.A.FinishesAhead != 1 {
+		t.Errorf("head-to-head is %+v against %+v", h.A, h.B)
+	}
+}
+
+func TestCompareWithoutLineUps(t *testing.T) {
+	s := season([][]uuid.UUID{{alpha, beta}, {beta, alpha}})
+	for i := range s.Results {
+		s.Results[i].ConstructorID = teamY
+		s.Qualifying[i].ConstructorID = teamY
+	}
+	// alpha has no race result in the second round
+	s.Results = s.Results[:3]
+
+	h := Compare(s, alpha, beta, false)
+	if len(h.Rounds) != 2 || *h.Rounds[0].ConstructorID != teamY {
+		t.Fatalf("compared rounds %+v", h.Rounds)
+	}
+	if h.Rounds[1].RaceAhead != nil || h.Rounds[1].QualifyingAhead == nil || *h.Rounds[1].QualifyingAhead != beta {
+		t.Errorf("round 2 is %+v, want only the qualifying won by beta", h.Rounds[1])
+	}
+	if h.A.Race != 1 || h.B.Race != 0 {
+		t.Errorf("race head-to-head %d to %d", h.A.Race, h.B.Race)
+	}
+}
@@ -0,0 +1,145 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+)
+
+// null is how the Ergast dump writes a missing value
+const null = `\N`
+
+// csvReader reads the rows of a CSV file with a header one at a time, so
+// that files of any size are streamed
+type csvReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+// newCSVReader reads the header and checks that the required columns are
+// present
+func newCSVReader(r io.Reader, required ...string) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("file has no header")
+		}
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.TrimPrefix(strings.TrimSpace(column), "\ufeff")] = i
+	}
+	for _, column := range required {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("file has no %s column", column)
+		}
+	}
+
+	return &csvReader{reader: reader, columns: columns}, nil
+}
+
+// next returns the next row, io.EOF after the last one. The row is only
+// valid until the following call
+func (cr *csvReader) next() (record, error) {
+	values, err := cr.reader.Read()
+	if err != nil {
+		return record{}, err
+	}
+	line, _ := cr.reader.FieldPos(0)
+	return record{values: values, columns: cr.columns, line: line}, nil
+}
+
+// record is a row of a CSV file read by column name. Missing columns and
+// \N values are null
+type record struct {
+	values  []string
+	columns map[string]int
+	line    int
+}
+
+func (r record) text(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.values) {
+		return ""
+	}
+	value := strings.TrimSpace(r.values[i])
+	if value == null {
+		return ""
+	}
+	return value
+}
+
+func (r record) null(column string) bool {
+	return r.text(column) == ""
+}
+
+func (r record) integer(column string) (int, error) {
+	value, err := strconv.Atoi(r.text(column))
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a number", column)
+	}
+	return value, nil
+}
+
+func (r record) optionalInt(column string) (*int, error) {
+	if r.null(column) {
+		return nil, nil
+	}
+	value, err := r.integer(column)
+	return &value, err
+}
+
+func (r record) number(column string) (float64, error) {
+	if r.null(column) {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(r.text(column), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s is not a number", column)
+	}
+	return value, nil
+}
+
+func (r record) date(column string) (time.Time, error) {
+	value, err := time.Parse(time.DateOnly, r.text(column))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s is not a date", column)
+	}
+	return value, nil
+}
+
+// millis reads a duration given in milliseconds
+func (r record) millis(column string) (*m.LapTime, error) {
+	if r.null(column) {
+		return nil, nil
+	}
+	value, err := strconv.ParseInt(r.text(column), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a number", column)
+	}
+	time := m.LapTimeFromMillis(value)
+	return &time, nil
+}
+
+// lapTime reads a duration written as "1:23.456"
+func (r record) lapTime(column string) (*m.LapTime, error) {
+	if r.null(column) {
+		return nil, nil
+	}
+	value, err := m.ParseLapTime(r.text(column))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a lap time", column)
+	}
+	return &value, nil
+}
@@ -0,0 +1,590 @@
+// Package importer loads historical data from external datasets into the
+// models of the project. Imports are idempotent, rows already imported are
+// updated in place when an import is run again
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Files of the Ergast dump in the order they are imported, later files refer
+// to the rows of earlier ones
+const (
+	fileSeasons       = "seasons.csv"
+	fileCircuits      = "circuits.csv"
+	fileConstructors  = "constructors.csv"
+	fileDrivers       = "drivers.csv"
+	fileStatus        = "status.csv"
+	fileRaces         = "races.csv"
+	fileResults       = "results.csv"
+	fileSprintResults = "sprint_results.csv"
+	fileQualifying    = "qualifying.csv"
+	fileLapTimes      = "lap_times.csv"
+	filePitStops      = "pit_stops.csv"
+)
+
+// computedFiles hold what the API computes from the results, they are
+// reported but not imported
+var computedFiles = []string{"driver_standings.csv", "constructor_standings.csv", "constructor_results.csv"}
+
+// DefaultBatchSize is the number of rows upserted in a single transaction
+const DefaultBatchSize = 5000
+
+// insertBatchSize keeps every insert statement well below the Postgres limit
+// of 65535 parameters
+const insertBatchSize = 1000
+
+// ErgastImporter imports the CSV dump of the Ergast database from a local
+// directory. Drivers, constructors and circuits keep their Ergast refs and
+// IDs, races are matched by season and round
+type ErgastImporter struct {
+	DB        *gorm.DB
+	Dir       string
+	BatchSize int
+	now       time.Time
+	ids       *ergastIDs
+	years     map[int]bool
+}
+
+func NewErgastImporter(db *gorm.DB, dir string) *ErgastImporter {
+	return &ErgastImporter{
+		DB:        db,
+		Dir:       dir,
+		BatchSize: DefaultBatchSize,
+		now:       time.Now().UTC(),
+		ids:       newErgastIDs(),
+		years:     map[int]bool{},
+	}
+}
+
+// Run imports the files found in the directory. An error stops the import
+// after the last committed batch, running it again resumes where it stopped
+func (imp *ErgastImporter) Run() (Summary, error) {
+	if err := imp.loadIDs(); err != nil {
+		return nil, err
+	}
+
+	steps := []struct {
+		file string
+		run  func(*FileSummary) error
+	}{
+		{fileSeasons, imp.importSeasons},
+		{fileCircuits, imp.importCircuits},
+		{fileConstructors, imp.importConstructors},
+		{fileDrivers, imp.importDrivers},
+		{fileStatus, imp.importStatus},
+		{fileRaces, imp.importRaces},
+		{fileResults, imp.importResults(m.SessionRace)},
+		{fileSprintResults, imp.importResults(m.SessionSprint)},
+		{fileQualifying, imp.importQualifying},
+		{fileLapTimes, imp.importLaps},
+		{filePitStops, imp.importPitStops},
+	}
+
+	summary := Summary{}
+	for _, step := range steps {
+		file := &FileSummary{File: step.file}
+		summary = append(summary, file)
+		logrus.Infof("Importing %s", step.file)
+		if err := step.run(file); err != nil {
+			return summary, fmt.Errorf("%s: %w", step.file, err)
+		}
+	}
+
+	lineUps := &FileSummary{File: "line-ups"}
+	summary = append(summary, lineUps)
+	if err := imp.importLineUps(lineUps); err != nil {
+		return summary, fmt.Errorf("line-ups: %w", err)
+	}
+
+	for _, file := range computedFiles {
+		if _, err := os.Stat(filepath.Join(imp.Dir, file)); err == nil {
+			summary = append(summary, &FileSummary{File: file, Note: "computed from the results, not imported"})
+		}
+	}
+	return summary, nil
+}
+
+// loadIDs maps the rows imported by earlier runs, so that a file can be
+// imported again without the files it refers to
+func (imp *ErgastImporter) loadIDs() error {
+	for _, table := range []struct {
+		model interface{}
+		ids   map[int]uuid.UUID
+	}{
+		{&m.Circuit{}, imp.ids.circuits},
+		{&m.Constructor{}, imp.ids.constructors},
+		{&m.Driver{}, imp.ids.drivers},
+	} {
+		if err := loadErgastIDs(imp.DB.Model(table.model).Where("ergast_id IS NOT NULL"), table.ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadErgastIDs(query *gorm.DB, ids map[int]uuid.UUID) error {
+	var rows []struct {
+		ID       uuid.UUID
+		ErgastID int
+	}
+	if err := query.Select("id, ergast_id").Scan(&rows).Error; err != nil {
+		return err
+	}
+	for _, row := range rows {
+		ids[row.ErgastID] = row.ID
+	}
+	return nil
+}
+
+// importFile streams the rows of the file, maps them and saves them in
+// batches, each in its own transaction. Rows which cannot be mapped are
+// skipped with a warning, as are rows repeating the key of an earlier row of
+// their batch, which Postgres cannot upsert twice in one statement. A missing
+// file is noted and skipped
+func importFile[T any, K comparable](imp *ErgastImporter, summary *FileSummary, required []string,
+	convert func(record, func(string)) (T, error), key func(T) K, save func(*gorm.DB, []T) error) error {
+	f, err := os.Open(filepath.Join(imp.Dir, summary.File))
+	if errors.Is(err, os.ErrNotExist) {
+		summary.Note = "not found, skipped"
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader, err := newCSVReader(f, required...)
+	if err != nil {
+		return err
+	}
+
+	batch := make([]T, 0, imp.BatchSize)
+	keys := make(map[K]bool, imp.BatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := imp.DB.Transaction(func(tx *gorm.DB) error { return save(tx, batch) }); err != nil {
+			return err
+		}
+		summary.Imported += len(batch)
+		batch = batch[:0]
+		clear(keys)
+		return nil
+	}
+
+	for {
+		r, err := reader.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		summary.Rows++
+
+		warn := func(message string) { summary.warn(r.line, message) }
+		row, err := convert(r, warn)
+		if err != nil {
+			summary.Skipped++
+			warn(err.Error())
+			continue
+		}
+		if keys[key(row)] {
+			summary.Skipped++
+			warn("repeats an earlier row, skipped")
+			continue
+		}
+		keys[key(row)] = true
+
+		batch = append(batch, row)
+		if len(batch) >= imp.BatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+// upsert inserts the rows, updating the columns of rows conflicting on the
+// unique columns
+func upsert[T any](tx *gorm.DB, rows []T, unique []string, updates ...string) error {
+	columns := make([]clause.Column, len(unique))
+	for i, name := range unique {
+		columns[i] = clause.Column{Name: name}
+	}
+	return tx.Omit(clause.Associations).
+		Clauses(clause.OnConflict{Columns: columns, DoUpdates: clause.AssignmentColumns(updates)}).
+		CreateInBatches(&rows, insertBatchSize).Error
+}
+
+func (imp *ErgastImporter) importSeasons(summary *FileSummary) error {
+	return importFile(imp, summary, []string{"year"},
+		func(r record, _ func(string)) (m.Season, error) { return seasonOf(r) },
+		func(s m.Season) int { return s.Year },
+		func(tx *gorm.DB, seasons []m.Season) error {
+			return upsert(tx, seasons, []string{"year"}, "url", "updated_at")
+		})
+}
+
+func (imp *ErgastImporter) importCircuits(summary *FileSummary) error {
+	return importFile(imp, summary, []string{"circuitId", "circuitRef", "name"}, circuitOf,
+		func(c m.Circuit) string { return c.Ref },
+		func(tx *gorm.DB, circuits []m.Circuit) error {
+			if err := upsert(tx, circuits, []string{"ref"},
+				"ergast_id", "name", "location", "country", "latitude", "longitude", "url", "updated_at"); err != nil {
+				return err
+			}
+			return loadErgastIDs(tx.Model(&m.Circuit{}).Where("ref IN ?", refs(circuits, func(c m.Circuit) string { return c.Ref })), imp.ids.circuits)
+		})
+}
+
+func (imp *ErgastImporter) importConstructors(summary *FileSummary) error {
+	return importFile(imp, summary, []string{"constructorId", "constructorRef", "name"}, constructorOf,
+		func(c m.Constructor) string { return c.Ref },
+		func(tx *gorm.DB, constructors []m.Constructor) error {
+			if err := upsert(tx, constructors, []string{"ref"},
+				"ergast_id", "name", "nationality", "url", "updated_at"); err != nil {
+				return err
+			}
+			return loadErgastIDs(tx.Model(&m.Constructor{}).Where("ref IN ?", refs(constructors, func(c m.Constructor) string { return c.Ref })), imp.ids.constructors)
+		})
+}
+
+func (imp *ErgastImporter) importDrivers(summary *FileSummary) error {
+	return importFile(imp, summary, []string{"driverId", "driverRef", "surname"}, driverOf,
+		func(d m.Driver) string { return d.Ref },
+		func(tx *gorm.DB, drivers []m.Driver) error {
+			if err := upsert(tx, drivers, []string{"ref"},
+				"ergast_id", "permanent_number", "code", "first_name", "last_name", "date_of_birth", "nationality", "url", "updated_at"); err != nil {
+				return err
+			}
+			return loadErgastIDs(tx.Model(&m.Driver{}).Where("ref IN ?", refs(drivers, func(d m.Driver) string { return d.Ref })), imp.ids.drivers)
+		})
+}
+
+func refs[T any](rows []T, ref func(T) string) []string {
+	values := make([]string, len(rows))
+	for i, row := range rows {
+		values[i] = ref(row)
+	}
+	return values
+}
+
+// importStatus reads the texts results refer to by statusId, they are
+// stored on the results
+func (imp *ErgastImporter) importStatus(summary *FileSummary) error {
+	type status struct {
+		id   int
+		text string
+	}
+	return importFile(imp, summary, []string{"statusId", "status"},
+		func(r record, _ func(string)) (status, error) {
+			id, err := r.integer("statusId")
+			return status{id: id, text: r.text("status")}, err
+		},
+		func(s status) int { return s.id },
+		func(_ *gorm.DB, rows []status) error {
+			for _, s := range rows {
+				imp.ids.status[s.id] = s.text
+			}
+			return nil
+		})
+}
+
+// ergastRace is a race with the ID it has in the dump
+type ergastRace struct {
+	id   int
+	race m.Race
+}
+
+// importRaces upserts the races and their sessions. Sessions keep a status
+// other than scheduled set through the API
+func (imp *ErgastImporter) importRaces(summary *FileSummary) error {
+	return importFile(imp, summary, []string{"raceId", "year", "round", "circuitId", "name", "date"},
+		func(r record, _ func(string)) (ergastRace, error) {
+			id, err := r.integer("raceId")
+			if err != nil {
+				return ergastRace{}, err
+			}
+			race, err := raceOf(r, imp.ids.circuits, imp.now)
+			return ergastRace{id: id, race: race}, err
+		},
+		func(r ergastRace) [2]int { return [2]int{r.race.Year, r.race.Round} },
+		imp.saveRaces)
+}
+
+func (imp *ErgastImporter) saveRaces(tx *gorm.DB, rows []ergastRace) error {
+	seasons := []m.Season{}
+	races := make([]m.Race, len(rows))
+	years := map[int]bool{}
+	for i, row := range rows {
+		races[i] = row.race
+		if !years[row.race.Year] {
+			years[row.race.Year] = true
+			seasons = append(seasons, m.Season{Year: row.race.Year})
+		}
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&seasons).Error; err != nil {
+		return err
+	}
+	if err := upsert(tx, races, []string{"year", "round"}, "name", "circuit_id", "date", "url", "updated_at"); err != nil {
+		return err
+	}
+
+	stored := []m.Race{}
+	yearList := make([]int, 0, len(years))
+	for year := range years {
+		yearList = append(yearList, year)
+		imp.years[year] = true
+	}
+	if err := tx.Where("year IN ?", yearList).Find(&stored).Error; err != nil {
+		return err
+	}
+	byRound := make(map[[2]int]m.Race, len(stored))
+	for _, race := range stored {
+		byRound[[2]int{race.Year, race.Round}] = race
+	}
+
+	sessions := []m.Session{}
+	raceIDs := []uuid.UUID{}
+	for _, row := range rows {
+		race := byRound[[2]int{row.race.Year, row.race.Round}]
+		imp.ids.races[row.id] = &raceRef{ID: race.ID, Year: race.Year, Round: race.Round, Date: race.Date, Sessions: map[string]uuid.UUID{}}
+		raceIDs = append(raceIDs, race.ID)
+		for _, session := range row.race.Sessions {
+			session.RaceID = race.ID
+			sessions = append(sessions, session)
+		}
+	}
+
+	err := tx.Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "race_id"}, {Name: "type"}},
+			DoUpdates: append(clause.AssignmentColumns([]string{"start_time", "end_time", "updated_at"}), clause.Assignment{
+				Column: clause.Column{Name: "status"},
+				Value:  gorm.Expr("CASE WHEN sessions.status = ? THEN excluded.status ELSE sessions.status END", m.SessionScheduled),
+			}),
+		}).
+		CreateInBatches(&sessions, insertBatchSize).Error
+	if err != nil {
+		return err
+	}
+	return imp.loadSessions(tx, raceIDs)
+}
+
+// loadSessions maps the sessions of the imported races by type
+func (imp *ErgastImporter) loadSessions(tx *gorm.DB, raceIDs []uuid.UUID) error {
+	sessions := []m.Session{}
+	if err := tx.Where("race_id IN ?", raceIDs).Find(&sessions).Error; err != nil {
+		return err
+	}
+	byRace := make(map[uuid.UUID]*raceRef, len(raceIDs))
+	for _, race := range imp.ids.races {
+		byRace[race.ID] = race
+	}
+	for _, session := range sessions {
+		if race, ok := byRace[session.RaceID]; ok {
+			race.Sessions[session.Type] = session.ID
+		}
+	}
+	return nil
+}
+
+// ensureSession creates the session of the race when the dump has results
+// for a session it gives no date for, which is the case for qualifying of
+// most historical races. It is scheduled on the day before the race
+func (imp *ErgastImporter) ensureSession(r record, sessionType string, warn func(string)) {
+	race, err := lookup(r, "raceId", imp.ids.races)
+	if err != nil {
+		return
+	}
+	if _, ok := race.Sessions[sessionType]; ok {
+		return
+	}
+
+	session := newSession(sessionType, race.Date.AddDate(0, 0, -1), imp.now)
+	session.RaceID = race.ID
+	err = imp.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&session).Error; err != nil {
+			return err
+		}
+		return imp.loadSessions(tx, []uuid.UUID{race.ID})
+	})
+	if err != nil {
+		warn(err.Error())
+		return
+	}
+	warn(fmt.Sprintf("race %d %d has no %s date, the session was scheduled on the day before the race", race.Year, race.Round, sessionType))
+}
+
+type sessionDriver struct {
+	session uuid.UUID
+	driver  uuid.UUID
+}
+
+// importResults imports results.csv or sprint_results.csv as the results of
+// the sessions of the type
+func (imp *ErgastImporter) importResults(sessionType string) func(*FileSummary) error {
+	return func(summary *FileSummary) error {
+		return importFile(imp, summary, []string{"raceId", "driverId", "constructorId", "grid", "positionText", "positionOrder", "laps"},
+			func(r record, warn func(string)) (m.Result, error) {
+				if sessionType != m.SessionRace {
+					imp.ensureSession(r, sessionType, warn)
+				}
+				return imp.ids.resultOf(r, sessionType, warn)
+			},
+			func(r m.Result) sessionDriver { return sessionDriver{r.SessionID, r.DriverID} },
+			func(tx *gorm.DB, results []m.Result) error {
+				return upsert(tx, results, []string{"session_id", "driver_id"},
+					"constructor_id", "car_number", "grid", "position", "position_text", "position_order", "classified",
+					"laps", "time_millis", "gap", "status", "points", "fastest_lap", "fastest_lap_time", "fastest_lap_rank", "updated_at")
+			})
+	}
+}
+
+func (imp *ErgastImporter) importQualifying(summary *FileSummary) error {
+	return importFile(imp, summary, []string{"raceId", "driverId", "constructorId", "position"},
+		func(r record, warn func(string)) (m.QualifyingResult, error) {
+			imp.ensureSession(r, m.SessionQualifying, warn)
+			return imp.ids.qualifyingOf(r, warn)
+		},
+		func(q m.QualifyingResult) sessionDriver { return sessionDriver{q.SessionID, q.DriverID} },
+		func(tx *gorm.DB, results []m.QualifyingResult) error {
+			return upsert(tx, results, []string{"session_id", "driver_id"},
+				"constructor_id", "car_number", "position", "q1", "q2", "q3", "eliminated_in", "updated_at")
+		})
+}
+
+type raceDriverNumber struct {
+	race   uuid.UUID
+	driver uuid.UUID
+	number int
+}
+
+func (imp *ErgastImporter) importLaps(summary *FileSummary) error {
+	return importFile(imp, summary, []string{"raceId", "driverId", "lap", "position", "milliseconds"},
+		func(r record, _ func(string)) (m.Lap, error) { return imp.ids.lapOf(r) },
+		func(l m.Lap) raceDriverNumber { return raceDriverNumber{l.RaceID, l.DriverID, l.Number} },
+		func(tx *gorm.DB, laps []m.Lap) error {
+			return upsert(tx, laps, []string{"race_id", "driver_id", "lap"}, "position", "time")
+		})
+}
+
+func (imp *ErgastImporter) importPitStops(summary *FileSummary) error {
+	return importFile(imp, summary, []string{"raceId", "driverId", "stop", "lap"},
+		func(r record, _ func(string)) (m.PitStop, error) { return imp.ids.pitStopOf(r) },
+		func(p m.PitStop) raceDriverNumber { return raceDriverNumber{p.RaceID, p.DriverID, p.Stop} },
+		func(tx *gorm.DB, stops []m.PitStop) error {
+			return upsert(tx, stops, []string{"race_id", "driver_id", "stop"}, "lap", "time_of_day", "pit_lane", "updated_at")
+		})
+}
+
+type constructorYear struct {
+	constructor uuid.UUID
+	year        int
+}
+
+// importLineUps enters the constructors of the imported seasons and adds the
+// drivers of their race results to their line-ups, from the first to the
+// last round they raced for the constructor. Line-ups already present are
+// left as they are
+func (imp *ErgastImporter) importLineUps(summary *FileSummary) error {
+	if len(imp.years) == 0 {
+		summary.Note = "no races imported, skipped"
+		return nil
+	}
+	years := make([]int, 0, len(imp.years))
+	for year := range imp.years {
+		years = append(years, year)
+	}
+
+	var rows []struct {
+		Year          int
+		ConstructorID uuid.UUID
+		DriverID      uuid.UUID
+		FirstRound    int
+		LastRound     int
+		CarNumber     int
+	}
+	err := imp.DB.Table("results").
+		Select("races.year, results.constructor_id, results.driver_id, MIN(races.round) AS first_round, MAX(races.round) AS last_round, "+
+			"(ARRAY_AGG(results.car_number ORDER BY races.round DESC))[1] AS car_number").
+		Joins("JOIN races ON races.id = results.race_id").
+		Joins("JOIN sessions ON sessions.id = results.session_id").
+		Where("sessions.type = ? AND races.year IN ?", m.SessionRace, years).
+		Group("races.year, results.constructor_id, results.driver_id").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+	summary.Rows = len(rows)
+
+	lastRounds := map[int]int{}
+	entries := []m.SeasonEntry{}
+	entered := map[constructorYear]bool{}
+	for _, row := range rows {
+		lastRounds[row.Year] = max(lastRounds[row.Year], row.LastRound)
+		key := constructorYear{row.ConstructorID, row.Year}
+		if !entered[key] {
+			entered[key] = true
+			entries = append(entries, m.SeasonEntry{ConstructorID: row.ConstructorID, Year: row.Year})
+		}
+	}
+
+	return imp.DB.Transaction(func(tx *gorm.DB) error {
+		if len(entries) > 0 {
+			if err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&entries, insertBatchSize).Error; err != nil {
+				return err
+			}
+		}
+
+		stored := []m.SeasonEntry{}
+		if err := tx.Preload("LineUps").Where("year IN ?", years).Find(&stored).Error; err != nil {
+			return err
+		}
+		byConstructor := make(map[constructorYear]m.SeasonEntry, len(stored))
+		for _, entry := range stored {
+			byConstructor[constructorYear{entry.ConstructorID, entry.Year}] = entry
+		}
+
+		lineUps := []m.LineUp{}
+	rows:
+		for _, row := range rows {
+			entry := byConstructor[constructorYear{row.ConstructorID, row.Year}]
+			for _, lineUp := range entry.LineUps {
+				if lineUp.DriverID == row.DriverID {
+					summary.Skipped++
+					continue rows
+				}
+			}
+			lineUp := m.LineUp{SeasonEntryID: entry.ID, DriverID: row.DriverID, CarNumber: row.CarNumber, StartRound: row.FirstRound}
+			if row.LastRound < lastRounds[row.Year] {
+				end := row.LastRound
+				lineUp.EndRound = &end
+			}
+			lineUps = append(lineUps, lineUp)
+		}
+		if len(lineUps) == 0 {
+			return nil
+		}
+		if err := tx.Omit(clause.Associations).CreateInBatches(&lineUps, insertBatchSize).Error; err != nil {
+			return err
+		}
+		summary.Imported = len(lineUps)
+		return nil
+	})
+}
@@ -0,0 +1,423 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/ergast"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/dewciu/f1_api/pkg/sprint"
+	"github.com/google/uuid"
+)
+
+// ergastIDs maps the IDs of the dump to the rows of the project they were
+// imported as
+type ergastIDs struct {
+	circuits     map[int]uuid.UUID
+	constructors map[int]uuid.UUID
+	drivers      map[int]uuid.UUID
+	races        map[int]*raceRef
+	status       map[int]string
+}
+
+// raceRef is an imported race with its sessions by type
+type raceRef struct {
+	ID       uuid.UUID
+	Year     int
+	Round    int
+	Date     time.Time
+	Sessions map[string]uuid.UUID
+}
+
+func newErgastIDs() *ergastIDs {
+	return &ergastIDs{
+		circuits:     map[int]uuid.UUID{},
+		constructors: map[int]uuid.UUID{},
+		drivers:      map[int]uuid.UUID{},
+		races:        map[int]*raceRef{},
+		status:       map[int]string{},
+	}
+}
+
+// lookup resolves the Ergast ID in the column
+func lookup[T any](r record, column string, ids map[int]T) (T, error) {
+	var zero T
+	id, err := r.integer(column)
+	if err != nil {
+		return zero, err
+	}
+	value, ok := ids[id]
+	if !ok {
+		return zero, fmt.Errorf("unknown %s %d", column, id)
+	}
+	return value, nil
+}
+
+func ergastID(r record, column string) (*int, error) {
+	id, err := r.integer(column)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// countryCode maps an Ergast country name or nationality to its ISO code,
+// unknown values are imported without a country
+func countryCode(value string, code func(string) (string, bool), warn func(string)) string {
+	if value == "" {
+		return ""
+	}
+	if c, ok := code(value); ok {
+		return c
+	}
+	warn(fmt.Sprintf("unknown country %q", value))
+	return ""
+}
+
+func seasonOf(r record) (m.Season, error) {
+	year, err := r.integer("year")
+	return m.Season{Year: year, URL: r.text("url")}, err
+}
+
+func circuitOf(r record, warn func(string)) (m.Circuit, error) {
+	id, err := ergastID(r, "circuitId")
+	if err != nil {
+		return m.Circuit{}, err
+	}
+	circuit := m.Circuit{
+		Ref:      r.text("circuitRef"),
+		ErgastID: id,
+		Name:     r.text("name"),
+		Location: r.text("location"),
+		Country:  countryCode(r.text("country"), ergast.CountryCode, warn),
+		URL:      r.text("url"),
+	}
+	if circuit.Latitude, err = r.number("lat"); err != nil {
+		return m.Circuit{}, err
+	}
+	if circuit.Longitude, err = r.number("lng"); err != nil {
+		return m.Circuit{}, err
+	}
+	if circuit.Ref == "" || circuit.Name == "" {
+		return m.Circuit{}, fmt.Errorf("circuit %d has no ref or name", *id)
+	}
+	return circuit, nil
+}
+
+func constructorOf(r record, warn func(string)) (m.Constructor, error) {
+	id, err := ergastID(r, "constructorId")
+	if err != nil {
+		return m.Constructor{}, err
+	}
+	constructor := m.Constructor{
+		Ref:         r.text("constructorRef"),
+		ErgastID:    id,
+		Name:        r.text("name"),
+		Nationality: countryCode(r.text("nationality"), ergast.NationalityCode, warn),
+		URL:         r.text("url"),
+	}
+	if constructor.Ref == "" || constructor.Name == "" {
+		return m.Constructor{}, fmt.Errorf("constructor %d has no ref or name", *id)
+	}
+	return constructor, nil
+}
+
+func driverOf(r record, warn func(string)) (m.Driver, error) {
+	id, err := ergastID(r, "driverId")
+	if err != nil {
+		return m.Driver{}, err
+	}
+	driver := m.Driver{
+		Ref:         r.text("driverRef"),
+		ErgastID:    id,
+		Code:        r.text("code"),
+		FirstName:   r.text("forename"),
+		LastName:    r.text("surname"),
+		Nationality: countryCode(r.text("nationality"), ergast.NationalityCode, warn),
+		URL:         r.text("url"),
+	}
+	if driver.PermanentNumber, err = r.optionalInt("number"); err != nil {
+		return m.Driver{}, err
+	}
+	if !r.null("dob") {
+		if driver.DateOfBirth, err = r.date("dob"); err != nil {
+			return m.Driver{}, err
+		}
+	}
+	if driver.Ref == "" || driver.LastName == "" {
+		return m.Driver{}, fmt.Errorf("driver %d has no ref or surname", *id)
+	}
+	return driver, nil
+}
+
+// raceSessionColumns are the column prefixes of the schedule in races.csv.
+// The second practice slot holds the shootout on sprint weekends which have
+// one
+var raceSessionColumns = []struct {
+	prefix  string
+	session string
+}{
+	{"fp1", m.SessionFP1},
+	{"fp2", m.SessionFP2},
+	{"fp3", m.SessionFP3},
+	{"quali", m.SessionQualifying},
+	{"sprint", m.SessionSprint},
+}
+
+// sessionStart combines the date and time columns, a session without a time
+// starts at midnight UTC
+func sessionStart(r record, dateColumn, timeColumn string) (time.Time, bool, error) {
+	if r.null(dateColumn) {
+		return time.Time{}, false, nil
+	}
+	start, err := r.date(dateColumn)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if !r.null(timeColumn) {
+		clock, err := time.Parse(time.TimeOnly, r.text(timeColumn))
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%s is not a time", timeColumn)
+		}
+		start = start.Add(clock.Sub(clock.Truncate(24 * time.Hour)))
+	}
+	return start, true, nil
+}
+
+// newSession schedules a session of the given type, sessions which have
+// started before now are finished
+func newSession(sessionType string, start, now time.Time) m.Session {
+	session := m.Session{
+		Type:      sessionType,
+		StartTime: start,
+		EndTime:   start.Add(m.SessionDurations[sessionType]),
+		Status:    m.SessionScheduled,
+	}
+	if start.Before(now) {
+		session.Status = m.SessionFinished
+	}
+	return session
+}
+
+// raceOf maps a row of races.csv to the race and its sessions, the race
+// session starts at the date and time of the race
+func raceOf(r record, circuits map[int]uuid.UUID, now time.Time) (m.Race, error) {
+	race := m.Race{Name: r.text("name"), URL: r.text("url"), PointsScale: 1}
+	var err error
+	if race.Year, err = r.integer("year"); err != nil {
+		return m.Race{}, err
+	}
+	if race.Round, err = r.integer("round"); err != nil {
+		return m.Race{}, err
+	}
+	if race.CircuitID, err = lookup(r, "circuitId", circuits); err != nil {
+		return m.Race{}, err
+	}
+
+	start, ok, err := sessionStart(r, "date", "time")
+	if err != nil {
+		return m.Race{}, err
+	}
+	if !ok {
+		return m.Race{}, fmt.Errorf("race %d %d has no date", race.Year, race.Round)
+	}
+	race.Date = start.Truncate(24 * time.Hour)
+	race.Sessions = []m.Session{newSession(m.SessionRace, start, now)}
+
+	format, sprintSeason := sprint.ForSeason(race.Year)
+	for _, column := range raceSessionColumns {
+		start, ok, err := sessionStart(r, column.prefix+"_date", column.prefix+"_time")
+		if err != nil {
+			return m.Race{}, err
+		}
+		if !ok {
+			continue
+		}
+		sessionType := column.session
+		if sessionType == m.SessionFP2 && sprintSeason && format.GridSession == m.SessionSprintShootout && !r.null("sprint_date") {
+			sessionType = m.SessionSprintShootout
+		}
+		race.Sessions = append(race.Sessions, newSession(sessionType, start, now))
+	}
+	return race, nil
+}
+
+// resultOf maps a row of results.csv or sprint_results.csv to the result of
+// the session of the given type
+func (ids *ergastIDs) resultOf(r record, sessionType string, warn func(string)) (m.Result, error) {
+	race, err := lookup(r, "raceId", ids.races)
+	if err != nil {
+		return m.Result{}, err
+	}
+	sessionID, ok := race.Sessions[sessionType]
+	if !ok {
+		return m.Result{}, fmt.Errorf("race %d %d has no %s session", race.Year, race.Round, sessionType)
+	}
+
+	result := m.Result{
+		SessionID:    sessionID,
+		RaceID:       race.ID,
+		PositionText: r.text("positionText"),
+	}
+	if result.DriverID, err = lookup(r, "driverId", ids.drivers); err != nil {
+		return m.Result{}, err
+	}
+	if result.ConstructorID, err = lookup(r, "constructorId", ids.constructors); err != nil {
+		return m.Result{}, err
+	}
+	if number, err := r.optionalInt("number"); err != nil {
+		return m.Result{}, err
+	} else if number != nil {
+		result.CarNumber = *number
+	}
+	if result.Grid, err = r.integer("grid"); err != nil {
+		return m.Result{}, err
+	}
+	if result.Position, err = r.optionalInt("position"); err != nil {
+		return m.Result{}, err
+	}
+	if result.PositionOrder, err = r.integer("positionOrder"); err != nil {
+		return m.Result{}, err
+	}
+	if result.Points, err = r.number("points"); err != nil {
+		return m.Result{}, err
+	}
+	if result.Laps, err = r.integer("laps"); err != nil {
+		return m.Result{}, err
+	}
+	if millis, err := r.millis("milliseconds"); err != nil {
+		return m.Result{}, err
+	} else if millis != nil {
+		total := millis.Milliseconds()
+		result.TimeMillis = &total
+	}
+	if strings.HasPrefix(r.text("time"), "+") {
+		result.Gap = r.text("time")
+	}
+	if result.FastestLap, err = r.optionalInt("fastestLap"); err != nil {
+		return m.Result{}, err
+	}
+	if result.FastestLapRank, err = r.optionalInt("rank"); err != nil {
+		return m.Result{}, err
+	}
+	if result.FastestLapTime, err = r.lapTime("fastestLapTime"); err != nil {
+		warn(err.Error())
+	}
+
+	if result.PositionText == "" {
+		return m.Result{}, fmt.Errorf("result has no positionText")
+	}
+	result.Classified = result.Position != nil
+	if status, err := lookup(r, "statusId", ids.status); err == nil {
+		result.Status = status
+	} else {
+		warn(err.Error())
+	}
+	return result, nil
+}
+
+// qualifyingOf maps a row of qualifying.csv, drivers are eliminated in the
+// last segment they set a time in from the 2006 knockout format on
+func (ids *ergastIDs) qualifyingOf(r record, warn func(string)) (m.QualifyingResult, error) {
+	race, err := lookup(r, "raceId", ids.races)
+	if err != nil {
+		return m.QualifyingResult{}, err
+	}
+	sessionID, ok := race.Sessions[m.SessionQualifying]
+	if !ok {
+		return m.QualifyingResult{}, fmt.Errorf("race %d %d has no %s session", race.Year, race.Round, m.SessionQualifying)
+	}
+
+	result := m.QualifyingResult{SessionID: sessionID, RaceID: race.ID}
+	if result.DriverID, err = lookup(r, "driverId", ids.drivers); err != nil {
+		return m.QualifyingResult{}, err
+	}
+	if result.ConstructorID, err = lookup(r, "constructorId", ids.constructors); err != nil {
+		return m.QualifyingResult{}, err
+	}
+	if number, err := r.optionalInt("number"); err != nil {
+		return m.QualifyingResult{}, err
+	} else if number != nil {
+		result.CarNumber = *number
+	}
+	if result.Position, err = r.integer("position"); err != nil {
+		return m.QualifyingResult{}, err
+	}
+	for _, segment := range []struct {
+		column string
+		time   **m.LapTime
+	}{{"q1", &result.Q1}, {"q2", &result.Q2}, {"q3", &result.Q3}} {
+		// times of drivers who did not set one are written as empty or as
+		// a note such as DNF
+		if *segment.time, err = r.lapTime(segment.column); err != nil {
+			warn(err.Error())
+		}
+	}
+
+	if race.Year >= knockoutSeason {
+		switch {
+		case !r.null("q3"):
+		case !r.null("q2"):
+			result.EliminatedIn = m.SegmentQ2
+		default:
+			result.EliminatedIn = m.SegmentQ1
+		}
+	}
+	return result, nil
+}
+
+// knockoutSeason introduced qualifying in three segments
+const knockoutSeason = 2006
+
+func (ids *ergastIDs) lapOf(r record) (m.Lap, error) {
+	race, err := lookup(r, "raceId", ids.races)
+	if err != nil {
+		return m.Lap{}, err
+	}
+	lap := m.Lap{RaceID: race.ID}
+	if lap.DriverID, err = lookup(r, "driverId", ids.drivers); err != nil {
+		return m.Lap{}, err
+	}
+	if lap.Number, err = r.integer("lap"); err != nil {
+		return m.Lap{}, err
+	}
+	if lap.Position, err = r.integer("position"); err != nil {
+		return m.Lap{}, err
+	}
+	time, err := r.millis("milliseconds")
+	if err != nil {
+		return m.Lap{}, err
+	}
+	if time == nil {
+		return m.Lap{}, fmt.Errorf("lap %d has no time", lap.Number)
+	}
+	lap.Time = *time
+	return lap, nil
+}
+
+// pitStopOf maps a row of pit_stops.csv, its duration is the time spent in
+// the pit lane
+func (ids *ergastIDs) pitStopOf(r record) (m.PitStop, error) {
+	race, err := lookup(r, "raceId", ids.races)
+	if err != nil {
+		return m.PitStop{}, err
+	}
+	stop := m.PitStop{RaceID: race.ID, TimeOfDay: r.text("time")}
+	if stop.DriverID, err = lookup(r, "driverId", ids.drivers); err != nil {
+		return m.PitStop{}, err
+	}
+	if stop.Stop, err = r.integer("stop"); err != nil {
+		return m.PitStop{}, err
+	}
+	if stop.Lap, err = r.integer("lap"); err != nil {
+		return m.PitStop{}, err
+	}
+	if stop.PitLane, err = r.millis("milliseconds"); err != nil {
+		return m.PitStop{}, err
+	}
+	if len(stop.TimeOfDay) > 8 {
+		return m.PitStop{}, fmt.Errorf("time %q is not a time of day", stop.TimeOfDay)
+	}
+	return stop, nil
+}
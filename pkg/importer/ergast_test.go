@@ -0,0 +1,272 @@
+package importer
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+var (
+	monza    = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	ferrari  = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	leclerc  = uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+	race2023 = uuid.MustParse("00000000-0000-0000-0000-00000000000d")
+	race1950 = uuid.MustParse("00000000-0000-0000-0000-00000000000e")
+	session  = uuid.MustParse("00000000-0000-0000-0000-00000000000f")
+)
+
+// rows reads the rows of a CSV file
+func rows(t *testing.T, file string) []record {
+	t.Helper()
+	reader, err := newCSVReader(strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := []record{}
+	for {
+		r, err := reader.next()
+		if errors.Is(err, io.EOF) {
+			return records
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		values := append([]string(nil), r.values...)
+		records = append(records, record{values: values, columns: r.columns, line: r.line})
+	}
+}
+
+func collect(warnings *[]string) func(string) {
+	return func(message string) { *warnings = append(*warnings, message) }
+}
+
+func testIDs() *ergastIDs {
+	ids := newErgastIDs()
+	ids.circuits[14] = monza
+	ids.constructors[6] = ferrari
+	ids.drivers[844] = leclerc
+	ids.status[1] = m.StatusFinished
+	ids.status[5] = "Engine"
+	ids.races[1108] = &raceRef{ID: race2023, Year: 2023, Round: 15, Sessions: map[string]uuid.UUID{m.SessionRace: session, m.SessionQualifying: session}}
+	ids.races[833] = &raceRef{ID: race1950, Year: 1950, Round: 1, Sessions: map[string]uuid.UUID{m.SessionRace: session, m.SessionQualifying: session}}
+	return ids
+}
+
+func TestCSVReader(t *testing.T) {
+	if _, err := newCSVReader(strings.NewReader("raceId,driverId\n"), "raceId", "lap"); err == nil {
+		t.Error("a file without a required column was read")
+	}
+	if _, err := newCSVReader(strings.NewReader(""), "raceId"); err == nil {
+		t.Error("an empty file was read")
+	}
+
+	r := rows(t, "\ufeffdriverId,number,code,dob\n1,\\N,,1997-10-16\n")[0]
+	if id, err := r.integer("driverId"); err != nil || id != 1 {
+		t.Errorf("driverId behind a byte order mark is %d, %v", id, err)
+	}
+	if number, err := r.optionalInt("number"); err != nil || number != nil {
+		t.Errorf("a null number is %v, %v", number, err)
+	}
+	if !r.null("code") || !r.null("missing") {
+		t.Error("empty values and missing columns are not null")
+	}
+	if _, err := r.integer("code"); err == nil {
+		t.Error("a null value was read as a number")
+	}
+	if dob, err := r.date("dob"); err != nil || dob != time.Date(1997, 10, 16, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("dob is %v, %v", dob, err)
+	}
+}
+
+func TestDriverOf(t *testing.T) {
+	file := `driverId,driverRef,number,code,forename,surname,dob,nationality,url
+844,leclerc,16,LEC,Charles,Leclerc,1997-10-16,Monegasque,http://en.wikipedia.org/wiki/Charles_Leclerc
+289,hill,\N,\N,Graham,Hill,1929-02-15,British,http://en.wikipedia.org/wiki/Graham_Hill
+500,someone,\N,\N,Some,One,\N,Martian,
+`
+	records := rows(t, file)
+	warnings := []string{}
+
+	driver, err := driverOf(records[0], collect(&warnings))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if driver.Ref != "leclerc" || *driver.ErgastID != 844 || *driver.PermanentNumber != 16 || driver.Code != "LEC" || driver.Nationality != "MC" {
+		t.Errorf("leclerc is %+v", driver)
+	}
+
+	hill, err := driverOf(records[1], collect(&warnings))
+	if err != nil || hill.PermanentNumber != nil || hill.Code != "" || hill.Nationality != "GB" {
+		t.Errorf("hill is %+v, %v", hill, err)
+	}
+
+	someone, err := driverOf(records[2], collect(&warnings))
+	if err != nil || someone.Nationality != "" || !someone.DateOfBirth.IsZero() {
+		t.Errorf("someone is %+v, %v", someone, err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "Martian") {
+		t.Errorf("warnings are %v", warnings)
+	}
+}
+
+func TestRaceOf(t *testing.T) {
+	file := `raceId,year,round,circuitId,name,date,time,url,fp1_date,fp1_time,fp2_date,fp2_time,fp3_date,fp3_time,quali_date,quali_time,sprint_date,sprint_time
+833,1950,1,9,British Grand Prix,1950-05-13,\N,http://en.wikipedia.org/wiki/1950_British_Grand_Prix,\N,\N,\N,\N,\N,\N,\N,\N,\N,\N
+1108,2023,15,14,Italian Grand Prix,2023-09-03,13:00:00,https://en.wikipedia.org/wiki/2023_Italian_Grand_Prix,2023-09-01,11:30:00,2023-09-01,15:00:00,2023-09-02,10:30:00,2023-09-02,14:00:00,\N,\N
+1115,2023,18,14,Qatar Grand Prix,2023-10-08,14:00:00,,2023-10-06,13:30:00,2023-10-07,13:00:00,\N,\N,2023-10-06,17:00:00,2023-10-07,17:30:00
+`
+	records := rows(t, file)
+	circuits := map[int]uuid.UUID{14: monza}
+	now := time.Date(2023, 9, 2, 12, 0, 0, 0, time.UTC)
+
+	if _, err := raceOf(records[0], circuits, now); err == nil {
+		t.Error("a race at an unknown circuit was mapped")
+	}
+
+	italy, err := raceOf(records[1], circuits, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if italy.Year != 2023 || italy.Round != 15 || italy.Date != time.Date(2023, 9, 3, 0, 0, 0, 0, time.UTC) || italy.PointsScale != 1 {
+		t.Errorf("italy is %+v", italy)
+	}
+	sessions := map[string]m.Session{}
+	for _, s := range italy.Sessions {
+		sessions[s.Type] = s
+	}
+	if len(sessions) != 5 {
+		t.Errorf("italy has %d sessions", len(sessions))
+	}
+	race := sessions[m.SessionRace]
+	if race.StartTime != time.Date(2023, 9, 3, 13, 0, 0, 0, time.UTC) || race.EndTime != race.StartTime.Add(2*time.Hour) || race.Status != m.SessionScheduled {
+		t.Errorf("race session is %+v", race)
+	}
+	if fp3 := sessions[m.SessionFP3]; fp3.Status != m.SessionFinished {
+		t.Errorf("FP3 which has started is %s", fp3.Status)
+	}
+
+	qatar, err := raceOf(records[2], circuits, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := []string{}
+	for _, s := range qatar.Sessions {
+		types = append(types, s.Type)
+	}
+	if strings.Join(types, ",") != "RACE,FP1,SPRINT_SHOOTOUT,QUALIFYING,SPRINT" {
+		t.Errorf("qatar sessions are %v", types)
+	}
+
+	silverstone, err := raceOf(records[0], map[int]uuid.UUID{9: monza}, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(silverstone.Sessions) != 1 || silverstone.Sessions[0].StartTime != time.Date(1950, 5, 13, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("silverstone sessions are %+v", silverstone.Sessions)
+	}
+}
+
+func TestResultOf(t *testing.T) {
+	file := `resultId,raceId,driverId,constructorId,number,grid,position,positionText,positionOrder,points,laps,time,milliseconds,fastestLap,rank,fastestLapTime,fastestLapSpeed,statusId
+1,1108,844,6,16,3,4,4,4,12,51,+15.394,4808791,47,5,1:25.922,242.713,1
+2,1108,844,6,16,3,\N,R,19,0,12,\N,\N,\N,\N,\N,\N,5
+3,833,844,6,\N,2,\N,R,12,0,8,\N,\N,\N,\N,\N,\N,99
+4,999,844,6,16,3,4,4,4,12,51,\N,\N,\N,\N,\N,\N,1
+`
+	records := rows(t, file)
+	ids := testIDs()
+	warnings := []string{}
+
+	fourth, err := ids.resultOf(records[0], m.SessionRace, collect(&warnings))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fourth.RaceID != race2023 || fourth.SessionID != session || fourth.DriverID != leclerc || fourth.ConstructorID != ferrari {
+		t.Errorf("fourth place refers to %+v", fourth)
+	}
+	if *fourth.Position != 4 || !fourth.Classified || fourth.Gap != "+15.394" || *fourth.TimeMillis != 4808791 || fourth.Points != 12 ||
+		*fourth.FastestLap != 47 || *fourth.FastestLapRank != 5 || fourth.FastestLapTime.String() != "1:25.922" || fourth.Status != m.StatusFinished {
+		t.Errorf("fourth place is %+v", fourth)
+	}
+
+	retired, err := ids.resultOf(records[1], m.SessionRace, collect(&warnings))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retired.Position != nil || retired.Classified || retired.PositionText != m.PositionRetired || retired.TimeMillis != nil || retired.Gap != "" || retired.Status != "Engine" {
+		t.Errorf("retirement is %+v", retired)
+	}
+
+	if len(warnings) != 0 {
+		t.Errorf("warnings are %v", warnings)
+	}
+	if unknownStatus, err := ids.resultOf(records[2], m.SessionRace, collect(&warnings)); err != nil || unknownStatus.CarNumber != 0 || unknownStatus.Status != "" || len(warnings) != 1 {
+		t.Errorf("result with an unknown status is %+v, %v, warnings %v", unknownStatus, err, warnings)
+	}
+	if _, err := ids.resultOf(records[3], m.SessionRace, collect(&warnings)); err == nil {
+		t.Error("result of an unknown race was mapped")
+	}
+	if _, err := ids.resultOf(records[0], m.SessionSprint, collect(&warnings)); err == nil {
+		t.Error("result of a race without a sprint was mapped")
+	}
+}
+
+func TestQualifyingOf(t *testing.T) {
+	file := `qualifyId,raceId,driverId,constructorId,number,position,q1,q2,q3
+1,1108,844,6,16,4,1:21.432,1:20.890,1:20.361
+2,1108,844,6,16,12,1:21.600,1:21.400,\N
+3,1108,844,6,16,18,1:22.100,,
+4,833,844,6,16,2,1:50.800,\N,\N
+5,1108,844,6,16,20,DNF,\N,\N
+`
+	records := rows(t, file)
+	ids := testIDs()
+	warnings := []string{}
+
+	eliminated := []string{}
+	for _, r := range records {
+		result, err := ids.qualifyingOf(r, collect(&warnings))
+		if err != nil {
+			t.Fatal(err)
+		}
+		eliminated = append(eliminated, result.EliminatedIn)
+	}
+	if strings.Join(eliminated, ",") != ",Q2,Q1,,Q1" {
+		t.Errorf("drivers were eliminated in %v", eliminated)
+	}
+
+	pole, _ := ids.qualifyingOf(records[0], collect(&warnings))
+	if pole.Position != 4 || pole.Q3.String() != "1:20.361" || pole.SessionID != session {
+		t.Errorf("Q3 result is %+v", pole)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "q1") {
+		t.Errorf("warnings are %v", warnings)
+	}
+}
+
+func TestLapAndPitStopOf(t *testing.T) {
+	ids := testIDs()
+
+	laps := rows(t, "raceId,driverId,lap,position,time,milliseconds\n1108,844,1,3,1:31.468,91468\n1108,844,2,3,1:27.000,\\N\n")
+	lap, err := ids.lapOf(laps[0])
+	if err != nil || lap.RaceID != race2023 || lap.DriverID != leclerc || lap.Number != 1 || lap.Position != 3 || lap.Time.String() != "1:31.468" {
+		t.Errorf("lap is %+v, %v", lap, err)
+	}
+	if _, err := ids.lapOf(laps[1]); err == nil {
+		t.Error("a lap without a time was mapped")
+	}
+
+	stops := rows(t, "raceId,driverId,stop,lap,time,duration,milliseconds\n1108,844,1,20,15:38:28,23.121,23121\n1108,844,2,38,16:10:02,\\N,\\N\n")
+	stop, err := ids.pitStopOf(stops[0])
+	if err != nil || stop.Stop != 1 || stop.Lap != 20 || stop.TimeOfDay != "15:38:28" || stop.PitLane.String() != "23.121" || stop.Stationary != nil {
+		t.Errorf("pit stop is %+v, %v", stop, err)
+	}
+	if stop, err := ids.pitStopOf(stops[1]); err != nil || stop.PitLane != nil {
+		t.Errorf("pit stop without a duration is %+v, %v", stop, err)
+	}
+}
@@ -0,0 +1,389 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+)
+
+// SourceOpenF1 names the OpenF1 dataset on queued records
+const SourceOpenF1 = "openf1"
+
+// openF1Files are the files of an OpenF1 export by kind in the order they
+// are read. A file is recognized by its name, which may carry a prefix such
+// as 9158_laps.json
+var openF1Files = []struct {
+	name string
+	kind string
+}{
+	{"meetings.json", KindMeeting},
+	{"sessions.json", KindSession},
+	{"drivers.json", KindDriver},
+	{"laps.json", KindLap},
+	{"stints.json", KindStint},
+	{"pit.json", KindPitStop},
+	{"race_control.json", KindRaceControl},
+	{"weather.json", KindWeather},
+}
+
+// openF1Positions holds the position changes the positions of laps are
+// taken from
+const openF1Positions = "position.json"
+
+// OpenF1Kind returns the kind of the records of an OpenF1 file by its name,
+// position.json has no kind of its own and is reported as a lap file
+func OpenF1Kind(name string) (string, bool) {
+	if matchesFile(name, openF1Positions) {
+		return KindLap, true
+	}
+	for _, file := range openF1Files {
+		if matchesFile(name, file.name) {
+			return file.kind, true
+		}
+	}
+	return "", false
+}
+
+// OpenF1File is a file of an OpenF1 export, a JSON array of records as
+// answered by the OpenF1 API
+type OpenF1File struct {
+	Name string
+	Open func() (io.ReadCloser, error)
+}
+
+// OpenF1Dir lists the OpenF1 files in the directory and its subdirectories,
+// other files are left out
+func OpenF1Dir(dir string) ([]OpenF1File, error) {
+	files := []OpenF1File{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		if _, ok := OpenF1Kind(path); ok {
+			name, _ := filepath.Rel(dir, path)
+			files = append(files, OpenF1File{Name: name, Open: func() (io.ReadCloser, error) { return os.Open(path) }})
+		}
+		return nil
+	})
+	return files, err
+}
+
+// OpenF1Source reads OpenF1 JSON files. Drivers are keyed by their car
+// number and sessions by their session and meeting keys
+type OpenF1Source struct {
+	files []OpenF1File
+	// positions holds the position changes of the drivers by session
+	positions map[int]map[int][]openF1Record
+}
+
+func NewOpenF1Source(files []OpenF1File) *OpenF1Source {
+	return &OpenF1Source{files: files}
+}
+
+func (s *OpenF1Source) Name() string {
+	return SourceOpenF1
+}
+
+// openF1Record holds the fields of all the OpenF1 files read
+type openF1Record struct {
+	MeetingKey   int        `json:"meeting_key"`
+	SessionKey   int        `json:"session_key"`
+	DriverNumber int        `json:"driver_number"`
+	Year         int        `json:"year"`
+	MeetingName  string     `json:"meeting_name"`
+	SessionName  string     `json:"session_name"`
+	Date         *time.Time `json:"date"`
+	DateStart    *time.Time `json:"date_start"`
+	DateEnd      *time.Time `json:"date_end"`
+	NameAcronym  string     `json:"name_acronym"`
+	FirstName    string     `json:"first_name"`
+	LastName     string     `json:"last_name"`
+	TeamName     string     `json:"team_name"`
+	Position     int        `json:"position"`
+	LapNumber    *int       `json:"lap_number"`
+	LapDuration  *float64   `json:"lap_duration"`
+	StintNumber  int        `json:"stint_number"`
+	LapStart     *int       `json:"lap_start"`
+	LapEnd       *int       `json:"lap_end"`
+	Compound     string     `json:"compound"`
+	TyreAge      int        `json:"tyre_age_at_start"`
+	PitDuration  *float64   `json:"pit_duration"`
+	Category     string     `json:"category"`
+	Flag         string     `json:"flag"`
+	Scope        string     `json:"scope"`
+	Sector       *int       `json:"sector"`
+	Message      string     `json:"message"`
+	// the fields of weather observations
+	AirTemperature   *float64 `json:"air_temperature"`
+	TrackTemperature *float64 `json:"track_temperature"`
+	Humidity         float64  `json:"humidity"`
+	Pressure         float64  `json:"pressure"`
+	WindSpeed        float64  `json:"wind_speed"`
+	WindDirection    float64  `json:"wind_direction"`
+	Rainfall         float64  `json:"rainfall"`
+}
+
+// Read emits the records of the files by kind. Position changes are read
+// first, they are only used for the positions of laps
+func (s *OpenF1Source) Read(emit func(Item) error) error {
+	s.positions = map[int]map[int][]openF1Record{}
+	for _, file := range s.files {
+		if matchesFile(file.Name, openF1Positions) {
+			if err := s.readPositions(file); err != nil {
+				return fmt.Errorf("%s: %w", file.Name, err)
+			}
+		}
+	}
+
+	for _, kind := range openF1Files {
+		for _, file := range s.files {
+			if !matchesFile(file.Name, kind.name) {
+				continue
+			}
+			err := readArray(file, func(index int, raw json.RawMessage) error {
+				return emit(s.item(kind.kind, file.Name, index, raw))
+			})
+			if err != nil {
+				return fmt.Errorf("%s: %w", file.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// matchesFile reports whether the file name is the OpenF1 file name with an
+// optional prefix
+func matchesFile(name, file string) bool {
+	base := strings.ToLower(filepath.Base(name))
+	return base == file || strings.HasSuffix(base, "_"+file) || strings.HasSuffix(base, "-"+file)
+}
+
+// readArray streams the elements of the JSON array in the file
+func readArray(file OpenF1File, element func(int, json.RawMessage) error) error {
+	r, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return errors.New("file is not a JSON array")
+	}
+	for index := 0; decoder.More(); index++ {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return err
+		}
+		if err := element(index, raw); err != nil {
+			return err
+		}
+	}
+	_, err = decoder.Token()
+	return err
+}
+
+func (s *OpenF1Source) readPositions(file OpenF1File) error {
+	err := readArray(file, func(_ int, raw json.RawMessage) error {
+		var record openF1Record
+		if json.Unmarshal(raw, &record) != nil || record.Date == nil || record.Position < 1 {
+			return nil
+		}
+		if s.positions[record.SessionKey] == nil {
+			s.positions[record.SessionKey] = map[int][]openF1Record{}
+		}
+		s.positions[record.SessionKey][record.DriverNumber] = append(s.positions[record.SessionKey][record.DriverNumber], record)
+		return nil
+	})
+	for _, drivers := range s.positions {
+		for _, changes := range drivers {
+			sort.SliceStable(changes, func(i, j int) bool { return changes[i].Date.Before(*changes[j].Date) })
+		}
+	}
+	return err
+}
+
+// position returns the position of the driver at the time
+func (s *OpenF1Source) position(session, driver int, at time.Time) *int {
+	changes := s.positions[session][driver]
+	i := sort.Search(len(changes), func(i int) bool { return changes[i].Date.After(at) })
+	if i == 0 {
+		return nil
+	}
+	position := changes[i-1].Position
+	return &position
+}
+
+func (s *OpenF1Source) item(kind, file string, index int, raw json.RawMessage) Item {
+	item := Item{Kind: kind, File: file, Index: index, Raw: raw}
+	var record openF1Record
+	if err := json.Unmarshal(raw, &record); err != nil {
+		item.Problem = "record is not an OpenF1 " + kind
+		return item
+	}
+	item.MeetingKey = record.MeetingKey
+	item.SessionKey = record.SessionKey
+	item.DriverNumber = record.DriverNumber
+
+	value, problem := s.value(kind, record)
+	item.Value = value
+	item.Problem = problem
+	return item
+}
+
+// value maps the record to the record type of its kind, or explains why it
+// cannot be
+func (s *OpenF1Source) value(kind string, record openF1Record) (interface{}, string) {
+	switch kind {
+	case KindMeeting:
+		if record.DateStart == nil || record.Year == 0 {
+			return nil, "meeting has no year or start date"
+		}
+		return Meeting{Year: record.Year, Start: record.DateStart.UTC(), Name: record.MeetingName}, ""
+
+	case KindSession:
+		if record.DateStart == nil || record.Year == 0 {
+			return nil, "session has no year or start date"
+		}
+		session := SessionRecord{Year: record.Year, Type: openF1SessionTypes[record.SessionName], Start: record.DateStart.UTC()}
+		if record.DateEnd != nil {
+			session.End = record.DateEnd.UTC()
+		}
+		return session, ""
+
+	case KindDriver:
+		return DriverRecord{Code: record.NameAcronym, FirstName: record.FirstName, LastName: record.LastName, Team: record.TeamName}, ""
+
+	case KindLap:
+		if record.LapNumber == nil {
+			return nil, "lap has no number"
+		}
+		lap := LapRecord{Number: *record.LapNumber, Time: seconds(record.LapDuration)}
+		if record.DateStart != nil && lap.Time != nil {
+			lap.Position = s.position(record.SessionKey, record.DriverNumber, record.DateStart.Add(lap.Time.Duration()))
+		}
+		return lap, ""
+
+	case KindStint:
+		if record.LapStart == nil || record.LapEnd == nil {
+			return nil, "stint has no start or end lap"
+		}
+		compound, ok := openF1Compounds[record.Compound]
+		if !ok {
+			compound = m.CompoundUnknown
+		}
+		return StintRecord{Number: record.StintNumber, Compound: compound, StartLap: *record.LapStart, EndLap: *record.LapEnd, TyreAge: record.TyreAge}, ""
+
+	case KindPitStop:
+		if record.LapNumber == nil {
+			return nil, "pit stop has no lap"
+		}
+		return PitStopRecord{Lap: *record.LapNumber, PitLane: seconds(record.PitDuration)}, ""
+
+	case KindRaceControl:
+		if record.Date == nil {
+			return nil, "message has no date"
+		}
+		return RaceControlRecord{
+			Time:    record.Date.UTC(),
+			Type:    raceControlType(record),
+			Lap:     record.LapNumber,
+			Sector:  record.Sector,
+			Message: record.Message,
+		}, ""
+
+	case KindWeather:
+		if record.Date == nil {
+			return nil, "observation has no date"
+		}
+		if record.AirTemperature == nil || record.TrackTemperature == nil {
+			return nil, "observation has no air or track temperature"
+		}
+		return WeatherRecord{
+			Time:             record.Date.UTC(),
+			AirTemperature:   *record.AirTemperature,
+			TrackTemperature: *record.TrackTemperature,
+			Humidity:         record.Humidity,
+			Pressure:         record.Pressure,
+			WindSpeed:        record.WindSpeed,
+			WindDirection:    int(math.Round(record.WindDirection)) % 360,
+			Rainfall:         record.Rainfall > 0,
+		}, ""
+	}
+	return nil, "records of this kind are not imported"
+}
+
+// seconds converts a duration in seconds to a lap time
+func seconds(value *float64) *m.LapTime {
+	if value == nil || *value <= 0 {
+		return nil
+	}
+	time := m.LapTimeFromMillis(int64(math.Round(*value * 1000)))
+	return &time
+}
+
+var openF1SessionTypes = map[string]string{
+	"Practice 1":        m.SessionFP1,
+	"Practice 2":        m.SessionFP2,
+	"Practice 3":        m.SessionFP3,
+	"Sprint Shootout":   m.SessionSprintShootout,
+	"Sprint Qualifying": m.SessionSprintShootout,
+	"Sprint":            m.SessionSprint,
+	"Qualifying":        m.SessionQualifying,
+	"Race":              m.SessionRace,
+}
+
+var openF1Compounds = map[string]string{
+	"SOFT":         m.CompoundSoft,
+	"MEDIUM":       m.CompoundMedium,
+	"HARD":         m.CompoundHard,
+	"INTERMEDIATE": m.CompoundIntermediate,
+	"WET":          m.CompoundWet,
+}
+
+var openF1Flags = map[string]string{
+	"GREEN":         m.EventGreenFlag,
+	"CLEAR":         m.EventGreenFlag,
+	"YELLOW":        m.EventYellowFlag,
+	"DOUBLE YELLOW": m.EventDoubleYellowFlag,
+	"RED":           m.EventRedFlag,
+	"CHEQUERED":     m.EventChequeredFlag,
+}
+
+// raceControlType maps a race control message to an event type. Blue flags,
+// DRS and other messages without a counterpart map to no type
+func raceControlType(record openF1Record) string {
+	message := strings.ToUpper(record.Message)
+	switch {
+	case record.Category == "Flag":
+		return openF1Flags[record.Flag]
+	case record.Category == "SafetyCar":
+		switch {
+		case strings.Contains(message, "VIRTUAL SAFETY CAR DEPLOYED"):
+			return m.EventVirtualSafetyCar
+		case strings.Contains(message, "VIRTUAL SAFETY CAR ENDING"):
+			return m.EventVirtualSafetyCarEnd
+		case strings.Contains(message, "SAFETY CAR DEPLOYED"):
+			return m.EventSafetyCarDeployed
+		case strings.Contains(message, "SAFETY CAR IN THIS LAP"):
+			return m.EventSafetyCarEnding
+		}
+	case strings.Contains(message, "TRACK LIMITS") && strings.Contains(message, "DELETED"):
+		return m.EventTrackLimits
+	case strings.Contains(message, "UNDER INVESTIGATION"):
+		return m.EventInvestigation
+	case strings.Contains(message, "PENALTY") && !strings.Contains(message, "NO FURTHER"):
+		return m.EventPenalty
+	}
+	return ""
+}
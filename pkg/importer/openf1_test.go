@@ -0,0 +1,210 @@
+package importer
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+)
+
+func openF1File(name, content string) OpenF1File {
+	return OpenF1File{Name: name, Open: func() (io.ReadCloser, error) { return io.NopCloser(strings.NewReader(content)), nil }}
+}
+
+// read returns the items of the files in the order they are emitted
+func read(t *testing.T, files ...OpenF1File) []Item {
+	t.Helper()
+	items := []Item{}
+	err := NewOpenF1Source(files).Read(func(item Item) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return items
+}
+
+func TestOpenF1Kind(t *testing.T) {
+	tests := []struct {
+		name string
+		kind string
+		ok   bool
+	}{
+		{"laps.json", KindLap, true},
+		{"9158_laps.json", KindLap, true},
+		{"monza/9158-Race_Control.json", KindRaceControl, true},
+		{"position.json", KindLap, true},
+		{"pit.json", KindPitStop, true},
+		{"relaps.json", "", false},
+		{"laps.csv", "", false},
+	}
+	for _, test := range tests {
+		kind, ok := OpenF1Kind(test.name)
+		if kind != test.kind || ok != test.ok {
+			t.Errorf("OpenF1Kind(%q) = %q, %v, want %q, %v", test.name, kind, ok, test.kind, test.ok)
+		}
+	}
+}
+
+func TestOpenF1Order(t *testing.T) {
+	items := read(t,
+		openF1File("laps.json", `[{"session_key": 9158, "driver_number": 16, "lap_number": 1, "lap_duration": 91.5}]`),
+		openF1File("drivers.json", `[{"session_key": 9158, "driver_number": 16, "name_acronym": "LEC", "first_name": "Charles", "last_name": "Leclerc", "team_name": "Ferrari"}]`),
+		openF1File("sessions.json", `[{"meeting_key": 1219, "session_key": 9158, "year": 2023, "session_name": "Race", "date_start": "2023-09-03T13:00:00+00:00", "date_end": "2023-09-03T15:00:00+00:00"}]`),
+	)
+	kinds := []string{}
+	for _, item := range items {
+		kinds = append(kinds, item.Kind)
+	}
+	if strings.Join(kinds, ",") != "session,driver,lap" {
+		t.Fatalf("kinds = %v, want sessions, then drivers, then laps", kinds)
+	}
+
+	session := items[0]
+	if session.MeetingKey != 1219 || session.SessionKey != 9158 || session.File != "sessions.json" || session.Index != 0 {
+		t.Errorf("session keys = %+v", session)
+	}
+	want := SessionRecord{
+		Year:  2023,
+		Type:  m.SessionRace,
+		Start: time.Date(2023, 9, 3, 13, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 9, 3, 15, 0, 0, 0, time.UTC),
+	}
+	if session.Value != want {
+		t.Errorf("session = %+v, want %+v", session.Value, want)
+	}
+
+	driver := items[1]
+	if driver.DriverNumber != 16 || driver.Value != (DriverRecord{Code: "LEC", FirstName: "Charles", LastName: "Leclerc", Team: "Ferrari"}) {
+		t.Errorf("driver = %+v", driver)
+	}
+}
+
+func TestOpenF1Laps(t *testing.T) {
+	items := read(t,
+		openF1File("position.json", `[
+			{"session_key": 9158, "driver_number": 16, "date": "2023-09-03T13:05:00+00:00", "position": 4},
+			{"session_key": 9158, "driver_number": 16, "date": "2023-09-03T13:03:00+00:00", "position": 3},
+			{"session_key": 9158, "driver_number": 1, "date": "2023-09-03T13:00:00+00:00", "position": 1}
+		]`),
+		openF1File("laps.json", `[
+			{"session_key": 9158, "driver_number": 16, "lap_number": 2, "date_start": "2023-09-03T13:02:00+00:00", "lap_duration": 90.25},
+			{"session_key": 9158, "driver_number": 16, "lap_number": 3, "date_start": "2023-09-03T13:03:30+00:00", "lap_duration": 91.0},
+			{"session_key": 9158, "driver_number": 16, "lap_number": 1, "date_start": null, "lap_duration": null},
+			{"session_key": 9158, "driver_number": 16, "lap_number": null}
+		]`),
+	)
+	if len(items) != 4 {
+		t.Fatalf("got %d items, want the 4 laps", len(items))
+	}
+
+	lap := items[0].Value.(LapRecord)
+	if lap.Number != 2 || lap.Time == nil || lap.Time.Milliseconds() != 90250 {
+		t.Errorf("lap 2 = %+v", lap)
+	}
+	if lap.Position == nil || *lap.Position != 3 {
+		t.Errorf("lap 2 completed at 13:03:30 in position %v, want 3", lap.Position)
+	}
+	lap = items[1].Value.(LapRecord)
+	if lap.Position == nil || *lap.Position != 4 {
+		t.Errorf("lap 3 completed at 13:05:01 in position %v, want 4", lap.Position)
+	}
+	lap = items[2].Value.(LapRecord)
+	if lap.Time != nil || lap.Position != nil {
+		t.Errorf("lap 1 without a time = %+v, want neither time nor position", lap)
+	}
+	if items[3].Value != nil || items[3].Problem == "" {
+		t.Errorf("lap without a number = %+v, want a problem", items[3])
+	}
+}
+
+func TestOpenF1Stints(t *testing.T) {
+	items := read(t, openF1File("stints.json", `[
+		{"session_key": 9158, "driver_number": 16, "stint_number": 1, "lap_start": 1, "lap_end": 20, "compound": "MEDIUM", "tyre_age_at_start": 3},
+		{"session_key": 9158, "driver_number": 16, "stint_number": 2, "lap_start": 21, "lap_end": 51, "compound": "TEST_UNKNOWN", "tyre_age_at_start": 0},
+		{"session_key": 9158, "driver_number": 16, "stint_number": 3, "lap_start": 52, "lap_end": null, "compound": "HARD"},
+		"not a stint"
+	]`))
+	if items[0].Value != (StintRecord{Number: 1, Compound: m.CompoundMedium, StartLap: 1, EndLap: 20, TyreAge: 3}) {
+		t.Errorf("stint 1 = %+v", items[0].Value)
+	}
+	if stint := items[1].Value.(StintRecord); stint.Compound != m.CompoundUnknown {
+		t.Errorf("compound = %q, want unknown", stint.Compound)
+	}
+	for _, item := range items[2:] {
+		if item.Value != nil || item.Problem == "" {
+			t.Errorf("item %d = %+v, want a problem", item.Index, item)
+		}
+	}
+}
+
+func TestOpenF1PitStops(t *testing.T) {
+	items := read(t, openF1File("pit.json", `[
+		{"session_key": 9158, "driver_number": 16, "lap_number": 20, "pit_duration": 22.456},
+		{"session_key": 9158, "driver_number": 16, "lap_number": 38, "pit_duration": null}
+	]`))
+	stop := items[0].Value.(PitStopRecord)
+	if stop.Lap != 20 || stop.PitLane == nil || stop.PitLane.Milliseconds() != 22456 {
+		t.Errorf("stop = %+v", stop)
+	}
+	if stop := items[1].Value.(PitStopRecord); stop.PitLane != nil {
+		t.Errorf("stop without a duration = %+v", stop)
+	}
+}
+
+func TestOpenF1Weather(t *testing.T) {
+	items := read(t, openF1File("weather.json", `[
+		{"session_key": 9158, "date": "2023-09-03T13:01:12.504000+00:00", "air_temperature": 28.4, "track_temperature": 44.1, "humidity": 40, "pressure": 996.8, "wind_speed": 1.3, "wind_direction": 359.6, "rainfall": 1},
+		{"session_key": 9158, "date": "2023-09-03T13:02:12.504000+00:00", "humidity": 41}
+	]`))
+	observation := items[0].Value.(WeatherRecord)
+	if observation.AirTemperature != 28.4 || observation.TrackTemperature != 44.1 || observation.WindDirection != 0 || !observation.Rainfall {
+		t.Errorf("observation = %+v", observation)
+	}
+	if items[1].Value != nil || items[1].Problem == "" {
+		t.Errorf("observation without temperatures = %+v", items[1])
+	}
+}
+
+func TestRaceControlType(t *testing.T) {
+	tests := []struct {
+		record openF1Record
+		want   string
+	}{
+		{openF1Record{Category: "Flag", Flag: "YELLOW"}, m.EventYellowFlag},
+		{openF1Record{Category: "Flag", Flag: "CLEAR"}, m.EventGreenFlag},
+		{openF1Record{Category: "Flag", Flag: "BLUE"}, ""},
+		{openF1Record{Category: "SafetyCar", Message: "SAFETY CAR DEPLOYED"}, m.EventSafetyCarDeployed},
+		{openF1Record{Category: "SafetyCar", Message: "SAFETY CAR IN THIS LAP"}, m.EventSafetyCarEnding},
+		{openF1Record{Category: "SafetyCar", Message: "VIRTUAL SAFETY CAR DEPLOYED"}, m.EventVirtualSafetyCar},
+		{openF1Record{Category: "SafetyCar", Message: "VIRTUAL SAFETY CAR ENDING"}, m.EventVirtualSafetyCarEnd},
+		{openF1Record{Category: "Other", Message: "CAR 16 (LEC) TIME 1:22.456 DELETED - TRACK LIMITS AT TURN 4 LAP 12"}, m.EventTrackLimits},
+		{openF1Record{Category: "Other", Message: "TURN 1 INCIDENT INVOLVING CARS 16 (LEC) AND 55 (SAI) UNDER INVESTIGATION"}, m.EventInvestigation},
+		{openF1Record{Category: "Other", Message: "FIA STEWARDS: 5 SECOND TIME PENALTY FOR CAR 16 (LEC)"}, m.EventPenalty},
+		{openF1Record{Category: "Other", Message: "FIA STEWARDS: TURN 1 INCIDENT REVIEWED NO FURTHER INVESTIGATION"}, ""},
+		{openF1Record{Category: "Drs", Message: "DRS ENABLED"}, ""},
+	}
+	for _, test := range tests {
+		if got := raceControlType(test.record); got != test.want {
+			t.Errorf("raceControlType(%+v) = %q, want %q", test.record, got, test.want)
+		}
+	}
+}
+
+func TestDigest(t *testing.T) {
+	item := Item{Kind: KindLap, File: "laps.json", Raw: []byte(`{"lap_number": 1}`)}
+	moved := item
+	moved.File = "9158_laps.json"
+	moved.Index = 12
+	if digest(SourceOpenF1, item) != digest(SourceOpenF1, moved) {
+		t.Error("the digest of a record depends on where it was read")
+	}
+	other := item
+	other.Raw = []byte(`{"lap_number": 2}`)
+	if digest(SourceOpenF1, item) == digest(SourceOpenF1, other) {
+		t.Error("records with other content share a digest")
+	}
+}
@@ -0,0 +1,495 @@
+package importer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	d "github.com/dewciu/f1_api/pkg/database"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// meetingDays is how many days after the start of a meeting its race may
+// be held
+const meetingDays = 4
+
+// SessionImporter stores the items of a source. Meetings and sessions are
+// matched to the races and sessions of the project by their keys, which are
+// stored on first match, and drivers by their car numbers in the line-ups.
+// Items which cannot be mapped are queued for review, items already
+// imported are updated or skipped, so a source can be imported again
+type SessionImporter struct {
+	DB *gorm.DB
+
+	races    map[int]*m.Race
+	sessions map[int]*sessionRef
+	// stops counts the pit stops read for each driver of a race
+	stops map[raceDriver]int
+	// events holds the race control events stored for each race
+	events map[uuid.UUID]map[eventKey]bool
+}
+
+// sessionRef is a session of the project with its race and the drivers
+// behind the car numbers of the source
+type sessionRef struct {
+	session m.Session
+	race    m.Race
+	drivers map[int]uuid.UUID
+}
+
+type raceDriver struct {
+	race   uuid.UUID
+	driver uuid.UUID
+}
+
+type eventKey struct {
+	time    int64
+	kind    string
+	message string
+}
+
+func NewSessionImporter(db *gorm.DB) *SessionImporter {
+	return &SessionImporter{
+		DB:       db,
+		races:    map[int]*m.Race{},
+		sessions: map[int]*sessionRef{},
+		stops:    map[raceDriver]int{},
+		events:   map[uuid.UUID]map[eventKey]bool{},
+	}
+}
+
+// pending holds the rows of a file until they are stored in a single
+// transaction, keyed so that a record repeated in the file is stored once
+type pending struct {
+	summary *FileSummary
+	laps    map[raceDriverNumber]m.Lap
+	stints  map[raceDriverNumber]m.Stint
+	stops   map[raceDriverNumber]m.PitStop
+	events  []m.RaceControlEvent
+	weather map[sessionTime]m.WeatherObservation
+	reviews map[string]m.ImportReview
+}
+
+type sessionTime struct {
+	session uuid.UUID
+	time    int64
+}
+
+func newPending(summary *FileSummary) *pending {
+	return &pending{
+		summary: summary,
+		laps:    map[raceDriverNumber]m.Lap{},
+		stints:  map[raceDriverNumber]m.Stint{},
+		stops:   map[raceDriverNumber]m.PitStop{},
+		weather: map[sessionTime]m.WeatherObservation{},
+		reviews: map[string]m.ImportReview{},
+	}
+}
+
+// Import reads the source and stores its items file by file, an error
+// stops the import after the last stored file
+func (si *SessionImporter) Import(src Source) (Summary, error) {
+	summary := Summary{}
+	var current *pending
+
+	err := src.Read(func(item Item) error {
+		if current == nil || current.summary.File != item.File {
+			if err := si.flush(current); err != nil {
+				return err
+			}
+			file := &FileSummary{File: item.File}
+			summary = append(summary, file)
+			current = newPending(file)
+		}
+
+		current.summary.Rows++
+		if reason := si.add(current, item); reason != "" {
+			review := m.ImportReview{
+				Source: src.Name(),
+				Kind:   item.Kind,
+				Origin: fmt.Sprintf("%s#%d", item.File, item.Index),
+				Reason: reason,
+				Digest: digest(src.Name(), item),
+				Record: m.JSON(append([]byte(nil), item.Raw...)),
+			}
+			current.reviews[review.Digest] = review
+		}
+		return nil
+	})
+	if err != nil {
+		return summary, err
+	}
+	return summary, si.flush(current)
+}
+
+// digest identifies the record of the item across imports
+func digest(source string, item Item) string {
+	hash := sha256.New()
+	hash.Write([]byte(source + "\x00" + item.Kind + "\x00"))
+	hash.Write(item.Raw)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// add maps the item and adds its row to the pending rows, it returns why
+// the item has to be reviewed when it cannot be mapped
+func (si *SessionImporter) add(p *pending, item Item) string {
+	if item.Value == nil {
+		return item.Problem
+	}
+
+	switch value := item.Value.(type) {
+	case Meeting:
+		race, reason := si.matchMeeting(item.MeetingKey, value.Year, value.Start)
+		if race == nil {
+			return reason
+		}
+		p.summary.Imported++
+
+	case SessionRecord:
+		return si.addSession(p, item, value)
+
+	case DriverRecord:
+		ref, reason := si.session(item.SessionKey)
+		if ref == nil {
+			return reason
+		}
+		if _, err := si.driver(ref, item.DriverNumber, value.Code); err != nil {
+			return err.Error()
+		}
+		p.summary.Imported++
+
+	case LapRecord:
+		ref, driver, reason := si.raceDriver(p, item)
+		if reason != "" || ref == nil {
+			return reason
+		}
+		if value.Time == nil {
+			return "lap has no time"
+		}
+		if value.Position == nil {
+			return "lap has no position, import the position changes of the session with its laps"
+		}
+		p.laps[raceDriverNumber{ref.race.ID, driver, value.Number}] = m.Lap{
+			RaceID: ref.race.ID, DriverID: driver, Number: value.Number, Position: *value.Position, Time: *value.Time,
+		}
+		p.summary.Imported++
+
+	case StintRecord:
+		ref, driver, reason := si.raceDriver(p, item)
+		if reason != "" || ref == nil {
+			return reason
+		}
+		if value.EndLap < value.StartLap {
+			return "stint ends before it starts"
+		}
+		p.stints[raceDriverNumber{ref.race.ID, driver, value.Number}] = m.Stint{
+			RaceID: ref.race.ID, DriverID: driver, Number: value.Number, Compound: value.Compound,
+			New: value.TyreAge == 0, StartLap: value.StartLap, EndLap: value.EndLap,
+		}
+		p.summary.Imported++
+
+	case PitStopRecord:
+		ref, driver, reason := si.raceDriver(p, item)
+		if reason != "" || ref == nil {
+			return reason
+		}
+		key := raceDriver{ref.race.ID, driver}
+		si.stops[key]++
+		p.stops[raceDriverNumber{ref.race.ID, driver, si.stops[key]}] = m.PitStop{
+			RaceID: ref.race.ID, DriverID: driver, Stop: si.stops[key], Lap: value.Lap, PitLane: value.PitLane,
+		}
+		p.summary.Imported++
+
+	case RaceControlRecord:
+		return si.addEvent(p, item, value)
+
+	case WeatherRecord:
+		ref, reason := si.session(item.SessionKey)
+		if ref == nil {
+			return reason
+		}
+		p.weather[sessionTime{ref.session.ID, value.Time.UnixMilli()}] = m.WeatherObservation{
+			SessionID:        ref.session.ID,
+			Time:             value.Time,
+			AirTemperature:   value.AirTemperature,
+			TrackTemperature: value.TrackTemperature,
+			Humidity:         value.Humidity,
+			Pressure:         value.Pressure,
+			WindSpeed:        value.WindSpeed,
+			WindDirection:    value.WindDirection,
+			Rainfall:         value.Rainfall,
+		}
+		p.summary.Imported++
+
+	default:
+		return "records of this kind are not imported"
+	}
+	return ""
+}
+
+// raceDriver resolves the race session and the driver of the item. Items of
+// other sessions are skipped, as laps, stints and pit stops are only stored
+// for races, the returned session is then nil
+func (si *SessionImporter) raceDriver(p *pending, item Item) (*sessionRef, uuid.UUID, string) {
+	ref, reason := si.session(item.SessionKey)
+	if ref == nil {
+		return nil, uuid.Nil, reason
+	}
+	if ref.session.Type != m.SessionRace {
+		p.summary.Skipped++
+		return nil, uuid.Nil, ""
+	}
+	driver, err := si.driver(ref, item.DriverNumber, "")
+	if err != nil {
+		return nil, uuid.Nil, err.Error()
+	}
+	return ref, driver, ""
+}
+
+func (si *SessionImporter) addSession(p *pending, item Item, value SessionRecord) string {
+	if value.Type == "" {
+		p.summary.Skipped++
+		return ""
+	}
+	race, reason := si.matchMeeting(item.MeetingKey, value.Year, value.Start)
+	if race == nil {
+		return reason
+	}
+
+	var session m.Session
+	err := si.DB.Where("race_id = ? AND type = ?", race.ID, value.Type).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Sprintf("%s has no %s session", race.Name, value.Type)
+	}
+	if err != nil {
+		return err.Error()
+	}
+	if session.SessionKey != nil && *session.SessionKey != item.SessionKey {
+		return fmt.Sprintf("%s session of %s is already matched to session %d", value.Type, race.Name, *session.SessionKey)
+	}
+	if session.SessionKey == nil {
+		key := item.SessionKey
+		if err := si.DB.Model(&session).Update("session_key", key).Error; err != nil {
+			return fmt.Sprintf("session key %d cannot be stored: %v", key, err)
+		}
+		session.SessionKey = &key
+	}
+
+	delete(si.sessions, item.SessionKey)
+	p.summary.Imported++
+	return ""
+}
+
+func (si *SessionImporter) addEvent(p *pending, item Item, value RaceControlRecord) string {
+	ref, reason := si.session(item.SessionKey)
+	if ref == nil {
+		return reason
+	}
+	if ref.session.Type != m.SessionRace || value.Type == "" {
+		p.summary.Skipped++
+		return ""
+	}
+
+	event := m.RaceControlEvent{
+		RaceID:  ref.race.ID,
+		Time:    value.Time,
+		Type:    value.Type,
+		Lap:     value.Lap,
+		Sector:  value.Sector,
+		Message: value.Message,
+	}
+	if item.DriverNumber != 0 {
+		driver, err := si.driver(ref, item.DriverNumber, "")
+		if err != nil {
+			return err.Error()
+		}
+		event.DriverID = &driver
+	}
+
+	stored, err := si.raceEvents(ref.race.ID)
+	if err != nil {
+		return err.Error()
+	}
+	key := eventKey{value.Time.UnixMilli(), value.Type, value.Message}
+	if stored[key] {
+		p.summary.Skipped++
+		return ""
+	}
+	stored[key] = true
+	p.events = append(p.events, event)
+	p.summary.Imported++
+	return ""
+}
+
+// raceEvents returns the keys of the events stored for the race
+func (si *SessionImporter) raceEvents(raceID uuid.UUID) (map[eventKey]bool, error) {
+	if events, ok := si.events[raceID]; ok {
+		return events, nil
+	}
+	stored := []m.RaceControlEvent{}
+	if err := si.DB.Where("race_id = ?", raceID).Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	events := make(map[eventKey]bool, len(stored))
+	for _, event := range stored {
+		events[eventKey{event.Time.UnixMilli(), event.Type, event.Message}] = true
+	}
+	si.events[raceID] = events
+	return events, nil
+}
+
+// matchMeeting returns the race of the meeting, a race without a meeting key
+// held within a few days after the start of the meeting is given its key
+func (si *SessionImporter) matchMeeting(key, year int, start time.Time) (*m.Race, string) {
+	if race, ok := si.races[key]; ok {
+		return race, ""
+	}
+
+	var race m.Race
+	err := si.DB.Where("meeting_key = ?", key).First(&race).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		day := start.Truncate(24 * time.Hour)
+		races := []m.Race{}
+		err = si.DB.Where("year = ? AND date >= ? AND date <= ?", year, day, day.AddDate(0, 0, meetingDays)).Find(&races).Error
+		if err != nil {
+			return nil, err.Error()
+		}
+		if len(races) != 1 {
+			return nil, fmt.Sprintf("no single race of %d is held within %d days of %s", year, meetingDays, day.Format(time.DateOnly))
+		}
+		race = races[0]
+		if race.MeetingKey != nil {
+			return nil, fmt.Sprintf("%s is already matched to meeting %d", race.Name, *race.MeetingKey)
+		}
+		if err := si.DB.Model(&race).Update("meeting_key", key).Error; err != nil {
+			return nil, err.Error()
+		}
+		race.MeetingKey = &key
+	} else if err != nil {
+		return nil, err.Error()
+	}
+
+	si.races[key] = &race
+	return &race, ""
+}
+
+// session returns the session with the key with its race
+func (si *SessionImporter) session(key int) (*sessionRef, string) {
+	if ref, ok := si.sessions[key]; ok {
+		return ref, ""
+	}
+
+	ref := &sessionRef{drivers: map[int]uuid.UUID{}}
+	err := si.DB.Where("session_key = ?", key).First(&ref.session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Sprintf("session %d is unknown, import its session record first", key)
+	}
+	if err != nil {
+		return nil, err.Error()
+	}
+	if err := si.DB.First(&ref.race, "id = ?", ref.session.RaceID).Error; err != nil {
+		return nil, err.Error()
+	}
+
+	si.sessions[key] = ref
+	return ref, ""
+}
+
+// driver returns the driver behind the car number in the session. Numbers
+// are looked up in the line-ups of the race, a driver record of the source
+// whose code matches an entrant maps numbers the line-ups do not know
+func (si *SessionImporter) driver(ref *sessionRef, number int, code string) (uuid.UUID, error) {
+	if driver, ok := ref.drivers[number]; ok {
+		return driver, nil
+	}
+
+	entrants, err := d.NewResultRepository(si.DB).GetRaceEntrantsQuery(ref.race)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	for _, entrant := range entrants {
+		if entrant.CarNumber == number {
+			ref.drivers[number] = entrant.DriverID
+			return entrant.DriverID, nil
+		}
+	}
+
+	if code != "" {
+		ids := make([]uuid.UUID, len(entrants))
+		for i, entrant := range entrants {
+			ids[i] = entrant.DriverID
+		}
+		drivers := []m.Driver{}
+		if err := si.DB.Where("id IN ? AND code = ?", ids, code).Find(&drivers).Error; err != nil {
+			return uuid.Nil, err
+		}
+		if len(drivers) == 1 {
+			ref.drivers[number] = drivers[0].ID
+			return drivers[0].ID, nil
+		}
+	}
+	return uuid.Nil, fmt.Errorf("no entrant of %s drives car %d", ref.race.Name, number)
+}
+
+// flush stores the pending rows of a file in a single transaction
+func (si *SessionImporter) flush(p *pending) error {
+	if p == nil {
+		return nil
+	}
+
+	queued := 0
+	err := si.DB.Transaction(func(tx *gorm.DB) error {
+		if laps := values(p.laps); len(laps) > 0 {
+			if err := upsert(tx, laps, []string{"race_id", "driver_id", "lap"}, "position", "time"); err != nil {
+				return err
+			}
+		}
+		if stints := values(p.stints); len(stints) > 0 {
+			if err := upsert(tx, stints, []string{"race_id", "driver_id", "number"}, "compound", "new", "start_lap", "end_lap", "updated_at"); err != nil {
+				return err
+			}
+		}
+		if stops := values(p.stops); len(stops) > 0 {
+			if err := upsert(tx, stops, []string{"race_id", "driver_id", "stop"}, "lap", "pit_lane", "updated_at"); err != nil {
+				return err
+			}
+		}
+		if len(p.events) > 0 {
+			if err := tx.Omit(clause.Associations).CreateInBatches(&p.events, insertBatchSize).Error; err != nil {
+				return err
+			}
+		}
+		if weather := values(p.weather); len(weather) > 0 {
+			if err := upsert(tx, weather, []string{"session_id", "time"}, "air_temperature", "track_temperature", "humidity", "pressure", "wind_speed", "wind_direction", "rainfall", "updated_at"); err != nil {
+				return err
+			}
+		}
+		if reviews := values(p.reviews); len(reviews) > 0 {
+			// reviews queued by an earlier import are skipped
+			result := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&reviews, insertBatchSize)
+			if result.Error != nil {
+				return result.Error
+			}
+			queued = int(result.RowsAffected)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", p.summary.File, err)
+	}
+	p.summary.Queued = queued
+	return nil
+}
+
+func values[K comparable, V any](rows map[K]V) []V {
+	list := make([]V, 0, len(rows))
+	for _, row := range rows {
+		list = append(list, row)
+	}
+	return list
+}
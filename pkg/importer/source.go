@@ -0,0 +1,122 @@
+package importer
+
+import (
+	"encoding/json"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+)
+
+// Kinds of the items read from a source
+const (
+	KindMeeting     = "meeting"
+	KindSession     = "session"
+	KindDriver      = "driver"
+	KindLap         = "lap"
+	KindStint       = "stint"
+	KindPitStop     = "pit_stop"
+	KindRaceControl = "race_control"
+	KindWeather     = "weather"
+)
+
+// Source reads the session data of an external dataset. Items are keyed the
+// way the dataset keys them and the SessionImporter maps them to the models,
+// so that a new dataset only needs a Source
+type Source interface {
+	// Name identifies the dataset on the items queued for review
+	Name() string
+	// Read passes the items of the source to emit, meetings, sessions and
+	// drivers before the items which refer to them. An error returned by
+	// emit stops the read
+	Read(emit func(Item) error) error
+}
+
+// Item is a record of a source. Value holds one of the record types below,
+// matching the kind, or nil for records the source could not parse
+type Item struct {
+	Kind string
+	// File and Index locate the record in the source
+	File  string
+	Index int
+	// Raw is the record as read, queued for review when it cannot be mapped
+	Raw json.RawMessage
+	// MeetingKey, SessionKey and DriverNumber are the keys of the dataset
+	// the record refers to, zero when it does not refer to one
+	MeetingKey   int
+	SessionKey   int
+	DriverNumber int
+	Value        interface{}
+	// Problem explains why Value is nil
+	Problem string
+}
+
+// Meeting is a race weekend, matched to the race of its season held within
+// a few days of its start
+type Meeting struct {
+	Year  int
+	Start time.Time
+	Name  string
+}
+
+// SessionRecord is a session of a meeting, Type is the session type of the
+// models or empty when the session has no counterpart
+type SessionRecord struct {
+	Year  int
+	Type  string
+	Start time.Time
+	End   time.Time
+}
+
+// DriverRecord names the driver behind a car number in a session
+type DriverRecord struct {
+	Code      string
+	FirstName string
+	LastName  string
+	Team      string
+}
+
+// LapRecord is a lap of a driver, Position is their position when they
+// completed it
+type LapRecord struct {
+	Number   int
+	Position *int
+	Time     *m.LapTime
+}
+
+type StintRecord struct {
+	Number   int
+	Compound string
+	StartLap int
+	EndLap   int
+	TyreAge  int
+}
+
+// PitStopRecord is a pit stop, stops are numbered by the order they are read
+// in for each driver
+type PitStopRecord struct {
+	Lap     int
+	PitLane *m.LapTime
+}
+
+// RaceControlRecord is a message of race control, Type is the event type of
+// the models or empty for messages which are not stored
+type RaceControlRecord struct {
+	Time    time.Time
+	Type    string
+	Lap     *int
+	Sector  *int
+	Message string
+}
+
+// WeatherRecord is an observation of the conditions at the track, wind
+// speed in metres per second and direction in degrees
+type WeatherRecord struct {
+	Time             time.Time
+	AirTemperature   float64
+	TrackTemperature float64
+	Humidity         float64
+	Pressure         float64
+	WindSpeed        float64
+	WindDirection    int
+	Rainfall         bool
+}
@@ -0,0 +1,52 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+)
+
+// maxListedWarnings caps the warnings printed for a file, the others are
+// only counted
+const maxListedWarnings = 10
+
+// FileSummary counts what became of the rows of an imported file
+type FileSummary struct {
+	File string
+	// Note explains why a file was not imported
+	Note     string
+	Rows     int
+	Imported int
+	Skipped  int
+	// Queued counts the rows queued for review
+	Queued   int
+	Warnings []string
+}
+
+func (fs *FileSummary) warn(line int, message string) {
+	fs.Warnings = append(fs.Warnings, fmt.Sprintf("line %d: %s", line, message))
+}
+
+type Summary []*FileSummary
+
+// Print writes a line per file followed by its first warnings
+func (s Summary) Print(w io.Writer) {
+	for _, file := range s {
+		if file.Note != "" {
+			fmt.Fprintf(w, "%-26s %s\n", file.File, file.Note)
+			continue
+		}
+		fmt.Fprintf(w, "%-26s %8d rows %8d imported %6d skipped %6d warnings",
+			file.File, file.Rows, file.Imported, file.Skipped, len(file.Warnings))
+		if file.Queued > 0 {
+			fmt.Fprintf(w, " %6d queued for review", file.Queued)
+		}
+		fmt.Fprintln(w)
+		for i, warning := range file.Warnings {
+			if i == maxListedWarnings {
+				fmt.Fprintf(w, "    ... %d more\n", len(file.Warnings)-maxListedWarnings)
+				break
+			}
+			fmt.Fprintf(w, "    %s\n", warning)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Package laps derives lap charts, gap charts and fastest lap rankings from
+// the stored laps of a race.
+package laps
+
+import (
+	"sort"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+// DriverLaps holds the laps of a driver in lap order
+type DriverLaps struct {
+	DriverID uuid.UUID
+	Laps     []m.Lap
+}
+
+// last returns the final lap of the driver
+func (d DriverLaps) last() m.Lap {
+	return d.Laps[len(d.Laps)-1]
+}
+
+// Group splits the laps by driver, drivers completing more laps come first
+// and drivers on the same lap are ordered by their position on it
+func Group(laps []m.Lap) []DriverLaps {
+	index := make(map[uuid.UUID]int)
+	drivers := []DriverLaps{}
+	for _, lap := range laps {
+		i, ok := index[lap.DriverID]
+		if !ok {
+			i = len(drivers)
+			index[lap.DriverID] = i
+			drivers = append(drivers, DriverLaps{DriverID: lap.DriverID})
+		}
+		drivers[i].Laps = append(drivers[i].Laps, lap)
+	}
+
+	for _, driver := range drivers {
+		driverLaps := driver.Laps
+		sort.Slice(driverLaps, func(i, j int) bool {
+			return driverLaps[i].Number < driverLaps[j].Number
+		})
+	}
+	sort.SliceStable(drivers, func(i, j int) bool {
+		a, b := drivers[i].last(), drivers[j].last()
+		if a.Number != b.Number {
+			return a.Number > b.Number
+		}
+		return a.Position < b.Position
+	})
+	return drivers
+}
+
+// count returns the highest lap number
+func count(drivers []DriverLaps) int {
+	laps := 0
+	for _, driver := range drivers {
+		if n := driver.last().Number; n > laps {
+			laps = n
+		}
+	}
+	return laps
+}
+
+// ChartLine holds the position of a driver at the end of every lap, nil
+// where the driver has no time for the lap
+type ChartLine struct {
+	DriverID  uuid.UUID
+	Positions []*int
+}
+
+type Chart struct {
+	Laps    int
+	Drivers []ChartLine
+}
+
+// LapChart returns the position of every driver lap by lap
+func LapChart(laps []m.Lap) Chart {
+	drivers := Group(laps)
+	chart := Chart{Laps: count(drivers), Drivers: []ChartLine{}}
+	for _, driver := range drivers {
+		line := ChartLine{DriverID: driver.DriverID, Positions: make([]*int, chart.Laps)}
+		for _, lap := range driver.Laps {
+			position := lap.Position
+			line.Positions[lap.Number-1] = &position
+		}
+		chart.Drivers = append(chart.Drivers, line)
+	}
+	return chart
+}
+
+// GapLine holds the gap of a driver to the leader at the end of every lap,
+// nil from the first lap the driver has no time for
+type GapLine struct {
+	DriverID uuid.UUID
+	Gaps     []*m.LapTime
+}
+
+type GapChart struct {
+	Laps    int
+	Drivers []GapLine
+}
+
+// Totals returns the cumulative race time of every driver at the end of each
+// lap, the first element being lap 1. A missing lap leaves the later totals
+// unknown so they stop before it
+func Totals(laps []m.Lap) map[uuid.UUID][]m.LapTime {
+	totals := make(map[uuid.UUID][]m.LapTime)
+	for _, driver := range Group(laps) {
+		elapsed := []m.LapTime{}
+		var total m.LapTime
+		for n, lap := range driver.Laps {
+			if lap.Number != n+1 {
+				break
+			}
+			total += lap.Time
+			elapsed = append(elapsed, total)
+		}
+		totals[driver.DriverID] = elapsed
+	}
+	return totals
+}
+
+// Gaps returns the cumulative race time of every driver at the end of each
+// lap minus the one of the driver who completed that lap first
+func Gaps(laps []m.Lap) GapChart {
+	drivers := Group(laps)
+	totals := Totals(laps)
+	chart := GapChart{Laps: count(drivers), Drivers: []GapLine{}}
+
+	leader := make([]*m.LapTime, chart.Laps)
+	for _, elapsed := range totals {
+		for n := range elapsed {
+			if leader[n] == nil || elapsed[n] < *leader[n] {
+				leader[n] = &elapsed[n]
+			}
+		}
+	}
+
+	for _, driver := range drivers {
+		line := GapLine{DriverID: driver.DriverID, Gaps: make([]*m.LapTime, chart.Laps)}
+		for n, total := range totals[driver.DriverID] {
+			gap := total - *leader[n]
+			line.Gaps[n] = &gap
+		}
+		chart.Drivers = append(chart.Drivers, line)
+	}
+	return chart
+}
+
+// FastestLap is the quickest lap of a driver with its gap to the fastest lap
+// of the race
+type FastestLap struct {
+	Rank int
+	m.Lap
+	Gap m.LapTime
+}
+
+// Fastest ranks every driver by their quickest lap, the earlier lap counts
+// when a driver sets the same time twice and the driver who set it first
+// by lap ranks ahead on equal times
+func Fastest(laps []m.Lap) []FastestLap {
+	ranking := []FastestLap{}
+	for _, driver := range Group(laps) {
+		best := driver.Laps[0]
+		for _, lap := range driver.Laps[1:] {
+			if lap.Time < best.Time {
+				best = lap
+			}
+		}
+		ranking = append(ranking, FastestLap{Lap: best})
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		if ranking[i].Time != ranking[j].Time {
+			return ranking[i].Time < ranking[j].Time
+		}
+		return ranking[i].Number < ranking[j].Number
+	})
+	for i := range ranking {
+		ranking[i].Rank = i + 1
+		ranking[i].Gap = ranking[i].Time - ranking[0].Time
+	}
+	return ranking
+}
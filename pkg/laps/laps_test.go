@@ -0,0 +1,135 @@
+package laps
+
+import (
+	"reflect"
+	"testing"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+var (
+	alpha = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	beta  = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	gamma = uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+)
+
+func lap(driver uuid.UUID, number, position int, millis int64) m.Lap {
+	return m.Lap{DriverID: driver, Number: number, Position: position, Time: m.LapTimeFromMillis(millis)}
+}
+
+// three laps where beta passes alpha on lap 2 and gamma retires after lap 1,
+// listed out of order as they may come from the store
+var race = []m.Lap{
+	lap(alpha, 3, 2, 90500),
+	lap(beta, 1, 2, 91000),
+	lap(alpha, 1, 1, 90000),
+	lap(gamma, 1, 3, 93000),
+	lap(beta, 2, 1, 89000),
+	lap(alpha, 2, 2, 90500),
+	lap(beta, 3, 1, 90200),
+}
+
+func ints(values ...int) []*int {
+	pointers := []*int{}
+	for _, v := range values {
+		v := v
+		if v == 0 {
+			pointers = append(pointers, nil)
+			continue
+		}
+		pointers = append(pointers, &v)
+	}
+	return pointers
+}
+
+func TestLapChart(t *testing.T) {
+	chart := LapChart(race)
+	if chart.Laps != 3 {
+		t.Fatalf("got %d laps, want 3", chart.Laps)
+	}
+
+	expected := []ChartLine{
+		{DriverID: beta, Positions: ints(2, 1, 1)},
+		{DriverID: alpha, Positions: ints(1, 2, 2)},
+		{DriverID: gamma, Positions: ints(3, 0, 0)},
+	}
+	if !reflect.DeepEqual(chart.Drivers, expected) {
+		t.Errorf("got %+v, want %+v", chart.Drivers, expected)
+	}
+}
+
+func TestGaps(t *testing.T) {
+	chart := Gaps(race)
+
+	gaps := make(map[uuid.UUID][]string)
+	for _, line := range chart.Drivers {
+		for _, gap := range line.Gaps {
+			if gap == nil {
+				gaps[line.DriverID] = append(gaps[line.DriverID], "-")
+				continue
+			}
+			gaps[line.DriverID] = append(gaps[line.DriverID], gap.String())
+		}
+	}
+
+	expected := map[uuid.UUID][]string{
+		beta:  {"1.000", "0.000", "0.000"},
+		alpha: {"0.000", "0.500", "0.800"},
+		gamma: {"3.000", "-", "-"},
+	}
+	if !reflect.DeepEqual(gaps, expected) {
+		t.Errorf("got %v, want %v", gaps, expected)
+	}
+}
+
+func TestGapsWithMissingLap(t *testing.T) {
+	chart := Gaps([]m.Lap{
+		lap(alpha, 1, 1, 90000),
+		lap(alpha, 2, 1, 90000),
+		lap(beta, 1, 2, 91000),
+		lap(beta, 3, 2, 91000),
+	})
+
+	for _, line := range chart.Drivers {
+		if line.DriverID == beta && (line.Gaps[0] == nil || line.Gaps[1] != nil) {
+			t.Errorf("beta gaps %v, want a gap on lap 1 only", line.Gaps)
+		}
+	}
+}
+
+func TestFastest(t *testing.T) {
+	ranking := Fastest(race)
+	if len(ranking) != 3 {
+		t.Fatalf("got %d drivers, want 3", len(ranking))
+	}
+
+	expected := []struct {
+		driver uuid.UUID
+		lap    int
+		gap    string
+	}{
+		{beta, 2, "0.000"},
+		{alpha, 1, "1.000"},
+		{gamma, 1, "4.000"},
+	}
+	for i, e := range expected {
+		got := ranking[i]
+		if got.Rank != i+1 || got.DriverID != e.driver || got.Number != e.lap || got.Gap.String() != e.gap {
+			t.Errorf("P%d: got %s on lap %d +%s, want %s on lap %d +%s",
+				i+1, got.DriverID, got.Number, got.Gap, e.driver, e.lap, e.gap)
+		}
+	}
+}
+
+func TestFastestKeepsEarlierLap(t *testing.T) {
+	ranking := Fastest([]m.Lap{
+		lap(alpha, 1, 1, 90000),
+		lap(alpha, 2, 1, 90000),
+		lap(beta, 1, 2, 90000),
+		lap(beta, 2, 2, 91000),
+	})
+	if ranking[0].DriverID != alpha || ranking[0].Number != 1 || ranking[1].DriverID != beta {
+		t.Errorf("got %+v", ranking)
+	}
+}
@@ -0,0 +1,163 @@
+package live
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Feed is a stream of timing updates. Next returns io.EOF once the stream
+// ended and a *LineError for an update it could not read, after which the
+// stream goes on
+type Feed interface {
+	Next() (Update, error)
+	Close() error
+}
+
+// LineError is an update of a feed which could not be read
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+type lineFeed struct {
+	source  io.ReadCloser
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewFeed reads updates as newline delimited JSON, blank lines are skipped
+func NewFeed(source io.ReadCloser) Feed {
+	return &lineFeed{source: source, scanner: bufio.NewScanner(source)}
+}
+
+func (f *lineFeed) Next() (Update, error) {
+	for f.scanner.Scan() {
+		f.line++
+		line := f.scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		update := Update{}
+		if err := json.Unmarshal(line, &update); err != nil {
+			return Update{}, &LineError{Line: f.line, Err: err}
+		}
+		if err := update.validate(); err != nil {
+			return Update{}, &LineError{Line: f.line, Err: err}
+		}
+		return update, nil
+	}
+	if err := f.scanner.Err(); err != nil {
+		return Update{}, err
+	}
+	return Update{}, io.EOF
+}
+
+func (f *lineFeed) Close() error {
+	return f.source.Close()
+}
+
+// OpenFeed opens the feed at the source, a file:// path or a tcp:// or
+// unix:// socket sending newline delimited JSON updates. A local file or
+// socket stands in for the upstream feed in tests
+func OpenFeed(source string) (Feed, error) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "file":
+		file, err := os.Open(u.Path)
+		if err != nil {
+			return nil, err
+		}
+		return NewFeed(file), nil
+	case "tcp":
+		conn, err := net.Dial("tcp", u.Host)
+		if err != nil {
+			return nil, err
+		}
+		return NewFeed(conn), nil
+	case "unix":
+		conn, err := net.Dial("unix", u.Path)
+		if err != nil {
+			return nil, err
+		}
+		return NewFeed(conn), nil
+	}
+	return nil, fmt.Errorf("unsupported feed source %q", source)
+}
+
+// Ingest applies the updates of the feed until it ends or the context is
+// done. Updates which cannot be read are logged and skipped
+func (h *Hub) Ingest(ctx context.Context, feed Feed) error {
+	stop := context.AfterFunc(ctx, func() { feed.Close() })
+	defer stop()
+
+	for {
+		update, err := feed.Next()
+		var lineErr *LineError
+		switch {
+		case errors.As(err, &lineErr):
+			log.Warnf("Skipped timing update: %v", lineErr)
+			continue
+		case err != nil:
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if _, _, err := h.Apply(update); err != nil {
+			log.Warnf("Skipped timing update: %v", err)
+		}
+	}
+}
+
+// Follow ingests the feed at the source until the context is done, opening
+// it again the given time after it ended or failed. A file:// source is
+// ingested once, its end is the end of the feed rather than a dropped
+// connection
+func (h *Hub) Follow(ctx context.Context, source string, retry time.Duration) {
+	if retry <= 0 {
+		retry = DefaultRetry
+	}
+	for {
+		feed, err := OpenFeed(source)
+		if err == nil {
+			err = h.Ingest(ctx, feed)
+			feed.Close()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, io.EOF) && strings.HasPrefix(source, "file://") {
+			log.Infof("Timing feed %s ingested", source)
+			return
+		}
+		log.Warnf("Timing feed %s ended: %v", source, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retry):
+		}
+	}
+}
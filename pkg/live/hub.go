@@ -0,0 +1,226 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// Defaults of the hub options
+const (
+	DefaultBuffer           = 256
+	DefaultSnapshotInterval = 10 * time.Second
+	DefaultRetry            = 5 * time.Second
+)
+
+var ErrSlowConsumer = errors.New("subscriber did not keep up with the timing")
+
+// Store persists snapshots of the timing tower, so that the state of a
+// session outlives the hub
+type Store interface {
+	SaveSnapshot(state State) error
+	// LoadSnapshot returns the last snapshot of the session, false when it
+	// has none
+	LoadSnapshot(sessionID uuid.UUID) (State, bool, error)
+}
+
+type Options struct {
+	// Buffer is the number of deltas a subscriber may fall behind before it
+	// is evicted
+	Buffer int
+	// SnapshotInterval is the time between two snapshots of a session
+	SnapshotInterval time.Duration
+}
+
+// Subscription receives the deltas of a session on C. C is closed when the
+// subscription ends, Err then tells whether the subscriber was evicted
+type Subscription struct {
+	C         <-chan Delta
+	c         chan Delta
+	sessionID uuid.UUID
+	err       error
+}
+
+// Err returns ErrSlowConsumer once C was closed because the subscriber fell
+// too far behind
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+// Hub keeps the timing towers of the sessions and fans their deltas out to
+// the subscribers. Publishing never waits for a subscriber, one whose buffer
+// is full is evicted instead of holding the feed back
+type Hub struct {
+	store   Store
+	options Options
+
+	mu       sync.Mutex
+	sessions map[uuid.UUID]*session
+}
+
+type session struct {
+	tower       *Tower
+	subscribers map[*Subscription]struct{}
+	// saved is the sequence of the last snapshot
+	saved uint64
+}
+
+func NewHub(store Store, options Options) *Hub {
+	if options.Buffer <= 0 {
+		options.Buffer = DefaultBuffer
+	}
+	if options.SnapshotInterval <= 0 {
+		options.SnapshotInterval = DefaultSnapshotInterval
+	}
+	return &Hub{store: store, options: options, sessions: make(map[uuid.UUID]*session)}
+}
+
+// lockSession locks the hub and returns the session, adding it when the hub
+// has none yet. A new session continues from its last snapshot, so that the
+// tower and its sequence carry on after a restart. The snapshot is loaded
+// without the lock, the lock is only held on return when there is no error
+func (h *Hub) lockSession(sessionID uuid.UUID) (*session, error) {
+	h.mu.Lock()
+	if s, ok := h.sessions[sessionID]; ok {
+		return s, nil
+	}
+	h.mu.Unlock()
+
+	s := &session{tower: NewTower(sessionID), subscribers: make(map[*Subscription]struct{})}
+	state, ok, err := h.store.LoadSnapshot(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		s.tower = Restore(state)
+		s.saved = state.Sequence
+	}
+
+	h.mu.Lock()
+	// another caller may have added the session while the snapshot loaded
+	if existing, ok := h.sessions[sessionID]; ok {
+		return existing, nil
+	}
+	h.sessions[sessionID] = s
+	return s, nil
+}
+
+// Apply applies the update to the tower of its session and publishes the
+// delta to the subscribers
+func (h *Hub) Apply(update Update) (Delta, bool, error) {
+	if err := update.validate(); err != nil {
+		return Delta{}, false, err
+	}
+
+	s, err := h.lockSession(update.SessionID)
+	if err != nil {
+		return Delta{}, false, err
+	}
+	defer h.mu.Unlock()
+	delta, changed := s.tower.Apply(update)
+	if !changed {
+		return Delta{}, false, nil
+	}
+	for subscription := range s.subscribers {
+		select {
+		case subscription.c <- delta:
+		default:
+			subscription.err = ErrSlowConsumer
+			h.remove(s, subscription)
+		}
+	}
+	return delta, true, nil
+}
+
+// State returns the timing tower of the session, false when neither the hub
+// nor the store has timing of it
+func (h *Hub) State(sessionID uuid.UUID) (State, bool, error) {
+	s, err := h.lockSession(sessionID)
+	if err != nil {
+		return State{}, false, err
+	}
+	defer h.mu.Unlock()
+	if s.tower.sequence == 0 {
+		return State{}, false, nil
+	}
+	return s.tower.State(), true, nil
+}
+
+// Subscribe returns the timing tower of the session together with a
+// subscription to the deltas following it, none is missed or repeated
+func (h *Hub) Subscribe(sessionID uuid.UUID) (State, *Subscription, error) {
+	s, err := h.lockSession(sessionID)
+	if err != nil {
+		return State{}, nil, err
+	}
+	defer h.mu.Unlock()
+	c := make(chan Delta, h.options.Buffer)
+	subscription := &Subscription{C: c, c: c, sessionID: sessionID}
+	s.subscribers[subscription] = struct{}{}
+	return s.tower.State(), subscription, nil
+}
+
+// Unsubscribe ends the subscription, it may have been evicted already
+func (h *Hub) Unsubscribe(subscription *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if s, ok := h.sessions[subscription.sessionID]; ok {
+		h.remove(s, subscription)
+	}
+}
+
+// remove closes the subscription once, the lock must be held
+func (h *Hub) remove(s *session, subscription *Subscription) {
+	if _, ok := s.subscribers[subscription]; !ok {
+		return
+	}
+	delete(s.subscribers, subscription)
+	close(subscription.c)
+}
+
+// Snapshot saves the towers changed since their last snapshot
+func (h *Hub) Snapshot() error {
+	h.mu.Lock()
+	states := []State{}
+	for _, s := range h.sessions {
+		if s.tower.sequence > s.saved {
+			states = append(states, s.tower.State())
+		}
+	}
+	h.mu.Unlock()
+
+	for _, state := range states {
+		if err := h.store.SaveSnapshot(state); err != nil {
+			return err
+		}
+		h.mu.Lock()
+		s := h.sessions[state.SessionID]
+		s.saved = max(s.saved, state.Sequence)
+		h.mu.Unlock()
+	}
+	return nil
+}
+
+// Persist saves snapshots at the snapshot interval until the context is
+// done, taking a last one then
+func (h *Hub) Persist(ctx context.Context) {
+	ticker := time.NewTicker(h.options.SnapshotInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := h.Snapshot(); err != nil {
+				log.Errorf("Failed to save timing snapshot: %v", err)
+			}
+			return
+		case <-ticker.C:
+			if err := h.Snapshot(); err != nil {
+				log.Errorf("Failed to save timing snapshot: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+var (
+	sessionID  = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	verstappen = uuid.MustParse("00000000-0000-0000-0000-000000000010")
+	norris     = uuid.MustParse("00000000-0000-0000-0000-000000000011")
+	start      = time.Date(2024, 7, 7, 14, 0, 0, 0, time.UTC)
+)
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func lapTime(value string) *m.LapTime {
+	t, err := m.ParseLapTime(value)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
+
+func update(driver uuid.UUID, seconds int) Update {
+	return Update{SessionID: sessionID, DriverID: driver, Time: start.Add(time.Duration(seconds) * time.Second)}
+}
+
+func TestTowerApply(t *testing.T) {
+	tower := NewTower(sessionID)
+
+	first := update(verstappen, 1)
+	first.Position = ptr(1)
+	first.LastLap = lapTime("1:30.000")
+	delta, changed := tower.Apply(first)
+	if !changed || delta.Sequence != 1 || *delta.Position != 1 || *delta.BestLap != *lapTime("1:30.000") {
+		t.Fatalf("first update gave %+v, %v", delta, changed)
+	}
+
+	slower := update(verstappen, 2)
+	slower.Position = ptr(1)
+	slower.LastLap = lapTime("1:31.000")
+	delta, changed = tower.Apply(slower)
+	if !changed || delta.Position != nil || delta.BestLap != nil || *delta.LastLap != *lapTime("1:31.000") {
+		t.Errorf("slower lap gave %+v, want only the last lap", delta)
+	}
+
+	if _, changed := tower.Apply(slower); changed {
+		t.Error("repeated update changed the tower")
+	}
+
+	faster := update(verstappen, 3)
+	faster.LastLap = lapTime("1:29.500")
+	delta, _ = tower.Apply(faster)
+	if delta.Sequence != 3 || *delta.BestLap != *lapTime("1:29.500") {
+		t.Errorf("faster lap gave %+v, want best lap at sequence 3", delta)
+	}
+
+	if _, changed := tower.Apply(first); changed {
+		t.Error("update older than the last one of the driver changed the tower")
+	}
+}
+
+func TestTowerState(t *testing.T) {
+	tower := NewTower(sessionID)
+	second := update(verstappen, 1)
+	second.Position = ptr(2)
+	first := update(norris, 1)
+	first.Position = ptr(1)
+	unknown := update(uuid.MustParse("00000000-0000-0000-0000-000000000012"), 1)
+	unknown.Compound = ptr("SOFT")
+	tower.Apply(second)
+	tower.Apply(unknown)
+	tower.Apply(first)
+
+	state := tower.State()
+	order := []uuid.UUID{norris, verstappen, unknown.DriverID}
+	if len(state.Entries) != len(order) || state.Sequence != 3 {
+		t.Fatalf("state has %d entries at sequence %d", len(state.Entries), state.Sequence)
+	}
+	for i, entry := range state.Entries {
+		if entry.DriverID != order[i] {
+			t.Errorf("entry %d is %s, want %s", i, entry.DriverID, order[i])
+		}
+	}
+
+	restored := Restore(state)
+	next := update(norris, 2)
+	next.Lap = ptr(2)
+	if delta, _ := restored.Apply(next); delta.Sequence != 4 {
+		t.Errorf("restored tower continued at sequence %d, want 4", delta.Sequence)
+	}
+}
+
+type memoryStore struct {
+	states []State
+}
+
+func (s *memoryStore) SaveSnapshot(state State) error {
+	s.states = append(s.states, state)
+	return nil
+}
+
+func (s *memoryStore) LoadSnapshot(sessionID uuid.UUID) (State, bool, error) {
+	for i := len(s.states) - 1; i >= 0; i-- {
+		if s.states[i].SessionID == sessionID {
+			return s.states[i], true, nil
+		}
+	}
+	return State{}, false, nil
+}
+
+func TestHubSubscribe(t *testing.T) {
+	hub := NewHub(&memoryStore{}, Options{})
+	lap := update(verstappen, 1)
+	lap.Lap = ptr(1)
+	hub.Apply(lap)
+
+	state, subscription, _ := hub.Subscribe(sessionID)
+	defer hub.Unsubscribe(subscription)
+	if state.Sequence != 1 || len(state.Entries) != 1 {
+		t.Fatalf("subscribed at %+v, want the first update", state)
+	}
+
+	lap = update(verstappen, 2)
+	lap.Lap = ptr(2)
+	hub.Apply(lap)
+	delta := <-subscription.C
+	if delta.Sequence != state.Sequence+1 || *delta.Lap != 2 {
+		t.Errorf("received %+v, want lap 2 following the state", delta)
+	}
+
+	if _, _, err := hub.Apply(Update{SessionID: sessionID}); !errors.Is(err, ErrInvalidUpdate) {
+		t.Errorf("applying an update without a driver gave %v", err)
+	}
+}
+
+func TestHubEvictsSlowConsumer(t *testing.T) {
+	hub := NewHub(&memoryStore{}, Options{Buffer: 2})
+	_, slow, _ := hub.Subscribe(sessionID)
+	_, fast, _ := hub.Subscribe(sessionID)
+	defer hub.Unsubscribe(fast)
+
+	received := 0
+	for i := 1; i <= 3; i++ {
+		lap := update(verstappen, i)
+		lap.Lap = ptr(i)
+		hub.Apply(lap)
+		<-fast.C
+		received++
+	}
+
+	deltas := 0
+	for range slow.C {
+		deltas++
+	}
+	if deltas != 2 || !errors.Is(slow.Err(), ErrSlowConsumer) {
+		t.Errorf("slow subscriber got %d deltas and %v, want 2 and eviction", deltas, slow.Err())
+	}
+	if received != 3 || fast.Err() != nil {
+		t.Errorf("fast subscriber got %d deltas and %v", received, fast.Err())
+	}
+	hub.Unsubscribe(slow)
+}
+
+func TestHubSnapshot(t *testing.T) {
+	store := &memoryStore{}
+	hub := NewHub(store, Options{})
+	lap := update(verstappen, 1)
+	lap.Lap = ptr(1)
+	hub.Apply(lap)
+
+	hub.Snapshot()
+	hub.Snapshot()
+	if len(store.states) != 1 || store.states[0].Sequence != 1 {
+		t.Fatalf("saved %+v, want one snapshot", store.states)
+	}
+
+	// a restarted hub applying the feed before the session is read carries
+	// on with the stored tower
+	resumed := NewHub(store, Options{})
+	resumed.Snapshot()
+	lap = update(norris, 2)
+	lap.Lap = ptr(1)
+	if delta, _, _ := resumed.Apply(lap); delta.Sequence != 2 {
+		t.Errorf("resumed hub continued at sequence %d, want 2", delta.Sequence)
+	}
+	if len(store.states) != 1 {
+		t.Errorf("resumed hub saved the snapshot it resumed from again")
+	}
+	state, ok, err := resumed.State(sessionID)
+	if err != nil || !ok || len(state.Entries) != 2 {
+		t.Errorf("resumed hub holds %+v, want both drivers", state)
+	}
+}
+
+const feedLines = `{"session_id":"00000000-0000-0000-0000-000000000001","driver_id":"00000000-0000-0000-0000-000000000010","time":"2024-07-07T14:00:01Z","position":1,"last_lap":"1:30.000"}
+not json
+
+{"session_id":"00000000-0000-0000-0000-000000000001","driver_id":"00000000-0000-0000-0000-000000000011","time":"2024-07-07T14:00:02Z","position":2,"gap":"1.200","compound":"MEDIUM"}
+`
+
+func checkIngested(t *testing.T, hub *Hub) {
+	t.Helper()
+	state, ok, _ := hub.State(sessionID)
+	if !ok || len(state.Entries) != 2 {
+		t.Fatalf("ingested %+v, want two drivers", state)
+	}
+	if entry := state.Entries[1]; entry.DriverID != norris || *entry.Gap != *lapTime("1.2") || entry.Compound != "MEDIUM" {
+		t.Errorf("second entry is %+v", entry)
+	}
+}
+
+func TestFeedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feed.ndjson")
+	if err := os.WriteFile(path, []byte(feedLines), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	feed, err := OpenFeed("file://" + path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := feed.Next(); err != nil {
+		t.Fatalf("first line gave %v", err)
+	}
+	var lineErr *LineError
+	if _, err := feed.Next(); !errors.As(err, &lineErr) || lineErr.Line != 2 {
+		t.Errorf("malformed line gave %v, want a line error on line 2", err)
+	}
+	feed.Close()
+
+	feed, _ = OpenFeed("file://" + path)
+	hub := NewHub(&memoryStore{}, Options{})
+	if err := hub.Ingest(context.Background(), feed); !errors.Is(err, io.EOF) {
+		t.Errorf("ingest ended with %v, want the end of the feed", err)
+	}
+	checkIngested(t, hub)
+}
+
+func TestFollowFileTwice(t *testing.T) {
+	// the leader drops behind, a replay must not hand the lead back
+	lines := feedLines + `{"session_id":"00000000-0000-0000-0000-000000000001","driver_id":"00000000-0000-0000-0000-000000000010","time":"2024-07-07T14:00:03Z","position":3}
+`
+	path := filepath.Join(t.TempDir(), "feed.ndjson")
+	if err := os.WriteFile(path, []byte(lines), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	hub := NewHub(&memoryStore{}, Options{})
+
+	// following a file returns at its end instead of opening it again
+	hub.Follow(ctx, "file://"+path, time.Millisecond)
+	if ctx.Err() != nil {
+		t.Fatal("following the file did not end with the file")
+	}
+	first, _, _ := hub.State(sessionID)
+	if len(first.Entries) != 2 || first.Entries[1].DriverID != verstappen || first.Entries[1].Position != 3 {
+		t.Fatalf("ingested %+v, want the leader in third", first)
+	}
+
+	hub.Follow(ctx, "file://"+path, time.Millisecond)
+	second, _, _ := hub.State(sessionID)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("feeding the file again changed the tower from %+v to %+v", first, second)
+	}
+}
+
+func TestFeedSocket(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on a local socket: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.Copy(conn, strings.NewReader(feedLines))
+	}()
+
+	feed, err := OpenFeed("tcp://" + listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer feed.Close()
+	hub := NewHub(&memoryStore{}, Options{})
+	hub.Ingest(context.Background(), feed)
+	checkIngested(t, hub)
+
+	if _, err := OpenFeed("http://example.com"); err == nil {
+		t.Error("opened a feed of an unsupported source")
+	}
+}
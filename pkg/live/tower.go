@@ -0,0 +1,186 @@
+// Package live keeps the timing tower of the sessions on track from the
+// updates of an upstream timing feed and fans its changes out to subscribers.
+package live
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidUpdate = errors.New("update needs a session, a driver and a time")
+
+// Update is a partial update of the timing of a driver as sent by the feed,
+// fields left out keep their value
+type Update struct {
+	SessionID uuid.UUID  `json:"session_id"`
+	DriverID  uuid.UUID  `json:"driver_id"`
+	Time      time.Time  `json:"time"`
+	Position  *int       `json:"position,omitempty"`
+	Lap       *int       `json:"lap,omitempty"`
+	Gap       *m.LapTime `json:"gap,omitempty"`
+	Interval  *m.LapTime `json:"interval,omitempty"`
+	LastLap   *m.LapTime `json:"last_lap,omitempty"`
+	Compound  *string    `json:"compound,omitempty"`
+	TyreAge   *int       `json:"tyre_age,omitempty"`
+	InPit     *bool      `json:"in_pit,omitempty"`
+}
+
+func (u *Update) validate() error {
+	if u.SessionID == uuid.Nil || u.DriverID == uuid.Nil || u.Time.IsZero() {
+		return ErrInvalidUpdate
+	}
+	if u.Position != nil && *u.Position < 1 {
+		return errors.New("position must be at least 1")
+	}
+	return nil
+}
+
+// Entry is the line of a driver on the timing tower, Updated is the time of
+// the last update applied to it
+type Entry struct {
+	DriverID uuid.UUID  `json:"driver_id"`
+	Updated  time.Time  `json:"updated"`
+	Position int        `json:"position"`
+	Lap      int        `json:"lap"`
+	Gap      *m.LapTime `json:"gap"`
+	Interval *m.LapTime `json:"interval"`
+	LastLap  *m.LapTime `json:"last_lap"`
+	BestLap  *m.LapTime `json:"best_lap"`
+	Compound string     `json:"compound"`
+	TyreAge  int        `json:"tyre_age"`
+	InPit    bool       `json:"in_pit"`
+}
+
+// State is the full timing tower of a session, entries in running order.
+// Sequence is the sequence of the last delta applied to it
+type State struct {
+	SessionID uuid.UUID `json:"session_id"`
+	Sequence  uint64    `json:"sequence"`
+	Updated   time.Time `json:"updated"`
+	Entries   []Entry   `json:"entries"`
+}
+
+// Delta holds the fields of the line of a driver changed by an update, its
+// sequence numbers the deltas of the session from 1 without gaps
+type Delta struct {
+	SessionID uuid.UUID  `json:"session_id"`
+	Sequence  uint64     `json:"sequence"`
+	Time      time.Time  `json:"time"`
+	DriverID  uuid.UUID  `json:"driver_id"`
+	Position  *int       `json:"position,omitempty"`
+	Lap       *int       `json:"lap,omitempty"`
+	Gap       *m.LapTime `json:"gap,omitempty"`
+	Interval  *m.LapTime `json:"interval,omitempty"`
+	LastLap   *m.LapTime `json:"last_lap,omitempty"`
+	BestLap   *m.LapTime `json:"best_lap,omitempty"`
+	Compound  *string    `json:"compound,omitempty"`
+	TyreAge   *int       `json:"tyre_age,omitempty"`
+	InPit     *bool      `json:"in_pit,omitempty"`
+}
+
+// Tower is the timing tower of a session
+type Tower struct {
+	sessionID uuid.UUID
+	sequence  uint64
+	updated   time.Time
+	entries   map[uuid.UUID]*Entry
+}
+
+func NewTower(sessionID uuid.UUID) *Tower {
+	return &Tower{sessionID: sessionID, entries: make(map[uuid.UUID]*Entry)}
+}
+
+// Restore continues the tower from a state, so that its sequence carries on
+func Restore(state State) *Tower {
+	t := NewTower(state.SessionID)
+	t.sequence = state.Sequence
+	t.updated = state.Updated
+	for _, entry := range state.Entries {
+		t.entries[entry.DriverID] = &entry
+	}
+	return t
+}
+
+// Apply merges the update into the line of the driver and returns what it
+// changed, false when it changed nothing. An update older than the last one
+// of the driver is dropped, so that a feed replayed from its start does not
+// roll the tower back. The best lap follows the last lap
+func (t *Tower) Apply(update Update) (Delta, bool) {
+	entry, ok := t.entries[update.DriverID]
+	if ok && update.Time.Before(entry.Updated) {
+		return Delta{}, false
+	}
+	if !ok {
+		entry = &Entry{DriverID: update.DriverID}
+		t.entries[update.DriverID] = entry
+	}
+
+	delta := Delta{SessionID: t.sessionID, Time: update.Time, DriverID: update.DriverID}
+	changed := !ok
+	changed = set(&entry.Position, update.Position, &delta.Position) || changed
+	changed = set(&entry.Lap, update.Lap, &delta.Lap) || changed
+	changed = setOptional(&entry.Gap, update.Gap, &delta.Gap) || changed
+	changed = setOptional(&entry.Interval, update.Interval, &delta.Interval) || changed
+	changed = setOptional(&entry.LastLap, update.LastLap, &delta.LastLap) || changed
+	if update.LastLap != nil && (entry.BestLap == nil || *update.LastLap < *entry.BestLap) {
+		changed = setOptional(&entry.BestLap, update.LastLap, &delta.BestLap) || changed
+	}
+	changed = set(&entry.Compound, update.Compound, &delta.Compound) || changed
+	changed = set(&entry.TyreAge, update.TyreAge, &delta.TyreAge) || changed
+	changed = set(&entry.InPit, update.InPit, &delta.InPit) || changed
+	if !changed {
+		return Delta{}, false
+	}
+
+	entry.Updated = update.Time
+	t.sequence++
+	if update.Time.After(t.updated) {
+		t.updated = update.Time
+	}
+	delta.Sequence = t.sequence
+	return delta, true
+}
+
+// set copies the value of an update into the field, noting it in the delta
+// when it differs
+func set[T comparable](field *T, value *T, delta **T) bool {
+	if value == nil || *field == *value {
+		return false
+	}
+	*field = *value
+	*delta = value
+	return true
+}
+
+func setOptional[T comparable](field **T, value *T, delta **T) bool {
+	if value == nil || (*field != nil && **field == *value) {
+		return false
+	}
+	copied := *value
+	*field = &copied
+	*delta = value
+	return true
+}
+
+// State returns a copy of the tower, drivers without a position last
+func (t *Tower) State() State {
+	state := State{SessionID: t.sessionID, Sequence: t.sequence, Updated: t.updated, Entries: []Entry{}}
+	for _, entry := range t.entries {
+		state.Entries = append(state.Entries, *entry)
+	}
+	sort.Slice(state.Entries, func(i, j int) bool {
+		a, b := state.Entries[i], state.Entries[j]
+		if (a.Position == 0) != (b.Position == 0) {
+			return b.Position == 0
+		}
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.DriverID.String() < b.DriverID.String()
+	})
+	return state
+}
@@ -11,9 +11,36 @@ func Migrate(DB *gorm.DB) error {
 		&models.Address{},
 		&models.Permission{},
 		&models.PermissionGroup{},
+		&models.Driver{},
+		&models.DriverSeason{},
+		&models.Constructor{},
+		&models.SeasonEntry{},
+		&models.LineUp{},
+		&models.Circuit{},
+		&models.CircuitLayout{},
+		&models.Season{},
+		&models.Race{},
+		&models.Session{},
+		&models.Result{},
+		&models.QualifyingResult{},
+		&models.GridPenalty{},
+		&models.Simulation{},
+		&models.Lap{},
+		&models.PitStop{},
+		&models.Stint{},
+		&models.RaceControlEvent{},
+		&models.StewardDecision{},
+		&models.ResultVersion{},
+		&models.ImportReview{},
+		&models.TimingSnapshot{},
+		&models.WeatherObservation{},
 	); err != nil {
 		return err
 	}
 
+	if err := DB.Exec(telemetryTable).Error; err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,20 @@
+package migrations
+
+// telemetryTable creates the parent of the telemetry partitions, the
+// partition of a session is created when its first samples are stored and
+// dropped with them. The primary key includes the session as the partition
+// key and serves the queries by driver and time
+const telemetryTable = `CREATE TABLE IF NOT EXISTS telemetry_samples (
+	session_id uuid NOT NULL REFERENCES sessions (id) ON DELETE CASCADE,
+	driver_id uuid NOT NULL REFERENCES drivers (id) ON DELETE CASCADE,
+	time timestamptz NOT NULL,
+	lap smallint NOT NULL,
+	distance real,
+	speed smallint NOT NULL,
+	rpm integer NOT NULL,
+	gear smallint NOT NULL,
+	throttle smallint NOT NULL,
+	brake boolean NOT NULL,
+	drs boolean NOT NULL,
+	PRIMARY KEY (session_id, driver_id, time)
+) PARTITION BY LIST (session_id)`
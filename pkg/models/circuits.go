@@ -0,0 +1,36 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	DirectionClockwise     = "clockwise"
+	DirectionAnticlockwise = "anticlockwise"
+)
+
+type Circuit struct {
+	Model
+	Ref       string          `gorm:"unique;not null;type:varchar(255)" json:"ref"`
+	ErgastID  *int            `gorm:"unique" json:"ergast_id"`
+	Name      string          `gorm:"not null;type:varchar(255)" json:"name"`
+	Location  string          `gorm:"type:varchar(255)" json:"location"`
+	Country   string          `gorm:"index;type:varchar(2)" json:"country"`
+	Latitude  float64         `gorm:"index" json:"latitude"`
+	Longitude float64         `gorm:"index" json:"longitude"`
+	URL       string          `json:"url"`
+	Layouts   []CircuitLayout `gorm:"constraint:OnDelete:CASCADE" json:"layouts"`
+} //@name Circuit
+
+// CircuitLayout is one historical configuration of the circuit, layouts are
+// numbered with consecutive versions starting from 1
+type CircuitLayout struct {
+	Model
+	CircuitID    uuid.UUID `gorm:"not null;index:,unique,composite:idx_circuit_version" json:"circuit_id"`
+	Version      int       `gorm:"not null;index:,unique,composite:idx_circuit_version" json:"version"`
+	Name         string    `gorm:"type:varchar(255)" json:"name"`
+	FirstYear    *int      `json:"first_year"`
+	LastYear     *int      `json:"last_year"`
+	LengthMeters int       `json:"length_meters"`
+	Turns        int       `json:"turns"`
+	Direction    string    `gorm:"type:varchar(16)" json:"direction"`
+	Geometry     GeoJSON   `gorm:"type:jsonb" json:"geometry"`
+} //@name CircuitLayout
@@ -0,0 +1,46 @@
+package models
+
+import "github.com/google/uuid"
+
+type Constructor struct {
+	Model
+	Ref         string `gorm:"unique;not null;type:varchar(255)" json:"ref"`
+	ErgastID    *int   `gorm:"unique" json:"ergast_id"`
+	Name        string `gorm:"not null;type:varchar(255)" json:"name"`
+	Nationality string `gorm:"index;type:varchar(2)" json:"nationality"`
+	URL         string `json:"url"`
+} //@name Constructor
+
+// SeasonEntry is the constructor's entry to the championship for a single year
+type SeasonEntry struct {
+	Model
+	ConstructorID uuid.UUID   `gorm:"not null;index:,unique,composite:idx_constructor_year" json:"constructor_id"`
+	Constructor   Constructor `json:"-"`
+	Year          int         `gorm:"not null;index:,unique,composite:idx_constructor_year" json:"year"`
+	EntrantName   string      `gorm:"type:varchar(255)" json:"entrant_name"`
+	ChassisName   string      `gorm:"type:varchar(255)" json:"chassis_name"`
+	PowerUnit     string      `gorm:"type:varchar(255)" json:"power_unit"`
+	LineUps       []LineUp    `gorm:"constraint:OnDelete:CASCADE" json:"line_ups"`
+} //@name SeasonEntry
+
+// LineUp links a driver to a season entry for the rounds between StartRound
+// and EndRound inclusive, a nil EndRound means until the end of the season
+type LineUp struct {
+	Model
+	SeasonEntryID uuid.UUID `gorm:"not null;index" json:"season_entry_id"`
+	DriverID      uuid.UUID `gorm:"not null;index" json:"driver_id"`
+	Driver        Driver    `json:"driver"`
+	CarNumber     int       `json:"car_number"`
+	StartRound    int       `gorm:"not null" json:"start_round"`
+	EndRound      *int      `json:"end_round"`
+} //@name LineUp
+
+// CoversRound reports whether the driver was part of the line-up in the given round
+func (l *LineUp) CoversRound(round int) bool {
+	return round >= l.StartRound && (l.EndRound == nil || round <= *l.EndRound)
+}
+
+// Overlaps reports whether the two line-ups share at least one round
+func (l *LineUp) Overlaps(other LineUp) bool {
+	return l.CoversRound(other.StartRound) || other.CoversRound(l.StartRound)
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Driver struct {
+	Model
+	Ref             string    `gorm:"unique;not null;type:varchar(255)" json:"ref"`
+	ErgastID        *int      `gorm:"unique" json:"ergast_id"`
+	PermanentNumber *int      `json:"permanent_number"`
+	Code            string    `gorm:"type:varchar(3)" json:"code"`
+	FirstName       string    `gorm:"not null;type:varchar(255)" json:"first_name"`
+	LastName        string    `gorm:"not null;type:varchar(255)" json:"last_name"`
+	DateOfBirth     time.Time `gorm:"type:date" json:"date_of_birth"`
+	Nationality     string    `gorm:"index;type:varchar(2)" json:"nationality"`
+	URL             string    `json:"url"`
+	// Seasons are the championship seasons the driver took part in
+	Seasons []DriverSeason `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+} //@name Driver
+
+type DriverSeason struct {
+	DriverID uuid.UUID `gorm:"primaryKey" json:"driver_id"`
+	Year     int       `gorm:"primaryKey;autoIncrement:false" json:"year"`
+} //@name DriverSeason
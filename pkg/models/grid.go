@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	GridPenaltyDrop       = "grid_drop"
+	GridPenaltyBackOfGrid = "back_of_grid"
+	GridPenaltyPitLane    = "pit_lane"
+)
+
+const (
+	ComponentPowerUnit = "power_unit"
+	ComponentGearbox   = "gearbox"
+	ComponentOther     = "other"
+)
+
+// MaxPowerUnitPlaces is the largest power unit grid drop served as places,
+// drivers collecting more start from the back of the grid
+const MaxPowerUnitPlaces = 15
+
+type GridPenalty struct {
+	Model
+	RaceID      uuid.UUID `gorm:"not null;index" json:"race_id"`
+	Race        Race      `json:"-"`
+	DriverID    uuid.UUID `gorm:"not null;index" json:"driver_id"`
+	Driver      Driver    `json:"driver"`
+	Type        string    `gorm:"not null;type:varchar(16)" json:"type"`
+	Places      int       `json:"places"`
+	Component   string    `gorm:"not null;type:varchar(16)" json:"component"`
+	Reason      string    `json:"reason"`
+	InfringedAt time.Time `gorm:"not null" json:"infringed_at"`
+} //@name GridPenalty
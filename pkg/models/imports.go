@@ -0,0 +1,17 @@
+package models
+
+// ImportReview is a record of an imported dataset which could not be mapped
+// to the models, kept for an administrator to review instead of failing the
+// whole import
+type ImportReview struct {
+	Model
+	Source string `gorm:"not null;type:varchar(32);index" json:"source"`
+	Kind   string `gorm:"not null;type:varchar(32)" json:"kind"`
+	// Origin names the file and the index of the record in it
+	Origin string `gorm:"type:varchar(255)" json:"origin"`
+	Reason string `gorm:"not null" json:"reason"`
+	// Digest identifies the record, so that importing it again does not
+	// queue it twice
+	Digest string `gorm:"not null;unique;type:varchar(64)" json:"-"`
+	Record JSON   `gorm:"type:jsonb;not null" json:"record"`
+} //@name ImportReview
@@ -0,0 +1,16 @@
+package models
+
+import "github.com/google/uuid"
+
+// Lap is a driver's lap of a race with their position at the end of it, the
+// table is keyed by race, driver and lap number to stay compact over full
+// historical seasons
+type Lap struct {
+	RaceID   uuid.UUID `gorm:"primaryKey" json:"race_id"`
+	Race     Race      `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	DriverID uuid.UUID `gorm:"primaryKey;index" json:"driver_id"`
+	Driver   Driver    `json:"-"`
+	Number   int       `gorm:"primaryKey;column:lap" json:"lap"`
+	Position int       `gorm:"not null" json:"position"`
+	Time     LapTime   `gorm:"not null" json:"time"`
+} //@name Lap
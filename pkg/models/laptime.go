@@ -0,0 +1,150 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LapTime is a lap or gap duration with millisecond precision, written as
+// "1:23.456" in JSON and query parameters and stored as milliseconds
+type LapTime time.Duration
+
+var ErrInvalidLapTime = errors.New(`lap time must be formatted as "1:23.456"`)
+
+func LapTimeFromMillis(millis int64) LapTime {
+	return LapTime(time.Duration(millis) * time.Millisecond)
+}
+
+// ParseLapTime parses "1:23.456", "83.456" and "1:02:03.456", the fraction
+// may have up to three digits
+func ParseLapTime(value string) (LapTime, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) > 3 {
+		return 0, ErrInvalidLapTime
+	}
+
+	last := len(parts) - 1
+	seconds, fraction, _ := strings.Cut(parts[last], ".")
+	parts[last] = seconds
+	if len(fraction) > 3 {
+		return 0, ErrInvalidLapTime
+	}
+
+	total := int64(0)
+	for i, part := range parts {
+		field, ok := parseDigits(part)
+		if !ok {
+			return 0, ErrInvalidLapTime
+		}
+		// every field after the leading one is a two digit sexagesimal field
+		if i > 0 && (len(part) != 2 || field >= 60) {
+			return 0, ErrInvalidLapTime
+		}
+		total = total*60 + field
+	}
+
+	millis := int64(0)
+	if fraction != "" {
+		f, ok := parseDigits(fraction + strings.Repeat("0", 3-len(fraction)))
+		if !ok {
+			return 0, ErrInvalidLapTime
+		}
+		millis = f
+	}
+
+	return LapTimeFromMillis(total*1000 + millis), nil
+}
+
+func parseDigits(field string) (int64, bool) {
+	if field == "" || strings.TrimLeft(field, "0123456789") != "" {
+		return 0, false
+	}
+	value, err := strconv.ParseInt(field, 10, 64)
+	return value, err == nil
+}
+
+func (t LapTime) Duration() time.Duration {
+	return time.Duration(t)
+}
+
+func (t LapTime) Milliseconds() int64 {
+	return time.Duration(t).Milliseconds()
+}
+
+func (t LapTime) String() string {
+	millis := t.Milliseconds()
+	sign := ""
+	if millis < 0 {
+		sign = "-"
+		millis = -millis
+	}
+
+	hours := millis / 3600000
+	minutes := millis / 60000 % 60
+	seconds := millis / 1000 % 60
+	millis %= 1000
+
+	switch {
+	case hours > 0:
+		return fmt.Sprintf("%s%d:%02d:%02d.%03d", sign, hours, minutes, seconds, millis)
+	case minutes > 0:
+		return fmt.Sprintf("%s%d:%02d.%03d", sign, minutes, seconds, millis)
+	default:
+		return fmt.Sprintf("%s%d.%03d", sign, seconds, millis)
+	}
+}
+
+func (t LapTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *LapTime) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return ErrInvalidLapTime
+	}
+	parsed, err := ParseLapTime(value)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+func (t LapTime) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *LapTime) UnmarshalText(data []byte) error {
+	parsed, err := ParseLapTime(string(data))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+func (t LapTime) Value() (driver.Value, error) {
+	return t.Milliseconds(), nil
+}
+
+func (t *LapTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case int64:
+		*t = LapTimeFromMillis(v)
+	case int32:
+		*t = LapTimeFromMillis(int64(v))
+	default:
+		return errors.New("unsupported type for LapTime")
+	}
+	return nil
+}
+
+func (LapTime) GormDataType() string {
+	return "bigint"
+}
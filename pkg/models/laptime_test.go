@@ -0,0 +1,153 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseLapTime(t *testing.T) {
+	tests := []struct {
+		value  string
+		millis int64
+	}{
+		{"83.456", 83456},
+		{"83", 83000},
+		{"0.5", 500},
+		{"1:23.456", 83456},
+		{"1:23.4", 83400},
+		{"1:23", 83000},
+		{"12:00.001", 720001},
+		{"1:02:03.456", 3723456},
+		{" 1:23.456 ", 83456},
+	}
+	for _, tt := range tests {
+		got, err := ParseLapTime(tt.value)
+		if err != nil {
+			t.Errorf("%q: %v", tt.value, err)
+			continue
+		}
+		if got.Milliseconds() != tt.millis {
+			t.Errorf("%q parsed as %d ms, want %d", tt.value, got.Milliseconds(), tt.millis)
+		}
+	}
+}
+
+func TestParseLapTimeInvalid(t *testing.T) {
+	for _, value := range []string{
+		"",
+		" ",
+		"1:60.000",
+		"1:2.3.4",
+		"1:2.345",
+		"-1:23.456",
+		"-83.456",
+		"1:23.4567",
+		"1:-3.456",
+		":23.456",
+		"1::23",
+		"1:02:03:04.000",
+		"1:02:60.000",
+		"a:23.456",
+		"1:23.45a",
+	} {
+		if got, err := ParseLapTime(value); err != ErrInvalidLapTime {
+			t.Errorf("%q parsed as %v, %v", value, got, err)
+		}
+	}
+}
+
+func TestLapTimeString(t *testing.T) {
+	tests := []struct {
+		millis int64
+		value  string
+	}{
+		{0, "0.000"},
+		{83456, "1:23.456"},
+		{59999, "59.999"},
+		{3723456, "1:02:03.456"},
+		{-1250, "-1.250"},
+	}
+	for _, tt := range tests {
+		if got := LapTimeFromMillis(tt.millis).String(); got != tt.value {
+			t.Errorf("%d ms formatted as %q, want %q", tt.millis, got, tt.value)
+		}
+	}
+}
+
+func TestLapTimeJSON(t *testing.T) {
+	for _, millis := range []int64{0, 500, 83456, 720001, 3723456} {
+		data, err := json.Marshal(LapTimeFromMillis(millis))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got LapTime
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Errorf("%s: %v", data, err)
+			continue
+		}
+		if got.Milliseconds() != millis {
+			t.Errorf("%d ms round trips through %s as %d", millis, data, got.Milliseconds())
+		}
+	}
+
+	var value struct {
+		Time *LapTime `json:"time"`
+	}
+	if err := json.Unmarshal([]byte(`{"time": "1:23.456"}`), &value); err != nil || value.Time.Milliseconds() != 83456 {
+		t.Errorf("unmarshalled %v, %v", value.Time, err)
+	}
+	for _, data := range []string{`83.456`, `"1:60.000"`, `""`, `{}`} {
+		var got LapTime
+		if err := json.Unmarshal([]byte(data), &got); err != ErrInvalidLapTime {
+			t.Errorf("%s unmarshalled as %v, %v", data, got, err)
+		}
+	}
+}
+
+func TestLapTimeText(t *testing.T) {
+	for _, millis := range []int64{0, 83456, 3723456} {
+		text, err := LapTimeFromMillis(millis).MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got LapTime
+		if err := got.UnmarshalText(text); err != nil || got.Milliseconds() != millis {
+			t.Errorf("%d ms round trips through %q as %v, %v", millis, text, got, err)
+		}
+	}
+	var got LapTime
+	if err := got.UnmarshalText([]byte("1:2.3.4")); err != ErrInvalidLapTime {
+		t.Errorf("unmarshalled malformed text: %v", err)
+	}
+}
+
+func TestLapTimeScan(t *testing.T) {
+	tests := []struct {
+		value  interface{}
+		millis int64
+		ok     bool
+	}{
+		{int64(83456), 83456, true},
+		{int32(83456), 83456, true},
+		{int64(0), 0, true},
+		{"1:23.456", 0, false},
+		{float64(83456), 0, false},
+		{nil, 0, false},
+	}
+	for _, tt := range tests {
+		var got LapTime
+		err := got.Scan(tt.value)
+		if (err == nil) != tt.ok {
+			t.Errorf("scanning %#v returned %v", tt.value, err)
+			continue
+		}
+		if got.Milliseconds() != tt.millis {
+			t.Errorf("scanned %#v as %d ms, want %d", tt.value, got.Milliseconds(), tt.millis)
+		}
+	}
+
+	value, err := LapTimeFromMillis(83456).Value()
+	if err != nil || value != int64(83456) {
+		t.Errorf("stored as %#v, %v", value, err)
+	}
+}
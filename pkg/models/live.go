@@ -0,0 +1,13 @@
+package models
+
+import "github.com/google/uuid"
+
+// TimingSnapshot is the last persisted timing tower of a live session, kept
+// one per session so that late joiners get the state after a restart
+type TimingSnapshot struct {
+	Model
+	SessionID uuid.UUID `gorm:"not null;unique" json:"session_id"`
+	Session   Session   `json:"-"`
+	Sequence  int64     `gorm:"not null" json:"sequence"`
+	State     JSON      `gorm:"type:jsonb;not null" json:"state"`
+} //@name TimingSnapshot
@@ -0,0 +1,72 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	SegmentQ1 = "Q1"
+	SegmentQ2 = "Q2"
+	SegmentQ3 = "Q3"
+)
+
+// QualifyingResult holds the best valid lap of each segment the driver took
+// part in. A deleted flag marks a segment whose fastest lap was deleted, the
+// stored time is then the best remaining lap, if any
+type QualifyingResult struct {
+	Model
+	SessionID     uuid.UUID   `gorm:"not null;index:,unique,composite:idx_qualifying_session_driver" json:"session_id"`
+	Session       Session     `json:"-"`
+	RaceID        uuid.UUID   `gorm:"not null;index" json:"race_id"`
+	Race          Race        `json:"-"`
+	DriverID      uuid.UUID   `gorm:"not null;index:,unique,composite:idx_qualifying_session_driver" json:"driver_id"`
+	Driver        Driver      `json:"driver"`
+	ConstructorID uuid.UUID   `gorm:"not null;index" json:"constructor_id"`
+	Constructor   Constructor `json:"constructor"`
+	CarNumber     int         `json:"car_number"`
+	Position      int         `gorm:"not null" json:"position"`
+	Q1            *LapTime    `json:"q1"`
+	Q2            *LapTime    `json:"q2"`
+	Q3            *LapTime    `json:"q3"`
+	Q1Deleted     bool        `json:"q1_deleted"`
+	Q2Deleted     bool        `json:"q2_deleted"`
+	Q3Deleted     bool        `json:"q3_deleted"`
+	// EliminatedIn is empty for drivers who took part in Q3
+	EliminatedIn string `gorm:"type:varchar(2)" json:"eliminated_in"`
+	Explanation  string `json:"explanation"`
+	// Admitted marks drivers outside the 107% rule permitted to start by the
+	// stewards
+	Admitted bool `json:"admitted"`
+} //@name QualifyingResult
+
+// Time returns the best valid lap of the segment
+func (q QualifyingResult) Time(segment string) *LapTime {
+	switch segment {
+	case SegmentQ1:
+		return q.Q1
+	case SegmentQ2:
+		return q.Q2
+	case SegmentQ3:
+		return q.Q3
+	}
+	return nil
+}
+
+// Deleted reports whether the fastest lap of the segment was deleted
+func (q QualifyingResult) Deleted(segment string) bool {
+	switch segment {
+	case SegmentQ1:
+		return q.Q1Deleted
+	case SegmentQ2:
+		return q.Q2Deleted
+	case SegmentQ3:
+		return q.Q3Deleted
+	}
+	return false
+}
+
+// LastSegment returns the last segment the driver took part in
+func (q QualifyingResult) LastSegment() string {
+	if q.EliminatedIn != "" {
+		return q.EliminatedIn
+	}
+	return SegmentQ3
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventGreenFlag           = "green_flag"
+	EventYellowFlag          = "yellow_flag"
+	EventDoubleYellowFlag    = "double_yellow_flag"
+	EventRedFlag             = "red_flag"
+	EventChequeredFlag       = "chequered_flag"
+	EventSafetyCarDeployed   = "safety_car_deployed"
+	EventSafetyCarEnding     = "safety_car_ending"
+	EventVirtualSafetyCar    = "vsc_deployed"
+	EventVirtualSafetyCarEnd = "vsc_ending"
+	EventTrackLimits         = "track_limits_deletion"
+	EventInvestigation       = "investigation"
+	EventPenalty             = "penalty"
+)
+
+// RaceControlEvent is a message of race control, flags without a sector
+// apply to the whole track
+type RaceControlEvent struct {
+	Model
+	RaceID   uuid.UUID  `gorm:"not null;index:,composite:idx_race_time" json:"race_id"`
+	Race     Race       `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	Time     time.Time  `gorm:"not null;index:,composite:idx_race_time" json:"time"`
+	Type     string     `gorm:"not null;type:varchar(32)" json:"type"`
+	Lap      *int       `json:"lap"`
+	Sector   *int       `json:"sector"`
+	DriverID *uuid.UUID `gorm:"index" json:"driver_id"`
+	Driver   *Driver    `json:"driver"`
+	Message  string     `json:"message"`
+} //@name RaceControlEvent
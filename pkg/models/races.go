@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SessionFP1            = "FP1"
+	SessionFP2            = "FP2"
+	SessionFP3            = "FP3"
+	SessionSprintShootout = "SPRINT_SHOOTOUT"
+	SessionSprint         = "SPRINT"
+	SessionQualifying     = "QUALIFYING"
+	SessionRace           = "RACE"
+)
+
+const (
+	SessionScheduled = "scheduled"
+	SessionLive      = "live"
+	SessionFinished  = "finished"
+	SessionCancelled = "cancelled"
+)
+
+// SessionDurations are the scheduled lengths used when a session is created
+// without an explicit end time
+var SessionDurations = map[string]time.Duration{
+	SessionFP1:            time.Hour,
+	SessionFP2:            time.Hour,
+	SessionFP3:            time.Hour,
+	SessionSprintShootout: 45 * time.Minute,
+	SessionSprint:         time.Hour,
+	SessionQualifying:     time.Hour,
+	SessionRace:           2 * time.Hour,
+}
+
+type Race struct {
+	Model
+	Year      int       `gorm:"not null;index:,unique,composite:idx_year_round" json:"year"`
+	Season    Season    `gorm:"foreignKey:Year;references:Year" json:"-"`
+	Round     int       `gorm:"not null;index:,unique,composite:idx_year_round" json:"round"`
+	Name      string    `gorm:"not null;type:varchar(255)" json:"name"`
+	CircuitID uuid.UUID `gorm:"not null;index" json:"circuit_id"`
+	Circuit   Circuit   `json:"circuit"`
+	Date      time.Time `gorm:"type:date;not null" json:"date"`
+	URL       string    `json:"url"`
+	// PointsScale multiplies the points awarded for the race, 0.5 for races
+	// awarding half points. Zero is treated as full points
+	PointsScale float64 `gorm:"not null;default:1" json:"points_scale"`
+	// MeetingKey is the key of the race weekend in OpenF1 data
+	MeetingKey *int      `gorm:"unique" json:"meeting_key"`
+	Sessions   []Session `gorm:"constraint:OnDelete:CASCADE" json:"sessions"`
+} //@name Race
+
+type Session struct {
+	Model
+	RaceID    uuid.UUID `gorm:"not null;index:,unique,composite:idx_race_type" json:"race_id"`
+	Type      string    `gorm:"not null;type:varchar(32);index:,unique,composite:idx_race_type" json:"type"`
+	StartTime time.Time `gorm:"not null;index" json:"start_time"`
+	EndTime   time.Time `gorm:"not null" json:"end_time"`
+	Status    string    `gorm:"not null;type:varchar(16);default:scheduled" json:"status"`
+	// SessionKey is the key of the session in OpenF1 data
+	SessionKey *int `gorm:"unique" json:"session_key"`
+} //@name Session
+
+// Overlaps reports whether both sessions are on track at the same time
+func (s *Session) Overlaps(other Session) bool {
+	return s.StartTime.Before(other.EndTime) && other.StartTime.Before(s.EndTime)
+}
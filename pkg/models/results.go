@@ -0,0 +1,42 @@
+package models
+
+import "github.com/google/uuid"
+
+// Position texts of drivers without a classified position, as used in the
+// official classification
+const (
+	PositionRetired         = "R"
+	PositionDisqualified    = "D"
+	PositionWithdrawn       = "W"
+	PositionNotClassified   = "N"
+	PositionExcluded        = "E"
+	PositionFailedToQualify = "F"
+)
+
+const StatusFinished = "Finished"
+
+type Result struct {
+	Model
+	SessionID      uuid.UUID   `gorm:"not null;index:,unique,composite:idx_session_driver" json:"session_id"`
+	Session        Session     `json:"-"`
+	RaceID         uuid.UUID   `gorm:"not null;index" json:"race_id"`
+	Race           Race        `json:"-"`
+	DriverID       uuid.UUID   `gorm:"not null;index:,unique,composite:idx_session_driver" json:"driver_id"`
+	Driver         Driver      `json:"driver"`
+	ConstructorID  uuid.UUID   `gorm:"not null;index" json:"constructor_id"`
+	Constructor    Constructor `json:"constructor"`
+	CarNumber      int         `json:"car_number"`
+	Grid           int         `json:"grid"`
+	Position       *int        `json:"position"`
+	PositionText   string      `gorm:"not null;type:varchar(3)" json:"position_text"`
+	PositionOrder  int         `gorm:"not null" json:"position_order"`
+	Classified     bool        `json:"classified"`
+	Laps           int         `json:"laps"`
+	TimeMillis     *int64      `json:"time_millis"`
+	Gap            string      `gorm:"type:varchar(32)" json:"gap"`
+	Status         string      `gorm:"type:varchar(64)" json:"status"`
+	Points         float64     `json:"points"`
+	FastestLap     *int        `json:"fastest_lap"`
+	FastestLapTime *LapTime    `json:"fastest_lap_time"`
+	FastestLapRank *int        `json:"fastest_lap_rank"`
+} //@name Result
@@ -0,0 +1,7 @@
+package models
+
+type Season struct {
+	Model
+	Year int    `gorm:"unique;not null" json:"year"`
+	URL  string `json:"url"`
+} //@name Season
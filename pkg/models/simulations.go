@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	SimulationPending  = "pending"
+	SimulationRunning  = "running"
+	SimulationFinished = "finished"
+	SimulationFailed   = "failed"
+)
+
+// SimulationOverride replaces the fitted performance of a driver
+type SimulationOverride struct {
+	DriverID       uuid.UUID `json:"driver_id"`
+	StrengthFactor *float64  `json:"strength_factor,omitempty"`
+	DNFProbability *float64  `json:"dnf_probability,omitempty"`
+} //@name SimulationOverride
+
+// Simulation is a Monte Carlo run of the remaining races of a season, the
+// outcome is stored once the background job finishes
+type Simulation struct {
+	Model
+	Year          int        `gorm:"not null;index" json:"year"`
+	Season        Season     `gorm:"foreignKey:Year;references:Year" json:"-"`
+	Seed          int64      `gorm:"not null" json:"seed"`
+	Runs          int        `gorm:"not null" json:"runs"`
+	Decay         float64    `gorm:"not null" json:"decay"`
+	StrengthPrior float64    `gorm:"not null" json:"strength_prior"`
+	DNFPrior      float64    `gorm:"not null" json:"dnf_prior"`
+	Overrides     JSON       `gorm:"type:jsonb" json:"overrides"`
+	Status        string     `gorm:"not null;type:varchar(16);default:pending" json:"status"`
+	Error         string     `json:"error"`
+	StartedAt     *time.Time `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at"`
+	Outcome       JSON       `gorm:"type:jsonb" json:"outcome"`
+} //@name Simulation
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PenaltyTime             = "time"
+	PenaltyDisqualification = "disqualification"
+	PenaltyReprimand        = "reprimand"
+	PenaltyWarning          = "warning"
+	PenaltyFine             = "fine"
+)
+
+const (
+	DecisionIssued     = "issued"
+	DecisionAppealed   = "appealed"
+	DecisionUpheld     = "upheld"
+	DecisionOverturned = "overturned"
+)
+
+// StewardDecision is a decision of the stewards on an infringement of a
+// driver in a race or sprint. Value holds the seconds of a time penalty and
+// the euros of a fine. AppealedAt and ResolvedAt record when the decision was
+// appealed and when it was upheld or overturned
+type StewardDecision struct {
+	Model
+	SessionID    uuid.UUID  `gorm:"not null;index" json:"session_id"`
+	Session      Session    `json:"-"`
+	RaceID       uuid.UUID  `gorm:"not null;index" json:"race_id"`
+	Race         Race       `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	DriverID     uuid.UUID  `gorm:"not null;index" json:"driver_id"`
+	Driver       Driver     `json:"driver"`
+	Document     string     `gorm:"not null;type:varchar(16)" json:"document"`
+	Infringement string     `gorm:"not null" json:"infringement"`
+	Penalty      string     `gorm:"not null;type:varchar(16)" json:"penalty"`
+	Value        float64    `json:"value"`
+	Status       string     `gorm:"not null;type:varchar(16)" json:"status"`
+	IssuedAt     time.Time  `gorm:"not null" json:"issued_at"`
+	AppealedAt   *time.Time `json:"appealed_at"`
+	ResolvedAt   *time.Time `json:"resolved_at"`
+} //@name StewardDecision
+
+// ResultVersion is the classification of a session from EffectiveAt until
+// the next version. Versions without a decision hold a submitted
+// classification, the others amend the latest submitted one with the
+// decisions in force
+type ResultVersion struct {
+	Model
+	SessionID   uuid.UUID        `gorm:"not null;index:,unique,composite:idx_session_version" json:"session_id"`
+	Session     Session          `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	Version     int              `gorm:"not null;index:,unique,composite:idx_session_version" json:"version"`
+	EffectiveAt time.Time        `gorm:"not null;index" json:"effective_at"`
+	DecisionID  *uuid.UUID       `gorm:"index" json:"decision_id"`
+	Decision    *StewardDecision `gorm:"constraint:OnDelete:SET NULL" json:"decision"`
+	// Status is the status the decision moved to
+	Status string `gorm:"type:varchar(16)" json:"status"`
+	// NeedsReview flags a classification with a time penalty on a lapped
+	// car, whose place is kept and has to be checked by hand
+	NeedsReview bool `gorm:"not null;default:false" json:"needs_review"`
+	Results     JSON `gorm:"type:jsonb;not null" json:"-"`
+} //@name ResultVersion
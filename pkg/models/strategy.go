@@ -0,0 +1,50 @@
+package models
+
+import "github.com/google/uuid"
+
+const (
+	CompoundHypersoft    = "HYPERSOFT"
+	CompoundUltrasoft    = "ULTRASOFT"
+	CompoundSupersoft    = "SUPERSOFT"
+	CompoundSoft         = "SOFT"
+	CompoundMedium       = "MEDIUM"
+	CompoundHard         = "HARD"
+	CompoundSuperhard    = "SUPERHARD"
+	CompoundIntermediate = "INTERMEDIATE"
+	CompoundWet          = "WET"
+	CompoundUnknown      = "UNKNOWN"
+)
+
+type PitStop struct {
+	Model
+	RaceID   uuid.UUID `gorm:"not null;index:,unique,composite:idx_race_driver_stop" json:"race_id"`
+	Race     Race      `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	DriverID uuid.UUID `gorm:"not null;index:,unique,composite:idx_race_driver_stop" json:"driver_id"`
+	Driver   Driver    `json:"driver"`
+	Stop     int       `gorm:"not null;index:,unique,composite:idx_race_driver_stop" json:"stop"`
+	Lap      int       `gorm:"not null" json:"lap"`
+	// TimeOfDay is the local time the car entered the pit lane, HH:MM:SS
+	TimeOfDay string `gorm:"type:varchar(8)" json:"time_of_day"`
+	// Stationary is the time the car stood in the box, PitLane the time from
+	// pit entry to pit exit
+	Stationary *LapTime `json:"stationary"`
+	PitLane    *LapTime `json:"pit_lane"`
+} //@name PitStop
+
+type Stint struct {
+	Model
+	RaceID   uuid.UUID `gorm:"not null;index:,unique,composite:idx_race_driver_stint" json:"race_id"`
+	Race     Race      `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	DriverID uuid.UUID `gorm:"not null;index:,unique,composite:idx_race_driver_stint" json:"driver_id"`
+	Driver   Driver    `json:"driver"`
+	Number   int       `gorm:"not null;index:,unique,composite:idx_race_driver_stint" json:"number"`
+	Compound string    `gorm:"not null;type:varchar(16)" json:"compound"`
+	New      bool      `json:"new"`
+	StartLap int       `gorm:"not null" json:"start_lap"`
+	EndLap   int       `gorm:"not null" json:"end_lap"`
+} //@name Stint
+
+// Laps returns the number of laps of the stint
+func (s Stint) Laps() int {
+	return s.EndLap - s.StartLap + 1
+}
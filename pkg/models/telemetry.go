@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TelemetrySample is the car data of a driver at an instant of a session,
+// recorded a few times a second. The table is partitioned by session, so it
+// is created by the migrations instead of AutoMigrate and written with COPY
+type TelemetrySample struct {
+	SessionID uuid.UUID `gorm:"primaryKey" json:"session_id"`
+	DriverID  uuid.UUID `gorm:"primaryKey" json:"driver_id"`
+	Time      time.Time `gorm:"primaryKey" json:"time"`
+	Lap       int       `json:"lap"`
+	// Distance is the distance covered on the lap in metres, when recorded
+	Distance *float64 `json:"distance"`
+	// Speed is in km/h
+	Speed    int  `json:"speed"`
+	RPM      int  `gorm:"column:rpm" json:"rpm"`
+	Gear     int  `json:"gear"`
+	Throttle int  `json:"throttle"`
+	Brake    bool `json:"brake"`
+	DRS      bool `gorm:"column:drs" json:"drs"`
+} //@name TelemetrySample
+
+func (TelemetrySample) TableName() string {
+	return "telemetry_samples"
+}
+
+// TelemetryColumns are the columns of the samples in the order of Values
+var TelemetryColumns = []string{"session_id", "driver_id", "time", "lap", "distance", "speed", "rpm", "gear", "throttle", "brake", "drs"}
+
+// Values returns the columns of the sample for COPY
+func (t TelemetrySample) Values() []interface{} {
+	return []interface{}{[16]byte(t.SessionID), [16]byte(t.DriverID), t.Time, t.Lap, t.Distance, t.Speed, t.RPM, t.Gear, t.Throttle, t.Brake, t.DRS}
+}
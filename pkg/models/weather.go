@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WeatherObservation is a sample of the conditions at the track during a
+// session, taken about once a minute
+type WeatherObservation struct {
+	Model
+	SessionID uuid.UUID `gorm:"not null;index:,unique,composite:idx_session_time" json:"session_id"`
+	Session   Session   `json:"-"`
+	Time      time.Time `gorm:"not null;index:,unique,composite:idx_session_time" json:"time"`
+	// AirTemperature and TrackTemperature are in degrees Celsius
+	AirTemperature   float64 `gorm:"not null" json:"air_temperature"`
+	TrackTemperature float64 `gorm:"not null" json:"track_temperature"`
+	// Humidity is the relative humidity in percent
+	Humidity float64 `json:"humidity"`
+	// Pressure is the air pressure in millibars
+	Pressure float64 `json:"pressure"`
+	// WindSpeed is in metres per second, WindDirection in degrees from north
+	WindSpeed     float64 `json:"wind_speed"`
+	WindDirection int     `json:"wind_direction"`
+	Rainfall      bool    `gorm:"not null;default:false" json:"rainfall"`
+} //@name WeatherObservation
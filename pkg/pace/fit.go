@@ -0,0 +1,170 @@
+package pace
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+var ErrTooFewPoints = errors.New("too few points to fit the model")
+
+// Fit is a polynomial fitted by least squares. Coefficients start with the
+// constant term, Residuals are the observed minus the fitted values
+type Fit struct {
+	Coefficients []float64
+	Residuals    []float64
+	// RMSE is the root mean square of the residuals
+	RMSE float64
+	// R2 is the share of the variance explained by the fit, 1 when the
+	// values do not vary
+	R2 float64
+}
+
+// At evaluates the fitted polynomial
+func (f Fit) At(x float64) float64 {
+	value := 0.0
+	for i := len(f.Coefficients) - 1; i >= 0; i-- {
+		value = value*x + f.Coefficients[i]
+	}
+	return value
+}
+
+// Polyfit fits a polynomial of the degree to the points. It needs at least
+// one point more than there are coefficients, so that the residuals say
+// something about the fit
+func Polyfit(x, y []float64, degree int) (Fit, error) {
+	n := degree + 1
+	if len(x) != len(y) || len(x) <= n {
+		return Fit{}, ErrTooFewPoints
+	}
+
+	// normal equations, the x values are centred to keep them well
+	// conditioned and the coefficients are shifted back afterwards
+	centre := mean(x)
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n+1)
+	}
+	for k := range x {
+		powers := make([]float64, 2*n-1)
+		powers[0] = 1
+		for p := 1; p < len(powers); p++ {
+			powers[p] = powers[p-1] * (x[k] - centre)
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				a[i][j] += powers[i+j]
+			}
+			a[i][n] += powers[i] * y[k]
+		}
+	}
+	centred, err := solve(a)
+	if err != nil {
+		return Fit{}, err
+	}
+
+	fit := Fit{Coefficients: shift(centred, -centre), Residuals: make([]float64, len(x))}
+	average := mean(y)
+	var squares, total float64
+	for k := range x {
+		fit.Residuals[k] = y[k] - fit.At(x[k])
+		squares += fit.Residuals[k] * fit.Residuals[k]
+		total += (y[k] - average) * (y[k] - average)
+	}
+	fit.RMSE = math.Sqrt(squares / float64(len(x)))
+	fit.R2 = 1
+	if total > 0 {
+		fit.R2 = 1 - squares/total
+	}
+	return fit, nil
+}
+
+// solve solves the augmented linear system by Gaussian elimination with
+// partial pivoting
+func solve(a [][]float64) ([]float64, error) {
+	n := len(a)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, ErrTooFewPoints
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		for row := col + 1; row < n; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k <= n; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+		}
+	}
+
+	solution := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		value := a[row][n]
+		for k := row + 1; k < n; k++ {
+			value -= a[row][k] * solution[k]
+		}
+		solution[row] = value / a[row][row]
+	}
+	return solution, nil
+}
+
+// shift returns the coefficients of p(x + offset) given those of p(x)
+func shift(coefficients []float64, offset float64) []float64 {
+	shifted := make([]float64, len(coefficients))
+	for i, c := range coefficients {
+		// binomial expansion of c * (x + offset)^i
+		binomial := 1.0
+		for k := 0; k <= i; k++ {
+			shifted[k] += c * binomial * math.Pow(offset, float64(i-k))
+			binomial = binomial * float64(i-k) / float64(k+1)
+		}
+	}
+	return shifted
+}
+
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// Median returns the median of the values, 0 for none
+func Median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// Outliers reports the values further from the median than the threshold
+// times the median absolute deviation, scaled to match the standard
+// deviation of normally distributed values
+func Outliers(values []float64, threshold float64) []bool {
+	outliers := make([]bool, len(values))
+	median := Median(values)
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - median)
+	}
+	spread := 1.4826 * Median(deviations)
+	if spread == 0 {
+		return outliers
+	}
+	for i, deviation := range deviations {
+		outliers[i] = deviation > threshold*spread
+	}
+	return outliers
+}
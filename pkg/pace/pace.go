@@ -0,0 +1,351 @@
+// Package pace estimates the representative race pace of drivers from their
+// clean laps and fits the degradation of their tyres over every stint.
+package pace
+
+import (
+	"sort"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/dewciu/f1_api/pkg/weather"
+	"github.com/google/uuid"
+)
+
+const (
+	ModelLinear    = "linear"
+	ModelQuadratic = "quadratic"
+)
+
+const (
+	// DefaultFuelCorrection is the time a lap gets quicker for every lap of
+	// fuel burnt, in seconds
+	DefaultFuelCorrection = 0.06
+	// DefaultOutlierThreshold is the number of deviations from the median of
+	// a stint beyond which a lap is an outlier
+	DefaultOutlierThreshold = 3.0
+)
+
+// Reasons for leaving a lap out of the analysis
+const (
+	ExcludedFirstLap    = "first_lap"
+	ExcludedInLap       = "in_lap"
+	ExcludedOutLap      = "out_lap"
+	ExcludedNeutralized = "neutralized"
+	ExcludedWet         = "wet"
+	ExcludedNoStint     = "no_stint"
+	ExcludedOutlier     = "outlier"
+)
+
+type Options struct {
+	// FuelCorrection is subtracted from a lap for every lap left to run, so
+	// that all laps are compared on an empty tank
+	FuelCorrection float64
+	// Model is the degradation model fitted to the stints, linear or
+	// quadratic in the age of the tyres
+	Model string
+	// OutlierThreshold is the number of deviations from the median of a
+	// stint beyond which a lap is an outlier
+	OutlierThreshold float64
+}
+
+func DefaultOptions() Options {
+	return Options{
+		FuelCorrection:   DefaultFuelCorrection,
+		Model:            ModelLinear,
+		OutlierThreshold: DefaultOutlierThreshold,
+	}
+}
+
+// degree returns the degree of the polynomial of the model
+func (o Options) degree() int {
+	if o.Model == ModelQuadratic {
+		return 2
+	}
+	return 1
+}
+
+// Exclusions are the laps left out besides in-laps, out-laps and outliers,
+// Neutralized by lap number and Wet by the lap of a driver
+type Exclusions struct {
+	Neutralized map[int]bool
+	Wet         map[weather.LapKey]bool
+}
+
+// Lap is a clean lap, Age counts the laps since the start of its stint and
+// Corrected is its time in seconds after the fuel correction. Residual is
+// nil when the stint has no fit
+type Lap struct {
+	Number    int
+	Age       int
+	Time      m.LapTime
+	Corrected float64
+	Residual  *float64
+}
+
+type ExcludedLap struct {
+	Number int
+	Time   m.LapTime
+	Reason string
+}
+
+// Stint is a stint of a driver with the fit of its clean laps, nil when it
+// has too few of them. Degradation is the slope of the fit at the mean age
+// of the laps, in seconds per lap
+type Stint struct {
+	Number      int
+	Compound    string
+	StartLap    int
+	EndLap      int
+	Laps        []Lap
+	Fit         *Fit
+	Degradation *float64
+}
+
+// DriverPace is the pace of a driver over their clean laps, Representative
+// being their median and Gap its difference to the quickest driver. Both are
+// nil for drivers without clean laps
+type DriverPace struct {
+	DriverID       uuid.UUID
+	Representative *float64
+	Mean           *float64
+	Gap            *float64
+	Laps           int
+	Stints         []Stint
+	Excluded       []ExcludedLap
+}
+
+// CompoundPace is the mean degradation of the fitted stints on a compound
+type CompoundPace struct {
+	Compound    string
+	Stints      int
+	Degradation float64
+}
+
+type Pace struct {
+	Options   Options
+	RaceLaps  int
+	Drivers   []DriverPace
+	Compounds []CompoundPace
+}
+
+// Analyse returns the pace of every driver with laps, quickest first. Laps
+// are grouped by the stints of their driver, drivers without stints get
+// stints split at their pit stops
+func Analyse(raceLaps []m.Lap, stints []m.Stint, stops []m.PitStop, exclusions Exclusions, options Options) Pace {
+	result := Pace{Options: options, Drivers: []DriverPace{}, Compounds: []CompoundPace{}}
+
+	driverLaps := make(map[uuid.UUID][]m.Lap)
+	order := []uuid.UUID{}
+	for _, lap := range raceLaps {
+		if _, ok := driverLaps[lap.DriverID]; !ok {
+			order = append(order, lap.DriverID)
+		}
+		driverLaps[lap.DriverID] = append(driverLaps[lap.DriverID], lap)
+		result.RaceLaps = max(result.RaceLaps, lap.Number)
+	}
+	driverStints := make(map[uuid.UUID][]m.Stint)
+	for _, stint := range stints {
+		driverStints[stint.DriverID] = append(driverStints[stint.DriverID], stint)
+	}
+	driverStops := make(map[uuid.UUID][]m.PitStop)
+	for _, stop := range stops {
+		driverStops[stop.DriverID] = append(driverStops[stop.DriverID], stop)
+	}
+
+	for _, driver := range order {
+		own := driverLaps[driver]
+		sort.Slice(own, func(i, j int) bool { return own[i].Number < own[j].Number })
+		timeline := driverStints[driver]
+		if len(timeline) == 0 {
+			timeline = splitAtStops(driver, driverStops[driver], own[len(own)-1].Number)
+		}
+		sort.Slice(timeline, func(i, j int) bool { return timeline[i].StartLap < timeline[j].StartLap })
+		result.Drivers = append(result.Drivers, analyseDriver(driver, own, timeline, driverStops[driver], exclusions, options, result.RaceLaps))
+	}
+
+	rank(result.Drivers)
+	result.Compounds = compounds(result.Drivers)
+	return result
+}
+
+// splitAtStops returns stints of an unknown compound ending on the laps the
+// driver stopped
+func splitAtStops(driver uuid.UUID, stops []m.PitStop, lastLap int) []m.Stint {
+	sort.Slice(stops, func(i, j int) bool { return stops[i].Lap < stops[j].Lap })
+	timeline := []m.Stint{}
+	start := 1
+	for _, stop := range stops {
+		if stop.Lap < start || stop.Lap >= lastLap {
+			continue
+		}
+		timeline = append(timeline, m.Stint{DriverID: driver, Number: len(timeline) + 1, Compound: m.CompoundUnknown, StartLap: start, EndLap: stop.Lap})
+		start = stop.Lap + 1
+	}
+	return append(timeline, m.Stint{DriverID: driver, Number: len(timeline) + 1, Compound: m.CompoundUnknown, StartLap: start, EndLap: lastLap})
+}
+
+func analyseDriver(driver uuid.UUID, own []m.Lap, timeline []m.Stint, stops []m.PitStop, exclusions Exclusions, options Options, raceLaps int) DriverPace {
+	result := DriverPace{DriverID: driver, Stints: []Stint{}, Excluded: []ExcludedLap{}}
+
+	// a stop or a change of stint makes an in-lap and the out-lap after it
+	inLaps, outLaps := make(map[int]bool), make(map[int]bool)
+	for _, stop := range stops {
+		inLaps[stop.Lap] = true
+		outLaps[stop.Lap+1] = true
+	}
+	for i := 1; i < len(timeline); i++ {
+		inLaps[timeline[i-1].EndLap] = true
+		outLaps[timeline[i].StartLap] = true
+	}
+
+	stintOf := func(number int) int {
+		for i, stint := range timeline {
+			if stint.StartLap <= number && number <= stint.EndLap {
+				return i
+			}
+		}
+		return -1
+	}
+	result.Stints = make([]Stint, len(timeline))
+	for i, stint := range timeline {
+		result.Stints[i] = Stint{Number: stint.Number, Compound: stint.Compound, StartLap: stint.StartLap, EndLap: stint.EndLap, Laps: []Lap{}}
+	}
+
+	for _, lap := range own {
+		reason := ""
+		i := stintOf(lap.Number)
+		switch {
+		case lap.Number == 1:
+			reason = ExcludedFirstLap
+		case inLaps[lap.Number]:
+			reason = ExcludedInLap
+		case outLaps[lap.Number]:
+			reason = ExcludedOutLap
+		case exclusions.Neutralized[lap.Number]:
+			reason = ExcludedNeutralized
+		case exclusions.Wet[weather.LapKey{DriverID: driver, Lap: lap.Number}]:
+			reason = ExcludedWet
+		case i < 0:
+			reason = ExcludedNoStint
+		}
+		if reason != "" {
+			result.Excluded = append(result.Excluded, ExcludedLap{Number: lap.Number, Time: lap.Time, Reason: reason})
+			continue
+		}
+		result.Stints[i].Laps = append(result.Stints[i].Laps, Lap{
+			Number:    lap.Number,
+			Age:       lap.Number - timeline[i].StartLap,
+			Time:      lap.Time,
+			Corrected: lap.Time.Duration().Seconds() - options.FuelCorrection*float64(raceLaps-lap.Number),
+		})
+	}
+
+	corrected := []float64{}
+	for i := range result.Stints {
+		stint := &result.Stints[i]
+		stint.Laps = dropOutliers(stint.Laps, options.OutlierThreshold, &result.Excluded)
+		fitStint(stint, options.degree())
+		for _, lap := range stint.Laps {
+			corrected = append(corrected, lap.Corrected)
+		}
+	}
+	sort.Slice(result.Excluded, func(i, j int) bool { return result.Excluded[i].Number < result.Excluded[j].Number })
+
+	result.Laps = len(corrected)
+	if len(corrected) > 0 {
+		representative, average := Median(corrected), mean(corrected)
+		result.Representative, result.Mean = &representative, &average
+	}
+	return result
+}
+
+// dropOutliers returns the laps which are not outliers of their stint,
+// recording the others as excluded
+func dropOutliers(stintLaps []Lap, threshold float64, excluded *[]ExcludedLap) []Lap {
+	values := make([]float64, len(stintLaps))
+	for i, lap := range stintLaps {
+		values[i] = lap.Corrected
+	}
+	kept := []Lap{}
+	for i, outlier := range Outliers(values, threshold) {
+		if outlier {
+			*excluded = append(*excluded, ExcludedLap{Number: stintLaps[i].Number, Time: stintLaps[i].Time, Reason: ExcludedOutlier})
+			continue
+		}
+		kept = append(kept, stintLaps[i])
+	}
+	return kept
+}
+
+func fitStint(stint *Stint, degree int) {
+	x := make([]float64, len(stint.Laps))
+	y := make([]float64, len(stint.Laps))
+	for i, lap := range stint.Laps {
+		x[i], y[i] = float64(lap.Age), lap.Corrected
+	}
+	fit, err := Polyfit(x, y, degree)
+	if err != nil {
+		return
+	}
+	stint.Fit = &fit
+	for i := range stint.Laps {
+		stint.Laps[i].Residual = &fit.Residuals[i]
+	}
+	// the slope of a polynomial a + bx + cx² is b + 2cx
+	slope := 0.0
+	age := mean(x)
+	for power := 1; power < len(fit.Coefficients); power++ {
+		term := float64(power) * fit.Coefficients[power]
+		for k := 1; k < power; k++ {
+			term *= age
+		}
+		slope += term
+	}
+	stint.Degradation = &slope
+}
+
+// rank sorts the drivers by their representative pace, drivers without one
+// last, and sets their gap to the quickest
+func rank(drivers []DriverPace) {
+	sort.SliceStable(drivers, func(i, j int) bool {
+		a, b := drivers[i].Representative, drivers[j].Representative
+		if a == nil || b == nil {
+			return a != nil
+		}
+		return *a < *b
+	})
+	if len(drivers) == 0 || drivers[0].Representative == nil {
+		return
+	}
+	best := *drivers[0].Representative
+	for i := range drivers {
+		if drivers[i].Representative != nil {
+			gap := *drivers[i].Representative - best
+			drivers[i].Gap = &gap
+		}
+	}
+}
+
+func compounds(drivers []DriverPace) []CompoundPace {
+	byCompound := make(map[string]*CompoundPace)
+	list := []CompoundPace{}
+	for _, driver := range drivers {
+		for _, stint := range driver.Stints {
+			if stint.Degradation == nil {
+				continue
+			}
+			compound, ok := byCompound[stint.Compound]
+			if !ok {
+				compound = &CompoundPace{Compound: stint.Compound}
+				byCompound[stint.Compound] = compound
+			}
+			compound.Stints++
+			compound.Degradation += *stint.Degradation
+		}
+	}
+	for _, compound := range byCompound {
+		compound.Degradation /= float64(compound.Stints)
+		list = append(list, *compound)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Compound < list[j].Compound })
+	return list
+}
@@ -0,0 +1,210 @@
+package pace
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/dewciu/f1_api/pkg/weather"
+	"github.com/google/uuid"
+)
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func lapTime(s float64) m.LapTime {
+	return m.LapTime(time.Duration(s * float64(time.Second)))
+}
+
+func TestPolyfit(t *testing.T) {
+	x := []float64{0, 1, 2, 3, 4, 5}
+	y := make([]float64, len(x))
+	for i, v := range x {
+		y[i] = 90 + 0.1*v + 0.02*v*v
+	}
+
+	fit, err := Polyfit(x, y, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []float64{90, 0.1, 0.02} {
+		if !near(fit.Coefficients[i], want) {
+			t.Errorf("coefficient %d is %v, want %v", i, fit.Coefficients[i], want)
+		}
+	}
+	if !near(fit.RMSE, 0) || !near(fit.R2, 1) {
+		t.Errorf("exact fit has RMSE %v and R2 %v", fit.RMSE, fit.R2)
+	}
+
+	linear, err := Polyfit(x, y, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := 0.0
+	for _, residual := range linear.Residuals {
+		sum += residual
+	}
+	if !near(sum, 0) || linear.RMSE == 0 || linear.R2 >= 1 {
+		t.Errorf("linear fit of a curve has residuals %v, R2 %v", linear.Residuals, linear.R2)
+	}
+
+	if _, err := Polyfit(x[:3], y[:3], 2); err != ErrTooFewPoints {
+		t.Errorf("fitted three coefficients to three points: %v", err)
+	}
+	if _, err := Polyfit([]float64{2, 2, 2}, []float64{1, 2, 3}, 1); err != ErrTooFewPoints {
+		t.Errorf("fitted a slope to a single x: %v", err)
+	}
+}
+
+func TestOutliers(t *testing.T) {
+	got := Outliers([]float64{90, 90.2, 89.9, 90.1, 95, 90}, DefaultOutlierThreshold)
+	for i, outlier := range got {
+		if outlier != (i == 4) {
+			t.Errorf("value %d outlier %v", i, outlier)
+		}
+	}
+	for _, outlier := range Outliers([]float64{90, 90, 90, 95}, DefaultOutlierThreshold) {
+		if outlier {
+			t.Error("found an outlier without a spread")
+		}
+	}
+	if Median([]float64{3, 1, 2, 4}) != 2.5 || Median(nil) != 0 {
+		t.Error("wrong median")
+	}
+}
+
+// race returns 20 laps of two drivers stopping on lap 10, the first losing
+// 0.1s a lap to tyre wear and gaining 0.06s to fuel, the second 0.5s slower
+func race() ([]m.Lap, []m.Stint, []m.PitStop, uuid.UUID, uuid.UUID) {
+	quick, slow := uuid.New(), uuid.New()
+	raceLaps := []m.Lap{}
+	stints := []m.Stint{}
+	stops := []m.PitStop{}
+	for _, driver := range []uuid.UUID{slow, quick} {
+		offset := 0.0
+		if driver == slow {
+			offset = 0.5
+		}
+		stints = append(stints,
+			m.Stint{DriverID: driver, Number: 1, Compound: m.CompoundMedium, StartLap: 1, EndLap: 10},
+			m.Stint{DriverID: driver, Number: 2, Compound: m.CompoundHard, StartLap: 11, EndLap: 20},
+		)
+		stops = append(stops, m.PitStop{DriverID: driver, Stop: 1, Lap: 10})
+		for lap := 1; lap <= 20; lap++ {
+			age := float64((lap - 1) % 10)
+			seconds := 90 + offset + 0.1*age + 0.06*float64(20-lap)
+			switch lap {
+			case 1, 10, 11:
+				seconds += 20
+			}
+			raceLaps = append(raceLaps, m.Lap{DriverID: driver, Number: lap, Time: lapTime(seconds)})
+		}
+	}
+	return raceLaps, stints, stops, quick, slow
+}
+
+func TestAnalyse(t *testing.T) {
+	raceLaps, stints, stops, quick, slow := race()
+	result := Analyse(raceLaps, stints, stops, Exclusions{}, DefaultOptions())
+	if result.RaceLaps != 20 || len(result.Drivers) != 2 {
+		t.Fatalf("analysed %d laps of %d drivers", result.RaceLaps, len(result.Drivers))
+	}
+	first, second := result.Drivers[0], result.Drivers[1]
+	if first.DriverID != quick || second.DriverID != slow {
+		t.Fatal("drivers not ranked by pace")
+	}
+	if *first.Gap != 0 || !near(*second.Gap, 0.5) {
+		t.Errorf("gaps are %v and %v, want 0 and 0.5", *first.Gap, *second.Gap)
+	}
+
+	// an outlier in the second stint of the quick driver
+	raceLaps[20+15].Time += lapTime(4)
+	first = Analyse(raceLaps, stints, stops, Exclusions{}, DefaultOptions()).Drivers[0]
+	reasons := map[int]string{}
+	for _, excluded := range first.Excluded {
+		reasons[excluded.Number] = excluded.Reason
+	}
+	want := map[int]string{1: ExcludedFirstLap, 10: ExcludedInLap, 11: ExcludedOutLap, 16: ExcludedOutlier}
+	if len(reasons) != len(want) {
+		t.Errorf("excluded %v, want %v", reasons, want)
+	}
+	for lap, reason := range want {
+		if reasons[lap] != reason {
+			t.Errorf("lap %d excluded as %q, want %q", lap, reasons[lap], reason)
+		}
+	}
+	if first.Laps != 16 {
+		t.Errorf("kept %d laps, want 16", first.Laps)
+	}
+
+	for _, stint := range second.Stints {
+		if stint.Fit == nil {
+			t.Fatalf("stint %d has no fit", stint.Number)
+		}
+		// the fuel correction leaves only the wear
+		if !near(stint.Fit.Coefficients[1], 0.1) || !near(*stint.Degradation, 0.1) {
+			t.Errorf("stint %d degrades by %v", stint.Number, stint.Fit.Coefficients[1])
+		}
+		for _, lap := range stint.Laps {
+			if lap.Residual == nil || !near(*lap.Residual, 0) {
+				t.Errorf("lap %d has residual %v", lap.Number, lap.Residual)
+			}
+		}
+	}
+	if !near(second.Stints[0].Fit.Coefficients[0], 90.5) {
+		t.Errorf("first stint starts at %v, want 90.5", second.Stints[0].Fit.Coefficients[0])
+	}
+
+	if len(result.Compounds) != 2 || result.Compounds[0].Compound != m.CompoundHard || result.Compounds[0].Stints != 2 {
+		t.Errorf("compounds are %+v", result.Compounds)
+	}
+}
+
+func TestAnalyseExclusions(t *testing.T) {
+	raceLaps, _, stops, quick, _ := race()
+	options := Options{Model: ModelQuadratic, OutlierThreshold: DefaultOutlierThreshold}
+	exclusions := Exclusions{
+		Neutralized: map[int]bool{5: true},
+		Wet:         map[weather.LapKey]bool{{DriverID: quick, Lap: 6}: true},
+	}
+
+	// without stints the laps are split at the stops
+	result := Analyse(raceLaps, nil, stops, exclusions, options)
+	for _, driver := range result.Drivers {
+		if len(driver.Stints) != 2 || driver.Stints[0].EndLap != 10 || driver.Stints[1].Compound != m.CompoundUnknown {
+			t.Fatalf("stints split at stops are %+v", driver.Stints)
+		}
+		reasons := map[int]string{}
+		for _, excluded := range driver.Excluded {
+			reasons[excluded.Number] = excluded.Reason
+		}
+		if reasons[5] != ExcludedNeutralized {
+			t.Errorf("lap 5 excluded as %q", reasons[5])
+		}
+		if wet := reasons[6] == ExcludedWet; wet != (driver.DriverID == quick) {
+			t.Errorf("lap 6 excluded as %q", reasons[6])
+		}
+		if fit := driver.Stints[0].Fit; fit == nil || len(fit.Coefficients) != 3 {
+			t.Errorf("first stint fit is %+v, want quadratic", fit)
+		}
+	}
+
+	// without a fuel correction the laps get quicker through the stint
+	if slope := *result.Drivers[0].Stints[1].Degradation; !near(slope, 0.04) {
+		t.Errorf("uncorrected degradation is %v, want 0.04", slope)
+	}
+}
+
+func TestAnalyseEmpty(t *testing.T) {
+	driver := uuid.New()
+	raceLaps := []m.Lap{{DriverID: driver, Number: 1, Time: m.LapTime(100 * time.Second)}}
+	result := Analyse(raceLaps, nil, nil, Exclusions{}, DefaultOptions())
+	if len(result.Drivers) != 1 || result.Drivers[0].Representative != nil || result.Drivers[0].Gap != nil {
+		t.Errorf("driver without clean laps is %+v", result.Drivers)
+	}
+	if len(Analyse(nil, nil, nil, Exclusions{}, DefaultOptions()).Drivers) != 0 {
+		t.Error("analysed drivers without laps")
+	}
+}
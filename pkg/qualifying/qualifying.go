@@ -0,0 +1,148 @@
+// Package qualifying evaluates a qualifying classification: gaps measured in
+// the segment each driver reached and the 107% rule.
+package qualifying
+
+import (
+	"fmt"
+	"strings"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+)
+
+// Drivers slower than this percentage of the fastest Q1 lap are outside the
+// 107% rule and need the permission of the stewards to start
+const MaxPercentOfFastest = 107
+
+type Evaluation struct {
+	m.QualifyingResult
+	// Segment is the last segment the driver took part in, the gaps are
+	// measured against the times set in it
+	Segment     string
+	SegmentTime *m.LapTime
+	GapToPole   *m.LapTime
+	Interval    *m.LapTime
+	Within107   bool
+}
+
+type Report struct {
+	FastestQ1    *m.LapTime
+	Threshold107 *m.LapTime
+	Results      []Evaluation
+}
+
+// Threshold returns the slowest lap time within the 107% rule, rounded up to
+// the millisecond so that a lap of exactly 107% is within it
+func Threshold(fastest m.LapTime) m.LapTime {
+	return m.LapTimeFromMillis((fastest.Milliseconds()*MaxPercentOfFastest + 99) / 100)
+}
+
+func gap(time, reference *m.LapTime) *m.LapTime {
+	if time == nil || reference == nil {
+		return nil
+	}
+	gap := *time - *reference
+	return &gap
+}
+
+// Evaluate computes the gaps and the 107% check of results ordered by
+// position
+func Evaluate(results []m.QualifyingResult) Report {
+	report := Report{Results: make([]Evaluation, 0, len(results))}
+
+	for _, result := range results {
+		if result.Q1 != nil && (report.FastestQ1 == nil || *result.Q1 < *report.FastestQ1) {
+			q1 := *result.Q1
+			report.FastestQ1 = &q1
+		}
+	}
+	if report.FastestQ1 != nil {
+		threshold := Threshold(*report.FastestQ1)
+		report.Threshold107 = &threshold
+	}
+
+	var pole *m.QualifyingResult
+	for i, result := range results {
+		if result.Position == 1 {
+			pole = &results[i]
+		}
+	}
+
+	for i, result := range results {
+		segment := result.LastSegment()
+		evaluation := Evaluation{
+			QualifyingResult: result,
+			Segment:          segment,
+			SegmentTime:      result.Time(segment),
+			Within107:        result.Q1 != nil && report.Threshold107 != nil && *result.Q1 <= *report.Threshold107,
+		}
+		if pole != nil && result.Position != 1 {
+			evaluation.GapToPole = gap(evaluation.SegmentTime, pole.Time(segment))
+		}
+		if i > 0 {
+			evaluation.Interval = gap(evaluation.SegmentTime, results[i-1].Time(segment))
+		}
+		if evaluation.Explanation == "" {
+			evaluation.Explanation = Explain(result, evaluation.Within107)
+		}
+		report.Results = append(report.Results, evaluation)
+	}
+
+	return report
+}
+
+// Explain describes how the driver ended up in their position when no
+// explanation was given by the submitter
+func Explain(result m.QualifyingResult, within107 bool) string {
+	if result.Q1 == nil && result.Q2 == nil && result.Q3 == nil {
+		if result.Q1Deleted {
+			return "No valid time set, all Q1 laps deleted"
+		}
+		return "No time set"
+	}
+
+	segment := result.LastSegment()
+	var explanation string
+	switch {
+	case result.EliminatedIn != "":
+		explanation = fmt.Sprintf("Eliminated in %s", segment)
+	case result.Position == 1:
+		explanation = "Pole position"
+	default:
+		explanation = fmt.Sprintf("Qualified P%d in Q3", result.Position)
+	}
+
+	if result.Deleted(segment) {
+		explanation += fmt.Sprintf(", fastest %s lap deleted", segment)
+	}
+	if !within107 {
+		explanation += ", outside 107% of the fastest Q1 time"
+	}
+	return explanation
+}
+
+// Filter keeps the drivers who took part in the segment and, when max time is
+// given, whose best lap in it was not slower than max time. An empty segment
+// keeps every driver and compares max time with their final segment time
+func (r Report) Filter(segment string, maxTime *m.LapTime) Report {
+	filtered := Report{FastestQ1: r.FastestQ1, Threshold107: r.Threshold107, Results: []Evaluation{}}
+
+	for _, evaluation := range r.Results {
+		time := evaluation.SegmentTime
+		if segment != "" {
+			if !reached(evaluation.LastSegment(), segment) {
+				continue
+			}
+			time = evaluation.Time(segment)
+		}
+		if maxTime != nil && (time == nil || *time > *maxTime) {
+			continue
+		}
+		filtered.Results = append(filtered.Results, evaluation)
+	}
+
+	return filtered
+}
+
+func reached(last, segment string) bool {
+	return strings.Compare(last, segment) >= 0
+}
@@ -0,0 +1,118 @@
+package qualifying
+
+import (
+	"testing"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+func lapTime(millis int64) *m.LapTime {
+	t := m.LapTimeFromMillis(millis)
+	return &t
+}
+
+func TestThreshold(t *testing.T) {
+	tests := []struct {
+		fastest   int64
+		threshold int64
+	}{
+		{80000, 85600},
+		// 107% of 80.001s is 85.60107s, a lap of 85.602s is the first outside
+		{80001, 85602},
+		{90123, 96432},
+		{100, 107},
+		{1, 2},
+		{0, 0},
+	}
+	for _, tt := range tests {
+		if got := Threshold(m.LapTimeFromMillis(tt.fastest)).Milliseconds(); got != tt.threshold {
+			t.Errorf("threshold of %d ms is %d, want %d", tt.fastest, got, tt.threshold)
+		}
+	}
+}
+
+func result(position int, eliminated string, q1, q2, q3 *m.LapTime) m.QualifyingResult {
+	return m.QualifyingResult{
+		DriverID:     uuid.New(),
+		Position:     position,
+		Q1:           q1,
+		Q2:           q2,
+		Q3:           q3,
+		EliminatedIn: eliminated,
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	results := []m.QualifyingResult{
+		result(1, "", lapTime(80500), lapTime(80200), lapTime(79900)),
+		result(2, "", lapTime(80000), lapTime(80300), lapTime(80100)),
+		result(3, m.SegmentQ2, lapTime(80900), lapTime(80800), nil),
+		// exactly 107% of the fastest Q1 lap
+		result(4, m.SegmentQ1, lapTime(85600), nil, nil),
+		result(5, m.SegmentQ1, lapTime(85601), nil, nil),
+		result(6, m.SegmentQ1, nil, nil, nil),
+	}
+
+	report := Evaluate(results)
+	if report.FastestQ1.Milliseconds() != 80000 || report.Threshold107.Milliseconds() != 85600 {
+		t.Fatalf("fastest Q1 %v and threshold %v", report.FastestQ1, report.Threshold107)
+	}
+
+	tests := []struct {
+		segment   string
+		gapToPole *int64
+		interval  *int64
+		within107 bool
+	}{
+		{m.SegmentQ3, nil, nil, true},
+		{m.SegmentQ3, ptr(200), ptr(200), true},
+		{m.SegmentQ2, ptr(600), ptr(500), true},
+		{m.SegmentQ1, ptr(5100), ptr(4700), true},
+		{m.SegmentQ1, ptr(5101), ptr(1), false},
+		{m.SegmentQ1, nil, nil, false},
+	}
+	for i, tt := range tests {
+		evaluation := report.Results[i]
+		if evaluation.Segment != tt.segment {
+			t.Errorf("P%d evaluated in %s, want %s", i+1, evaluation.Segment, tt.segment)
+		}
+		if !sameGap(evaluation.GapToPole, tt.gapToPole) {
+			t.Errorf("P%d gap to pole is %v, want %v", i+1, evaluation.GapToPole, tt.gapToPole)
+		}
+		if !sameGap(evaluation.Interval, tt.interval) {
+			t.Errorf("P%d interval is %v, want %v", i+1, evaluation.Interval, tt.interval)
+		}
+		if evaluation.Within107 != tt.within107 {
+			t.Errorf("P%d within 107%% is %v", i+1, evaluation.Within107)
+		}
+	}
+
+	if got := report.Results[4].Explanation; got != "Eliminated in Q1, outside 107% of the fastest Q1 time" {
+		t.Errorf("explained P5 as %q", got)
+	}
+	if got := report.Results[5].Explanation; got != "No time set" {
+		t.Errorf("explained P6 as %q", got)
+	}
+}
+
+func TestEvaluateWithoutTimes(t *testing.T) {
+	report := Evaluate([]m.QualifyingResult{result(1, m.SegmentQ1, nil, nil, nil)})
+	if report.FastestQ1 != nil || report.Threshold107 != nil || report.Results[0].Within107 {
+		t.Errorf("evaluated %+v", report)
+	}
+	if len(Evaluate(nil).Results) != 0 {
+		t.Error("evaluated results out of nothing")
+	}
+}
+
+func ptr(millis int64) *int64 {
+	return &millis
+}
+
+func sameGap(gap *m.LapTime, millis *int64) bool {
+	if gap == nil || millis == nil {
+		return gap == nil && millis == nil
+	}
+	return gap.Milliseconds() == *millis
+}
@@ -0,0 +1,143 @@
+// Package racecontrol derives the periods a race was neutralized from the
+// messages of race control.
+package racecontrol
+
+import (
+	"sort"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+)
+
+const (
+	SafetyCar        = "safety_car"
+	VirtualSafetyCar = "virtual_safety_car"
+	RedFlag          = "red_flag"
+)
+
+// Period is a neutralization of the race. End is nil while it has not been
+// closed, EndLap is nil when the event closing it has no lap
+type Period struct {
+	Type     string
+	Start    time.Time
+	End      *time.Time
+	StartLap *int
+	EndLap   *int
+}
+
+// Covers reports whether the lap was run at least partly under the period,
+// a period still open covers every lap from its start
+func (p Period) Covers(lap int) bool {
+	if p.StartLap == nil || lap < *p.StartLap {
+		return false
+	}
+	if p.End == nil {
+		return true
+	}
+	if p.EndLap == nil {
+		return lap == *p.StartLap
+	}
+	return lap <= *p.EndLap
+}
+
+// Neutralizations returns the Safety Car, Virtual Safety Car and red flag
+// periods in start order. A Safety Car deployed during a Virtual Safety Car
+// ends the latter, a green flag for the whole track ends a red flag and any
+// period still open and the chequered flag ends them all
+func Neutralizations(events []m.RaceControlEvent) []Period {
+	sorted := append([]m.RaceControlEvent{}, events...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	periods := []Period{}
+	open := make(map[string]int)
+	start := func(kind string, event m.RaceControlEvent) {
+		if _, ok := open[kind]; ok {
+			return
+		}
+		open[kind] = len(periods)
+		periods = append(periods, Period{Type: kind, Start: event.Time, StartLap: event.Lap})
+	}
+	end := func(kind string, event m.RaceControlEvent) {
+		i, ok := open[kind]
+		if !ok {
+			return
+		}
+		t := event.Time
+		periods[i].End = &t
+		periods[i].EndLap = event.Lap
+		delete(open, kind)
+	}
+
+	for _, event := range sorted {
+		switch event.Type {
+		case m.EventSafetyCarDeployed:
+			end(VirtualSafetyCar, event)
+			start(SafetyCar, event)
+		case m.EventSafetyCarEnding:
+			end(SafetyCar, event)
+		case m.EventVirtualSafetyCar:
+			start(VirtualSafetyCar, event)
+		case m.EventVirtualSafetyCarEnd:
+			end(VirtualSafetyCar, event)
+		case m.EventRedFlag:
+			start(RedFlag, event)
+		case m.EventGreenFlag:
+			if event.Sector == nil {
+				for _, kind := range []string{RedFlag, SafetyCar, VirtualSafetyCar} {
+					end(kind, event)
+				}
+			}
+		case m.EventChequeredFlag:
+			for _, kind := range []string{RedFlag, SafetyCar, VirtualSafetyCar} {
+				end(kind, event)
+			}
+		}
+	}
+
+	return periods
+}
+
+// NeutralizedLaps returns the laps covered by any of the periods
+func NeutralizedLaps(periods []Period, laps []m.Lap) map[int]bool {
+	neutralized := make(map[int]bool)
+	for _, lap := range laps {
+		if neutralized[lap.Number] {
+			continue
+		}
+		for _, period := range periods {
+			if period.Covers(lap.Number) {
+				neutralized[lap.Number] = true
+				break
+			}
+		}
+	}
+	return neutralized
+}
+
+// CoveredLaps returns the laps up to lastLap covered by any of the periods
+func CoveredLaps(periods []Period, lastLap int) []int {
+	covered := []int{}
+	for lap := 1; lap <= lastLap; lap++ {
+		for _, period := range periods {
+			if period.Covers(lap) {
+				covered = append(covered, lap)
+				break
+			}
+		}
+	}
+	return covered
+}
+
+// ExcludeNeutralized returns the laps not covered by any of the periods
+func ExcludeNeutralized(periods []Period, laps []m.Lap) []m.Lap {
+	neutralized := NeutralizedLaps(periods, laps)
+	kept := []m.Lap{}
+	for _, lap := range laps {
+		if !neutralized[lap.Number] {
+			kept = append(kept, lap)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,110 @@
+package racecontrol
+
+import (
+	"testing"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+)
+
+var start = time.Date(2021, 12, 12, 13, 0, 0, 0, time.UTC)
+
+func event(minute int, kind string, lap int) m.RaceControlEvent {
+	e := m.RaceControlEvent{Time: start.Add(time.Duration(minute) * time.Minute), Type: kind}
+	if lap > 0 {
+		e.Lap = &lap
+	}
+	return e
+}
+
+func sector(e m.RaceControlEvent, s int) m.RaceControlEvent {
+	e.Sector = &s
+	return e
+}
+
+func laps(numbers ...int) []m.Lap {
+	result := []m.Lap{}
+	for _, n := range numbers {
+		result = append(result, m.Lap{Number: n})
+	}
+	return result
+}
+
+func TestNeutralizations(t *testing.T) {
+	events := []m.RaceControlEvent{
+		event(90, m.EventChequeredFlag, 58),
+		event(10, m.EventVirtualSafetyCar, 36),
+		event(12, m.EventVirtualSafetyCarEnd, 37),
+		sector(event(20, m.EventYellowFlag, 40), 2),
+		sector(event(21, m.EventGreenFlag, 40), 2),
+		event(30, m.EventSafetyCarDeployed, 53),
+		event(40, m.EventSafetyCarEnding, 57),
+		event(41, m.EventGreenFlag, 58),
+	}
+
+	periods := Neutralizations(events)
+	if len(periods) != 2 {
+		t.Fatalf("got %d periods, want 2", len(periods))
+	}
+	vsc, sc := periods[0], periods[1]
+	if vsc.Type != VirtualSafetyCar || *vsc.StartLap != 36 || *vsc.EndLap != 37 {
+		t.Errorf("got %+v, want a VSC on laps 36-37", vsc)
+	}
+	if sc.Type != SafetyCar || *sc.StartLap != 53 || *sc.EndLap != 57 || !sc.End.Equal(start.Add(40*time.Minute)) {
+		t.Errorf("got %+v, want a Safety Car on laps 53-57", sc)
+	}
+
+	neutralized := NeutralizedLaps(periods, laps(35, 36, 37, 38, 52, 53, 57, 58))
+	for lap, want := range map[int]bool{35: false, 36: true, 37: true, 38: false, 52: false, 53: true, 57: true, 58: false} {
+		if neutralized[lap] != want {
+			t.Errorf("lap %d neutralized %v, want %v", lap, neutralized[lap], want)
+		}
+	}
+}
+
+func TestSafetyCarReplacesVirtualSafetyCar(t *testing.T) {
+	periods := Neutralizations([]m.RaceControlEvent{
+		event(0, m.EventVirtualSafetyCar, 10),
+		event(1, m.EventSafetyCarDeployed, 11),
+		event(2, m.EventSafetyCarDeployed, 11),
+		event(8, m.EventSafetyCarEnding, 14),
+	})
+	if len(periods) != 2 || periods[0].Type != VirtualSafetyCar || *periods[0].EndLap != 11 || *periods[1].EndLap != 14 {
+		t.Errorf("got %+v", periods)
+	}
+}
+
+func TestRedFlag(t *testing.T) {
+	periods := Neutralizations([]m.RaceControlEvent{
+		event(0, m.EventSafetyCarDeployed, 1),
+		event(2, m.EventRedFlag, 2),
+		event(50, m.EventGreenFlag, 0),
+	})
+	if len(periods) != 2 || periods[1].Type != RedFlag || periods[1].End == nil {
+		t.Fatalf("got %+v", periods)
+	}
+	// the green flag closes both periods without a lap
+	if periods[0].Covers(2) || !periods[0].Covers(1) {
+		t.Errorf("Safety Car closed without a lap covers %+v", periods[0])
+	}
+}
+
+func TestOpenPeriodCoversLaterLaps(t *testing.T) {
+	periods := Neutralizations([]m.RaceControlEvent{event(0, m.EventSafetyCarDeployed, 5)})
+	kept := ExcludeNeutralized(periods, laps(3, 4, 5, 6))
+	if len(kept) != 2 || kept[1].Number != 4 {
+		t.Errorf("kept %+v, want laps 3 and 4", kept)
+	}
+}
+
+func TestCoveredLaps(t *testing.T) {
+	periods := Neutralizations([]m.RaceControlEvent{
+		event(0, m.EventVirtualSafetyCar, 2),
+		event(1, m.EventVirtualSafetyCarEnd, 3),
+		event(5, m.EventSafetyCarDeployed, 6),
+	})
+	covered := CoveredLaps(periods, 8)
+	if len(covered) != 5 || covered[0] != 2 || covered[2] != 6 || covered[4] != 8 {
+		t.Errorf("got %v, want [2 3 6 7 8]", covered)
+	}
+}
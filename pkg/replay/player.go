@@ -0,0 +1,316 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Playback controls
+const (
+	ActionPause  = "pause"
+	ActionResume = "resume"
+	ActionSeek   = "seek"
+	ActionSpeed  = "speed"
+)
+
+var ErrClosed = errors.New("replay has been closed")
+
+// Clock tells the time of the playback, tests replace SystemClock to play
+// back deterministically
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+type systemClock struct{}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+var SystemClock Clock = systemClock{}
+
+// Control changes the playback, Offset is the target of a seek and Speed the
+// multiplier of a change of speed
+type Control struct {
+	Action string
+	Offset time.Duration
+	Speed  float64
+}
+
+// Player plays a replay back for a viewer. Its playhead only moves within
+// Run, controls are passed to it so they apply between two events. Owner is
+// the user who opened it, the only one who may control it
+type Player struct {
+	ID       uuid.UUID
+	Owner    string
+	replay   *Replay
+	clock    Clock
+	controls chan Control
+	done     chan struct{}
+
+	speed    float64
+	paused   bool
+	position time.Duration
+	// anchor is the time the playhead was at position
+	anchor time.Time
+	// next is the index of the next event to emit
+	next  int
+	ended bool
+}
+
+func newPlayer(replay *Replay, clock Clock, start Control) *Player {
+	p := &Player{
+		ID:       uuid.New(),
+		replay:   replay,
+		clock:    clock,
+		controls: make(chan Control),
+		done:     make(chan struct{}),
+		speed:    1,
+	}
+	if start.Speed > 0 {
+		p.speed = start.Speed
+	}
+	p.paused = start.Action == ActionPause
+	p.seek(start.Offset)
+	return p
+}
+
+// RaceID returns the race played back
+func (p *Player) RaceID() uuid.UUID {
+	return p.replay.RaceID
+}
+
+// Start returns the start of the race played back
+func (p *Player) Start() time.Time {
+	return p.replay.Start
+}
+
+// Control passes the control to the playback, it fails once Run returned
+func (p *Player) Control(control Control) error {
+	select {
+	case p.controls <- control:
+		return nil
+	case <-p.done:
+		return ErrClosed
+	}
+}
+
+// Run emits the events of the replay as the clock reaches them, starting
+// with the state of the playback and the running order at the playhead. It
+// returns when the context is done or emit fails, the end of the race is
+// reported but the playback can still be sought back
+func (p *Player) Run(ctx context.Context, emit func(Event) error) error {
+	defer close(p.done)
+
+	p.anchor = p.clock.Now()
+	if err := p.emitState(emit, true); err != nil {
+		return err
+	}
+
+	events := p.replay.Events
+	for {
+		var fire <-chan time.Time
+		var timer Timer
+		switch {
+		case p.next < len(events) && !p.paused:
+			timer = p.clock.NewTimer(time.Duration(float64(events[p.next].Offset-p.position) / p.speed))
+			fire = timer.C()
+		case p.next == len(events) && !p.ended:
+			p.ended = true
+			if err := emit(Event{Type: EventEnd, Offset: p.replay.Duration}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			stop(timer)
+			return nil
+
+		case control := <-p.controls:
+			stop(timer)
+			p.settle(p.clock.Now())
+			snapshot := p.apply(control)
+			if err := p.emitState(emit, snapshot); err != nil {
+				return err
+			}
+
+		case <-fire:
+			p.settle(p.clock.Now())
+			// the timer was set for the event, rounding cannot keep it back
+			p.position = max(p.position, events[p.next].Offset)
+			for ; p.next < len(events) && events[p.next].Offset <= p.position; p.next++ {
+				if err := emit(events[p.next]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func stop(timer Timer) {
+	if timer != nil {
+		timer.Stop()
+	}
+}
+
+// settle moves the playhead to the time
+func (p *Player) settle(now time.Time) {
+	if !p.paused {
+		p.position += time.Duration(float64(now.Sub(p.anchor)) * p.speed)
+	}
+	p.anchor = now
+}
+
+// apply changes the playback, reporting whether the running order has to be
+// sent again
+func (p *Player) apply(control Control) bool {
+	switch control.Action {
+	case ActionPause:
+		p.paused = true
+	case ActionResume:
+		p.paused = false
+	case ActionSpeed:
+		if control.Speed > 0 {
+			p.speed = control.Speed
+		}
+	case ActionSeek:
+		p.seek(control.Offset)
+		return true
+	}
+	return false
+}
+
+// seek moves the playhead to the offset, the events at the offset itself
+// are emitted next
+func (p *Player) seek(offset time.Duration) {
+	p.position = min(max(offset, 0), p.replay.Duration)
+	events := p.replay.Events
+	p.next = sort.Search(len(events), func(i int) bool { return events[i].Offset >= p.position })
+	p.ended = false
+}
+
+func (p *Player) emitState(emit func(Event) error, snapshot bool) error {
+	state := State{Viewer: p.ID, Offset: p.position, Duration: p.replay.Duration, Speed: p.speed, Paused: p.paused}
+	if err := emit(Event{Type: EventState, Offset: p.position, Data: state}); err != nil {
+		return err
+	}
+	if !snapshot {
+		return nil
+	}
+	// the events at the playhead itself are still to come
+	return emit(Event{Type: EventSnapshot, Offset: p.position, Data: p.replay.SnapshotAt(p.position - 1)})
+}
+
+// Loader loads the replay of a race
+type Loader func(raceID uuid.UUID) (*Replay, error)
+
+// Library hands out players of races. A race is loaded by its first viewer
+// and shared by all viewers joining while it is played, it is released with
+// its last viewer
+type Library struct {
+	load  Loader
+	clock Clock
+
+	mu      sync.Mutex
+	races   map[uuid.UUID]*loaded
+	players map[uuid.UUID]*Player
+}
+
+type loaded struct {
+	ready   chan struct{}
+	replay  *Replay
+	err     error
+	viewers int
+}
+
+func NewLibrary(load Loader, clock Clock) *Library {
+	return &Library{load: load, clock: clock, races: make(map[uuid.UUID]*loaded), players: make(map[uuid.UUID]*Player)}
+}
+
+// Open returns a new player of the race owned by the user, which has to be
+// closed. Viewers joining while the race is loaded wait for that load
+func (l *Library) Open(raceID uuid.UUID, owner string, start Control) (*Player, error) {
+	l.mu.Lock()
+	race, ok := l.races[raceID]
+	if !ok {
+		race = &loaded{ready: make(chan struct{})}
+		l.races[raceID] = race
+	}
+	race.viewers++
+	l.mu.Unlock()
+
+	if ok {
+		<-race.ready
+	} else {
+		race.replay, race.err = l.load(raceID)
+		close(race.ready)
+	}
+	if race.err != nil {
+		l.release(raceID, race)
+		return nil, race.err
+	}
+
+	player := newPlayer(race.replay, l.clock, start)
+	player.Owner = owner
+	l.mu.Lock()
+	l.players[player.ID] = player
+	l.mu.Unlock()
+	return player, nil
+}
+
+// Player returns the open player of the viewer, false when the user does not
+// own it
+func (l *Library) Player(viewer uuid.UUID, owner string) (*Player, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	player, ok := l.players[viewer]
+	if !ok || player.Owner != owner {
+		return nil, false
+	}
+	return player, true
+}
+
+// Close releases the player and the race with its last viewer
+func (l *Library) Close(player *Player) {
+	l.mu.Lock()
+	delete(l.players, player.ID)
+	race := l.races[player.RaceID()]
+	l.mu.Unlock()
+	if race != nil {
+		l.release(player.RaceID(), race)
+	}
+}
+
+func (l *Library) release(raceID uuid.UUID, race *loaded) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	race.viewers--
+	if race.viewers == 0 && l.races[raceID] == race {
+		delete(l.races, raceID)
+	}
+}
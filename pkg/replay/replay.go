@@ -0,0 +1,186 @@
+// Package replay plays a finished race back as if it were live, from its
+// stored laps, pit stops and race control messages.
+package replay
+
+import (
+	"sort"
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/laps"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+// Types of the events of a replay
+const (
+	EventPosition    = "position"
+	EventGap         = "gap"
+	EventPit         = "pit"
+	EventRaceControl = "race_control"
+	// EventState reports the playback after it started or changed
+	EventState = "state"
+	// EventSnapshot holds the running order at the playhead after a seek
+	EventSnapshot = "snapshot"
+	// EventEnd follows the last event of the race
+	EventEnd = "end"
+)
+
+// Event happens Offset into the race. Data holds a Position, Gap, Pit,
+// m.RaceControlEvent, State or Snapshot matching the type, nil for the end
+type Event struct {
+	Type   string
+	Offset time.Duration
+	Data   interface{}
+}
+
+// Position is the position of a driver as they complete a lap
+type Position struct {
+	DriverID uuid.UUID
+	Lap      int
+	Position int
+}
+
+// Gap is the time a driver completed a lap after the leader and, Interval,
+// after the driver one position ahead on the lap
+type Gap struct {
+	DriverID uuid.UUID
+	Lap      int
+	Gap      m.LapTime
+	Interval *m.LapTime
+}
+
+// Pit is a pit stop, happening as the driver completes their in-lap
+type Pit struct {
+	m.PitStop
+}
+
+// State is the playback of a viewer
+type State struct {
+	Viewer   uuid.UUID
+	Offset   time.Duration
+	Duration time.Duration
+	Speed    float64
+	Paused   bool
+}
+
+// Snapshot holds the last position and gap of every driver at an offset, in
+// running order
+type Snapshot struct {
+	Positions []Position
+	Gaps      []Gap
+}
+
+// Replay holds the events of a race in offset order, shared by its viewers
+type Replay struct {
+	RaceID uuid.UUID
+	Start  time.Time
+	Events []Event
+	// Duration is the offset of the last event
+	Duration time.Duration
+}
+
+// Build orders the events of the race from the start of the race session.
+// Laps are placed by the cumulative lap times of the driver, so a driver
+// missing a lap has no events from that lap on, and pit stops are placed at
+// the end of the in-lap. Race control messages keep their own time
+func Build(raceID uuid.UUID, start time.Time, raceLaps []m.Lap, stops []m.PitStop, messages []m.RaceControlEvent) *Replay {
+	r := &Replay{RaceID: raceID, Start: start, Events: []Event{}}
+	for _, message := range messages {
+		r.Events = append(r.Events, Event{Type: EventRaceControl, Offset: max(message.Time.Sub(start), 0), Data: message})
+	}
+
+	totals := laps.Totals(raceLaps)
+	for _, stop := range stops {
+		elapsed := totals[stop.DriverID]
+		if stop.Lap < 1 || stop.Lap > len(elapsed) {
+			continue
+		}
+		r.Events = append(r.Events, Event{Type: EventPit, Offset: elapsed[stop.Lap-1].Duration(), Data: Pit{stop}})
+	}
+
+	r.Events = append(r.Events, lapEvents(raceLaps, totals)...)
+	sort.SliceStable(r.Events, func(i, j int) bool { return r.Events[i].Offset < r.Events[j].Offset })
+	if len(r.Events) > 0 {
+		r.Duration = r.Events[len(r.Events)-1].Offset
+	}
+	return r
+}
+
+// lapEvents returns the position and gap of every driver completing a lap,
+// the gap being taken to the first driver completing it
+func lapEvents(raceLaps []m.Lap, totals map[uuid.UUID][]m.LapTime) []Event {
+	type running struct {
+		driver uuid.UUID
+		total  m.LapTime
+	}
+	byLap := make(map[int][]running)
+	positions := make(map[int]map[uuid.UUID]int)
+	for _, lap := range raceLaps {
+		elapsed := totals[lap.DriverID]
+		if lap.Number > len(elapsed) {
+			continue
+		}
+		byLap[lap.Number] = append(byLap[lap.Number], running{lap.DriverID, elapsed[lap.Number-1]})
+		if positions[lap.Number] == nil {
+			positions[lap.Number] = make(map[uuid.UUID]int)
+		}
+		positions[lap.Number][lap.DriverID] = lap.Position
+	}
+
+	events := []Event{}
+	for lap, drivers := range byLap {
+		sort.Slice(drivers, func(i, j int) bool { return drivers[i].total < drivers[j].total })
+		for i, driver := range drivers {
+			gap := Gap{DriverID: driver.driver, Lap: lap, Gap: driver.total - drivers[0].total}
+			if i > 0 {
+				interval := driver.total - drivers[i-1].total
+				gap.Interval = &interval
+			}
+			offset := driver.total.Duration()
+			events = append(events,
+				Event{Type: EventPosition, Offset: offset, Data: Position{DriverID: driver.driver, Lap: lap, Position: positions[lap][driver.driver]}},
+				Event{Type: EventGap, Offset: offset, Data: gap},
+			)
+		}
+	}
+	// the map leaves the laps unordered, the events of a lap are ordered by
+	// their offsets already
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Offset < events[j].Offset })
+	return events
+}
+
+// SnapshotAt returns the running order at the offset from the last lap each
+// driver completed, drivers on more laps first
+func (r *Replay) SnapshotAt(offset time.Duration) Snapshot {
+	positions := make(map[uuid.UUID]Position)
+	gaps := make(map[uuid.UUID]Gap)
+	for _, event := range r.Events {
+		if event.Offset > offset {
+			break
+		}
+		switch data := event.Data.(type) {
+		case Position:
+			positions[data.DriverID] = data
+		case Gap:
+			gaps[data.DriverID] = data
+		}
+	}
+
+	snapshot := Snapshot{Positions: []Position{}, Gaps: []Gap{}}
+	for _, position := range positions {
+		snapshot.Positions = append(snapshot.Positions, position)
+	}
+	sort.Slice(snapshot.Positions, func(i, j int) bool {
+		a, b := snapshot.Positions[i], snapshot.Positions[j]
+		if a.Lap != b.Lap {
+			return a.Lap > b.Lap
+		}
+		return a.Position < b.Position
+	})
+	for _, position := range snapshot.Positions {
+		if gap, ok := gaps[position.DriverID]; ok {
+			snapshot.Gaps = append(snapshot.Gaps, gap)
+		}
+	}
+	return snapshot
+}
@@ -0,0 +1,378 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+var (
+	race  = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	alpha = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	beta  = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	start = time.Date(2023, 9, 3, 13, 0, 0, 0, time.UTC)
+)
+
+func seconds(s float64) m.LapTime {
+	return m.LapTimeFromMillis(int64(s * 1000))
+}
+
+// testReplay is a three lap race. Alpha laps in 90 s, beta in 91 s and then
+// 95 s with a pit stop at the end of lap 2, passing alpha on lap 1. The
+// safety car comes out 100 s into the race
+func testReplay() *Replay {
+	laps := []m.Lap{
+		{DriverID: alpha, Number: 1, Position: 2, Time: seconds(90)},
+		{DriverID: alpha, Number: 2, Position: 1, Time: seconds(90)},
+		{DriverID: alpha, Number: 3, Position: 1, Time: seconds(90)},
+		{DriverID: beta, Number: 1, Position: 1, Time: seconds(89)},
+		{DriverID: beta, Number: 2, Position: 2, Time: seconds(95)},
+		{DriverID: beta, Number: 3, Position: 2, Time: seconds(91)},
+	}
+	stops := []m.PitStop{{DriverID: beta, Stop: 1, Lap: 2}}
+	messages := []m.RaceControlEvent{{Time: start.Add(100 * time.Second), Type: m.EventSafetyCarDeployed}}
+	return Build(race, start, laps, stops, messages)
+}
+
+func TestBuild(t *testing.T) {
+	r := testReplay()
+	want := []struct {
+		kind   string
+		offset time.Duration
+	}{
+		{EventPosition, 89 * time.Second}, {EventGap, 89 * time.Second},
+		{EventPosition, 90 * time.Second}, {EventGap, 90 * time.Second},
+		{EventRaceControl, 100 * time.Second},
+		{EventPosition, 180 * time.Second}, {EventGap, 180 * time.Second},
+		{EventPit, 184 * time.Second},
+		{EventPosition, 184 * time.Second}, {EventGap, 184 * time.Second},
+		{EventPosition, 270 * time.Second}, {EventGap, 270 * time.Second},
+		{EventPosition, 275 * time.Second}, {EventGap, 275 * time.Second},
+	}
+	if len(r.Events) != len(want) {
+		t.Fatalf("got %d events, want %d", len(r.Events), len(want))
+	}
+	for i, w := range want {
+		if r.Events[i].Type != w.kind || r.Events[i].Offset != w.offset {
+			t.Errorf("event %d is %s at %v, want %s at %v", i, r.Events[i].Type, r.Events[i].Offset, w.kind, w.offset)
+		}
+	}
+	if r.Duration != 275*time.Second {
+		t.Errorf("duration = %v, want 275 s", r.Duration)
+	}
+
+	gap := r.Events[3].Data.(Gap)
+	if gap.DriverID != alpha || gap.Gap != seconds(1) || gap.Interval == nil || *gap.Interval != seconds(1) {
+		t.Errorf("gap of alpha after lap 1 = %+v, want 1 s to beta", gap)
+	}
+	if leader := r.Events[1].Data.(Gap); leader.Gap != 0 || leader.Interval != nil {
+		t.Errorf("gap of the leader = %+v, want none", leader)
+	}
+}
+
+func TestSnapshotAt(t *testing.T) {
+	r := testReplay()
+	snapshot := r.SnapshotAt(200 * time.Second)
+	if len(snapshot.Positions) != 2 || snapshot.Positions[0].DriverID != alpha || snapshot.Positions[0].Lap != 2 {
+		t.Fatalf("positions = %+v, want alpha on lap 2 ahead of beta on lap 2", snapshot.Positions)
+	}
+	if len(snapshot.Gaps) != 2 || snapshot.Gaps[1].Gap != seconds(4) {
+		t.Errorf("gaps = %+v, want beta 4 s behind", snapshot.Gaps)
+	}
+	if empty := r.SnapshotAt(0); len(empty.Positions) != 0 {
+		t.Errorf("positions before the first lap = %+v", empty.Positions)
+	}
+}
+
+// fakeClock only moves when advanced
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at      time.Time
+	c       chan time.Time
+	stopped atomic.Bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	return !t.stopped.Swap(true)
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	timer := &fakeTimer{at: c.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		timer.c <- c.now
+		return timer
+	}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+// Advance moves the clock and fires the timers due
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		switch {
+		case timer.stopped.Load():
+		case !timer.at.After(c.now):
+			timer.c <- timer.at
+		default:
+			pending = append(pending, timer)
+		}
+	}
+	c.timers = pending
+}
+
+// waiting reports whether a timer is pending
+func (c *fakeClock) waiting() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, timer := range c.timers {
+		if !timer.stopped.Load() {
+			return true
+		}
+	}
+	return false
+}
+
+// viewer runs a player and collects its events
+type viewer struct {
+	t      *testing.T
+	clock  *fakeClock
+	player *Player
+	events chan Event
+	cancel context.CancelFunc
+}
+
+func watch(t *testing.T, r *Replay, start Control) *viewer {
+	clock := &fakeClock{now: start0}
+	player := newPlayer(r, clock, start)
+	ctx, cancel := context.WithCancel(context.Background())
+	v := &viewer{t: t, clock: clock, player: player, events: make(chan Event, 100), cancel: cancel}
+	go player.Run(ctx, func(e Event) error {
+		v.events <- e
+		return nil
+	})
+	t.Cleanup(cancel)
+	return v
+}
+
+var start0 = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// next returns the next event emitted
+func (v *viewer) next() Event {
+	v.t.Helper()
+	select {
+	case e := <-v.events:
+		return e
+	case <-time.After(time.Second):
+		v.t.Fatal("no event emitted")
+		return Event{}
+	}
+}
+
+// none checks that no event was emitted
+func (v *viewer) none() {
+	v.t.Helper()
+	select {
+	case e := <-v.events:
+		v.t.Fatalf("unexpected %s event at %v", e.Type, e.Offset)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+// advance waits for the player to wait on the clock and advances it
+func (v *viewer) advance(d time.Duration) {
+	v.t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !v.clock.waiting() {
+		if time.Now().After(deadline) {
+			v.t.Fatal("player is not waiting on the clock")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	v.clock.Advance(d)
+}
+
+func TestPlayerSpeed(t *testing.T) {
+	v := watch(t, testReplay(), Control{Speed: 10})
+	if state := v.next(); state.Type != EventState || state.Data.(State).Speed != 10 {
+		t.Fatalf("first event = %+v, want the state", state)
+	}
+	if snapshot := v.next(); snapshot.Type != EventSnapshot {
+		t.Fatalf("second event = %+v, want the running order", snapshot)
+	}
+
+	// 8.9 s at ten times the speed is lap 1 of beta
+	v.advance(8800 * time.Millisecond)
+	v.none()
+	v.advance(100 * time.Millisecond)
+	if e := v.next(); e.Type != EventPosition || e.Offset != 89*time.Second {
+		t.Fatalf("event = %s at %v, want the position of beta after 89 s", e.Type, e.Offset)
+	}
+	if e := v.next(); e.Type != EventGap {
+		t.Fatalf("event = %s, want the gap of beta", e.Type)
+	}
+	v.none()
+
+	// a clock jumping past several events emits them in order
+	v.advance(time.Second)
+	v.advance(time.Second)
+	for _, kind := range []string{EventPosition, EventGap, EventRaceControl} {
+		if e := v.next(); e.Type != kind {
+			t.Fatalf("event = %s, want %s", e.Type, kind)
+		}
+	}
+}
+
+func TestPlayerControls(t *testing.T) {
+	v := watch(t, testReplay(), Control{Action: ActionPause, Offset: 95 * time.Second})
+	state := v.next()
+	if s := state.Data.(State); !s.Paused || s.Offset != 95*time.Second {
+		t.Fatalf("state = %+v, want paused at 95 s", s)
+	}
+	snapshot := v.next().Data.(Snapshot)
+	if len(snapshot.Positions) != 2 {
+		t.Fatalf("running order at 95 s = %+v, want both drivers", snapshot.Positions)
+	}
+
+	if err := v.player.Control(Control{Action: ActionResume}); err != nil {
+		t.Fatal(err)
+	}
+	if s := v.next().Data.(State); s.Paused {
+		t.Fatal("still paused after resuming")
+	}
+	v.advance(5 * time.Second)
+	if e := v.next(); e.Type != EventRaceControl {
+		t.Fatalf("event = %s, want the safety car 5 s after 95 s", e.Type)
+	}
+
+	// seeking to the pit stop emits the running order before it, then the
+	// stop itself
+	if err := v.player.Control(Control{Action: ActionSeek, Offset: 184 * time.Second}); err != nil {
+		t.Fatal(err)
+	}
+	if s := v.next().Data.(State); s.Offset != 184*time.Second {
+		t.Fatalf("state after the seek = %+v", s)
+	}
+	if snapshot := v.next().Data.(Snapshot); snapshot.Positions[0].Lap != 2 || snapshot.Positions[1].Lap != 1 {
+		t.Fatalf("running order before the stop = %+v, want alpha on lap 2 and beta on lap 1", snapshot.Positions)
+	}
+	if e := v.next(); e.Type != EventPit {
+		t.Fatalf("event = %s, want the pit stop", e.Type)
+	}
+
+	// seeking past the end reports the end
+	if err := v.player.Control(Control{Action: ActionSeek, Offset: time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	v.next()
+	v.next()
+	for e := v.next(); e.Type != EventEnd; e = v.next() {
+	}
+
+	v.cancel()
+	deadline := time.After(time.Second)
+	for {
+		if err := v.player.Control(Control{Action: ActionPause}); errors.Is(err, ErrClosed) {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("player still running after the context was cancelled")
+		default:
+		}
+	}
+}
+
+func TestLibraryLoadsOnce(t *testing.T) {
+	var loads atomic.Int32
+	release := make(chan struct{})
+	library := NewLibrary(func(raceID uuid.UUID) (*Replay, error) {
+		loads.Add(1)
+		<-release
+		return testReplay(), nil
+	}, &fakeClock{now: start0})
+
+	players := make(chan *Player, 10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			player, err := library.Open(race, "alice", Control{})
+			if err != nil {
+				t.Error(err)
+			}
+			players <- player
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	opened := []*Player{}
+	for i := 0; i < 10; i++ {
+		opened = append(opened, <-players)
+	}
+	if loads.Load() != 1 {
+		t.Fatalf("race loaded %d times for 10 viewers, want once", loads.Load())
+	}
+	if opened[0].replay != opened[9].replay {
+		t.Error("viewers do not share the replay")
+	}
+	if player, ok := library.Player(opened[3].ID, "alice"); !ok || player != opened[3] {
+		t.Error("player not found by its viewer")
+	}
+	if _, ok := library.Player(opened[3].ID, "bob"); ok {
+		t.Error("player found for a user who did not open it")
+	}
+
+	for _, player := range opened {
+		library.Close(player)
+	}
+	if _, ok := library.Player(opened[3].ID, "alice"); ok {
+		t.Error("closed player still found")
+	}
+	if _, err := library.Open(race, "alice", Control{}); err != nil || loads.Load() != 2 {
+		t.Errorf("race loaded %d times after its last viewer left, want it loaded again", loads.Load())
+	}
+}
+
+func TestLibraryLoadError(t *testing.T) {
+	failed := errors.New("database is down")
+	var loads atomic.Int32
+	library := NewLibrary(func(raceID uuid.UUID) (*Replay, error) {
+		if loads.Add(1) == 1 {
+			return nil, failed
+		}
+		return testReplay(), nil
+	}, &fakeClock{now: start0})
+
+	if _, err := library.Open(race, "alice", Control{}); !errors.Is(err, failed) {
+		t.Fatalf("err = %v, want the load error", err)
+	}
+	if _, err := library.Open(race, "alice", Control{}); err != nil {
+		t.Fatalf("failed load was kept: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package routes
+
+import (
+	c "github.com/dewciu/f1_api/pkg/controllers"
+	"github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	CircuitsEndpoint = "/circuits"
+	LayoutsEndpoint  = "/layouts"
+	NearbyEndpoint   = "/nearby"
+	MapEndpoint      = "/map"
+)
+
+func AddCircuitsRoutes(rg *gin.RouterGroup, db *gorm.DB, middlewareHandlers ...gin.HandlerFunc) {
+	circuits := rg.Group(CircuitsEndpoint, middlewareHandlers...)
+	c := c.NewCircuitController(db)
+	{
+		circuits.GET("/", c.GetAllCircuits)
+		circuits.POST("/", c.CreateCircuit)
+		circuits.GET(NearbyEndpoint, c.GetNearbyCircuits)
+		circuits.GET("/:id", c.GetCircuitByID)
+		circuits.DELETE("/:id", c.DeleteCircuitByID)
+		circuits.PUT("/:id", c.UpdateCircuit)
+		circuits.POST("/:id"+LayoutsEndpoint, c.CreateCircuitLayout)
+		circuits.GET("/:id"+LayoutsEndpoint+"/:layout"+MapEndpoint, c.GetCircuitLayoutMap)
+	}
+}
+
+func GetCircuitPermissions() []models.Permission {
+	return []models.Permission{
+		{
+			Endpoint: CircuitsEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: CircuitsEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: CircuitsEndpoint + NearbyEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: CircuitsEndpoint + "/:id",
+			Method:   "GET",
+		},
+		{
+			Endpoint: CircuitsEndpoint + "/:id",
+			Method:   "DELETE",
+		},
+		{
+			Endpoint: CircuitsEndpoint + "/:id",
+			Method:   "PUT",
+		},
+		{
+			Endpoint: CircuitsEndpoint + "/:id" + LayoutsEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: CircuitsEndpoint + "/:id" + LayoutsEndpoint + "/:layout" + MapEndpoint,
+			Method:   "GET",
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package routes
+
+import (
+	c "github.com/dewciu/f1_api/pkg/controllers"
+	"github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	ConstructorsEndpoint = "/constructors"
+)
+
+func AddConstructorsRoutes(rg *gin.RouterGroup, db *gorm.DB, middlewareHandlers ...gin.HandlerFunc) {
+	constructors := rg.Group(ConstructorsEndpoint, middlewareHandlers...)
+	c := c.NewConstructorController(db)
+	{
+		constructors.GET("/", c.GetAllConstructors)
+		constructors.POST("/", c.CreateConstructor)
+		constructors.GET("/:id", c.GetConstructorByID)
+		constructors.DELETE("/:id", c.DeleteConstructorByID)
+		constructors.PUT("/:id", c.UpdateConstructor)
+		constructors.GET("/:id"+SeasonsEndpoint+"/:year", c.GetSeasonEntry)
+		constructors.PUT("/:id"+SeasonsEndpoint+"/:year", c.SaveSeasonEntry)
+	}
+}
+
+func GetConstructorPermissions() []models.Permission {
+	return []models.Permission{
+		{
+			Endpoint: ConstructorsEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: ConstructorsEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: ConstructorsEndpoint + "/:id",
+			Method:   "GET",
+		},
+		{
+			Endpoint: ConstructorsEndpoint + "/:id",
+			Method:   "DELETE",
+		},
+		{
+			Endpoint: ConstructorsEndpoint + "/:id",
+			Method:   "PUT",
+		},
+		{
+			Endpoint: ConstructorsEndpoint + "/:id" + SeasonsEndpoint + "/:year",
+			Method:   "GET",
+		},
+		{
+			Endpoint: ConstructorsEndpoint + "/:id" + SeasonsEndpoint + "/:year",
+			Method:   "PUT",
+		},
+	}
+}
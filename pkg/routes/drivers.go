@@ -0,0 +1,55 @@
+package routes
+
+import (
+	c "github.com/dewciu/f1_api/pkg/controllers"
+	"github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	DriversEndpoint = "/drivers"
+)
+
+func AddDriversRoutes(rg *gin.RouterGroup, db *gorm.DB, middlewareHandlers ...gin.HandlerFunc) {
+	drivers := rg.Group(DriversEndpoint, middlewareHandlers...)
+	rc := c.NewResultController(db)
+	c := c.NewDriverController(db)
+	{
+		drivers.GET("/", c.GetAllDrivers)
+		drivers.POST("/", c.CreateDriver)
+		drivers.GET("/:id", c.GetDriverByID)
+		drivers.DELETE("/:id", c.DeleteDriverByID)
+		drivers.PUT("/:id", c.UpdateDriver)
+		drivers.GET("/:id"+ResultsEndpoint, rc.GetDriverResults)
+	}
+}
+
+func GetDriverPermissions() []models.Permission {
+	return []models.Permission{
+		{
+			Endpoint: DriversEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: DriversEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: DriversEndpoint + "/:id",
+			Method:   "GET",
+		},
+		{
+			Endpoint: DriversEndpoint + "/:id",
+			Method:   "DELETE",
+		},
+		{
+			Endpoint: DriversEndpoint + "/:id",
+			Method:   "PUT",
+		},
+		{
+			Endpoint: DriversEndpoint + "/:id" + ResultsEndpoint,
+			Method:   "GET",
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	c "github.com/dewciu/f1_api/pkg/controllers"
+	"github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	ErgastEndpoint = "/ergast"
+	SeriesEndpoint = "/f1"
+)
+
+// AddErgastRoutes adds the Ergast-compatible read API. Like the Ergast API it
+// is public, the scripts and tools using it do not authenticate
+func AddErgastRoutes(rg *gin.RouterGroup, db *gorm.DB, handlers ...gin.HandlerFunc) {
+	ergast := rg.Group(ErgastEndpoint, handlers...)
+	c := c.NewErgastController(db)
+	{
+		ergast.GET(SeriesEndpoint+"/*path", c.GetErgast)
+	}
+}
+
+func GetErgastPermissions() []models.Permission {
+	return []models.Permission{
+		{
+			Endpoint: ErgastEndpoint + SeriesEndpoint + "/*path",
+			Method:   "GET",
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package routes
+
+import (
+	c "github.com/dewciu/f1_api/pkg/controllers"
+	"github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	ImportsEndpoint = "/imports"
+	OpenF1Endpoint  = "/openf1"
+	ReviewsEndpoint = "/reviews"
+)
+
+func AddImportsRoutes(rg *gin.RouterGroup, db *gorm.DB, middlewareHandlers ...gin.HandlerFunc) {
+	imports := rg.Group(ImportsEndpoint, middlewareHandlers...)
+	c := c.NewImportController(db)
+	{
+		imports.POST(OpenF1Endpoint, c.ImportOpenF1)
+		imports.GET(ReviewsEndpoint, c.GetImportReviews)
+		imports.DELETE(ReviewsEndpoint+"/:id", c.DeleteImportReview)
+	}
+}
+
+func GetImportPermissions() []models.Permission {
+	return []models.Permission{
+		{
+			Endpoint: ImportsEndpoint + OpenF1Endpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: ImportsEndpoint + ReviewsEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: ImportsEndpoint + ReviewsEndpoint + "/:id",
+			Method:   "DELETE",
+		},
+	}
+}
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"github.com/dewciu/f1_api/pkg/config"
+	c "github.com/dewciu/f1_api/pkg/controllers"
+	"github.com/dewciu/f1_api/pkg/live"
+	"github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	LiveEndpoint  = "/live"
+	StateEndpoint = "/state"
+)
+
+func AddLiveRoutes(rg *gin.RouterGroup, db *gorm.DB, hub *live.Hub, conf *config.Config, middlewareHandlers ...gin.HandlerFunc) {
+	lives := rg.Group(LiveEndpoint, middlewareHandlers...)
+	c := c.NewLiveController(db, hub, conf.Server.AllowedOrigins)
+	{
+		lives.GET("/:session"+StateEndpoint, c.GetLiveState)
+		lives.GET("/:session"+WebSocketEndpoint, c.SubscribeLive)
+	}
+}
+
+func GetLivePermissions() []models.Permission {
+	return []models.Permission{
+		{
+			Endpoint: LiveEndpoint + "/:session" + StateEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: LiveEndpoint + "/:session" + WebSocketEndpoint,
+			Method:   "GET",
+		},
+	}
+}
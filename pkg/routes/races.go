@@ -0,0 +1,306 @@
+package routes
+
+import (
+	"github.com/dewciu/f1_api/pkg/config"
+	c "github.com/dewciu/f1_api/pkg/controllers"
+	"github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	RacesEndpoint           = "/races"
+	SessionsEndpoint        = "/sessions"
+	NextEndpoint            = "/next"
+	ResultsEndpoint         = "/results"
+	QualifyingEndpoint      = "/qualifying"
+	GridEndpoint            = "/grid"
+	PenaltiesEndpoint       = "/penalties"
+	LapsEndpoint            = "/laps"
+	LapChartEndpoint        = "/chart"
+	GapChartEndpoint        = "/gaps"
+	FastestEndpoint         = "/fastest"
+	PitStopsEndpoint        = "/pit-stops"
+	StintsEndpoint          = "/stints"
+	StrategyEndpoint        = "/strategy"
+	RaceControlEndpoint     = "/race-control"
+	NeutralizationsEndpoint = "/neutralizations"
+	DecisionsEndpoint       = "/decisions"
+	VersionsEndpoint        = "/versions"
+	SprintEndpoint          = "/sprint"
+	ShootoutEndpoint        = "/shootout"
+	TelemetryEndpoint       = "/telemetry"
+	CompareEndpoint         = "/compare"
+	ReplayEndpoint          = "/replay"
+	WebSocketEndpoint       = "/ws"
+	WeatherEndpoint         = "/weather"
+	SummaryEndpoint         = "/summary"
+	PaceEndpoint            = "/pace"
+)
+
+func AddRacesRoutes(rg *gin.RouterGroup, db *gorm.DB, conf *config.Config, middlewareHandlers ...gin.HandlerFunc) {
+	races := rg.Group(RacesEndpoint, middlewareHandlers...)
+	rc := c.NewResultController(db)
+	lc := c.NewLapController(db)
+	sc := c.NewStrategyController(db)
+	rcc := c.NewRaceControlController(db)
+	stc := c.NewStewardController(db)
+	rpc := c.NewReplayController(db, conf.Server.AllowedOrigins)
+	c := c.NewRaceController(db)
+	{
+		races.GET("/:id", c.GetRaceByID)
+		races.DELETE("/:id", c.DeleteRaceByID)
+		races.PUT("/:id", c.UpdateRace)
+		races.GET("/:id"+SessionsEndpoint, c.GetRaceSessions)
+		races.POST("/:id"+SessionsEndpoint, c.CreateSession)
+		races.PUT("/:id"+SessionsEndpoint+"/:session", c.UpdateSession)
+		races.GET("/:id"+ResultsEndpoint, rc.GetRaceResults)
+		races.POST("/:id"+ResultsEndpoint, rc.SaveRaceResults)
+		races.GET("/:id"+QualifyingEndpoint, rc.GetRaceQualifying)
+		races.POST("/:id"+QualifyingEndpoint, rc.SaveRaceQualifying)
+		races.GET("/:id"+GridEndpoint, rc.GetRaceGrid)
+		races.GET("/:id"+SprintEndpoint, rc.GetSprintResults)
+		races.POST("/:id"+SprintEndpoint, rc.SaveSprintResults)
+		races.GET("/:id"+SprintEndpoint+ShootoutEndpoint, rc.GetSprintShootout)
+		races.POST("/:id"+SprintEndpoint+ShootoutEndpoint, rc.SaveSprintShootout)
+		races.GET("/:id"+SprintEndpoint+GridEndpoint, rc.GetSprintGrid)
+		races.GET("/:id"+GridEndpoint+PenaltiesEndpoint, rc.GetGridPenalties)
+		races.POST("/:id"+GridEndpoint+PenaltiesEndpoint, rc.CreateGridPenalty)
+		races.DELETE("/:id"+GridEndpoint+PenaltiesEndpoint+"/:penalty", rc.DeleteGridPenalty)
+		races.GET("/:id"+LapsEndpoint, lc.GetRaceLaps)
+		races.POST("/:id"+LapsEndpoint, lc.SaveRaceLaps)
+		races.GET("/:id"+LapsEndpoint+LapChartEndpoint, lc.GetLapChart)
+		races.GET("/:id"+LapsEndpoint+GapChartEndpoint, lc.GetGapChart)
+		races.GET("/:id"+LapsEndpoint+FastestEndpoint, lc.GetFastestLaps)
+		races.GET("/:id"+PaceEndpoint, lc.GetRacePace)
+		races.GET("/:id"+PitStopsEndpoint, sc.GetPitStops)
+		races.POST("/:id"+PitStopsEndpoint, sc.SavePitStops)
+		races.GET("/:id"+StintsEndpoint, sc.GetStints)
+		races.POST("/:id"+StintsEndpoint, sc.SaveStints)
+		races.GET("/:id"+StrategyEndpoint, sc.GetRaceStrategy)
+		races.GET("/:id"+RaceControlEndpoint, rcc.GetRaceControl)
+		races.POST("/:id"+RaceControlEndpoint, rcc.CreateRaceControlEvents)
+		races.GET("/:id"+NeutralizationsEndpoint, rcc.GetNeutralizations)
+		races.GET("/:id"+DecisionsEndpoint, stc.GetStewardDecisions)
+		races.POST("/:id"+DecisionsEndpoint, stc.CreateStewardDecision)
+		races.PUT("/:id"+DecisionsEndpoint+"/:decision", stc.UpdateStewardDecisionStatus)
+		races.GET("/:id"+ResultsEndpoint+VersionsEndpoint, stc.GetResultVersions)
+		races.GET("/:id"+ReplayEndpoint, rpc.StreamReplay)
+		races.GET("/:id"+ReplayEndpoint+WebSocketEndpoint, rpc.ReplayWebSocket)
+		races.POST("/:id"+ReplayEndpoint+"/:viewer", rpc.ControlReplay)
+	}
+}
+
+func GetRacePermissions() []models.Permission {
+	return []models.Permission{
+		{
+			Endpoint: RacesEndpoint + "/:id",
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id",
+			Method:   "DELETE",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id",
+			Method:   "PUT",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + SessionsEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + SessionsEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + SessionsEndpoint + "/:session",
+			Method:   "PUT",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + ResultsEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + ResultsEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + QualifyingEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + QualifyingEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + GridEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + SprintEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + SprintEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + SprintEndpoint + ShootoutEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + SprintEndpoint + ShootoutEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + SprintEndpoint + GridEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + GridEndpoint + PenaltiesEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + GridEndpoint + PenaltiesEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + GridEndpoint + PenaltiesEndpoint + "/:penalty",
+			Method:   "DELETE",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + LapsEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + LapsEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + LapsEndpoint + LapChartEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + LapsEndpoint + GapChartEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + LapsEndpoint + FastestEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + PaceEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + PitStopsEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + PitStopsEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + StintsEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + StintsEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + StrategyEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + RaceControlEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + RaceControlEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + NeutralizationsEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + DecisionsEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + DecisionsEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + DecisionsEndpoint + "/:decision",
+			Method:   "PUT",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + ResultsEndpoint + VersionsEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + ReplayEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + ReplayEndpoint + WebSocketEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: RacesEndpoint + "/:id" + ReplayEndpoint + "/:viewer",
+			Method:   "POST",
+		},
+	}
+}
+
+func AddSessionsRoutes(rg *gin.RouterGroup, db *gorm.DB, middlewareHandlers ...gin.HandlerFunc) {
+	sessions := rg.Group(SessionsEndpoint, middlewareHandlers...)
+	tc := c.NewTelemetryController(db)
+	wc := c.NewWeatherController(db)
+	c := c.NewRaceController(db)
+	{
+		sessions.GET(NextEndpoint, c.GetNextSession)
+		sessions.GET("/:id"+TelemetryEndpoint, tc.GetTelemetry)
+		sessions.POST("/:id"+TelemetryEndpoint, tc.SaveTelemetry)
+		sessions.DELETE("/:id"+TelemetryEndpoint, tc.DeleteTelemetry)
+		sessions.GET("/:id"+TelemetryEndpoint+CompareEndpoint, tc.CompareTelemetry)
+		sessions.GET("/:id"+WeatherEndpoint, wc.GetSessionWeather)
+		sessions.GET("/:id"+WeatherEndpoint+SummaryEndpoint, wc.GetWeatherSummary)
+	}
+}
+
+func GetSessionPermissions() []models.Permission {
+	return []models.Permission{
+		{
+			Endpoint: SessionsEndpoint + NextEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: SessionsEndpoint + "/:id" + TelemetryEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: SessionsEndpoint + "/:id" + TelemetryEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: SessionsEndpoint + "/:id" + TelemetryEndpoint,
+			Method:   "DELETE",
+		},
+		{
+			Endpoint: SessionsEndpoint + "/:id" + TelemetryEndpoint + CompareEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: SessionsEndpoint + "/:id" + WeatherEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: SessionsEndpoint + "/:id" + WeatherEndpoint + SummaryEndpoint,
+			Method:   "GET",
+		},
+	}
+}
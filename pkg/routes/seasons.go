@@ -0,0 +1,98 @@
+package routes
+
+import (
+	"github.com/dewciu/f1_api/pkg/config"
+	c "github.com/dewciu/f1_api/pkg/controllers"
+	"github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	SeasonsEndpoint     = "/seasons"
+	EntriesEndpoint     = "/entries"
+	StandingsEndpoint   = "/standings"
+	ContendersEndpoint  = "/contenders"
+	SimulationsEndpoint = "/simulations"
+	HeadToHeadEndpoint  = "/head-to-head"
+)
+
+func AddSeasonsRoutes(rg *gin.RouterGroup, db *gorm.DB, conf *config.Config, middlewareHandlers ...gin.HandlerFunc) {
+	seasons := rg.Group(SeasonsEndpoint, middlewareHandlers...)
+	sc := c.NewSeasonController(db)
+	cc := c.NewConstructorController(db)
+	stc := c.NewStandingsController(db)
+	smc := c.NewSimulationController(db, conf.Simulations.Concurrency)
+	{
+		seasons.GET("/", sc.GetAllSeasons)
+		seasons.POST("/", sc.CreateSeason)
+		seasons.GET("/:year"+RacesEndpoint, sc.GetSeasonRaces)
+		seasons.POST("/:year"+RacesEndpoint, sc.CreateRace)
+		seasons.GET("/:year"+EntriesEndpoint, cc.GetSeasonEntries)
+		seasons.GET("/:year"+StandingsEndpoint+DriversEndpoint, stc.GetDriverStandings)
+		seasons.GET("/:year"+StandingsEndpoint+ConstructorsEndpoint, stc.GetConstructorStandings)
+		seasons.GET("/:year"+StandingsEndpoint+ContendersEndpoint, stc.GetContenders)
+		seasons.POST("/:year"+StandingsEndpoint+ContendersEndpoint, stc.GetContendersWhatIf)
+		seasons.GET("/:year"+HeadToHeadEndpoint, stc.GetHeadToHead)
+		seasons.GET("/:year"+SimulationsEndpoint, smc.GetSeasonSimulations)
+		seasons.POST("/:year"+SimulationsEndpoint, smc.CreateSimulation)
+		seasons.GET("/:year"+SimulationsEndpoint+"/:id", smc.GetSimulation)
+	}
+}
+
+func GetSeasonPermissions() []models.Permission {
+	return []models.Permission{
+		{
+			Endpoint: SeasonsEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: SeasonsEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: SeasonsEndpoint + "/:year" + RacesEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: SeasonsEndpoint + "/:year" + RacesEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: SeasonsEndpoint + "/:year" + EntriesEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: SeasonsEndpoint + "/:year" + StandingsEndpoint + DriversEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: SeasonsEndpoint + "/:year" + StandingsEndpoint + ConstructorsEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: SeasonsEndpoint + "/:year" + StandingsEndpoint + ContendersEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: SeasonsEndpoint + "/:year" + StandingsEndpoint + ContendersEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: SeasonsEndpoint + "/:year" + HeadToHeadEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: SeasonsEndpoint + "/:year" + SimulationsEndpoint,
+			Method:   "GET",
+		},
+		{
+			Endpoint: SeasonsEndpoint + "/:year" + SimulationsEndpoint,
+			Method:   "POST",
+		},
+		{
+			Endpoint: SeasonsEndpoint + "/:year" + SimulationsEndpoint + "/:id",
+			Method:   "GET",
+		},
+	}
+}
@@ -1,6 +1,8 @@
 package routes
 
 import (
+	"github.com/dewciu/f1_api/pkg/config"
+	"github.com/dewciu/f1_api/pkg/live"
 	"github.com/dewciu/f1_api/pkg/middleware"
 	"github.com/gin-gonic/gin"
 	files "github.com/swaggo/files"
@@ -8,7 +10,7 @@ import (
 	"gorm.io/gorm"
 )
 
-func SetupRouter(DB *gorm.DB) *gin.Engine {
+func SetupRouter(DB *gorm.DB, hub *live.Hub, conf *config.Config) *gin.Engine {
 	r := gin.Default()
 	r.Handler()
 	v1 := r.Group("/api/v1")
@@ -18,12 +20,68 @@ func SetupRouter(DB *gorm.DB) *gin.Engine {
 		v1,
 		DB,
 	)
+	AddErgastRoutes(
+		v1,
+		DB,
+	)
 	AddUsersRoutes(
 		v1,
 		DB,
 		authMiddleware.CheckJWT(),
 		authMiddleware.CheckPermissions(v1.BasePath()),
 	)
+	AddDriversRoutes(
+		v1,
+		DB,
+		authMiddleware.CheckJWT(),
+		authMiddleware.CheckPermissions(v1.BasePath()),
+	)
+	AddConstructorsRoutes(
+		v1,
+		DB,
+		authMiddleware.CheckJWT(),
+		authMiddleware.CheckPermissions(v1.BasePath()),
+	)
+	AddCircuitsRoutes(
+		v1,
+		DB,
+		authMiddleware.CheckJWT(),
+		authMiddleware.CheckPermissions(v1.BasePath()),
+	)
+	AddSeasonsRoutes(
+		v1,
+		DB,
+		conf,
+		authMiddleware.CheckJWT(),
+		authMiddleware.CheckPermissions(v1.BasePath()),
+	)
+	AddRacesRoutes(
+		v1,
+		DB,
+		conf,
+		authMiddleware.CheckJWT(),
+		authMiddleware.CheckPermissions(v1.BasePath()),
+	)
+	AddSessionsRoutes(
+		v1,
+		DB,
+		authMiddleware.CheckJWT(),
+		authMiddleware.CheckPermissions(v1.BasePath()),
+	)
+	AddImportsRoutes(
+		v1,
+		DB,
+		authMiddleware.CheckJWT(),
+		authMiddleware.CheckPermissions(v1.BasePath()),
+	)
+	AddLiveRoutes(
+		v1,
+		DB,
+		hub,
+		conf,
+		authMiddleware.CheckJWT(),
+		authMiddleware.CheckPermissions(v1.BasePath()),
+	)
 	return r
 }
 
@@ -0,0 +1,90 @@
+package seeding
+
+import (
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/database"
+	"github.com/dewciu/f1_api/pkg/models"
+	"gorm.io/gorm"
+)
+
+type sampleRace struct {
+	round      int
+	name       string
+	circuitRef string
+	date       string
+	raceStart  string
+}
+
+var sampleRaces = []sampleRace{
+	{1, "Bahrain Grand Prix", "bahrain", "2023-03-05", "2023-03-05T15:00:00Z"},
+	{2, "Saudi Arabian Grand Prix", "jeddah", "2023-03-19", "2023-03-19T17:00:00Z"},
+	{3, "Australian Grand Prix", "albert_park", "2023-04-02", "2023-04-02T05:00:00Z"},
+	{4, "Azerbaijan Grand Prix", "baku", "2023-04-30", "2023-04-30T11:00:00Z"},
+	{5, "Miami Grand Prix", "miami", "2023-05-07", "2023-05-07T19:30:00Z"},
+	{6, "Monaco Grand Prix", "monaco", "2023-05-28", "2023-05-28T13:00:00Z"},
+	{7, "Spanish Grand Prix", "catalunya", "2023-06-04", "2023-06-04T13:00:00Z"},
+	{8, "Canadian Grand Prix", "villeneuve", "2023-06-18", "2023-06-18T18:00:00Z"},
+	{9, "Austrian Grand Prix", "red_bull_ring", "2023-07-02", "2023-07-02T13:00:00Z"},
+	{10, "British Grand Prix", "silverstone", "2023-07-09", "2023-07-09T14:00:00Z"},
+	{11, "Hungarian Grand Prix", "hungaroring", "2023-07-23", "2023-07-23T13:00:00Z"},
+	{12, "Belgian Grand Prix", "spa", "2023-07-30", "2023-07-30T13:00:00Z"},
+	{13, "Dutch Grand Prix", "zandvoort", "2023-08-27", "2023-08-27T13:00:00Z"},
+	{14, "Italian Grand Prix", "monza", "2023-09-03", "2023-09-03T13:00:00Z"},
+	{15, "Singapore Grand Prix", "marina_bay", "2023-09-17", "2023-09-17T12:00:00Z"},
+	{16, "Japanese Grand Prix", "suzuka", "2023-09-24", "2023-09-24T05:00:00Z"},
+	{17, "Qatar Grand Prix", "losail", "2023-10-08", "2023-10-08T14:00:00Z"},
+	{18, "United States Grand Prix", "americas", "2023-10-22", "2023-10-22T19:00:00Z"},
+	{19, "Mexico City Grand Prix", "rodriguez", "2023-10-29", "2023-10-29T20:00:00Z"},
+	{20, "São Paulo Grand Prix", "interlagos", "2023-11-05", "2023-11-05T17:00:00Z"},
+	{21, "Las Vegas Grand Prix", "vegas", "2023-11-18", "2023-11-19T06:00:00Z"},
+	{22, "Abu Dhabi Grand Prix", "yas_marina", "2023-11-26", "2023-11-26T13:00:00Z"},
+}
+
+// seedSampleCalendar creates the sample season with its races and their race
+// sessions, unless the season already has races
+func seedSampleCalendar(DB *gorm.DB, circuits map[string]models.Circuit) error {
+	season := models.Season{Year: SampleSeason}
+	if err := DB.Where(models.Season{Year: SampleSeason}).FirstOrCreate(&season).Error; err != nil {
+		return err
+	}
+
+	if DB.First(&models.Race{}, "year = ?", SampleSeason).RowsAffected > 0 {
+		return nil
+	}
+
+	raceRepo := database.NewRaceRepository(DB)
+	for _, r := range sampleRaces {
+		date, err := time.Parse("2006-01-02", r.date)
+		if err != nil {
+			return err
+		}
+		start, err := time.Parse(time.RFC3339, r.raceStart)
+		if err != nil {
+			return err
+		}
+
+		race, err := raceRepo.CreateRaceQuery(models.Race{
+			Year:      SampleSeason,
+			Round:     r.round,
+			Name:      r.name,
+			CircuitID: circuits[r.circuitRef].ID,
+			Date:      date,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = raceRepo.CreateSessionQuery(race, models.Session{
+			Type:      models.SessionRace,
+			StartTime: start,
+			EndTime:   start.Add(models.SessionDurations[models.SessionRace]),
+			Status:    models.SessionFinished,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package seeding
+
+import (
+	"github.com/dewciu/f1_api/pkg/models"
+	"gorm.io/gorm"
+)
+
+type sampleCircuit struct {
+	ref          string
+	ergastID     int
+	name         string
+	location     string
+	country      string
+	latitude     float64
+	longitude    float64
+	lengthMeters int
+	turns        int
+	direction    string
+}
+
+var sampleCircuits = []sampleCircuit{
+	{"bahrain", 3, "Bahrain International Circuit", "Sakhir", "BH", 26.0325, 50.5106, 5412, 15, models.DirectionClockwise},
+	{"jeddah", 77, "Jeddah Corniche Circuit", "Jeddah", "SA", 21.6319, 39.1044, 6174, 27, models.DirectionAnticlockwise},
+	{"albert_park", 1, "Albert Park Grand Prix Circuit", "Melbourne", "AU", -37.8497, 144.968, 5278, 14, models.DirectionClockwise},
+	{"baku", 73, "Baku City Circuit", "Baku", "AZ", 40.3725, 49.8533, 6003, 20, models.DirectionAnticlockwise},
+	{"miami", 79, "Miami International Autodrome", "Miami", "US", 25.9581, -80.2389, 5412, 19, models.DirectionAnticlockwise},
+	{"monaco", 6, "Circuit de Monaco", "Monte-Carlo", "MC", 43.7347, 7.42056, 3337, 19, models.DirectionClockwise},
+	{"catalunya", 4, "Circuit de Barcelona-Catalunya", "Montmeló", "ES", 41.57, 2.26111, 4657, 14, models.DirectionClockwise},
+	{"villeneuve", 7, "Circuit Gilles Villeneuve", "Montreal", "CA", 45.5, -73.5228, 4361, 14, models.DirectionClockwise},
+	{"red_bull_ring", 70, "Red Bull Ring", "Spielberg", "AT", 47.2197, 14.7647, 4318, 10, models.DirectionClockwise},
+	{"silverstone", 9, "Silverstone Circuit", "Silverstone", "GB", 52.0786, -1.01694, 5891, 18, models.DirectionClockwise},
+	{"hungaroring", 11, "Hungaroring", "Budapest", "HU", 47.5789, 19.2486, 4381, 14, models.DirectionClockwise},
+	{"spa", 13, "Circuit de Spa-Francorchamps", "Spa", "BE", 50.4372, 5.97139, 7004, 19, models.DirectionClockwise},
+	{"zandvoort", 39, "Circuit Park Zandvoort", "Zandvoort", "NL", 52.3888, 4.54092, 4259, 14, models.DirectionClockwise},
+	{"monza", 14, "Autodromo Nazionale di Monza", "Monza", "IT", 45.6156, 9.28111, 5793, 11, models.DirectionClockwise},
+	{"marina_bay", 15, "Marina Bay Street Circuit", "Marina Bay", "SG", 1.2914, 103.864, 4940, 19, models.DirectionAnticlockwise},
+	{"suzuka", 22, "Suzuka Circuit", "Suzuka", "JP", 34.8431, 136.541, 5807, 18, models.DirectionClockwise},
+	{"losail", 78, "Losail International Circuit", "Al Daayen", "QA", 25.49, 51.4542, 5419, 16, models.DirectionClockwise},
+	{"americas", 69, "Circuit of the Americas", "Austin", "US", 30.1328, -97.6411, 5513, 20, models.DirectionAnticlockwise},
+	{"rodriguez", 32, "Autódromo Hermanos Rodríguez", "Mexico City", "MX", 19.4042, -99.0907, 4304, 17, models.DirectionClockwise},
+	{"interlagos", 18, "Autódromo José Carlos Pace", "São Paulo", "BR", -23.7036, -46.6997, 4309, 15, models.DirectionAnticlockwise},
+	{"vegas", 80, "Las Vegas Strip Street Circuit", "Las Vegas", "US", 36.1147, -115.173, 6201, 17, models.DirectionAnticlockwise},
+	{"yas_marina", 24, "Yas Marina Circuit", "Abu Dhabi", "AE", 24.4672, 54.6031, 5281, 16, models.DirectionAnticlockwise},
+}
+
+// seedCircuits creates the circuits of the sample season with their current
+// layout, circuits which already exist are left untouched
+func seedCircuits(DB *gorm.DB) (map[string]models.Circuit, error) {
+	circuits := make(map[string]models.Circuit)
+
+	for _, c := range sampleCircuits {
+		ergastID := c.ergastID
+		circuit := models.Circuit{
+			Ref:       c.ref,
+			ErgastID:  &ergastID,
+			Name:      c.name,
+			Location:  c.location,
+			Country:   c.country,
+			Latitude:  c.latitude,
+			Longitude: c.longitude,
+			Layouts: []models.CircuitLayout{
+				{
+					Version:      1,
+					Name:         "Grand Prix Circuit",
+					LengthMeters: c.lengthMeters,
+					Turns:        c.turns,
+					Direction:    c.direction,
+				},
+			},
+		}
+		if err := DB.Where(models.Circuit{Ref: c.ref}).FirstOrCreate(&circuit).Error; err != nil {
+			return nil, err
+		}
+		circuits[c.ref] = circuit
+	}
+
+	return circuits, nil
+}
@@ -0,0 +1,183 @@
+package seeding
+
+import (
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/database"
+	"github.com/dewciu/f1_api/pkg/models"
+	"gorm.io/gorm"
+)
+
+const SampleSeason = 2023
+
+type sampleDriver struct {
+	ref         string
+	ergastID    int
+	number      int
+	code        string
+	firstName   string
+	lastName    string
+	dateOfBirth string
+	nationality string
+}
+
+type sampleLineUp struct {
+	driverRef  string
+	carNumber  int
+	startRound int
+	endRound   int
+}
+
+type sampleEntry struct {
+	ref         string
+	ergastID    int
+	name        string
+	nationality string
+	entrantName string
+	chassisName string
+	powerUnit   string
+	lineUps     []sampleLineUp
+}
+
+var sampleDrivers = []sampleDriver{
+	{"max_verstappen", 830, 33, "VER", "Max", "Verstappen", "1997-09-30", "NL"},
+	{"perez", 815, 11, "PER", "Sergio", "Pérez", "1990-01-26", "MX"},
+	{"hamilton", 1, 44, "HAM", "Lewis", "Hamilton", "1985-01-07", "GB"},
+	{"russell", 847, 63, "RUS", "George", "Russell", "1998-02-15", "GB"},
+	{"leclerc", 844, 16, "LEC", "Charles", "Leclerc", "1997-10-16", "MC"},
+	{"sainz", 832, 55, "SAI", "Carlos", "Sainz", "1994-09-01", "ES"},
+	{"norris", 846, 4, "NOR", "Lando", "Norris", "1999-11-13", "GB"},
+	{"piastri", 857, 81, "PIA", "Oscar", "Piastri", "2001-04-06", "AU"},
+	{"alonso", 4, 14, "ALO", "Fernando", "Alonso", "1981-07-29", "ES"},
+	{"stroll", 840, 18, "STR", "Lance", "Stroll", "1998-10-29", "CA"},
+	{"gasly", 842, 10, "GAS", "Pierre", "Gasly", "1996-02-07", "FR"},
+	{"ocon", 839, 31, "OCO", "Esteban", "Ocon", "1996-09-17", "FR"},
+	{"albon", 848, 23, "ALB", "Alexander", "Albon", "1996-03-23", "TH"},
+	{"sargeant", 858, 2, "SAR", "Logan", "Sargeant", "2000-12-31", "US"},
+	{"tsunoda", 852, 22, "TSU", "Yuki", "Tsunoda", "2000-05-11", "JP"},
+	{"de_vries", 856, 21, "DEV", "Nyck", "de Vries", "1995-02-26", "NL"},
+	{"ricciardo", 817, 3, "RIC", "Daniel", "Ricciardo", "1989-07-01", "AU"},
+	{"lawson", 859, 40, "LAW", "Liam", "Lawson", "2002-02-11", "NZ"},
+	{"bottas", 822, 77, "BOT", "Valtteri", "Bottas", "1989-08-28", "FI"},
+	{"zhou", 855, 24, "ZHO", "Guanyu", "Zhou", "1999-05-30", "CN"},
+	{"hulkenberg", 807, 27, "HUL", "Nico", "Hülkenberg", "1987-08-19", "DE"},
+	{"kevin_magnussen", 825, 20, "MAG", "Kevin", "Magnussen", "1992-10-05", "DK"},
+}
+
+// End round 0 means the driver stayed until the end of the season
+var sampleEntries = []sampleEntry{
+	{"red_bull", 9, "Red Bull", "AT", "Oracle Red Bull Racing", "RB19", "Honda RBPT", []sampleLineUp{
+		{"max_verstappen", 1, 1, 0},
+		{"perez", 11, 1, 0},
+	}},
+	{"mercedes", 131, "Mercedes", "DE", "Mercedes-AMG Petronas F1 Team", "W14", "Mercedes", []sampleLineUp{
+		{"hamilton", 44, 1, 0},
+		{"russell", 63, 1, 0},
+	}},
+	{"ferrari", 6, "Ferrari", "IT", "Scuderia Ferrari", "SF-23", "Ferrari", []sampleLineUp{
+		{"leclerc", 16, 1, 0},
+		{"sainz", 55, 1, 0},
+	}},
+	{"mclaren", 1, "McLaren", "GB", "McLaren F1 Team", "MCL60", "Mercedes", []sampleLineUp{
+		{"norris", 4, 1, 0},
+		{"piastri", 81, 1, 0},
+	}},
+	{"aston_martin", 117, "Aston Martin", "GB", "Aston Martin Aramco Cognizant F1 Team", "AMR23", "Mercedes", []sampleLineUp{
+		{"alonso", 14, 1, 0},
+		{"stroll", 18, 1, 0},
+	}},
+	{"alpine", 214, "Alpine F1 Team", "FR", "BWT Alpine F1 Team", "A523", "Renault", []sampleLineUp{
+		{"gasly", 10, 1, 0},
+		{"ocon", 31, 1, 0},
+	}},
+	{"williams", 3, "Williams", "GB", "Williams Racing", "FW45", "Mercedes", []sampleLineUp{
+		{"albon", 23, 1, 0},
+		{"sargeant", 2, 1, 0},
+	}},
+	{"alphatauri", 213, "AlphaTauri", "IT", "Scuderia AlphaTauri", "AT04", "Honda RBPT", []sampleLineUp{
+		{"tsunoda", 22, 1, 0},
+		{"de_vries", 21, 1, 10},
+		{"ricciardo", 3, 11, 12},
+		{"lawson", 40, 13, 17},
+		{"ricciardo", 3, 18, 0},
+	}},
+	{"alfa", 51, "Alfa Romeo", "CH", "Alfa Romeo F1 Team Stake", "C43", "Ferrari", []sampleLineUp{
+		{"bottas", 77, 1, 0},
+		{"zhou", 24, 1, 0},
+	}},
+	{"haas", 210, "Haas F1 Team", "US", "MoneyGram Haas F1 Team", "VF-23", "Ferrari", []sampleLineUp{
+		{"hulkenberg", 27, 1, 0},
+		{"kevin_magnussen", 20, 1, 0},
+	}},
+}
+
+// seedSampleSeason creates the drivers, constructors and entries of the
+// sample season, unless the season already has entries
+func seedSampleSeason(DB *gorm.DB) error {
+	if DB.First(&models.SeasonEntry{}, "year = ?", SampleSeason).RowsAffected > 0 {
+		return nil
+	}
+
+	drivers := make(map[string]models.Driver)
+	for _, d := range sampleDrivers {
+		dob, err := time.Parse("2006-01-02", d.dateOfBirth)
+		if err != nil {
+			return err
+		}
+		ergastID, number := d.ergastID, d.number
+		driver := models.Driver{
+			Ref:             d.ref,
+			ErgastID:        &ergastID,
+			PermanentNumber: &number,
+			Code:            d.code,
+			FirstName:       d.firstName,
+			LastName:        d.lastName,
+			DateOfBirth:     dob,
+			Nationality:     d.nationality,
+		}
+		if err := DB.Where(models.Driver{Ref: d.ref}).FirstOrCreate(&driver).Error; err != nil {
+			return err
+		}
+		drivers[d.ref] = driver
+	}
+
+	constructorRepo := database.NewConstructorRepository(DB)
+	for _, e := range sampleEntries {
+		ergastID := e.ergastID
+		constructor := models.Constructor{
+			Ref:         e.ref,
+			ErgastID:    &ergastID,
+			Name:        e.name,
+			Nationality: e.nationality,
+		}
+		if err := DB.Where(models.Constructor{Ref: e.ref}).FirstOrCreate(&constructor).Error; err != nil {
+			return err
+		}
+
+		entry := models.SeasonEntry{
+			ConstructorID: constructor.ID,
+			Year:          SampleSeason,
+			EntrantName:   e.entrantName,
+			ChassisName:   e.chassisName,
+			PowerUnit:     e.powerUnit,
+		}
+		for _, l := range e.lineUps {
+			lineUp := models.LineUp{
+				DriverID:   drivers[l.driverRef].ID,
+				CarNumber:  l.carNumber,
+				StartRound: l.startRound,
+			}
+			if l.endRound > 0 {
+				endRound := l.endRound
+				lineUp.EndRound = &endRound
+			}
+			entry.LineUps = append(entry.LineUps, lineUp)
+		}
+
+		if _, err := constructorRepo.SaveSeasonEntryQuery(entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"github.com/dewciu/f1_api/pkg/database"
 	"github.com/dewciu/f1_api/pkg/models"
 	"github.com/dewciu/f1_api/pkg/routes"
-	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
@@ -14,34 +13,71 @@ func Seed(DB *gorm.DB) error {
 	adminName := "admin"
 	userRepo := database.NewUserRepository(DB)
 
-	if DB.First(&models.User{}, "username = ?", adminName).RowsAffected <= 0 {
-		var permissions [][]models.Permission = [][]models.Permission{
-			routes.GetUserPermissions(),
-			routes.GetAuthPermissions(),
-		}
-
-		var batchPermissions []models.Permission
-
-		for _, permission := range permissions {
-			batchPermissions = append(batchPermissions, permission...)
-		}
+	permissions, err := seedPermissions(DB)
+	if err != nil {
+		return err
+	}
 
-		if err := DB.Create(&batchPermissions).Error; err != nil {
-			err := err.(*pgconn.PgError)
+	var admin models.User
 
-			if err.Code != "23505" {
-				return err
-			}
-		}
+	if DB.First(&admin, "username = ?", adminName).RowsAffected <= 0 {
 		err := userRepo.CreateUserQuery(models.User{
 			Username:    adminName,
 			Password:    "admin",
-			Permissions: batchPermissions,
+			Permissions: permissions,
 		})
 		if err != nil {
 			return err
 		}
+	} else {
+		// Admin already exists, grant the permissions of routes added since it was created
+		err := DB.Model(&admin).Association("Permissions").Append(permissions)
+		if err != nil {
+			return err
+		}
+	}
+
+	circuits, err := seedCircuits(DB)
+	if err != nil {
+		return err
+	}
+
+	if err := seedSampleCalendar(DB, circuits); err != nil {
+		return err
+	}
+
+	return seedSampleSeason(DB)
+}
+
+func seedPermissions(DB *gorm.DB) ([]models.Permission, error) {
+	var permissions [][]models.Permission = [][]models.Permission{
+		routes.GetUserPermissions(),
+		routes.GetAuthPermissions(),
+		routes.GetDriverPermissions(),
+		routes.GetConstructorPermissions(),
+		routes.GetCircuitPermissions(),
+		routes.GetSeasonPermissions(),
+		routes.GetRacePermissions(),
+		routes.GetSessionPermissions(),
+		routes.GetErgastPermissions(),
+		routes.GetImportPermissions(),
+		routes.GetLivePermissions(),
+	}
+
+	var batchPermissions []models.Permission
+
+	for _, group := range permissions {
+		for _, permission := range group {
+			err := DB.Where(models.Permission{
+				Endpoint: permission.Endpoint,
+				Method:   permission.Method,
+			}).FirstOrCreate(&permission).Error
+			if err != nil {
+				return nil, err
+			}
+			batchPermissions = append(batchPermissions, permission)
+		}
 	}
 
-	return nil
+	return batchPermissions, nil
 }
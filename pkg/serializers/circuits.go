@@ -0,0 +1,148 @@
+package serializers
+
+import (
+	"math"
+
+	"github.com/dewciu/f1_api/pkg/database"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type CircuitLayoutResponse struct {
+	Version      int    `json:"version"`
+	Name         string `json:"name"`
+	FirstYear    *int   `json:"first_year"`
+	LastYear     *int   `json:"last_year"`
+	LengthMeters int    `json:"length_meters"`
+	Turns        int    `json:"turns"`
+	Direction    string `json:"direction"`
+} //@name CircuitLayoutResponse
+
+type CircuitResponse struct {
+	ID        uuid.UUID               `json:"id"`
+	Ref       string                  `json:"ref"`
+	ErgastID  *int                    `json:"ergast_id,omitempty"`
+	Name      string                  `json:"name"`
+	Location  string                  `json:"location"`
+	Country   string                  `json:"country"`
+	Latitude  float64                 `json:"latitude"`
+	Longitude float64                 `json:"longitude"`
+	URL       string                  `json:"url,omitempty"`
+	Layouts   []CircuitLayoutResponse `json:"layouts,omitempty"`
+} //@name CircuitResponse
+
+type CircuitLayoutSerializer struct {
+	C *gin.Context
+	m.CircuitLayout
+}
+
+func (s *CircuitLayoutSerializer) Response() CircuitLayoutResponse {
+	response := CircuitLayoutResponse{
+		Version:      s.Version,
+		Name:         s.Name,
+		FirstYear:    s.FirstYear,
+		LastYear:     s.LastYear,
+		LengthMeters: s.LengthMeters,
+		Turns:        s.Turns,
+		Direction:    s.Direction,
+	}
+
+	return response
+}
+
+type CircuitSerializer struct {
+	C *gin.Context
+	m.Circuit
+}
+
+func (s *CircuitSerializer) Response() CircuitResponse {
+	response := CircuitResponse{
+		ID:        s.ID,
+		Ref:       s.Ref,
+		ErgastID:  s.ErgastID,
+		Name:      s.Name,
+		Location:  s.Location,
+		Country:   s.Country,
+		Latitude:  s.Latitude,
+		Longitude: s.Longitude,
+		URL:       s.URL,
+	}
+
+	for _, layout := range s.Layouts {
+		serializer := CircuitLayoutSerializer{s.C, layout}
+		response.Layouts = append(response.Layouts, serializer.Response())
+	}
+
+	return response
+}
+
+type CircuitsSerializer struct {
+	C        *gin.Context
+	Circuits []m.Circuit
+}
+
+func (s *CircuitsSerializer) Response() []CircuitResponse {
+	response := []CircuitResponse{}
+	for _, circuit := range s.Circuits {
+		serializer := CircuitSerializer{s.C, circuit}
+		response = append(response, serializer.Response())
+	}
+
+	return response
+}
+
+type NearbyCircuitResponse struct {
+	CircuitResponse
+	DistanceKm float64 `json:"distance_km"`
+} //@name NearbyCircuitResponse
+
+type NearbyCircuitsSerializer struct {
+	C        *gin.Context
+	Circuits []database.CircuitDistance
+}
+
+func (s *NearbyCircuitsSerializer) Response() []NearbyCircuitResponse {
+	response := []NearbyCircuitResponse{}
+	for _, nearby := range s.Circuits {
+		serializer := CircuitSerializer{s.C, nearby.Circuit}
+		response = append(response, NearbyCircuitResponse{
+			CircuitResponse: serializer.Response(),
+			DistanceKm:      math.Round(nearby.DistanceKm*100) / 100,
+		})
+	}
+
+	return response
+}
+
+type CircuitMapProperties struct {
+	Circuit string `json:"circuit"`
+	CircuitLayoutResponse
+} //@name CircuitMapProperties
+
+type CircuitMapResponse struct {
+	Type       string               `json:"type"`
+	Geometry   m.GeoJSON            `json:"geometry" swaggertype:"object"`
+	Properties CircuitMapProperties `json:"properties"`
+} //@name CircuitMapResponse
+
+type CircuitMapSerializer struct {
+	C       *gin.Context
+	Circuit string
+	m.CircuitLayout
+}
+
+// Response wraps the stored geometry of the layout in a GeoJSON Feature
+func (s *CircuitMapSerializer) Response() CircuitMapResponse {
+	layout := CircuitLayoutSerializer{s.C, s.CircuitLayout}
+	response := CircuitMapResponse{
+		Type:     "Feature",
+		Geometry: s.Geometry,
+		Properties: CircuitMapProperties{
+			Circuit:               s.Circuit,
+			CircuitLayoutResponse: layout.Response(),
+		},
+	}
+
+	return response
+}
@@ -0,0 +1,111 @@
+package serializers
+
+import (
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ConstructorResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Ref         string    `json:"ref"`
+	ErgastID    *int      `json:"ergast_id,omitempty"`
+	Name        string    `json:"name"`
+	Nationality string    `json:"nationality"`
+	URL         string    `json:"url,omitempty"`
+} //@name ConstructorResponse
+
+type ConstructorSerializer struct {
+	C *gin.Context
+	m.Constructor
+}
+
+func (s *ConstructorSerializer) Response() ConstructorResponse {
+	response := ConstructorResponse{
+		ID:          s.ID,
+		Ref:         s.Ref,
+		ErgastID:    s.ErgastID,
+		Name:        s.Name,
+		Nationality: s.Nationality,
+		URL:         s.URL,
+	}
+
+	return response
+}
+
+type ConstructorsSerializer struct {
+	C            *gin.Context
+	Constructors []m.Constructor
+}
+
+func (s *ConstructorsSerializer) Response() []ConstructorResponse {
+	response := []ConstructorResponse{}
+	for _, constructor := range s.Constructors {
+		serializer := ConstructorSerializer{s.C, constructor}
+		response = append(response, serializer.Response())
+	}
+
+	return response
+}
+
+type LineUpResponse struct {
+	Driver     DriverResponse `json:"driver"`
+	CarNumber  int            `json:"car_number"`
+	StartRound int            `json:"start_round"`
+	EndRound   *int           `json:"end_round"`
+} //@name LineUpResponse
+
+type SeasonEntryResponse struct {
+	ID          uuid.UUID           `json:"id"`
+	Year        int                 `json:"year"`
+	Constructor ConstructorResponse `json:"constructor"`
+	EntrantName string              `json:"entrant_name"`
+	ChassisName string              `json:"chassis_name"`
+	PowerUnit   string              `json:"power_unit"`
+	LineUps     []LineUpResponse    `json:"line_ups"`
+} //@name SeasonEntryResponse
+
+type SeasonEntrySerializer struct {
+	C *gin.Context
+	m.SeasonEntry
+}
+
+func (s *SeasonEntrySerializer) Response() SeasonEntryResponse {
+	constructor := ConstructorSerializer{s.C, s.Constructor}
+	response := SeasonEntryResponse{
+		ID:          s.ID,
+		Year:        s.Year,
+		Constructor: constructor.Response(),
+		EntrantName: s.EntrantName,
+		ChassisName: s.ChassisName,
+		PowerUnit:   s.PowerUnit,
+		LineUps:     []LineUpResponse{},
+	}
+
+	for _, lineUp := range s.LineUps {
+		driver := DriverSerializer{s.C, lineUp.Driver}
+		response.LineUps = append(response.LineUps, LineUpResponse{
+			Driver:     driver.Response(),
+			CarNumber:  lineUp.CarNumber,
+			StartRound: lineUp.StartRound,
+			EndRound:   lineUp.EndRound,
+		})
+	}
+
+	return response
+}
+
+type SeasonEntriesSerializer struct {
+	C       *gin.Context
+	Entries []m.SeasonEntry
+}
+
+func (s *SeasonEntriesSerializer) Response() []SeasonEntryResponse {
+	response := []SeasonEntryResponse{}
+	for _, entry := range s.Entries {
+		serializer := SeasonEntrySerializer{s.C, entry}
+		response = append(response, serializer.Response())
+	}
+
+	return response
+}
@@ -0,0 +1,62 @@
+package serializers
+
+import (
+	"github.com/dewciu/f1_api/pkg/common"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type DriverResponse struct {
+	ID              uuid.UUID `json:"id"`
+	Ref             string    `json:"ref"`
+	ErgastID        *int      `json:"ergast_id,omitempty"`
+	PermanentNumber *int      `json:"permanent_number,omitempty"`
+	Code            string    `json:"code,omitempty"`
+	FirstName       string    `json:"first_name"`
+	LastName        string    `json:"last_name"`
+	DateOfBirth     string    `json:"date_of_birth"`
+	Nationality     string    `json:"nationality"`
+	URL             string    `json:"url,omitempty"`
+	Seasons         []int     `json:"seasons,omitempty"`
+} //@name DriverResponse
+
+type DriverSerializer struct {
+	C *gin.Context
+	m.Driver
+}
+
+func (s *DriverSerializer) Response() DriverResponse {
+	response := DriverResponse{
+		ID:              s.ID,
+		Ref:             s.Ref,
+		ErgastID:        s.ErgastID,
+		PermanentNumber: s.PermanentNumber,
+		Code:            s.Code,
+		FirstName:       s.FirstName,
+		LastName:        s.LastName,
+		DateOfBirth:     s.DateOfBirth.Format(common.DateLayout),
+		Nationality:     s.Nationality,
+		URL:             s.URL,
+	}
+	for _, season := range s.Seasons {
+		response.Seasons = append(response.Seasons, season.Year)
+	}
+
+	return response
+}
+
+type DriversSerializer struct {
+	C       *gin.Context
+	Drivers []m.Driver
+}
+
+func (s *DriversSerializer) Response() []DriverResponse {
+	response := []DriverResponse{}
+	for _, driver := range s.Drivers {
+		serializer := DriverSerializer{s.C, driver}
+		response = append(response, serializer.Response())
+	}
+
+	return response
+}
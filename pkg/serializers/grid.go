@@ -0,0 +1,101 @@
+package serializers
+
+import (
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/grid"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type GridPenaltyResponse struct {
+	ID          uuid.UUID      `json:"id"`
+	Driver      DriverResponse `json:"driver"`
+	Type        string         `json:"type"`
+	Places      int            `json:"places,omitempty"`
+	Component   string         `json:"component"`
+	Reason      string         `json:"reason,omitempty"`
+	InfringedAt time.Time      `json:"infringed_at"`
+} //@name GridPenaltyResponse
+
+type GridPenaltySerializer struct {
+	C *gin.Context
+	m.GridPenalty
+}
+
+func (s *GridPenaltySerializer) Response() GridPenaltyResponse {
+	driver := DriverSerializer{s.C, s.Driver}
+	response := GridPenaltyResponse{
+		ID:          s.ID,
+		Driver:      driver.Response(),
+		Type:        s.Type,
+		Places:      s.Places,
+		Component:   s.Component,
+		Reason:      s.Reason,
+		InfringedAt: s.InfringedAt.UTC(),
+	}
+
+	return response
+}
+
+type GridPenaltiesSerializer struct {
+	C         *gin.Context
+	Penalties []m.GridPenalty
+}
+
+func (s *GridPenaltiesSerializer) Response() []GridPenaltyResponse {
+	response := []GridPenaltyResponse{}
+	for _, penalty := range s.Penalties {
+		serializer := GridPenaltySerializer{s.C, penalty}
+		response = append(response, serializer.Response())
+	}
+
+	return response
+}
+
+type GridChangeResponse struct {
+	From   int    `json:"from"`
+	To     int    `json:"to"`
+	Reason string `json:"reason"`
+} //@name GridChangeResponse
+
+type GridSlotResponse struct {
+	Grid        int                  `json:"grid"`
+	PitLane     bool                 `json:"pit_lane"`
+	Starts      bool                 `json:"starts"`
+	Qualified   int                  `json:"qualified"`
+	CarNumber   int                  `json:"car_number"`
+	Driver      DriverResponse       `json:"driver"`
+	Constructor ConstructorResponse  `json:"constructor"`
+	Audit       []GridChangeResponse `json:"audit"`
+} //@name GridSlotResponse
+
+type StartingGridSerializer struct {
+	C *gin.Context
+	grid.StartingGrid
+}
+
+func (s *StartingGridSerializer) Response() []GridSlotResponse {
+	response := []GridSlotResponse{}
+	for _, slot := range s.Slots {
+		driver := DriverSerializer{s.C, slot.Driver}
+		constructor := ConstructorSerializer{s.C, slot.Constructor}
+		audit := []GridChangeResponse{}
+		for _, change := range slot.Audit {
+			audit = append(audit, GridChangeResponse{From: change.From, To: change.To, Reason: change.Reason})
+		}
+		response = append(response, GridSlotResponse{
+			Grid:        slot.Grid,
+			PitLane:     slot.PitLane,
+			Starts:      slot.Starts,
+			Qualified:   slot.Position,
+			CarNumber:   slot.CarNumber,
+			Driver:      driver.Response(),
+			Constructor: constructor.Response(),
+			Audit:       audit,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,78 @@
+package serializers
+
+import (
+	h2h "github.com/dewciu/f1_api/pkg/headtohead"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// HeadToHeadDriverResponse is what a driver scored against the other over
+// the compared rounds, DNFs counting retirements by cause
+type HeadToHeadDriverResponse struct {
+	Driver        DriverResponse `json:"driver"`
+	Qualifying    int            `json:"qualifying"`
+	Race          int            `json:"race"`
+	FinishesAhead int            `json:"finishes_ahead"`
+	Points        float64        `json:"points"`
+	DNFs          map[string]int `json:"dnfs"`
+} //@name HeadToHeadDriverResponse
+
+// HeadToHeadRoundResponse holds the driver ahead in each session of the
+// round, null when both did not take part. The qualifying gap is that of
+// driver A to driver B in percent
+type HeadToHeadRoundResponse struct {
+	Round           int        `json:"round"`
+	RaceID          uuid.UUID  `json:"race_id"`
+	ConstructorID   *uuid.UUID `json:"constructor_id,omitempty"`
+	QualifyingAhead *uuid.UUID `json:"qualifying_ahead"`
+	QualifyingGap   *float64   `json:"qualifying_gap"`
+	RaceAhead       *uuid.UUID `json:"race_ahead"`
+	BothClassified  bool       `json:"both_classified"`
+} //@name HeadToHeadRoundResponse
+
+type HeadToHeadResponse struct {
+	Year      int                      `json:"year"`
+	Teammates bool                     `json:"teammates"`
+	DriverA   HeadToHeadDriverResponse `json:"driver_a"`
+	DriverB   HeadToHeadDriverResponse `json:"driver_b"`
+	// QualifyingGap is the mean gap of driver A to driver B in percent,
+	// negative when driver A was quicker
+	QualifyingGap *float64                  `json:"qualifying_gap"`
+	Rounds        []HeadToHeadRoundResponse `json:"rounds"`
+} //@name HeadToHeadResponse
+
+type HeadToHeadSerializer struct {
+	C       *gin.Context
+	Year    int
+	DriverA m.Driver
+	DriverB m.Driver
+	h2h.HeadToHead
+}
+
+func headToHeadDriver(c *gin.Context, driver m.Driver, tally h2h.Tally) HeadToHeadDriverResponse {
+	serializer := DriverSerializer{c, driver}
+	return HeadToHeadDriverResponse{
+		Driver:        serializer.Response(),
+		Qualifying:    tally.Qualifying,
+		Race:          tally.Race,
+		FinishesAhead: tally.FinishesAhead,
+		Points:        tally.Points,
+		DNFs:          tally.DNFs,
+	}
+}
+
+func (s *HeadToHeadSerializer) Response() HeadToHeadResponse {
+	response := HeadToHeadResponse{
+		Year:          s.Year,
+		Teammates:     s.Teammates,
+		DriverA:       headToHeadDriver(s.C, s.DriverA, s.A),
+		DriverB:       headToHeadDriver(s.C, s.DriverB, s.B),
+		QualifyingGap: s.QualifyingGap,
+		Rounds:        []HeadToHeadRoundResponse{},
+	}
+	for _, round := range s.Rounds {
+		response.Rounds = append(response.Rounds, HeadToHeadRoundResponse(round))
+	}
+	return response
+}
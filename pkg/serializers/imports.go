@@ -0,0 +1,90 @@
+package serializers
+
+import (
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/importer"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ImportFileResponse struct {
+	File     string   `json:"file"`
+	Note     string   `json:"note,omitempty"`
+	Rows     int      `json:"rows"`
+	Imported int      `json:"imported"`
+	Skipped  int      `json:"skipped"`
+	Queued   int      `json:"queued"`
+	Warnings []string `json:"warnings"`
+} //@name ImportFileResponse
+
+type ImportSummarySerializer struct {
+	C       *gin.Context
+	Summary importer.Summary
+}
+
+func (s *ImportSummarySerializer) Response() []ImportFileResponse {
+	response := []ImportFileResponse{}
+	for _, file := range s.Summary {
+		warnings := file.Warnings
+		if warnings == nil {
+			warnings = []string{}
+		}
+		response = append(response, ImportFileResponse{
+			File:     file.File,
+			Note:     file.Note,
+			Rows:     file.Rows,
+			Imported: file.Imported,
+			Skipped:  file.Skipped,
+			Queued:   file.Queued,
+			Warnings: warnings,
+		})
+	}
+
+	return response
+}
+
+type ImportReviewResponse struct {
+	ID       uuid.UUID `json:"id"`
+	Source   string    `json:"source"`
+	Kind     string    `json:"kind"`
+	Origin   string    `json:"origin"`
+	Reason   string    `json:"reason"`
+	Record   m.JSON    `json:"record" swaggertype:"object"`
+	QueuedAt time.Time `json:"queued_at"`
+} //@name ImportReviewResponse
+
+type ImportReviewSerializer struct {
+	C *gin.Context
+	m.ImportReview
+}
+
+func (s *ImportReviewSerializer) Response() ImportReviewResponse {
+	response := ImportReviewResponse{
+		ID:       s.ID,
+		Source:   s.Source,
+		Kind:     s.Kind,
+		Origin:   s.Origin,
+		Reason:   s.Reason,
+		Record:   s.Record,
+		QueuedAt: s.CreatedAt.UTC(),
+	}
+
+	return response
+}
+
+type ImportReviewsSerializer struct {
+	C       *gin.Context
+	Reviews []m.ImportReview
+}
+
+func (s *ImportReviewsSerializer) Response() []ImportReviewResponse {
+	response := []ImportReviewResponse{}
+	for _, review := range s.Reviews {
+		serializer := ImportReviewSerializer{s.C, review}
+		response = append(response, serializer.Response())
+	}
+
+	return response
+}
@@ -0,0 +1,178 @@
+package serializers
+
+import (
+	"github.com/dewciu/f1_api/pkg/laps"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/dewciu/f1_api/pkg/weather"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type LapResponse struct {
+	Lap      int    `json:"lap"`
+	Position int    `json:"position"`
+	Time     string `json:"time"`
+	Millis   int64  `json:"millis"`
+	// Weather is the observation nearest to the lap, when asked for
+	Weather *WeatherObservationResponse `json:"weather,omitempty"`
+} //@name LapResponse
+
+type DriverLapsResponse struct {
+	Driver DriverResponse `json:"driver"`
+	Laps   []LapResponse  `json:"laps"`
+} //@name DriverLapsResponse
+
+type RaceLapsResponse struct {
+	RaceID  uuid.UUID            `json:"race_id"`
+	Drivers []DriverLapsResponse `json:"drivers"`
+} //@name RaceLapsResponse
+
+// RaceLapsSerializer groups the laps by driver, the drivers are looked up in
+// Drivers by their ID. Laps with an observation in Weather are annotated
+// with it
+type RaceLapsSerializer struct {
+	C       *gin.Context
+	RaceID  uuid.UUID
+	Laps    []m.Lap
+	Drivers map[uuid.UUID]m.Driver
+	Weather map[weather.LapKey]m.WeatherObservation
+}
+
+func lapDriver(c *gin.Context, drivers map[uuid.UUID]m.Driver, id uuid.UUID) DriverResponse {
+	serializer := DriverSerializer{c, drivers[id]}
+	return serializer.Response()
+}
+
+func (s *RaceLapsSerializer) Response() RaceLapsResponse {
+	response := RaceLapsResponse{RaceID: s.RaceID, Drivers: []DriverLapsResponse{}}
+	for _, driver := range laps.Group(s.Laps) {
+		driverLaps := DriverLapsResponse{Driver: lapDriver(s.C, s.Drivers, driver.DriverID), Laps: []LapResponse{}}
+		for _, lap := range driver.Laps {
+			lapResponse := LapResponse{
+				Lap:      lap.Number,
+				Position: lap.Position,
+				Time:     lap.Time.String(),
+				Millis:   lap.Time.Milliseconds(),
+			}
+			if observation, ok := s.Weather[weather.LapKey{DriverID: lap.DriverID, Lap: lap.Number}]; ok {
+				annotation := weatherObservation(observation)
+				lapResponse.Weather = &annotation
+			}
+			driverLaps.Laps = append(driverLaps.Laps, lapResponse)
+		}
+		response.Drivers = append(response.Drivers, driverLaps)
+	}
+
+	return response
+}
+
+type LapChartLineResponse struct {
+	Driver    DriverResponse `json:"driver"`
+	Positions []*int         `json:"positions"`
+} //@name LapChartLineResponse
+
+type LapChartResponse struct {
+	RaceID  uuid.UUID              `json:"race_id"`
+	Laps    int                    `json:"laps"`
+	Drivers []LapChartLineResponse `json:"drivers"`
+} //@name LapChartResponse
+
+type LapChartSerializer struct {
+	C       *gin.Context
+	RaceID  uuid.UUID
+	Laps    []m.Lap
+	Drivers map[uuid.UUID]m.Driver
+}
+
+func (s *LapChartSerializer) Response() LapChartResponse {
+	chart := laps.LapChart(s.Laps)
+	response := LapChartResponse{RaceID: s.RaceID, Laps: chart.Laps, Drivers: []LapChartLineResponse{}}
+	for _, line := range chart.Drivers {
+		response.Drivers = append(response.Drivers, LapChartLineResponse{
+			Driver:    lapDriver(s.C, s.Drivers, line.DriverID),
+			Positions: line.Positions,
+		})
+	}
+
+	return response
+}
+
+type GapChartLineResponse struct {
+	Driver DriverResponse `json:"driver"`
+	// GapsMillis holds the gap to the leader at the end of every lap in
+	// milliseconds
+	GapsMillis []*int64 `json:"gaps_millis"`
+} //@name GapChartLineResponse
+
+type GapChartResponse struct {
+	RaceID  uuid.UUID              `json:"race_id"`
+	Laps    int                    `json:"laps"`
+	Drivers []GapChartLineResponse `json:"drivers"`
+} //@name GapChartResponse
+
+type GapChartSerializer struct {
+	C       *gin.Context
+	RaceID  uuid.UUID
+	Laps    []m.Lap
+	Drivers map[uuid.UUID]m.Driver
+}
+
+func (s *GapChartSerializer) Response() GapChartResponse {
+	chart := laps.Gaps(s.Laps)
+	response := GapChartResponse{RaceID: s.RaceID, Laps: chart.Laps, Drivers: []GapChartLineResponse{}}
+	for _, line := range chart.Drivers {
+		gaps := make([]*int64, len(line.Gaps))
+		for i, gap := range line.Gaps {
+			if gap != nil {
+				millis := gap.Milliseconds()
+				gaps[i] = &millis
+			}
+		}
+		response.Drivers = append(response.Drivers, GapChartLineResponse{
+			Driver:     lapDriver(s.C, s.Drivers, line.DriverID),
+			GapsMillis: gaps,
+		})
+	}
+
+	return response
+}
+
+type FastestLapResponse struct {
+	Rank   int            `json:"rank"`
+	Driver DriverResponse `json:"driver"`
+	Lap    int            `json:"lap"`
+	Time   string         `json:"time"`
+	Millis int64          `json:"millis"`
+	Gap    string         `json:"gap,omitempty"`
+} //@name FastestLapResponse
+
+type FastestLapsResponse struct {
+	RaceID  uuid.UUID            `json:"race_id"`
+	Ranking []FastestLapResponse `json:"ranking"`
+} //@name FastestLapsResponse
+
+type FastestLapsSerializer struct {
+	C       *gin.Context
+	RaceID  uuid.UUID
+	Laps    []m.Lap
+	Drivers map[uuid.UUID]m.Driver
+}
+
+func (s *FastestLapsSerializer) Response() FastestLapsResponse {
+	response := FastestLapsResponse{RaceID: s.RaceID, Ranking: []FastestLapResponse{}}
+	for _, fastest := range laps.Fastest(s.Laps) {
+		lap := FastestLapResponse{
+			Rank:   fastest.Rank,
+			Driver: lapDriver(s.C, s.Drivers, fastest.DriverID),
+			Lap:    fastest.Number,
+			Time:   fastest.Time.String(),
+			Millis: fastest.Time.Milliseconds(),
+		}
+		if fastest.Rank > 1 {
+			lap.Gap = formatGap(&fastest.Gap)
+		}
+		response.Ranking = append(response.Ranking, lap)
+	}
+
+	return response
+}
@@ -0,0 +1,90 @@
+package serializers
+
+import (
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/live"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Types of the messages of a live timing subscription
+const (
+	LiveMessageState   = "state"
+	LiveMessageDelta   = "delta"
+	LiveMessageEvicted = "evicted"
+)
+
+type LiveEntryResponse struct {
+	DriverID uuid.UUID  `json:"driver_id"`
+	Updated  time.Time  `json:"updated"`
+	Position int        `json:"position"`
+	Lap      int        `json:"lap"`
+	Gap      *m.LapTime `json:"gap" swaggertype:"string" example:"1.254"`
+	Interval *m.LapTime `json:"interval" swaggertype:"string" example:"0.412"`
+	LastLap  *m.LapTime `json:"last_lap" swaggertype:"string" example:"1:31.447"`
+	BestLap  *m.LapTime `json:"best_lap" swaggertype:"string" example:"1:30.965"`
+	Compound string     `json:"compound" example:"MEDIUM"`
+	TyreAge  int        `json:"tyre_age"`
+	InPit    bool       `json:"in_pit"`
+} //@name LiveEntryResponse
+
+type LiveStateResponse struct {
+	SessionID uuid.UUID `json:"session_id"`
+	// Sequence is the sequence of the last delta in the state
+	Sequence uint64              `json:"sequence"`
+	Updated  time.Time           `json:"updated"`
+	Entries  []LiveEntryResponse `json:"entries"`
+} //@name LiveStateResponse
+
+type LiveDeltaResponse struct {
+	SessionID uuid.UUID  `json:"session_id"`
+	Sequence  uint64     `json:"sequence"`
+	Time      time.Time  `json:"time"`
+	DriverID  uuid.UUID  `json:"driver_id"`
+	Position  *int       `json:"position,omitempty"`
+	Lap       *int       `json:"lap,omitempty"`
+	Gap       *m.LapTime `json:"gap,omitempty" swaggertype:"string" example:"1.254"`
+	Interval  *m.LapTime `json:"interval,omitempty" swaggertype:"string" example:"0.412"`
+	LastLap   *m.LapTime `json:"last_lap,omitempty" swaggertype:"string" example:"1:31.447"`
+	BestLap   *m.LapTime `json:"best_lap,omitempty" swaggertype:"string" example:"1:30.965"`
+	Compound  *string    `json:"compound,omitempty" example:"MEDIUM"`
+	TyreAge   *int       `json:"tyre_age,omitempty"`
+	InPit     *bool      `json:"in_pit,omitempty"`
+} //@name LiveDeltaResponse
+
+// LiveMessageResponse is a message of a live timing subscription, Data holds
+// the LiveStateResponse of a state message, the LiveDeltaResponse of a delta
+// and the reason of an eviction
+type LiveMessageResponse struct {
+	Type string      `json:"type" example:"delta"`
+	Data interface{} `json:"data" swaggertype:"object"`
+} //@name LiveMessageResponse
+
+type LiveStateSerializer struct {
+	C     *gin.Context
+	State live.State
+}
+
+func (s *LiveStateSerializer) Response() LiveStateResponse {
+	response := LiveStateResponse{
+		SessionID: s.State.SessionID,
+		Sequence:  s.State.Sequence,
+		Updated:   s.State.Updated,
+		Entries:   []LiveEntryResponse{},
+	}
+	for _, entry := range s.State.Entries {
+		response.Entries = append(response.Entries, LiveEntryResponse(entry))
+	}
+	return response
+}
+
+type LiveDeltaSerializer struct {
+	C     *gin.Context
+	Delta live.Delta
+}
+
+func (s *LiveDeltaSerializer) Response() LiveDeltaResponse {
+	return LiveDeltaResponse(s.Delta)
+}
@@ -0,0 +1,138 @@
+package serializers
+
+import (
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/dewciu/f1_api/pkg/pace"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PaceLapResponse is a clean lap, Corrected is its time in seconds after the
+// fuel correction and Residual its difference to the fit of the stint
+type PaceLapResponse struct {
+	Lap       int      `json:"lap"`
+	Age       int      `json:"age"`
+	Time      string   `json:"time"`
+	Corrected float64  `json:"corrected"`
+	Residual  *float64 `json:"residual,omitempty"`
+} //@name PaceLapResponse
+
+type ExcludedLapResponse struct {
+	Lap    int    `json:"lap"`
+	Time   string `json:"time"`
+	Reason string `json:"reason"`
+} //@name ExcludedLapResponse
+
+// StintFitResponse is the degradation model of a stint in the age of the
+// tyres, coefficients starting with the constant term in seconds
+type StintFitResponse struct {
+	Coefficients []float64 `json:"coefficients"`
+	Residuals    []float64 `json:"residuals"`
+	RMSE         float64   `json:"rmse"`
+	R2           float64   `json:"r2"`
+} //@name StintFitResponse
+
+type PaceStintResponse struct {
+	Number      int               `json:"number"`
+	Compound    string            `json:"compound"`
+	StartLap    int               `json:"start_lap"`
+	EndLap      int               `json:"end_lap"`
+	Fit         *StintFitResponse `json:"fit"`
+	Degradation *float64          `json:"degradation"`
+	Laps        []PaceLapResponse `json:"laps"`
+} //@name PaceStintResponse
+
+// DriverPaceResponse holds the pace of a driver in seconds, null when they
+// have no clean laps
+type DriverPaceResponse struct {
+	Driver         DriverResponse        `json:"driver"`
+	Representative *float64              `json:"representative"`
+	Mean           *float64              `json:"mean"`
+	Gap            *float64              `json:"gap"`
+	Laps           int                   `json:"laps"`
+	Stints         []PaceStintResponse   `json:"stints"`
+	Excluded       []ExcludedLapResponse `json:"excluded"`
+} //@name DriverPaceResponse
+
+type CompoundPaceResponse struct {
+	Compound    string  `json:"compound"`
+	Stints      int     `json:"stints"`
+	Degradation float64 `json:"degradation"`
+} //@name CompoundPaceResponse
+
+type PaceResponse struct {
+	RaceID           uuid.UUID              `json:"race_id"`
+	Model            string                 `json:"model"`
+	FuelCorrection   float64                `json:"fuel_correction"`
+	OutlierThreshold float64                `json:"outlier_threshold"`
+	RaceLaps         int                    `json:"race_laps"`
+	Drivers          []DriverPaceResponse   `json:"drivers"`
+	Compounds        []CompoundPaceResponse `json:"compounds"`
+} //@name PaceResponse
+
+type PaceSerializer struct {
+	C       *gin.Context
+	RaceID  uuid.UUID
+	Drivers map[uuid.UUID]m.Driver
+	pace.Pace
+}
+
+func (s *PaceSerializer) Response() PaceResponse {
+	response := PaceResponse{
+		RaceID:           s.RaceID,
+		Model:            s.Options.Model,
+		FuelCorrection:   s.Options.FuelCorrection,
+		OutlierThreshold: s.Options.OutlierThreshold,
+		RaceLaps:         s.RaceLaps,
+		Drivers:          []DriverPaceResponse{},
+		Compounds:        []CompoundPaceResponse{},
+	}
+	for _, d := range s.Pace.Drivers {
+		serializer := DriverSerializer{s.C, s.Drivers[d.DriverID]}
+		driver := DriverPaceResponse{
+			Driver:         serializer.Response(),
+			Representative: d.Representative,
+			Mean:           d.Mean,
+			Gap:            d.Gap,
+			Laps:           d.Laps,
+			Stints:         []PaceStintResponse{},
+			Excluded:       []ExcludedLapResponse{},
+		}
+		for _, stint := range d.Stints {
+			stintResponse := PaceStintResponse{
+				Number:      stint.Number,
+				Compound:    stint.Compound,
+				StartLap:    stint.StartLap,
+				EndLap:      stint.EndLap,
+				Degradation: stint.Degradation,
+				Laps:        []PaceLapResponse{},
+			}
+			if stint.Fit != nil {
+				stintResponse.Fit = &StintFitResponse{
+					Coefficients: stint.Fit.Coefficients,
+					Residuals:    stint.Fit.Residuals,
+					RMSE:         stint.Fit.RMSE,
+					R2:           stint.Fit.R2,
+				}
+			}
+			for _, lap := range stint.Laps {
+				stintResponse.Laps = append(stintResponse.Laps, PaceLapResponse{
+					Lap:       lap.Number,
+					Age:       lap.Age,
+					Time:      lap.Time.String(),
+					Corrected: lap.Corrected,
+					Residual:  lap.Residual,
+				})
+			}
+			driver.Stints = append(driver.Stints, stintResponse)
+		}
+		for _, lap := range d.Excluded {
+			driver.Excluded = append(driver.Excluded, ExcludedLapResponse{Lap: lap.Number, Time: lap.Time.String(), Reason: lap.Reason})
+		}
+		response.Drivers = append(response.Drivers, driver)
+	}
+	for _, compound := range s.Compounds {
+		response.Compounds = append(response.Compounds, CompoundPaceResponse(compound))
+	}
+	return response
+}
@@ -0,0 +1,96 @@
+package serializers
+
+import (
+	m "github.com/dewciu/f1_api/pkg/models"
+	q "github.com/dewciu/f1_api/pkg/qualifying"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func formatGap(t *m.LapTime) string {
+	if t == nil {
+		return ""
+	}
+	if *t < 0 {
+		return t.String()
+	}
+	return "+" + t.String()
+}
+
+type QualifyingResultResponse struct {
+	ID           uuid.UUID           `json:"id"`
+	Position     int                 `json:"position"`
+	CarNumber    int                 `json:"car_number"`
+	Driver       DriverResponse      `json:"driver"`
+	Constructor  ConstructorResponse `json:"constructor"`
+	Q1           string              `json:"q1,omitempty" example:"1:30.503"`
+	Q2           string              `json:"q2,omitempty" example:"1:30.282"`
+	Q3           string              `json:"q3,omitempty" example:"1:29.708"`
+	Q1Deleted    bool                `json:"q1_deleted"`
+	Q2Deleted    bool                `json:"q2_deleted"`
+	Q3Deleted    bool                `json:"q3_deleted"`
+	EliminatedIn string              `json:"eliminated_in,omitempty"`
+	Segment      string              `json:"segment"`
+	GapToPole    string              `json:"gap_to_pole,omitempty" example:"+0.138"`
+	Interval     string              `json:"interval,omitempty" example:"+0.027"`
+	Within107    bool                `json:"within_107"`
+	Admitted     bool                `json:"admitted"`
+	Explanation  string              `json:"explanation"`
+} //@name QualifyingResultResponse
+
+type QualifyingResultSerializer struct {
+	C *gin.Context
+	q.Evaluation
+}
+
+func (s *QualifyingResultSerializer) Response() QualifyingResultResponse {
+	driver := DriverSerializer{s.C, s.Driver}
+	constructor := ConstructorSerializer{s.C, s.Constructor}
+	response := QualifyingResultResponse{
+		ID:           s.ID,
+		Position:     s.Position,
+		CarNumber:    s.CarNumber,
+		Driver:       driver.Response(),
+		Constructor:  constructor.Response(),
+		Q1:           formatLapTime(s.Q1),
+		Q2:           formatLapTime(s.Q2),
+		Q3:           formatLapTime(s.Q3),
+		Q1Deleted:    s.Q1Deleted,
+		Q2Deleted:    s.Q2Deleted,
+		Q3Deleted:    s.Q3Deleted,
+		EliminatedIn: s.EliminatedIn,
+		Segment:      s.Segment,
+		GapToPole:    formatGap(s.GapToPole),
+		Interval:     formatGap(s.Interval),
+		Within107:    s.Within107,
+		Admitted:     s.Admitted,
+		Explanation:  s.Explanation,
+	}
+
+	return response
+}
+
+type QualifyingResponse struct {
+	FastestQ1    string                     `json:"fastest_q1,omitempty" example:"1:29.708"`
+	Threshold107 string                     `json:"threshold_107,omitempty" example:"1:35.787"`
+	Results      []QualifyingResultResponse `json:"results"`
+} //@name QualifyingResponse
+
+type QualifyingSerializer struct {
+	C *gin.Context
+	q.Report
+}
+
+func (s *QualifyingSerializer) Response() QualifyingResponse {
+	response := QualifyingResponse{
+		FastestQ1:    formatLapTime(s.FastestQ1),
+		Threshold107: formatLapTime(s.Threshold107),
+		Results:      []QualifyingResultResponse{},
+	}
+	for _, evaluation := range s.Results {
+		serializer := QualifyingResultSerializer{s.C, evaluation}
+		response.Results = append(response.Results, serializer.Response())
+	}
+
+	return response
+}
@@ -0,0 +1,86 @@
+package serializers
+
+import (
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	rc "github.com/dewciu/f1_api/pkg/racecontrol"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RaceControlEventResponse struct {
+	ID      uuid.UUID       `json:"id"`
+	Time    time.Time       `json:"time"`
+	Type    string          `json:"type"`
+	Lap     *int            `json:"lap"`
+	Sector  *int            `json:"sector"`
+	Driver  *DriverResponse `json:"driver"`
+	Message string          `json:"message,omitempty"`
+} //@name RaceControlEventResponse
+
+type RaceControlEventsSerializer struct {
+	C      *gin.Context
+	Events []m.RaceControlEvent
+}
+
+func (s *RaceControlEventsSerializer) Response() []RaceControlEventResponse {
+	response := []RaceControlEventResponse{}
+	for _, event := range s.Events {
+		e := RaceControlEventResponse{
+			ID:      event.ID,
+			Time:    event.Time.UTC(),
+			Type:    event.Type,
+			Lap:     event.Lap,
+			Sector:  event.Sector,
+			Message: event.Message,
+		}
+		if event.Driver != nil {
+			driver := DriverSerializer{s.C, *event.Driver}
+			response := driver.Response()
+			e.Driver = &response
+		}
+		response = append(response, e)
+	}
+	return response
+}
+
+type NeutralizationResponse struct {
+	Type     string     `json:"type"`
+	Start    time.Time  `json:"start"`
+	End      *time.Time `json:"end"`
+	StartLap *int       `json:"start_lap"`
+	EndLap   *int       `json:"end_lap"`
+} //@name NeutralizationResponse
+
+type NeutralizationsResponse struct {
+	RaceID  uuid.UUID                `json:"race_id"`
+	Periods []NeutralizationResponse `json:"periods"`
+	// Laps lists the laps run at least partly under a neutralization
+	Laps []int `json:"laps"`
+} //@name NeutralizationsResponse
+
+type NeutralizationsSerializer struct {
+	C       *gin.Context
+	RaceID  uuid.UUID
+	Periods []rc.Period
+	LastLap int
+}
+
+func (s *NeutralizationsSerializer) Response() NeutralizationsResponse {
+	response := NeutralizationsResponse{
+		RaceID:  s.RaceID,
+		Periods: []NeutralizationResponse{},
+		Laps:    rc.CoveredLaps(s.Periods, s.LastLap),
+	}
+	for _, period := range s.Periods {
+		response.Periods = append(response.Periods, NeutralizationResponse{
+			Type:     period.Type,
+			Start:    period.Start.UTC(),
+			End:      period.End,
+			StartLap: period.StartLap,
+			EndLap:   period.EndLap,
+		})
+	}
+	return response
+}
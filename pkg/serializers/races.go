@@ -0,0 +1,164 @@
+package serializers
+
+import (
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/dewciu/f1_api/pkg/sprint"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SessionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	Name      string    `json:"name,omitempty"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Status    string    `json:"status"`
+	// SessionKey is the key of the session in OpenF1 data
+	SessionKey *int `json:"session_key,omitempty"`
+} //@name SessionResponse
+
+type SessionSerializer struct {
+	C *gin.Context
+	m.Session
+}
+
+func (s *SessionSerializer) Response() SessionResponse {
+	response := SessionResponse{
+		ID:         s.ID,
+		Type:       s.Type,
+		StartTime:  s.StartTime.UTC(),
+		EndTime:    s.EndTime.UTC(),
+		Status:     s.Status,
+		SessionKey: s.SessionKey,
+	}
+
+	return response
+}
+
+type SessionsSerializer struct {
+	C        *gin.Context
+	Sessions []m.Session
+}
+
+func (s *SessionsSerializer) Response() []SessionResponse {
+	response := []SessionResponse{}
+	for _, session := range s.Sessions {
+		serializer := SessionSerializer{s.C, session}
+		response = append(response, serializer.Response())
+	}
+
+	return response
+}
+
+type RaceResponse struct {
+	ID          uuid.UUID         `json:"id"`
+	Year        int               `json:"year"`
+	Round       int               `json:"round"`
+	Name        string            `json:"name"`
+	Date        string            `json:"date"`
+	URL         string            `json:"url,omitempty"`
+	PointsScale float64           `json:"points_scale"`
+	MeetingKey  *int              `json:"meeting_key,omitempty"`
+	Circuit     CircuitResponse   `json:"circuit"`
+	Sessions    []SessionResponse `json:"sessions"`
+	// Sprint describes the format of sprint weekends
+	Sprint *SprintFormatResponse `json:"sprint,omitempty"`
+} //@name RaceResponse
+
+type SprintFormatResponse struct {
+	Format       string    `json:"format"`
+	Name         string    `json:"name"`
+	GridSession  string    `json:"grid_session"`
+	SetsRaceGrid bool      `json:"sets_race_grid"`
+	Sessions     []string  `json:"sessions"`
+	Points       []float64 `json:"points"`
+} //@name SprintFormatResponse
+
+type RaceSerializer struct {
+	C *gin.Context
+	m.Race
+}
+
+func (s *RaceSerializer) Response() RaceResponse {
+	circuit := CircuitSerializer{s.C, s.Circuit}
+	sessions := SessionsSerializer{s.C, s.Sessions}
+	response := RaceResponse{
+		ID:          s.ID,
+		Year:        s.Year,
+		Round:       s.Round,
+		Name:        s.Name,
+		Date:        s.Date.Format(common.DateLayout),
+		URL:         s.URL,
+		PointsScale: s.PointsScale,
+		MeetingKey:  s.MeetingKey,
+		Circuit:     circuit.Response(),
+		Sessions:    sessions.Response(),
+	}
+	for i := range response.Sessions {
+		response.Sessions[i].Name = sprint.SessionName(s.Year, response.Sessions[i].Type)
+	}
+
+	if format, ok := sprint.ForSeason(s.Year); ok && sprint.IsSprintWeekend(s.Race) {
+		response.Sprint = &SprintFormatResponse{
+			Format:       format.Name,
+			Name:         sprint.SessionName(s.Year, m.SessionSprint),
+			GridSession:  format.GridSession,
+			SetsRaceGrid: format.SetsRaceGrid,
+			Sessions:     format.Sessions,
+			Points:       format.Points,
+		}
+	}
+
+	return response
+}
+
+type RacesSerializer struct {
+	C     *gin.Context
+	Races []m.Race
+}
+
+func (s *RacesSerializer) Response() []RaceResponse {
+	response := []RaceResponse{}
+	for _, race := range s.Races {
+		serializer := RaceSerializer{s.C, race}
+		response = append(response, serializer.Response())
+	}
+
+	return response
+}
+
+type NextSessionResponse struct {
+	Session         SessionResponse `json:"session"`
+	Race            RaceResponse    `json:"race"`
+	Live            bool            `json:"live"`
+	StartsInSeconds int64           `json:"starts_in_seconds"`
+} //@name NextSessionResponse
+
+type NextSessionSerializer struct {
+	C       *gin.Context
+	Now     time.Time
+	Session m.Session
+	Race    m.Race
+}
+
+func (s *NextSessionSerializer) Response() NextSessionResponse {
+	session := SessionSerializer{s.C, s.Session}
+	race := RaceSerializer{s.C, s.Race}
+	startsIn := s.Session.StartTime.Sub(s.Now)
+	if startsIn < 0 {
+		startsIn = 0
+	}
+
+	response := NextSessionResponse{
+		Session:         session.Response(),
+		Race:            race.Response(),
+		Live:            s.Session.Status == m.SessionLive || !s.Now.Before(s.Session.StartTime),
+		StartsInSeconds: int64(startsIn.Seconds()),
+	}
+
+	return response
+}
@@ -0,0 +1,101 @@
+package serializers
+
+import (
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/dewciu/f1_api/pkg/replay"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ReplayEventResponse struct {
+	Type string `json:"type"`
+	// Offset is the time into the race in seconds
+	Offset float64     `json:"offset"`
+	Time   time.Time   `json:"time"`
+	Data   interface{} `json:"data" swaggertype:"object"`
+} //@name ReplayEventResponse
+
+type ReplayPositionResponse struct {
+	DriverID uuid.UUID `json:"driver_id"`
+	Lap      int       `json:"lap"`
+	Position int       `json:"position"`
+} //@name ReplayPositionResponse
+
+type ReplayGapResponse struct {
+	DriverID uuid.UUID  `json:"driver_id"`
+	Lap      int        `json:"lap"`
+	Gap      m.LapTime  `json:"gap" swaggertype:"string" example:"+1.254"`
+	Interval *m.LapTime `json:"interval" swaggertype:"string" example:"+0.412"`
+} //@name ReplayGapResponse
+
+type ReplayStateResponse struct {
+	// Viewer identifies the playback to control over SSE
+	Viewer   uuid.UUID `json:"viewer"`
+	Offset   float64   `json:"offset"`
+	Duration float64   `json:"duration"`
+	Speed    float64   `json:"speed"`
+	Paused   bool      `json:"paused"`
+} //@name ReplayStateResponse
+
+type ReplaySnapshotResponse struct {
+	Positions []ReplayPositionResponse `json:"positions"`
+	Gaps      []ReplayGapResponse      `json:"gaps"`
+} //@name ReplaySnapshotResponse
+
+type ReplayEventSerializer struct {
+	C     *gin.Context
+	Start time.Time
+	Event replay.Event
+}
+
+func (s *ReplayEventSerializer) Response() ReplayEventResponse {
+	return ReplayEventResponse{
+		Type:   s.Event.Type,
+		Offset: s.Event.Offset.Seconds(),
+		Time:   s.Start.Add(s.Event.Offset).UTC(),
+		Data:   s.data(),
+	}
+}
+
+func (s *ReplayEventSerializer) data() interface{} {
+	switch data := s.Event.Data.(type) {
+	case replay.Position:
+		return replayPosition(data)
+	case replay.Gap:
+		return replayGap(data)
+	case replay.Pit:
+		stops := PitStopsSerializer{C: s.C, PitStops: []m.PitStop{data.PitStop}}
+		return stops.Response()[0]
+	case m.RaceControlEvent:
+		events := RaceControlEventsSerializer{C: s.C, Events: []m.RaceControlEvent{data}}
+		return events.Response()[0]
+	case replay.State:
+		return ReplayStateResponse{
+			Viewer:   data.Viewer,
+			Offset:   data.Offset.Seconds(),
+			Duration: data.Duration.Seconds(),
+			Speed:    data.Speed,
+			Paused:   data.Paused,
+		}
+	case replay.Snapshot:
+		snapshot := ReplaySnapshotResponse{Positions: []ReplayPositionResponse{}, Gaps: []ReplayGapResponse{}}
+		for _, position := range data.Positions {
+			snapshot.Positions = append(snapshot.Positions, replayPosition(position))
+		}
+		for _, gap := range data.Gaps {
+			snapshot.Gaps = append(snapshot.Gaps, replayGap(gap))
+		}
+		return snapshot
+	}
+	return nil
+}
+
+func replayPosition(position replay.Position) ReplayPositionResponse {
+	return ReplayPositionResponse{DriverID: position.DriverID, Lap: position.Lap, Position: position.Position}
+}
+
+func replayGap(gap replay.Gap) ReplayGapResponse {
+	return ReplayGapResponse{DriverID: gap.DriverID, Lap: gap.Lap, Gap: gap.Gap, Interval: gap.Interval}
+}
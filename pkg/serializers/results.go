@@ -0,0 +1,123 @@
+package serializers
+
+import (
+	"github.com/dewciu/f1_api/pkg/common"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func formatMillis(millis *int64) string {
+	if millis == nil {
+		return ""
+	}
+	return m.LapTimeFromMillis(*millis).String()
+}
+
+func formatLapTime(t *m.LapTime) string {
+	if t == nil {
+		return ""
+	}
+	return t.String()
+}
+
+type ResultResponse struct {
+	ID             uuid.UUID           `json:"id"`
+	Position       *int                `json:"position"`
+	PositionText   string              `json:"position_text"`
+	PositionOrder  int                 `json:"position_order"`
+	Classified     bool                `json:"classified"`
+	CarNumber      int                 `json:"car_number"`
+	Driver         DriverResponse      `json:"driver"`
+	Constructor    ConstructorResponse `json:"constructor"`
+	Grid           int                 `json:"grid"`
+	Laps           int                 `json:"laps"`
+	Time           string              `json:"time,omitempty"`
+	TimeMillis     *int64              `json:"time_millis,omitempty"`
+	Gap            string              `json:"gap,omitempty"`
+	Status         string              `json:"status"`
+	Points         float64             `json:"points"`
+	FastestLap     *int                `json:"fastest_lap,omitempty"`
+	FastestLapTime string              `json:"fastest_lap_time,omitempty"`
+	FastestLapRank *int                `json:"fastest_lap_rank,omitempty"`
+} //@name ResultResponse
+
+type ResultSerializer struct {
+	C *gin.Context
+	m.Result
+}
+
+func (s *ResultSerializer) Response() ResultResponse {
+	driver := DriverSerializer{s.C, s.Driver}
+	constructor := ConstructorSerializer{s.C, s.Constructor}
+	response := ResultResponse{
+		ID:             s.ID,
+		Position:       s.Position,
+		PositionText:   s.PositionText,
+		PositionOrder:  s.PositionOrder,
+		Classified:     s.Classified,
+		CarNumber:      s.CarNumber,
+		Driver:         driver.Response(),
+		Constructor:    constructor.Response(),
+		Grid:           s.Grid,
+		Laps:           s.Laps,
+		Time:           formatMillis(s.TimeMillis),
+		TimeMillis:     s.TimeMillis,
+		Gap:            s.Gap,
+		Status:         s.Status,
+		Points:         s.Points,
+		FastestLap:     s.FastestLap,
+		FastestLapTime: formatLapTime(s.FastestLapTime),
+		FastestLapRank: s.FastestLapRank,
+	}
+
+	return response
+}
+
+type ResultsSerializer struct {
+	C       *gin.Context
+	Results []m.Result
+}
+
+func (s *ResultsSerializer) Response() []ResultResponse {
+	response := []ResultResponse{}
+	for _, result := range s.Results {
+		serializer := ResultSerializer{s.C, result}
+		response = append(response, serializer.Response())
+	}
+
+	return response
+}
+
+type DriverResultResponse struct {
+	ResultResponse
+	Session  string    `json:"session"`
+	RaceID   uuid.UUID `json:"race_id"`
+	RaceName string    `json:"race_name"`
+	Year     int       `json:"year"`
+	Round    int       `json:"round"`
+	Date     string    `json:"date"`
+} //@name DriverResultResponse
+
+type DriverResultsSerializer struct {
+	C       *gin.Context
+	Results []m.Result
+}
+
+func (s *DriverResultsSerializer) Response() []DriverResultResponse {
+	response := []DriverResultResponse{}
+	for _, result := range s.Results {
+		serializer := ResultSerializer{s.C, result}
+		response = append(response, DriverResultResponse{
+			ResultResponse: serializer.Response(),
+			Session:        result.Session.Type,
+			RaceID:         result.Race.ID,
+			RaceName:       result.Race.Name,
+			Year:           result.Race.Year,
+			Round:          result.Race.Round,
+			Date:           result.Race.Date.Format(common.DateLayout),
+		})
+	}
+
+	return response
+}
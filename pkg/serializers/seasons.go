@@ -0,0 +1,40 @@
+package serializers
+
+import (
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+)
+
+type SeasonResponse struct {
+	Year int    `json:"year"`
+	URL  string `json:"url,omitempty"`
+} //@name SeasonResponse
+
+type SeasonSerializer struct {
+	C *gin.Context
+	m.Season
+}
+
+func (s *SeasonSerializer) Response() SeasonResponse {
+	response := SeasonResponse{
+		Year: s.Year,
+		URL:  s.URL,
+	}
+
+	return response
+}
+
+type SeasonsSerializer struct {
+	C       *gin.Context
+	Seasons []m.Season
+}
+
+func (s *SeasonsSerializer) Response() []SeasonResponse {
+	response := []SeasonResponse{}
+	for _, season := range s.Seasons {
+		serializer := SeasonSerializer{s.C, season}
+		response = append(response, serializer.Response())
+	}
+
+	return response
+}
@@ -0,0 +1,131 @@
+package serializers
+
+import (
+	"encoding/json"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	sim "github.com/dewciu/f1_api/pkg/simulation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SimulationDriverResponse struct {
+	Driver      DriverResponse      `json:"driver"`
+	Constructor ConstructorResponse `json:"constructor"`
+	// Strength and DNFProbability are left out for drivers not taking part
+	// in the remaining races
+	Strength         *float64  `json:"strength,omitempty"`
+	DNFProbability   *float64  `json:"dnf_probability,omitempty"`
+	Position         int       `json:"position"`
+	Points           float64   `json:"points"`
+	TitleProbability float64   `json:"title_probability"`
+	ExpectedPoints   float64   `json:"expected_points"`
+	Positions        []float64 `json:"positions"`
+} //@name SimulationDriverResponse
+
+type SimulationOutcomeResponse struct {
+	AfterRound      int                        `json:"after_round"`
+	RemainingRounds []int                      `json:"remaining_rounds"`
+	Drivers         []SimulationDriverResponse `json:"drivers"`
+} //@name SimulationOutcomeResponse
+
+type SimulationOutcomeSerializer struct {
+	C *gin.Context
+	sim.Outcome
+}
+
+func (s *SimulationOutcomeSerializer) Response() SimulationOutcomeResponse {
+	response := SimulationOutcomeResponse{
+		AfterRound:      s.AfterRound,
+		RemainingRounds: s.RemainingRounds,
+		Drivers:         []SimulationDriverResponse{},
+	}
+	for _, d := range s.Drivers {
+		driver := DriverSerializer{s.C, d.Driver}
+		constructor := ConstructorSerializer{s.C, d.Constructor}
+		outcome := SimulationDriverResponse{
+			Driver:           driver.Response(),
+			Constructor:      constructor.Response(),
+			Position:         d.Position,
+			Points:           d.Points,
+			TitleProbability: d.TitleProbability,
+			ExpectedPoints:   d.ExpectedPoints,
+			Positions:        d.Positions,
+		}
+		if d.Model != nil {
+			strength, dnf := d.Model.Strength, d.Model.DNFProbability
+			outcome.Strength = &strength
+			outcome.DNFProbability = &dnf
+		}
+		response.Drivers = append(response.Drivers, outcome)
+	}
+
+	return response
+}
+
+type SimulationResponse struct {
+	ID            uuid.UUID                  `json:"id"`
+	Year          int                        `json:"year"`
+	Status        string                     `json:"status"`
+	Error         string                     `json:"error,omitempty"`
+	Seed          int64                      `json:"seed"`
+	Runs          int                        `json:"runs"`
+	Decay         float64                    `json:"decay"`
+	StrengthPrior float64                    `json:"strength_prior"`
+	DNFPrior      float64                    `json:"dnf_prior"`
+	Overrides     []m.SimulationOverride     `json:"overrides"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	StartedAt     *time.Time                 `json:"started_at"`
+	FinishedAt    *time.Time                 `json:"finished_at"`
+	Outcome       *SimulationOutcomeResponse `json:"outcome,omitempty"`
+} //@name SimulationResponse
+
+type SimulationSerializer struct {
+	C *gin.Context
+	m.Simulation
+}
+
+// Response includes the stored outcome once the simulation has finished
+func (s *SimulationSerializer) Response() SimulationResponse {
+	response := SimulationResponse{
+		ID:            s.ID,
+		Year:          s.Year,
+		Status:        s.Status,
+		Error:         s.Error,
+		Seed:          s.Seed,
+		Runs:          s.Runs,
+		Decay:         s.Decay,
+		StrengthPrior: s.StrengthPrior,
+		DNFPrior:      s.DNFPrior,
+		Overrides:     []m.SimulationOverride{},
+		CreatedAt:     s.CreatedAt,
+		StartedAt:     s.StartedAt,
+		FinishedAt:    s.FinishedAt,
+	}
+	if len(s.Overrides) > 0 {
+		_ = json.Unmarshal(s.Overrides, &response.Overrides)
+	}
+	if len(s.Outcome) > 0 {
+		outcome := SimulationOutcomeResponse{}
+		if err := json.Unmarshal(s.Outcome, &outcome); err == nil {
+			response.Outcome = &outcome
+		}
+	}
+
+	return response
+}
+
+type SimulationsSerializer struct {
+	C           *gin.Context
+	Simulations []m.Simulation
+}
+
+func (s *SimulationsSerializer) Response() []SimulationResponse {
+	response := []SimulationResponse{}
+	for _, simulation := range s.Simulations {
+		serializer := SimulationSerializer{s.C, simulation}
+		response = append(response, serializer.Response())
+	}
+	return response
+}
@@ -0,0 +1,174 @@
+package serializers
+
+import (
+	st "github.com/dewciu/f1_api/pkg/standings"
+	"github.com/gin-gonic/gin"
+)
+
+type DriverStandingResponse struct {
+	Position     int                   `json:"position"`
+	Driver       DriverResponse        `json:"driver"`
+	Constructors []ConstructorResponse `json:"constructors"`
+	Points       float64               `json:"points"`
+	GrossPoints  float64               `json:"gross_points"`
+	Wins         int                   `json:"wins"`
+	SprintPoints float64               `json:"sprint_points"`
+	SprintWins   int                   `json:"sprint_wins"`
+} //@name DriverStandingResponse
+
+type DriverStandingsResponse struct {
+	Year       int                      `json:"year"`
+	AfterRound int                      `json:"after_round"`
+	Standings  []DriverStandingResponse `json:"standings"`
+} //@name DriverStandingsResponse
+
+type DriverStandingsSerializer struct {
+	C *gin.Context
+	st.Table
+}
+
+func (s *DriverStandingsSerializer) Response() DriverStandingsResponse {
+	response := DriverStandingsResponse{
+		Year:       s.Year,
+		AfterRound: s.AfterRound,
+		Standings:  []DriverStandingResponse{},
+	}
+	for _, standing := range s.Drivers {
+		driver := DriverSerializer{s.C, standing.Driver}
+		constructors := ConstructorsSerializer{s.C, standing.Constructors}
+		response.Standings = append(response.Standings, DriverStandingResponse{
+			Position:     standing.Position,
+			Driver:       driver.Response(),
+			Constructors: constructors.Response(),
+			Points:       standing.Points,
+			GrossPoints:  standing.GrossPoints,
+			Wins:         standing.Wins,
+			SprintPoints: standing.SprintPoints,
+			SprintWins:   standing.SprintWins,
+		})
+	}
+
+	return response
+}
+
+type ConstructorStandingResponse struct {
+	Position     int                 `json:"position"`
+	Constructor  ConstructorResponse `json:"constructor"`
+	Points       float64             `json:"points"`
+	Wins         int                 `json:"wins"`
+	SprintPoints float64             `json:"sprint_points"`
+	SprintWins   int                 `json:"sprint_wins"`
+} //@name ConstructorStandingResponse
+
+type ConstructorStandingsResponse struct {
+	Year       int                           `json:"year"`
+	AfterRound int                           `json:"after_round"`
+	Standings  []ConstructorStandingResponse `json:"standings"`
+} //@name ConstructorStandingsResponse
+
+type ConstructorStandingsSerializer struct {
+	C *gin.Context
+	st.Table
+}
+
+func (s *ConstructorStandingsSerializer) Response() ConstructorStandingsResponse {
+	response := ConstructorStandingsResponse{
+		Year:       s.Year,
+		AfterRound: s.AfterRound,
+		Standings:  []ConstructorStandingResponse{},
+	}
+	for _, standing := range s.Constructors {
+		constructor := ConstructorSerializer{s.C, standing.Constructor}
+		response.Standings = append(response.Standings, ConstructorStandingResponse{
+			Position:     standing.Position,
+			Constructor:  constructor.Response(),
+			Points:       standing.Points,
+			Wins:         standing.Wins,
+			SprintPoints: standing.SprintPoints,
+			SprintWins:   standing.SprintWins,
+		})
+	}
+
+	return response
+}
+
+type DriverContenderResponse struct {
+	DriverStandingResponse
+	MaxPoints  float64 `json:"max_points"`
+	Eliminated bool    `json:"eliminated"`
+} //@name DriverContenderResponse
+
+type ConstructorContenderResponse struct {
+	ConstructorStandingResponse
+	MaxPoints  float64 `json:"max_points"`
+	Eliminated bool    `json:"eliminated"`
+} //@name ConstructorContenderResponse
+
+type ClinchResponse struct {
+	Leader    DriverResponse `json:"leader"`
+	Round     int            `json:"round"`
+	Clinched  bool           `json:"clinched"`
+	Position  int            `json:"position,omitempty"`
+	AnyResult bool           `json:"any_result"`
+} //@name ClinchResponse
+
+type ContendersResponse struct {
+	Year            int                            `json:"year"`
+	AfterRound      int                            `json:"after_round"`
+	RemainingRounds []int                          `json:"remaining_rounds"`
+	Drivers         []DriverContenderResponse      `json:"drivers"`
+	Constructors    []ConstructorContenderResponse `json:"constructors"`
+	Clinch          *ClinchResponse                `json:"clinch"`
+} //@name ContendersResponse
+
+type ContendersSerializer struct {
+	C *gin.Context
+	st.Contenders
+}
+
+func (s *ContendersSerializer) Response() ContendersResponse {
+	drivers := []st.DriverStanding{}
+	for _, contender := range s.Drivers {
+		drivers = append(drivers, contender.DriverStanding)
+	}
+	constructors := []st.ConstructorStanding{}
+	for _, contender := range s.Constructors {
+		constructors = append(constructors, contender.ConstructorStanding)
+	}
+	driverStandings := DriverStandingsSerializer{s.C, st.Table{Drivers: drivers}}
+	constructorStandings := ConstructorStandingsSerializer{s.C, st.Table{Constructors: constructors}}
+
+	response := ContendersResponse{
+		Year:            s.Year,
+		AfterRound:      s.AfterRound,
+		RemainingRounds: s.RemainingRounds,
+		Drivers:         []DriverContenderResponse{},
+		Constructors:    []ConstructorContenderResponse{},
+	}
+	for i, standing := range driverStandings.Response().Standings {
+		response.Drivers = append(response.Drivers, DriverContenderResponse{
+			DriverStandingResponse: standing,
+			MaxPoints:              s.Drivers[i].MaxPoints,
+			Eliminated:             s.Drivers[i].Eliminated,
+		})
+	}
+	for i, standing := range constructorStandings.Response().Standings {
+		response.Constructors = append(response.Constructors, ConstructorContenderResponse{
+			ConstructorStandingResponse: standing,
+			MaxPoints:                   s.Constructors[i].MaxPoints,
+			Eliminated:                  s.Constructors[i].Eliminated,
+		})
+	}
+	if s.Clinch != nil {
+		leader := DriverSerializer{s.C, s.Clinch.Leader}
+		response.Clinch = &ClinchResponse{
+			Leader:    leader.Response(),
+			Round:     s.Clinch.Round,
+			Clinched:  s.Clinch.Clinched,
+			Position:  s.Clinch.Position,
+			AnyResult: s.Clinch.AnyResult,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,98 @@
+package serializers
+
+import (
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StewardDecisionResponse struct {
+	ID           uuid.UUID      `json:"id"`
+	Session      string         `json:"session"`
+	Driver       DriverResponse `json:"driver"`
+	Document     string         `json:"document"`
+	Infringement string         `json:"infringement"`
+	Penalty      string         `json:"penalty"`
+	Value        float64        `json:"value,omitempty"`
+	Status       string         `json:"status"`
+	IssuedAt     time.Time      `json:"issued_at"`
+	AppealedAt   *time.Time     `json:"appealed_at,omitempty"`
+	ResolvedAt   *time.Time     `json:"resolved_at,omitempty"`
+} //@name StewardDecisionResponse
+
+type StewardDecisionSerializer struct {
+	C *gin.Context
+	m.StewardDecision
+}
+
+func (s *StewardDecisionSerializer) Response() StewardDecisionResponse {
+	driver := DriverSerializer{s.C, s.Driver}
+	response := StewardDecisionResponse{
+		ID:           s.ID,
+		Session:      s.Session.Type,
+		Driver:       driver.Response(),
+		Document:     s.Document,
+		Infringement: s.Infringement,
+		Penalty:      s.Penalty,
+		Value:        s.Value,
+		Status:       s.Status,
+		IssuedAt:     s.IssuedAt.UTC(),
+		AppealedAt:   s.AppealedAt,
+		ResolvedAt:   s.ResolvedAt,
+	}
+
+	return response
+}
+
+type StewardDecisionsSerializer struct {
+	C         *gin.Context
+	Decisions []m.StewardDecision
+}
+
+func (s *StewardDecisionsSerializer) Response() []StewardDecisionResponse {
+	response := []StewardDecisionResponse{}
+	for _, decision := range s.Decisions {
+		serializer := StewardDecisionSerializer{s.C, decision}
+		response = append(response, serializer.Response())
+	}
+
+	return response
+}
+
+type ResultVersionResponse struct {
+	Version     int        `json:"version"`
+	EffectiveAt time.Time  `json:"effective_at"`
+	DecisionID  *uuid.UUID `json:"decision_id,omitempty"`
+	Document    string     `json:"document,omitempty"`
+	// Status is the status the decision moved to, versions without a
+	// decision hold a submitted classification
+	Status string `json:"status,omitempty"`
+	// NeedsReview flags a time penalty on a lapped car, which keeps its place
+	NeedsReview bool `json:"needs_review"`
+} //@name ResultVersionResponse
+
+type ResultVersionsSerializer struct {
+	C        *gin.Context
+	Versions []m.ResultVersion
+}
+
+func (s *ResultVersionsSerializer) Response() []ResultVersionResponse {
+	response := []ResultVersionResponse{}
+	for _, version := range s.Versions {
+		r := ResultVersionResponse{
+			Version:     version.Version,
+			EffectiveAt: version.EffectiveAt.UTC(),
+			DecisionID:  version.DecisionID,
+			Status:      version.Status,
+			NeedsReview: version.NeedsReview,
+		}
+		if version.Decision != nil {
+			r.Document = version.Decision.Document
+		}
+		response = append(response, r)
+	}
+
+	return response
+}
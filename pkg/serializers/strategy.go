@@ -0,0 +1,192 @@
+package serializers
+
+import (
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/dewciu/f1_api/pkg/strategy"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PitStopResponse struct {
+	ID         uuid.UUID      `json:"id"`
+	Driver     DriverResponse `json:"driver"`
+	Stop       int            `json:"stop"`
+	Lap        int            `json:"lap"`
+	TimeOfDay  string         `json:"time_of_day,omitempty"`
+	Stationary string         `json:"stationary,omitempty"`
+	PitLane    string         `json:"pit_lane,omitempty"`
+} //@name PitStopResponse
+
+type PitStopsSerializer struct {
+	C        *gin.Context
+	PitStops []m.PitStop
+}
+
+func (s *PitStopsSerializer) Response() []PitStopResponse {
+	response := []PitStopResponse{}
+	for _, stop := range s.PitStops {
+		driver := DriverSerializer{s.C, stop.Driver}
+		response = append(response, PitStopResponse{
+			ID:         stop.ID,
+			Driver:     driver.Response(),
+			Stop:       stop.Stop,
+			Lap:        stop.Lap,
+			TimeOfDay:  stop.TimeOfDay,
+			Stationary: formatLapTime(stop.Stationary),
+			PitLane:    formatLapTime(stop.PitLane),
+		})
+	}
+	return response
+}
+
+type StintResponse struct {
+	Number   int    `json:"number"`
+	Compound string `json:"compound"`
+	New      bool   `json:"new"`
+	StartLap int    `json:"start_lap"`
+	EndLap   int    `json:"end_lap"`
+	Laps     int    `json:"laps"`
+} //@name StintResponse
+
+func stintResponse(stint m.Stint) StintResponse {
+	return StintResponse{
+		Number:   stint.Number,
+		Compound: stint.Compound,
+		New:      stint.New,
+		StartLap: stint.StartLap,
+		EndLap:   stint.EndLap,
+		Laps:     stint.Laps(),
+	}
+}
+
+type DriverStintsResponse struct {
+	Driver DriverResponse  `json:"driver"`
+	Stints []StintResponse `json:"stints"`
+} //@name DriverStintsResponse
+
+type StintsSerializer struct {
+	C      *gin.Context
+	Stints []m.Stint
+}
+
+// Response groups the stints by driver in the order they are given
+func (s *StintsSerializer) Response() []DriverStintsResponse {
+	response := []DriverStintsResponse{}
+	index := make(map[uuid.UUID]int)
+	for _, stint := range s.Stints {
+		i, ok := index[stint.DriverID]
+		if !ok {
+			i = len(response)
+			index[stint.DriverID] = i
+			driver := DriverSerializer{s.C, stint.Driver}
+			response = append(response, DriverStintsResponse{Driver: driver.Response(), Stints: []StintResponse{}})
+		}
+		response[i].Stints = append(response[i].Stints, stintResponse(stint))
+	}
+	return response
+}
+
+type DriverStrategyResponse struct {
+	Driver   DriverResponse    `json:"driver"`
+	Stops    int               `json:"stops"`
+	Stints   []StintResponse   `json:"stints"`
+	PitStops []PitStopResponse `json:"pit_stops"`
+} //@name DriverStrategyResponse
+
+type RankedStopResponse struct {
+	Rank   int            `json:"rank"`
+	Driver DriverResponse `json:"driver"`
+	Stop   int            `json:"stop"`
+	Lap    int            `json:"lap"`
+	Time   string         `json:"time"`
+} //@name RankedStopResponse
+
+type FastestStopsResponse struct {
+	RankedBy string               `json:"ranked_by"`
+	Ranking  []RankedStopResponse `json:"ranking"`
+} //@name FastestStopsResponse
+
+type ExchangeResponse struct {
+	Early     DriverResponse `json:"early"`
+	Late      DriverResponse `json:"late"`
+	EarlyLap  int            `json:"early_lap"`
+	LateLap   int            `json:"late_lap"`
+	GapBefore string         `json:"gap_before"`
+	GapAfter  string         `json:"gap_after"`
+	Gain      string         `json:"gain"`
+	Outcome   string         `json:"outcome"`
+} //@name ExchangeResponse
+
+type StrategyResponse struct {
+	RaceID       uuid.UUID                `json:"race_id"`
+	Drivers      []DriverStrategyResponse `json:"drivers"`
+	FastestStops FastestStopsResponse     `json:"fastest_stops"`
+	// Analysed is false when the race has no laps to compare the stops with
+	Analysed  bool               `json:"analysed"`
+	Exchanges []ExchangeResponse `json:"exchanges"`
+} //@name StrategyResponse
+
+type StrategySerializer struct {
+	C      *gin.Context
+	RaceID uuid.UUID
+	strategy.Strategy
+}
+
+func (s *StrategySerializer) Response() StrategyResponse {
+	drivers := make(map[uuid.UUID]DriverResponse)
+	for _, d := range s.Drivers {
+		for _, stint := range d.Stints {
+			serializer := DriverSerializer{s.C, stint.Driver}
+			drivers[d.DriverID] = serializer.Response()
+		}
+		for _, stop := range d.PitStops {
+			serializer := DriverSerializer{s.C, stop.Driver}
+			drivers[d.DriverID] = serializer.Response()
+		}
+	}
+
+	response := StrategyResponse{
+		RaceID:       s.RaceID,
+		Drivers:      []DriverStrategyResponse{},
+		FastestStops: FastestStopsResponse{RankedBy: s.RankedBy, Ranking: []RankedStopResponse{}},
+		Analysed:     s.Analysed,
+		Exchanges:    []ExchangeResponse{},
+	}
+	for _, d := range s.Drivers {
+		driver := DriverStrategyResponse{
+			Driver:   drivers[d.DriverID],
+			Stops:    d.Stops,
+			Stints:   []StintResponse{},
+			PitStops: []PitStopResponse{},
+		}
+		for _, stint := range d.Stints {
+			driver.Stints = append(driver.Stints, stintResponse(stint))
+		}
+		stops := PitStopsSerializer{s.C, d.PitStops}
+		driver.PitStops = stops.Response()
+		response.Drivers = append(response.Drivers, driver)
+	}
+	for _, stop := range s.Fastest {
+		response.FastestStops.Ranking = append(response.FastestStops.Ranking, RankedStopResponse{
+			Rank:   stop.Rank,
+			Driver: drivers[stop.DriverID],
+			Stop:   stop.Stop,
+			Lap:    stop.Lap,
+			Time:   stop.Time.String(),
+		})
+	}
+	for _, exchange := range s.Exchanges {
+		response.Exchanges = append(response.Exchanges, ExchangeResponse{
+			Early:     drivers[exchange.Early],
+			Late:      drivers[exchange.Late],
+			EarlyLap:  exchange.EarlyLap,
+			LateLap:   exchange.LateLap,
+			GapBefore: formatGap(&exchange.GapBefore),
+			GapAfter:  formatGap(&exchange.GapAfter),
+			Gain:      formatGap(&exchange.Gain),
+			Outcome:   exchange.Outcome,
+		})
+	}
+
+	return response
+}
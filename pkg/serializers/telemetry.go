@@ -0,0 +1,162 @@
+package serializers
+
+import (
+	"time"
+
+	d "github.com/dewciu/f1_api/pkg/database"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/dewciu/f1_api/pkg/telemetry"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TelemetryCopyResponse struct {
+	SessionID uuid.UUID `json:"session_id"`
+	Received  int64     `json:"received"`
+	Stored    int64     `json:"stored"`
+	// Duplicates counts the samples received which were already stored
+	Duplicates int64 `json:"duplicates"`
+} //@name TelemetryCopyResponse
+
+type TelemetryCopySerializer struct {
+	C         *gin.Context
+	SessionID uuid.UUID
+	Copy      d.TelemetryCopy
+}
+
+func (s *TelemetryCopySerializer) Response() TelemetryCopyResponse {
+	return TelemetryCopyResponse{
+		SessionID:  s.SessionID,
+		Received:   s.Copy.Received,
+		Stored:     s.Copy.Stored,
+		Duplicates: s.Copy.Received - s.Copy.Stored,
+	}
+}
+
+type TelemetrySampleResponse struct {
+	Time     time.Time `json:"time"`
+	Lap      int       `json:"lap"`
+	Distance *float64  `json:"distance"`
+	Speed    int       `json:"speed"`
+	RPM      int       `json:"rpm"`
+	Gear     int       `json:"gear"`
+	Throttle int       `json:"throttle"`
+	Brake    bool      `json:"brake"`
+	DRS      bool      `json:"drs"`
+} //@name TelemetrySampleResponse
+
+type TelemetryResponse struct {
+	SessionID uuid.UUID `json:"session_id"`
+	DriverID  uuid.UUID `json:"driver_id"`
+	// Samples counts the samples stored for the lap or time range, Points
+	// holds them downsampled when fewer points were requested
+	Samples int                       `json:"samples"`
+	Points  []TelemetrySampleResponse `json:"points"`
+} //@name TelemetryResponse
+
+type TelemetrySerializer struct {
+	C         *gin.Context
+	SessionID uuid.UUID
+	DriverID  uuid.UUID
+	Samples   int
+	Points    []m.TelemetrySample
+}
+
+func (s *TelemetrySerializer) Response() TelemetryResponse {
+	points := make([]TelemetrySampleResponse, 0, len(s.Points))
+	for _, point := range s.Points {
+		points = append(points, TelemetrySampleResponse{
+			Time:     point.Time,
+			Lap:      point.Lap,
+			Distance: point.Distance,
+			Speed:    point.Speed,
+			RPM:      point.RPM,
+			Gear:     point.Gear,
+			Throttle: point.Throttle,
+			Brake:    point.Brake,
+			DRS:      point.DRS,
+		})
+	}
+
+	return TelemetryResponse{
+		SessionID: s.SessionID,
+		DriverID:  s.DriverID,
+		Samples:   s.Samples,
+		Points:    points,
+	}
+}
+
+type ComparedLapResponse struct {
+	DriverID uuid.UUID `json:"driver_id"`
+	Lap      int       `json:"lap"`
+} //@name ComparedLapResponse
+
+type ComparisonPointResponse struct {
+	Distance  float64 `json:"distance"`
+	SpeedA    float64 `json:"speed_a"`
+	SpeedB    float64 `json:"speed_b"`
+	ThrottleA float64 `json:"throttle_a"`
+	ThrottleB float64 `json:"throttle_b"`
+	// Delta is the time in seconds driver B lost to driver A up to the
+	// distance, negative where driver B is ahead
+	Delta float64 `json:"delta"`
+} //@name ComparisonPointResponse
+
+type MiniSectorResponse struct {
+	Number int     `json:"number"`
+	Start  float64 `json:"start"`
+	End    float64 `json:"end"`
+	TimeA  float64 `json:"time_a"`
+	TimeB  float64 `json:"time_b"`
+	// Winner is the driver faster through the mini-sector, null on a tie
+	Winner *uuid.UUID `json:"winner"`
+} //@name MiniSectorResponse
+
+type TelemetryComparisonResponse struct {
+	SessionID uuid.UUID           `json:"session_id"`
+	A         ComparedLapResponse `json:"a"`
+	B         ComparedLapResponse `json:"b"`
+	// Length is the lap length in metres both laps are aligned on
+	Length      float64                   `json:"length"`
+	Delta       float64                   `json:"delta"`
+	Points      []ComparisonPointResponse `json:"points"`
+	MiniSectors []MiniSectorResponse      `json:"mini_sectors"`
+} //@name TelemetryComparisonResponse
+
+type TelemetryComparisonSerializer struct {
+	C          *gin.Context
+	SessionID  uuid.UUID
+	A          ComparedLapResponse
+	B          ComparedLapResponse
+	Comparison telemetry.Comparison
+}
+
+func (s *TelemetryComparisonSerializer) Response() TelemetryComparisonResponse {
+	points := make([]ComparisonPointResponse, 0, len(s.Comparison.Points))
+	for _, point := range s.Comparison.Points {
+		points = append(points, ComparisonPointResponse(point))
+	}
+
+	winners := map[string]*uuid.UUID{telemetry.LapA: &s.A.DriverID, telemetry.LapB: &s.B.DriverID}
+	sectors := make([]MiniSectorResponse, 0, len(s.Comparison.MiniSectors))
+	for _, sector := range s.Comparison.MiniSectors {
+		sectors = append(sectors, MiniSectorResponse{
+			Number: sector.Number,
+			Start:  sector.Start,
+			End:    sector.End,
+			TimeA:  sector.TimeA,
+			TimeB:  sector.TimeB,
+			Winner: winners[sector.Winner],
+		})
+	}
+
+	return TelemetryComparisonResponse{
+		SessionID:   s.SessionID,
+		A:           s.A,
+		B:           s.B,
+		Length:      s.Comparison.Length,
+		Delta:       s.Comparison.Delta,
+		Points:      points,
+		MiniSectors: sectors,
+	}
+}
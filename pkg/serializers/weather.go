@@ -0,0 +1,102 @@
+package serializers
+
+import (
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/dewciu/f1_api/pkg/weather"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type WeatherObservationResponse struct {
+	Time             time.Time `json:"time"`
+	AirTemperature   float64   `json:"air_temperature" example:"27.4"`
+	TrackTemperature float64   `json:"track_temperature" example:"43.9"`
+	Humidity         float64   `json:"humidity" example:"41"`
+	Pressure         float64   `json:"pressure" example:"996.8"`
+	WindSpeed        float64   `json:"wind_speed" example:"1.3"`
+	WindDirection    int       `json:"wind_direction" example:"215"`
+	Rainfall         bool      `json:"rainfall"`
+} //@name WeatherObservationResponse
+
+func weatherObservation(observation m.WeatherObservation) WeatherObservationResponse {
+	return WeatherObservationResponse{
+		Time:             observation.Time,
+		AirTemperature:   observation.AirTemperature,
+		TrackTemperature: observation.TrackTemperature,
+		Humidity:         observation.Humidity,
+		Pressure:         observation.Pressure,
+		WindSpeed:        observation.WindSpeed,
+		WindDirection:    observation.WindDirection,
+		Rainfall:         observation.Rainfall,
+	}
+}
+
+type WeatherResponse struct {
+	SessionID    uuid.UUID                    `json:"session_id"`
+	Observations []WeatherObservationResponse `json:"observations"`
+} //@name WeatherResponse
+
+type WeatherSerializer struct {
+	C            *gin.Context
+	SessionID    uuid.UUID
+	Observations []m.WeatherObservation
+}
+
+func (s *WeatherSerializer) Response() WeatherResponse {
+	response := WeatherResponse{SessionID: s.SessionID, Observations: []WeatherObservationResponse{}}
+	for _, observation := range s.Observations {
+		response.Observations = append(response.Observations, weatherObservation(observation))
+	}
+	return response
+}
+
+type WeatherRangeResponse struct {
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Mean float64 `json:"mean"`
+} //@name WeatherRangeResponse
+
+type WeatherSummaryResponse struct {
+	SessionID        uuid.UUID            `json:"session_id"`
+	Observations     int                  `json:"observations"`
+	From             *time.Time           `json:"from"`
+	To               *time.Time           `json:"to"`
+	AirTemperature   WeatherRangeResponse `json:"air_temperature"`
+	TrackTemperature WeatherRangeResponse `json:"track_temperature"`
+	Humidity         WeatherRangeResponse `json:"humidity"`
+	Pressure         WeatherRangeResponse `json:"pressure"`
+	WindSpeed        WeatherRangeResponse `json:"wind_speed"`
+	// WindDirection is the mean direction weighted by the wind speed
+	WindDirection *int `json:"wind_direction" example:"215"`
+	Rainfall      bool `json:"rainfall"`
+	// WetShare is the share of the observations reporting rainfall
+	WetShare float64 `json:"wet_share" example:"0.25"`
+} //@name WeatherSummaryResponse
+
+type WeatherSummarySerializer struct {
+	C         *gin.Context
+	SessionID uuid.UUID
+	Summary   weather.Summary
+}
+
+func (s *WeatherSummarySerializer) Response() WeatherSummaryResponse {
+	response := WeatherSummaryResponse{
+		SessionID:        s.SessionID,
+		Observations:     s.Summary.Observations,
+		AirTemperature:   WeatherRangeResponse(s.Summary.AirTemperature),
+		TrackTemperature: WeatherRangeResponse(s.Summary.TrackTemperature),
+		Humidity:         WeatherRangeResponse(s.Summary.Humidity),
+		Pressure:         WeatherRangeResponse(s.Summary.Pressure),
+		WindSpeed:        WeatherRangeResponse(s.Summary.WindSpeed),
+		WindDirection:    s.Summary.WindDirection,
+		Rainfall:         s.Summary.Rainfall,
+		WetShare:         s.Summary.WetShare,
+	}
+	if s.Summary.Observations > 0 {
+		response.From = &s.Summary.From
+		response.To = &s.Summary.To
+	}
+	return response
+}
@@ -0,0 +1,245 @@
+// Package simulation estimates championship outcomes by sampling the
+// remaining races of a season many times with a performance model fitted
+// from the results so far.
+package simulation
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+var ErrNoResults = errors.New("season has no race results to fit the model")
+
+const (
+	fitIterations = 500
+	fitTolerance  = 1e-10
+)
+
+// Override replaces the fitted performance of a driver, a StrengthFactor of
+// 2 makes the driver twice as likely to beat any rival
+type Override struct {
+	StrengthFactor *float64
+	DNFProbability *float64
+}
+
+// Config tunes the fitted model. Decay weights the race n rounds before the
+// latest one by Decay^n, 1 weighting all races equally. StrengthPrior adds as
+// many virtual wins and losses against an average driver, DNFPrior as many
+// virtual starts at the field's retirement rate
+type Config struct {
+	Decay         float64
+	StrengthPrior float64
+	DNFPrior      float64
+	Overrides     map[uuid.UUID]Override
+}
+
+func DefaultConfig() Config {
+	return Config{Decay: 1, StrengthPrior: 1, DNFPrior: 5}
+}
+
+// DriverModel is the performance of a driver in the Plackett-Luce model, a
+// driver finishes ahead of the others still running with probability
+// proportional to Strength
+type DriverModel struct {
+	Driver         m.Driver
+	Constructor    m.Constructor
+	Strength       float64
+	DNFProbability float64
+	Starts         int
+	DNFs           int
+}
+
+// Model holds the drivers taking part in the remaining races, ordered by
+// their ID
+type Model struct {
+	Drivers []DriverModel
+}
+
+// classification is a finishing order of a race with its weight
+type classification struct {
+	weight    float64
+	finishers []uuid.UUID
+}
+
+// Fit estimates the strength of every driver from the finishing orders of
+// the season's races with the minorization-maximization algorithm, retirements
+// are left out of the orders and counted towards the DNF probability. The
+// model includes the drivers who took part in the latest race
+func Fit(races []m.Race, results []m.Result, config Config) (Model, error) {
+	rounds := make(map[uuid.UUID]int)
+	for _, race := range races {
+		rounds[race.ID] = race.Round
+	}
+
+	byRace := make(map[uuid.UUID][]m.Result)
+	latest := 0
+	for _, result := range results {
+		if result.Session.Type != m.SessionRace {
+			continue
+		}
+		byRace[result.RaceID] = append(byRace[result.RaceID], result)
+		if rounds[result.RaceID] > latest {
+			latest = rounds[result.RaceID]
+		}
+	}
+	if len(byRace) == 0 {
+		return Model{}, ErrNoResults
+	}
+
+	decay := config.Decay
+	if decay <= 0 || decay > 1 {
+		decay = 1
+	}
+
+	index := make(map[uuid.UUID]int)
+	drivers := []DriverModel{}
+	starts := []float64{}
+	dnfs := []float64{}
+	classifications := []classification{}
+	var fieldStarts, fieldDNFs float64
+
+	raceIDs := []uuid.UUID{}
+	for id := range byRace {
+		raceIDs = append(raceIDs, id)
+	}
+	sort.Slice(raceIDs, func(i, j int) bool {
+		return rounds[raceIDs[i]] < rounds[raceIDs[j]] ||
+			rounds[raceIDs[i]] == rounds[raceIDs[j]] && raceIDs[i].String() < raceIDs[j].String()
+	})
+
+	for _, raceID := range raceIDs {
+		raceResults := byRace[raceID]
+		weight := math.Pow(decay, float64(latest-rounds[raceID]))
+
+		finished := []m.Result{}
+		for _, result := range raceResults {
+			i, ok := index[result.DriverID]
+			if !ok {
+				i = len(drivers)
+				index[result.DriverID] = i
+				drivers = append(drivers, DriverModel{Driver: result.Driver})
+				starts = append(starts, 0)
+				dnfs = append(dnfs, 0)
+			}
+			drivers[i].Starts++
+			starts[i] += weight
+			fieldStarts += weight
+			if result.Position == nil {
+				drivers[i].DNFs++
+				dnfs[i] += weight
+				fieldDNFs += weight
+				continue
+			}
+			finished = append(finished, result)
+		}
+
+		sort.SliceStable(finished, func(i, j int) bool {
+			return *finished[i].Position < *finished[j].Position
+		})
+		order := classification{weight: weight}
+		for _, result := range finished {
+			order.finishers = append(order.finishers, result.DriverID)
+		}
+		if len(order.finishers) > 1 {
+			classifications = append(classifications, order)
+		}
+	}
+
+	strengths := fitStrengths(len(drivers), index, classifications, config.StrengthPrior)
+
+	fieldRate := 0.0
+	if fieldStarts > 0 {
+		fieldRate = fieldDNFs / fieldStarts
+	}
+
+	model := Model{Drivers: []DriverModel{}}
+	for _, raceID := range raceIDs {
+		if rounds[raceID] != latest {
+			continue
+		}
+		for _, result := range byRace[raceID] {
+			i := index[result.DriverID]
+			driver := drivers[i]
+			driver.Constructor = result.Constructor
+			driver.Strength = strengths[i]
+			driver.DNFProbability = (dnfs[i] + config.DNFPrior*fieldRate) / (starts[i] + config.DNFPrior)
+
+			if override, ok := config.Overrides[result.DriverID]; ok {
+				if override.StrengthFactor != nil {
+					driver.Strength *= *override.StrengthFactor
+				}
+				if override.DNFProbability != nil {
+					driver.DNFProbability = *override.DNFProbability
+				}
+			}
+			model.Drivers = append(model.Drivers, driver)
+		}
+	}
+	sort.Slice(model.Drivers, func(i, j int) bool {
+		return model.Drivers[i].Driver.ID.String() < model.Drivers[j].Driver.ID.String()
+	})
+
+	return model, nil
+}
+
+// fitStrengths runs the MM updates of Hunter (2004) for the Plackett-Luce
+// model. Each driver also gets prior wins and losses against a virtual
+// driver of strength 1, which keeps the strengths finite for drivers who
+// never beat anyone and anchors their scale
+func fitStrengths(n int, index map[uuid.UUID]int, classifications []classification, prior float64) []float64 {
+	strengths := make([]float64, n)
+	for i := range strengths {
+		strengths[i] = 1
+	}
+
+	wins := make([]float64, n)
+	for _, c := range classifications {
+		for _, driver := range c.finishers[:len(c.finishers)-1] {
+			wins[index[driver]] += c.weight
+		}
+	}
+
+	denominators := make([]float64, n)
+	for iteration := 0; iteration < fitIterations; iteration++ {
+		for i := range denominators {
+			denominators[i] = 2 * prior / (strengths[i] + 1)
+		}
+
+		for _, c := range classifications {
+			// suffix sums of the strengths of the drivers still running
+			remaining := make([]float64, len(c.finishers))
+			sum := 0.0
+			for j := len(c.finishers) - 1; j >= 0; j-- {
+				sum += strengths[index[c.finishers[j]]]
+				remaining[j] = sum
+			}
+
+			cumulative := 0.0
+			for j, driver := range c.finishers {
+				if j < len(c.finishers)-1 {
+					cumulative += c.weight / remaining[j]
+				}
+				denominators[index[driver]] += cumulative
+			}
+		}
+
+		change := 0.0
+		for i := range strengths {
+			updated := strengths[i]
+			if denominators[i] > 0 {
+				updated = (wins[i] + prior) / denominators[i]
+			}
+			change = math.Max(change, math.Abs(updated-strengths[i]))
+			strengths[i] = updated
+		}
+		if change < fitTolerance {
+			break
+		}
+	}
+
+	return strengths
+}
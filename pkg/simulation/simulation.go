@@ -0,0 +1,194 @@
+package simulation
+
+import (
+	"math/rand"
+	"sort"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	st "github.com/dewciu/f1_api/pkg/standings"
+	"github.com/google/uuid"
+)
+
+type DriverOutcome struct {
+	Driver      m.Driver
+	Constructor m.Constructor
+	// Model is nil for drivers not taking part in the remaining races
+	Model            *DriverModel
+	Position         int
+	Points           float64
+	TitleProbability float64
+	ExpectedPoints   float64
+	// Positions holds the probability of every championship position, the
+	// first element being the title
+	Positions []float64
+}
+
+type Outcome struct {
+	Year            int
+	Runs            int
+	Seed            int64
+	AfterRound      int
+	RemainingRounds []int
+	// Drivers are ordered by expected points
+	Drivers []DriverOutcome
+}
+
+type sampler struct {
+	rng   *rand.Rand
+	model Model
+}
+
+func (s *sampler) result(race m.Race, driver DriverModel, session string) m.Result {
+	return m.Result{
+		RaceID:        race.ID,
+		Session:       m.Session{Type: session},
+		DriverID:      driver.Driver.ID,
+		Driver:        driver.Driver,
+		ConstructorID: driver.Constructor.ID,
+		Constructor:   driver.Constructor,
+	}
+}
+
+// session samples a classification: every driver retires with their DNF
+// probability and the others finish in the order of exponential arrival
+// times with rates equal to their strengths, which follows the Plackett-Luce
+// model. The fastest lap goes to a finisher with probability proportional to
+// their strength
+func (s *sampler) session(race m.Race, session string) []m.Result {
+	type run struct {
+		driver DriverModel
+		time   float64
+	}
+
+	finishers := []run{}
+	results := []m.Result{}
+	for _, driver := range s.model.Drivers {
+		retired := s.rng.Float64() < driver.DNFProbability
+		time := s.rng.ExpFloat64() / driver.Strength
+		if retired {
+			results = append(results, s.result(race, driver, session))
+			continue
+		}
+		finishers = append(finishers, run{driver: driver, time: time})
+	}
+	sort.SliceStable(finishers, func(i, j int) bool {
+		return finishers[i].time < finishers[j].time
+	})
+
+	fastestLap := -1
+	if session == m.SessionRace && len(finishers) > 0 {
+		total := 0.0
+		for _, f := range finishers {
+			total += f.driver.Strength
+		}
+		pick := s.rng.Float64() * total
+		for i, f := range finishers {
+			pick -= f.driver.Strength
+			if pick < 0 || i == len(finishers)-1 {
+				fastestLap = i
+				break
+			}
+		}
+	}
+
+	for i, f := range finishers {
+		result := s.result(race, f.driver, session)
+		position := i + 1
+		result.Position = &position
+		if i == fastestLap {
+			rank := 1
+			result.FastestLapRank = &rank
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// Run samples the remaining races and sprints of the season runs times with
+// the model and scores every run with the points system. Runs with the same
+// seed and inputs give the same outcome
+func Run(year int, system st.PointsSystem, races []m.Race, results []m.Result, model Model, runs int, seed int64) Outcome {
+	remaining := st.RemainingRaces(races, results)
+	current := st.Compute(year, system, races, results, 0)
+
+	outcome := Outcome{
+		Year:            year,
+		Runs:            runs,
+		Seed:            seed,
+		RemainingRounds: []int{},
+		Drivers:         []DriverOutcome{},
+	}
+	upcoming := make(map[uuid.UUID]bool)
+	for _, race := range remaining {
+		upcoming[race.ID] = true
+		outcome.RemainingRounds = append(outcome.RemainingRounds, race.Round)
+	}
+	for _, race := range races {
+		if !upcoming[race.ID] && !st.RaceCancelled(race) && race.Round > outcome.AfterRound {
+			outcome.AfterRound = race.Round
+		}
+	}
+
+	models := make(map[uuid.UUID]*DriverModel)
+	for i := range model.Drivers {
+		models[model.Drivers[i].Driver.ID] = &model.Drivers[i]
+	}
+
+	index := make(map[uuid.UUID]int)
+	for _, standing := range current.Drivers {
+		index[standing.Driver.ID] = len(outcome.Drivers)
+		driver := DriverOutcome{
+			Driver:   standing.Driver,
+			Model:    models[standing.Driver.ID],
+			Position: standing.Position,
+			Points:   standing.Points,
+		}
+		if len(standing.Constructors) > 0 {
+			driver.Constructor = standing.Constructors[len(standing.Constructors)-1]
+		}
+		outcome.Drivers = append(outcome.Drivers, driver)
+	}
+
+	counts := make([][]int, len(outcome.Drivers))
+	for i := range counts {
+		counts[i] = make([]int, len(outcome.Drivers))
+	}
+	totals := make([]float64, len(outcome.Drivers))
+
+	s := &sampler{rng: rand.New(rand.NewSource(seed)), model: model}
+	for r := 0; r < runs; r++ {
+		sampled := append([]m.Result{}, results...)
+		for _, race := range remaining {
+			sampled = append(sampled, s.session(race, m.SessionRace)...)
+			if st.HasSprint(race) {
+				sampled = append(sampled, s.session(race, m.SessionSprint)...)
+			}
+		}
+
+		table := st.Compute(year, system, races, sampled, 0)
+		for _, standing := range table.Drivers {
+			i := index[standing.Driver.ID]
+			counts[i][standing.Position-1]++
+			totals[i] += standing.Points
+		}
+	}
+
+	for i := range outcome.Drivers {
+		driver := &outcome.Drivers[i]
+		driver.Positions = make([]float64, len(counts[i]))
+		if runs == 0 {
+			continue
+		}
+		for position, count := range counts[i] {
+			driver.Positions[position] = float64(count) / float64(runs)
+		}
+		driver.TitleProbability = driver.Positions[0]
+		driver.ExpectedPoints = totals[i] / float64(runs)
+	}
+
+	sort.SliceStable(outcome.Drivers, func(i, j int) bool {
+		return outcome.Drivers[i].ExpectedPoints > outcome.Drivers[j].ExpectedPoints
+	})
+
+	return outcome
+}
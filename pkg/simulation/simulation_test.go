@@ -0,0 +1,247 @@
+package simulation
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	st "github.com/dewciu/f1_api/pkg/standings"
+	"github.com/google/uuid"
+)
+
+// fixture builds a season of the given rounds where every driver finishes
+// each completed race in the position given by their orders, 0 standing for
+// a retirement
+type fixture struct {
+	year      int
+	rounds    int
+	completed int
+	orders    map[string][]int
+	sprints   map[int]bool
+}
+
+func (f fixture) build() ([]m.Race, []m.Result, map[string]m.Driver) {
+	races := []m.Race{}
+	for round := 1; round <= f.rounds; round++ {
+		race := m.Race{Model: m.Model{ID: uuid.New()}, Year: f.year, Round: round}
+		if f.sprints[round] {
+			race.Sessions = []m.Session{{Type: m.SessionSprint}, {Type: m.SessionRace}}
+		}
+		races = append(races, race)
+	}
+
+	constructor := m.Constructor{Model: m.Model{ID: uuid.New()}, Ref: "team", Name: "Team"}
+	drivers := make(map[string]m.Driver)
+	results := []m.Result{}
+	for ref, positions := range f.orders {
+		driver := m.Driver{Model: m.Model{ID: uuid.New()}, Ref: ref, LastName: ref}
+		drivers[ref] = driver
+		for i, position := range positions[:f.completed] {
+			result := m.Result{
+				RaceID:        races[i].ID,
+				Session:       m.Session{Type: m.SessionRace},
+				DriverID:      driver.ID,
+				Driver:        driver,
+				ConstructorID: constructor.ID,
+				Constructor:   constructor,
+			}
+			if position > 0 {
+				position := position
+				result.Position = &position
+			}
+			results = append(results, result)
+		}
+	}
+	return races, results, drivers
+}
+
+func strength(model Model, driver m.Driver) DriverModel {
+	for _, d := range model.Drivers {
+		if d.Driver.ID == driver.ID {
+			return d
+		}
+	}
+	return DriverModel{}
+}
+
+func outcome(out Outcome, driver m.Driver) DriverOutcome {
+	for _, d := range out.Drivers {
+		if d.Driver.ID == driver.ID {
+			return d
+		}
+	}
+	return DriverOutcome{}
+}
+
+var season2019 = fixture{year: 2019, rounds: 6, completed: 4, orders: map[string][]int{
+	"alpha": {1, 1, 2, 1, 0, 0},
+	"beta":  {2, 3, 1, 2, 0, 0},
+	"gamma": {3, 2, 0, 3, 0, 0},
+	"delta": {4, 0, 3, 4, 0, 0},
+}}
+
+func TestFitOrdersDriversByPerformance(t *testing.T) {
+	races, results, drivers := season2019.build()
+	model, err := Fit(races, results, DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(model.Drivers) != 4 {
+		t.Fatalf("got %d drivers, want 4", len(model.Drivers))
+	}
+
+	order := []string{"alpha", "beta", "gamma", "delta"}
+	for i := 1; i < len(order); i++ {
+		better, worse := strength(model, drivers[order[i-1]]), strength(model, drivers[order[i]])
+		if better.Strength <= worse.Strength {
+			t.Errorf("%s has strength %v, not above %s with %v", order[i-1], better.Strength, order[i], worse.Strength)
+		}
+	}
+
+	// one retirement in four starts with five prior starts at the field
+	// rate of 2/16
+	gamma := strength(model, drivers["gamma"])
+	if want := (1 + 5*2.0/16) / 9; math.Abs(gamma.DNFProbability-want) > 1e-12 {
+		t.Errorf("gamma DNF probability %v, want %v", gamma.DNFProbability, want)
+	}
+	if gamma.Starts != 4 || gamma.DNFs != 1 {
+		t.Errorf("gamma has %d starts and %d DNFs, want 4 and 1", gamma.Starts, gamma.DNFs)
+	}
+}
+
+func TestFitWithoutResults(t *testing.T) {
+	races, results, _ := fixture{year: 2019, rounds: 3, orders: map[string][]int{"alpha": {0, 0, 0}}}.build()
+	if _, err := Fit(races, results, DefaultConfig()); err != ErrNoResults {
+		t.Fatalf("got %v, want ErrNoResults", err)
+	}
+}
+
+func TestFitOverrides(t *testing.T) {
+	races, results, drivers := season2019.build()
+	factor, dnf := 3.0, 0.5
+	config := DefaultConfig()
+	config.Overrides = map[uuid.UUID]Override{drivers["delta"].ID: {StrengthFactor: &factor, DNFProbability: &dnf}}
+
+	base, _ := Fit(races, results, DefaultConfig())
+	model, _ := Fit(races, results, config)
+	before, after := strength(base, drivers["delta"]), strength(model, drivers["delta"])
+	if math.Abs(after.Strength-3*before.Strength) > 1e-12 || after.DNFProbability != 0.5 {
+		t.Errorf("override gave strength %v and DNF %v, want %v and 0.5", after.Strength, after.DNFProbability, 3*before.Strength)
+	}
+}
+
+func TestFitDecayFavoursRecentForm(t *testing.T) {
+	races, results, drivers := fixture{year: 2019, rounds: 4, completed: 4, orders: map[string][]int{
+		"alpha": {1, 1, 1, 2},
+		"beta":  {2, 2, 2, 1},
+	}}.build()
+
+	config := DefaultConfig()
+	config.Decay = 0.1
+	model, _ := Fit(races, results, config)
+	if alpha, beta := strength(model, drivers["alpha"]), strength(model, drivers["beta"]); beta.Strength <= alpha.Strength {
+		t.Errorf("beta won the latest race but has strength %v against %v", beta.Strength, alpha.Strength)
+	}
+}
+
+func TestRunIsDeterministic(t *testing.T) {
+	races, results, _ := season2019.build()
+	system := st.SystemForSeason(2019)
+	model, _ := Fit(races, results, DefaultConfig())
+
+	first := Run(2019, system, races, results, model, 500, 42)
+	second := Run(2019, system, races, results, model, 500, 42)
+	if !reflect.DeepEqual(first, second) {
+		t.Fatal("runs with the same seed differ")
+	}
+
+	other := Run(2019, system, races, results, model, 500, 43)
+	if reflect.DeepEqual(first.Drivers, other.Drivers) {
+		t.Error("runs with different seeds are identical")
+	}
+}
+
+func TestRunDistributions(t *testing.T) {
+	races, results, drivers := season2019.build()
+	system := st.SystemForSeason(2019)
+	model, _ := Fit(races, results, DefaultConfig())
+	out := Run(2019, system, races, results, model, 2000, 7)
+
+	if out.AfterRound != 4 || !reflect.DeepEqual(out.RemainingRounds, []int{5, 6}) {
+		t.Errorf("after round %d with remaining %v, want 4 and [5 6]", out.AfterRound, out.RemainingRounds)
+	}
+
+	title := 0.0
+	for _, driver := range out.Drivers {
+		title += driver.TitleProbability
+		total := 0.0
+		for _, p := range driver.Positions {
+			total += p
+		}
+		if math.Abs(total-1) > 1e-9 {
+			t.Errorf("%s positions sum to %v", driver.Driver.Ref, total)
+		}
+		if driver.ExpectedPoints < driver.Points {
+			t.Errorf("%s expects %v points, fewer than the %v scored", driver.Driver.Ref, driver.ExpectedPoints, driver.Points)
+		}
+	}
+	if math.Abs(title-1) > 1e-9 {
+		t.Errorf("title probabilities sum to %v", title)
+	}
+
+	alpha, delta := outcome(out, drivers["alpha"]), outcome(out, drivers["delta"])
+	if out.Drivers[0].Driver.ID != drivers["alpha"].ID || alpha.TitleProbability < 0.5 {
+		t.Errorf("alpha leads by 17 points and has title probability %v", alpha.TitleProbability)
+	}
+	// delta is 54 points behind with 52 left
+	if delta.TitleProbability != 0 {
+		t.Errorf("eliminated delta has title probability %v", delta.TitleProbability)
+	}
+}
+
+func TestRunFinishedSeason(t *testing.T) {
+	f := season2019
+	f.completed = 4
+	f.rounds = 4
+	races, results, drivers := f.build()
+	system := st.SystemForSeason(2019)
+	model, _ := Fit(races, results, DefaultConfig())
+	out := Run(2019, system, races, results, model, 10, 1)
+
+	if len(out.RemainingRounds) != 0 || outcome(out, drivers["alpha"]).TitleProbability != 1 {
+		t.Errorf("champion has title probability %v", outcome(out, drivers["alpha"]).TitleProbability)
+	}
+}
+
+func TestRunSprints(t *testing.T) {
+	f := season2019
+	f.year = 2022
+	f.sprints = map[int]bool{6: true}
+	races, results, _ := f.build()
+	system := st.SystemForSeason(2022)
+	model, _ := Fit(races, results, DefaultConfig())
+
+	s := &sampler{rng: rand.New(rand.NewSource(1)), model: model}
+	sampled := s.session(races[5], m.SessionSprint)
+	if len(sampled) != 4 {
+		t.Fatalf("sampled %d sprint results, want 4", len(sampled))
+	}
+	for _, result := range sampled {
+		if result.FastestLapRank != nil {
+			t.Error("sprint result has the fastest lap")
+		}
+	}
+
+	out := Run(2022, system, races, results, model, 200, 1)
+	// two races of 25+18+15+12 with the fastest lap and a sprint of 8+7+6+5
+	// at most on top of the points already scored
+	gained := 0.0
+	for _, driver := range out.Drivers {
+		gained += driver.ExpectedPoints - driver.Points
+	}
+	if gained <= 0 || gained > 2*71+26+1e-9 {
+		t.Errorf("drivers are expected to gain %v points", gained)
+	}
+}
@@ -0,0 +1,163 @@
+// Package sprint describes the sprint weekend formats run since 2021: which
+// session sets the sprint grid, whether the sprint sets the grid of the race,
+// the running order of the weekend and the names the sessions went by.
+package sprint
+
+import (
+	"fmt"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	st "github.com/dewciu/f1_api/pkg/standings"
+)
+
+const (
+	// FormatSprintQualifying is the 2021 format, Friday qualifying set the
+	// grid of the Saturday sprint, called Sprint Qualifying, which set the
+	// grid of the race
+	FormatSprintQualifying = "sprint_qualifying"
+	// FormatSprint is the 2022 format, the same weekend with the sprint
+	// called Sprint
+	FormatSprint = "sprint"
+	// FormatSprintShootout is the 2023 format, Friday qualifying sets the
+	// grid of the race and the Saturday Sprint Shootout the grid of the sprint
+	FormatSprintShootout = "sprint_shootout"
+	// FormatSprintFirst is the format from 2024, the shootout, renamed Sprint
+	// Qualifying, runs on Friday and the sprint before qualifying on Saturday
+	FormatSprintFirst = "sprint_first"
+)
+
+// FirstSeason is the first season with sprint weekends
+const FirstSeason = 2021
+
+var sessionNames = map[string]string{
+	m.SessionFP1:            "Practice 1",
+	m.SessionFP2:            "Practice 2",
+	m.SessionFP3:            "Practice 3",
+	m.SessionSprintShootout: "Sprint Shootout",
+	m.SessionSprint:         "Sprint",
+	m.SessionQualifying:     "Qualifying",
+	m.SessionRace:           "Race",
+}
+
+type Format struct {
+	Name string
+	// Sessions lists the sessions of the weekend in running order
+	Sessions []string
+	// GridSession is the session whose classification sets the sprint grid
+	GridSession string
+	// SetsRaceGrid is true when the sprint classification sets the grid of
+	// the race instead of qualifying
+	SetsRaceGrid bool
+	// Points holds the sprint points by finishing position
+	Points []float64
+	names  map[string]string
+}
+
+// ForSeason returns the sprint format of the season, false before sprints
+// were introduced
+func ForSeason(year int) (Format, bool) {
+	format := Format{
+		GridSession: m.SessionQualifying,
+		Points:      st.SystemForSeason(year).Sprint,
+		names:       map[string]string{},
+	}
+
+	switch {
+	case year < FirstSeason:
+		return Format{}, false
+	case year <= 2022:
+		format.Name = FormatSprint
+		if year == 2021 {
+			format.Name = FormatSprintQualifying
+			format.names[m.SessionSprint] = "Sprint Qualifying"
+		}
+		format.Sessions = []string{m.SessionFP1, m.SessionQualifying, m.SessionFP2, m.SessionSprint, m.SessionRace}
+		format.SetsRaceGrid = true
+	case year == 2023:
+		format.Name = FormatSprintShootout
+		format.Sessions = []string{m.SessionFP1, m.SessionQualifying, m.SessionSprintShootout, m.SessionSprint, m.SessionRace}
+		format.GridSession = m.SessionSprintShootout
+	default:
+		format.Name = FormatSprintFirst
+		format.Sessions = []string{m.SessionFP1, m.SessionSprintShootout, m.SessionSprint, m.SessionQualifying, m.SessionRace}
+		format.GridSession = m.SessionSprintShootout
+		format.names[m.SessionSprintShootout] = "Sprint Qualifying"
+	}
+
+	return format, true
+}
+
+// SessionName returns the name the session went by in the season
+func SessionName(year int, sessionType string) string {
+	if format, ok := ForSeason(year); ok {
+		if name, ok := format.names[sessionType]; ok {
+			return name
+		}
+	}
+	return sessionNames[sessionType]
+}
+
+// RaceGridSession returns the session whose classification sets the grid of
+// the race
+func (f Format) RaceGridSession() string {
+	if f.SetsRaceGrid {
+		return m.SessionSprint
+	}
+	return m.SessionQualifying
+}
+
+// IsSprintWeekend reports whether the race weekend has a sprint, cancelled
+// or not
+func IsSprintWeekend(race m.Race) bool {
+	for _, session := range race.Sessions {
+		if session.Type == m.SessionSprint {
+			return true
+		}
+	}
+	return false
+}
+
+// Check verifies that the sprint sessions of the weekend exist in the format
+// of the season and that the sessions setting a grid run before the session
+// starting from it, cancelled sessions are not checked for their order
+func Check(race m.Race) error {
+	sessions := make(map[string]m.Session)
+	for _, session := range race.Sessions {
+		sessions[session.Type] = session
+	}
+	_, sprint := sessions[m.SessionSprint]
+	_, shootout := sessions[m.SessionSprintShootout]
+	if !sprint && !shootout {
+		return nil
+	}
+
+	format, ok := ForSeason(race.Year)
+	if !ok {
+		return fmt.Errorf("%s has a sprint session but sprints were introduced in %d", race.Name, FirstSeason)
+	}
+	if shootout && format.GridSession != m.SessionSprintShootout {
+		return fmt.Errorf("%s has a %s session but the %d sprint grid is set by %s", race.Name, m.SessionSprintShootout, race.Year, SessionName(race.Year, format.GridSession))
+	}
+
+	before := func(first, second string) error {
+		a, ok := sessions[first]
+		b, ok2 := sessions[second]
+		if !ok || !ok2 || a.Status == m.SessionCancelled || b.Status == m.SessionCancelled {
+			return nil
+		}
+		if !a.StartTime.Before(b.StartTime) {
+			return fmt.Errorf("%s %s session has to run before the %s session", race.Name, first, second)
+		}
+		return nil
+	}
+	if err := before(format.GridSession, m.SessionSprint); err != nil {
+		return err
+	}
+	if err := before(m.SessionSprint, m.SessionRace); err != nil {
+		return err
+	}
+	if format.Name == FormatSprintFirst {
+		return before(m.SessionSprint, m.SessionQualifying)
+	}
+	return nil
+}
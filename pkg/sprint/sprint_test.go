@@ -0,0 +1,162 @@
+package sprint
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+)
+
+type scheduled struct {
+	session string
+	day     int
+	hour    int
+}
+
+// weekend builds a race whose sessions start on the given day of the month
+// and hour in UTC
+func weekend(name string, year int, month time.Month, sessions ...scheduled) m.Race {
+	race := m.Race{Name: name, Year: year}
+	for _, s := range sessions {
+		start := time.Date(year, month, s.day, s.hour, 0, 0, 0, time.UTC)
+		race.Sessions = append(race.Sessions, m.Session{
+			Type:      s.session,
+			StartTime: start,
+			EndTime:   start.Add(time.Hour),
+			Status:    m.SessionFinished,
+		})
+		if s.session == m.SessionRace {
+			race.Date = start
+		}
+	}
+	return race
+}
+
+var (
+	silverstone2021 = weekend("British Grand Prix", 2021, time.July,
+		scheduled{m.SessionFP1, 16, 13}, scheduled{m.SessionQualifying, 16, 17},
+		scheduled{m.SessionFP2, 17, 11}, scheduled{m.SessionSprint, 17, 15},
+		scheduled{m.SessionRace, 18, 14})
+	imola2022 = weekend("Emilia Romagna Grand Prix", 2022, time.April,
+		scheduled{m.SessionFP1, 22, 11}, scheduled{m.SessionQualifying, 22, 15},
+		scheduled{m.SessionFP2, 23, 10}, scheduled{m.SessionSprint, 23, 14},
+		scheduled{m.SessionRace, 24, 13})
+	baku2023 = weekend("Azerbaijan Grand Prix", 2023, time.April,
+		scheduled{m.SessionFP1, 28, 9}, scheduled{m.SessionQualifying, 28, 13},
+		scheduled{m.SessionSprintShootout, 29, 8}, scheduled{m.SessionSprint, 29, 12},
+		scheduled{m.SessionRace, 30, 11})
+	shanghai2024 = weekend("Chinese Grand Prix", 2024, time.April,
+		scheduled{m.SessionFP1, 19, 3}, scheduled{m.SessionSprintShootout, 19, 7},
+		scheduled{m.SessionSprint, 20, 3}, scheduled{m.SessionQualifying, 20, 7},
+		scheduled{m.SessionRace, 21, 7})
+)
+
+func TestForSeason(t *testing.T) {
+	if _, ok := ForSeason(2020); ok {
+		t.Error("found a sprint format before 2021")
+	}
+
+	tests := []struct {
+		race         m.Race
+		name         string
+		gridSession  string
+		raceGrid     string
+		sprintWinner float64
+	}{
+		{silverstone2021, FormatSprintQualifying, m.SessionQualifying, m.SessionSprint, 3},
+		{imola2022, FormatSprint, m.SessionQualifying, m.SessionSprint, 8},
+		{baku2023, FormatSprintShootout, m.SessionSprintShootout, m.SessionQualifying, 8},
+		{shanghai2024, FormatSprintFirst, m.SessionSprintShootout, m.SessionQualifying, 8},
+	}
+	for _, tt := range tests {
+		format, ok := ForSeason(tt.race.Year)
+		if !ok {
+			t.Fatalf("no sprint format in %d", tt.race.Year)
+		}
+		if format.Name != tt.name || format.GridSession != tt.gridSession || format.RaceGridSession() != tt.raceGrid {
+			t.Errorf("%d is %s with the sprint grid set by %s and the race grid by %s", tt.race.Year, format.Name, format.GridSession, format.RaceGridSession())
+		}
+		if len(format.Points) == 0 || format.Points[0] != tt.sprintWinner {
+			t.Errorf("%d sprint points are %v, want %v for the winner", tt.race.Year, format.Points, tt.sprintWinner)
+		}
+
+		sessions := []string{}
+		for _, session := range tt.race.Sessions {
+			sessions = append(sessions, session.Type)
+		}
+		if !reflect.DeepEqual(sessions, format.Sessions) {
+			t.Errorf("%s runs %v, format lists %v", tt.race.Name, sessions, format.Sessions)
+		}
+		if err := Check(tt.race); err != nil {
+			t.Errorf("%s: %v", tt.race.Name, err)
+		}
+	}
+}
+
+func TestSessionName(t *testing.T) {
+	tests := []struct {
+		year    int
+		session string
+		want    string
+	}{
+		{2021, m.SessionSprint, "Sprint Qualifying"},
+		{2022, m.SessionSprint, "Sprint"},
+		{2023, m.SessionSprintShootout, "Sprint Shootout"},
+		{2024, m.SessionSprintShootout, "Sprint Qualifying"},
+		{2024, m.SessionQualifying, "Qualifying"},
+		{2019, m.SessionFP3, "Practice 3"},
+	}
+	for _, tt := range tests {
+		if got := SessionName(tt.year, tt.session); got != tt.want {
+			t.Errorf("%s in %d is called %q, want %q", tt.session, tt.year, got, tt.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	withYear := func(race m.Race, year int) m.Race {
+		race.Year = year
+		return race
+	}
+	swapped := func(race m.Race, a, b int) m.Race {
+		sessions := append([]m.Session{}, race.Sessions...)
+		sessions[a].StartTime, sessions[b].StartTime = sessions[b].StartTime, sessions[a].StartTime
+		race.Sessions = sessions
+		return race
+	}
+	cancelled := func(race m.Race, i int) m.Race {
+		sessions := append([]m.Session{}, race.Sessions...)
+		sessions[i].Status = m.SessionCancelled
+		race.Sessions = sessions
+		return race
+	}
+
+	tests := []struct {
+		name string
+		race m.Race
+		err  string
+	}{
+		{"sprint before sprints were introduced", withYear(imola2022, 2020), "sprints were introduced in 2021"},
+		{"shootout in a season whose sprint grid is set by qualifying", withYear(baku2023, 2022), "sprint grid is set by Qualifying"},
+		{"sprint before its grid session", swapped(imola2022, 1, 3), "QUALIFYING session has to run before the SPRINT session"},
+		{"sprint after qualifying on a sprint-first weekend", swapped(shanghai2024, 2, 3), "SPRINT session has to run before the QUALIFYING session"},
+		{"order of cancelled sessions is not checked", cancelled(swapped(shanghai2024, 2, 3), 2), ""},
+		{"weekend without a sprint", m.Race{Name: "Monaco Grand Prix", Year: 2019}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.race)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("got error %v, want %q", err, tt.err)
+			}
+		})
+	}
+}
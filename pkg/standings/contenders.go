@@ -0,0 +1,359 @@
+package standings
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+var ErrUnknownDriver = errors.New("driver has no result in the season")
+
+// Scenario is a hypothetical classification of an upcoming race, listing
+// drivers in finishing order
+type Scenario struct {
+	Round      int
+	Race       []uuid.UUID
+	Sprint     []uuid.UUID
+	FastestLap *uuid.UUID
+}
+
+type DriverContender struct {
+	DriverStanding
+	MaxPoints  float64
+	Eliminated bool
+}
+
+type ConstructorContender struct {
+	ConstructorStanding
+	MaxPoints  float64
+	Eliminated bool
+}
+
+// Clinch tells what the drivers' championship leader needs at the next round
+// to become champion. Position is the worst finish at the next race which
+// clinches the title whatever the others do, 0 when it cannot be clinched or
+// when any result does
+type Clinch struct {
+	Leader    m.Driver
+	Round     int
+	Clinched  bool
+	Position  int
+	AnyResult bool
+}
+
+type Contenders struct {
+	Year            int
+	AfterRound      int
+	RemainingRounds []int
+	Drivers         []DriverContender
+	Constructors    []ConstructorContender
+	// Clinch is nil when the season is over
+	Clinch *Clinch
+}
+
+// entrant is a driver with the constructor of their latest result
+type entrant struct {
+	driver      m.Driver
+	constructor m.Constructor
+}
+
+type contest struct {
+	year      int
+	system    PointsSystem
+	races     []m.Race
+	results   []m.Result
+	entrants  map[uuid.UUID]entrant
+	remaining []m.Race
+}
+
+// HasSprint reports whether the race weekend has a sprint which was not
+// cancelled
+func HasSprint(race m.Race) bool {
+	for _, session := range race.Sessions {
+		if session.Type == m.SessionSprint && session.Status != m.SessionCancelled {
+			return true
+		}
+	}
+	return false
+}
+
+// RaceCancelled reports whether the race session of the weekend was
+// cancelled
+func RaceCancelled(race m.Race) bool {
+	for _, session := range race.Sessions {
+		if session.Type == m.SessionRace {
+			return session.Status == m.SessionCancelled
+		}
+	}
+	return false
+}
+
+func (c *contest) result(race m.Race, driver uuid.UUID, session string, position int, fastestLap bool) m.Result {
+	e := c.entrants[driver]
+	result := m.Result{
+		RaceID:        race.ID,
+		Session:       m.Session{Type: session},
+		DriverID:      driver,
+		Driver:        e.driver,
+		ConstructorID: e.constructor.ID,
+		Constructor:   e.constructor,
+		Position:      &position,
+	}
+	if fastestLap {
+		rank := 1
+		result.FastestLapRank = &rank
+	}
+	return result
+}
+
+// sweep returns results of the drivers finishing in the given order in every
+// race, the first of them with the fastest lap
+func (c *contest) sweep(races []m.Race, drivers ...uuid.UUID) []m.Result {
+	results := []m.Result{}
+	for _, race := range races {
+		for i, driver := range drivers {
+			results = append(results, c.result(race, driver, m.SessionRace, i+1, i == 0))
+			if HasSprint(race) {
+				results = append(results, c.result(race, driver, m.SessionSprint, i+1, false))
+			}
+		}
+	}
+	return results
+}
+
+func (c *contest) table(extra []m.Result) Table {
+	return Compute(c.year, c.system, c.races, append(append([]m.Result{}, c.results...), extra...), 0)
+}
+
+func (c *contest) driverPosition(table Table, driver uuid.UUID) (int, float64) {
+	for _, standing := range table.Drivers {
+		if standing.Driver.ID == driver {
+			return standing.Position, standing.Points
+		}
+	}
+	return 0, 0
+}
+
+func (c *contest) constructorPosition(table Table, constructor uuid.UUID) (int, float64) {
+	for _, standing := range table.Constructors {
+		if standing.Constructor.ID == constructor {
+			return standing.Position, standing.Points
+		}
+	}
+	return 0, 0
+}
+
+// RemainingRaces returns the races without race results which were not
+// cancelled, in round order
+func RemainingRaces(races []m.Race, results []m.Result) []m.Race {
+	completed := make(map[uuid.UUID]bool)
+	for _, result := range results {
+		if result.Session.Type == m.SessionRace {
+			completed[result.RaceID] = true
+		}
+	}
+
+	remaining := []m.Race{}
+	for _, race := range races {
+		if !completed[race.ID] && !RaceCancelled(race) {
+			remaining = append(remaining, race)
+		}
+	}
+	sort.Slice(remaining, func(i, j int) bool {
+		return remaining[i].Round < remaining[j].Round
+	})
+	return remaining
+}
+
+// applyScenarios adds the hypothetical results of upcoming races
+func (c *contest) applyScenarios(scenarios []Scenario) error {
+	completed := make(map[uuid.UUID]bool)
+	for _, result := range c.results {
+		if result.Session.Type == m.SessionRace {
+			completed[result.RaceID] = true
+		}
+	}
+
+	byRound := make(map[int]m.Race)
+	for _, race := range c.races {
+		byRound[race.Round] = race
+	}
+
+	for _, scenario := range scenarios {
+		race, ok := byRound[scenario.Round]
+		if !ok || completed[race.ID] || RaceCancelled(race) {
+			return fmt.Errorf("round %d is not an upcoming race", scenario.Round)
+		}
+		if len(scenario.Sprint) > 0 && !HasSprint(race) {
+			return fmt.Errorf("round %d has no sprint", scenario.Round)
+		}
+
+		for _, order := range [][]uuid.UUID{scenario.Race, scenario.Sprint} {
+			seen := make(map[uuid.UUID]bool)
+			for _, driver := range order {
+				if _, ok := c.entrants[driver]; !ok {
+					return fmt.Errorf("%w: %s", ErrUnknownDriver, driver)
+				}
+				if seen[driver] {
+					return fmt.Errorf("driver %s is classified more than once in round %d", driver, scenario.Round)
+				}
+				seen[driver] = true
+			}
+		}
+		if scenario.FastestLap != nil {
+			if _, ok := c.entrants[*scenario.FastestLap]; !ok {
+				return fmt.Errorf("%w: %s", ErrUnknownDriver, *scenario.FastestLap)
+			}
+		}
+
+		for i, driver := range scenario.Race {
+			fastestLap := scenario.FastestLap != nil && *scenario.FastestLap == driver
+			c.results = append(c.results, c.result(race, driver, m.SessionRace, i+1, fastestLap))
+		}
+		for i, driver := range scenario.Sprint {
+			c.results = append(c.results, c.result(race, driver, m.SessionSprint, i+1, false))
+		}
+		completed[race.ID] = true
+	}
+
+	c.remaining = RemainingRaces(c.races, c.results)
+	return nil
+}
+
+// Contest computes the maximum totals of the drivers and constructors over
+// the races without results, with the hypothetical scenarios applied first.
+// A driver is eliminated when winning every remaining race and sprint with
+// the fastest lap, with the others scoring nothing more, does not make them
+// champion. Constructors are checked with their two latest drivers finishing
+// first and second
+func Contest(year int, system PointsSystem, races []m.Race, results []m.Result, scenarios []Scenario) (Contenders, error) {
+	c := &contest{year: year, system: system, races: races, results: append([]m.Result{}, results...), entrants: make(map[uuid.UUID]entrant)}
+
+	sorted := append([]m.Result{}, results...)
+	rounds := make(map[uuid.UUID]int)
+	for _, race := range races {
+		rounds[race.ID] = race.Round
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return rounds[sorted[i].RaceID] < rounds[sorted[j].RaceID]
+	})
+	// constructor drivers in order of their latest result
+	lineUps := make(map[uuid.UUID][]uuid.UUID)
+	for _, result := range sorted {
+		c.entrants[result.DriverID] = entrant{driver: result.Driver, constructor: result.Constructor}
+	}
+	for _, result := range sorted {
+		drivers := []uuid.UUID{result.DriverID}
+		for _, driver := range lineUps[result.ConstructorID] {
+			if driver != result.DriverID {
+				drivers = append(drivers, driver)
+			}
+		}
+		lineUps[result.ConstructorID] = drivers
+	}
+
+	if err := c.applyScenarios(scenarios); err != nil {
+		return Contenders{}, err
+	}
+
+	current := c.table(nil)
+	contenders := Contenders{
+		Year:            year,
+		AfterRound:      0,
+		RemainingRounds: []int{},
+		Drivers:         []DriverContender{},
+		Constructors:    []ConstructorContender{},
+	}
+	for _, race := range c.races {
+		completed := true
+		for _, remaining := range c.remaining {
+			completed = completed && remaining.ID != race.ID
+		}
+		if completed && race.Round > contenders.AfterRound {
+			contenders.AfterRound = race.Round
+		}
+	}
+	for _, race := range c.remaining {
+		contenders.RemainingRounds = append(contenders.RemainingRounds, race.Round)
+	}
+
+	for _, standing := range current.Drivers {
+		best := c.table(c.sweep(c.remaining, standing.Driver.ID))
+		position, points := c.driverPosition(best, standing.Driver.ID)
+		contenders.Drivers = append(contenders.Drivers, DriverContender{
+			DriverStanding: standing,
+			MaxPoints:      points,
+			Eliminated:     position != 1,
+		})
+	}
+
+	for _, standing := range current.Constructors {
+		drivers := lineUps[standing.Constructor.ID]
+		if len(drivers) > 2 {
+			drivers = drivers[:2]
+		}
+		best := c.table(c.sweep(c.remaining, drivers...))
+		position, points := c.constructorPosition(best, standing.Constructor.ID)
+		contenders.Constructors = append(contenders.Constructors, ConstructorContender{
+			ConstructorStanding: standing,
+			MaxPoints:           points,
+			Eliminated:          position != 1,
+		})
+	}
+
+	if len(contenders.Drivers) > 0 && len(c.remaining) > 0 {
+		contenders.Clinch = c.clinch(contenders.Drivers)
+	}
+
+	return contenders, nil
+}
+
+// clinch finds the worst finish of the leader at the next race with which no
+// rival can catch up, the rival winning that race and sprint with the fastest
+// lap and every race after it while the leader scores nothing more
+func (c *contest) clinch(drivers []DriverContender) *Clinch {
+	leader := drivers[0].Driver.ID
+	next, rest := c.remaining[0], c.remaining[1:]
+	clinch := &Clinch{Leader: drivers[0].Driver, Round: next.Round, Clinched: true}
+
+	rivals := []uuid.UUID{}
+	for _, driver := range drivers[1:] {
+		if !driver.Eliminated {
+			clinch.Clinched = false
+			rivals = append(rivals, driver.Driver.ID)
+		}
+	}
+	if clinch.Clinched {
+		return clinch
+	}
+
+	for position := 1; position <= len(c.system.Race)+1; position++ {
+		for _, rival := range rivals {
+			rivalPosition := 1
+			if position == 1 {
+				rivalPosition = 2
+			}
+			extra := []m.Result{
+				c.result(next, leader, m.SessionRace, position, false),
+				c.result(next, rival, m.SessionRace, rivalPosition, true),
+			}
+			if HasSprint(next) {
+				extra = append(extra, c.result(next, rival, m.SessionSprint, 1, false))
+			}
+			extra = append(extra, c.sweep(rest, rival)...)
+
+			if p, _ := c.driverPosition(c.table(extra), leader); p != 1 {
+				return clinch
+			}
+		}
+		clinch.Position = position
+	}
+
+	// finishing outside the points still clinches the title
+	clinch.Position = 0
+	clinch.AnyResult = true
+	return clinch
+}
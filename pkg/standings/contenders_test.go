@@ -0,0 +1,86 @@
+package standings
+
+import (
+	"testing"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+// 2021 before Abu Dhabi: level on points with Verstappen ahead on countback,
+// only a win clinches the title
+func season2021BeforeFinal() season {
+	return season{year: 2021, rounds: 22, scales: map[int]float64{12: 0.5}, entries: []entry{
+		{
+			ref: "max_verstappen", constructor: "red_bull",
+			races:   "2 1 2 2F 1 R 1F 1 1F R 9 1 1 R 2 2 1 1 2 2F 2 -",
+			sprints: map[int]int{10: 1, 14: 2, 19: 2},
+		},
+		{
+			ref: "hamilton", constructor: "mercedes",
+			races:   "1 2F 1 1 7F 15 2 2F 4 1 2 3 2F R 1 5 2F 2 1 1 1F -",
+			sprints: map[int]int{10: 2, 14: 5, 19: 5},
+		},
+		{
+			ref: "bottas", constructor: "mercedes",
+			races:   "3F 2 3F 3 R 12 3 3 2 3 R 12 3F 3 5 1F 6 15 3 R R -",
+			sprints: map[int]int{10: 3, 14: 1, 19: 1},
+		},
+	}}
+}
+
+func TestContestBeforeFinalRound(t *testing.T) {
+	races, results := season2021BeforeFinal().build(t)
+
+	contenders, err := Contest(2021, SystemForSeason(2021), races, results, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(contenders.RemainingRounds) != 1 || contenders.RemainingRounds[0] != 22 {
+		t.Fatalf("got remaining rounds %v, want [22]", contenders.RemainingRounds)
+	}
+	for _, driver := range contenders.Drivers[:2] {
+		if driver.Eliminated || driver.MaxPoints != 395.5 {
+			t.Errorf("%s: got eliminated %v with max %v, want a contender with 395.5", driver.Driver.Ref, driver.Eliminated, driver.MaxPoints)
+		}
+	}
+	if !contenders.Drivers[2].Eliminated {
+		t.Errorf("%s is not eliminated", contenders.Drivers[2].Driver.Ref)
+	}
+
+	clinch := contenders.Clinch
+	if clinch == nil || clinch.Leader.Ref != "max_verstappen" || clinch.Round != 22 || clinch.Position != 1 || clinch.AnyResult {
+		t.Errorf("got clinch %+v, want max_verstappen needing a win in round 22", clinch)
+	}
+}
+
+func TestContestWhatIf(t *testing.T) {
+	races, results := season2021BeforeFinal().build(t)
+	drivers := make(map[string]m.Result)
+	for _, result := range results {
+		drivers[result.Driver.Ref] = result
+	}
+	verstappen, hamilton := drivers["max_verstappen"].DriverID, drivers["hamilton"].DriverID
+
+	contenders, err := Contest(2021, SystemForSeason(2021), races, results, []Scenario{
+		{Round: 22, Race: []uuid.UUID{verstappen, hamilton}, FastestLap: &verstappen},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if contenders.Clinch != nil || len(contenders.RemainingRounds) != 0 {
+		t.Fatalf("got clinch %+v with rounds %v remaining, want the season over", contenders.Clinch, contenders.RemainingRounds)
+	}
+	if contenders.Drivers[0].Driver.Ref != "max_verstappen" || contenders.Drivers[0].Points != 395.5 || contenders.Drivers[0].Eliminated {
+		t.Errorf("got leader %+v, want max_verstappen champion with 395.5", contenders.Drivers[0].DriverStanding)
+	}
+	if !contenders.Drivers[1].Eliminated {
+		t.Errorf("hamilton is not eliminated after the final round")
+	}
+
+	if _, err := Contest(2021, SystemForSeason(2021), races, results, []Scenario{{Round: 21, Race: []uuid.UUID{hamilton}}}); err == nil {
+		t.Errorf("got no error for a scenario of a completed round")
+	}
+}
@@ -0,0 +1,123 @@
+package standings
+
+// Block is a part of the season in which only the best results count, Rounds
+// is 0 for the rest of the season and Best is 0 when every result counts
+type Block struct {
+	Rounds int
+	Best   int
+}
+
+// PointsSystem describes how points are awarded in a season
+type PointsSystem struct {
+	// Race and Sprint hold the points by finishing position
+	Race   []float64
+	Sprint []float64
+	// FastestLap is the bonus for the fastest lap of the race, awarded only
+	// within the top FastestLapTopN finishers unless it is 0
+	FastestLap     float64
+	FastestLapTopN int
+	// Blocks hold the dropped scores rules of the drivers' championship,
+	// sprint points are never dropped
+	Blocks []Block
+	// ConstructorsFrom is the first season of the constructors'
+	// championship, in which until 1978 only the best placed car scored
+	ConstructorsFrom       int
+	ConstructorBestCarOnly bool
+	ConstructorFastestLap  bool
+}
+
+var (
+	points1950 = []float64{8, 6, 4, 3, 2}
+	points1960 = []float64{8, 6, 4, 3, 2, 1}
+	points1961 = []float64{9, 6, 4, 3, 2, 1}
+	points1991 = []float64{10, 6, 4, 3, 2, 1}
+	points2003 = []float64{10, 8, 6, 5, 4, 3, 2, 1}
+	points2010 = []float64{25, 18, 15, 12, 10, 8, 6, 4, 2, 1}
+
+	sprint2021 = []float64{3, 2, 1}
+	sprint2022 = []float64{8, 7, 6, 5, 4, 3, 2, 1}
+)
+
+func best(n int) []Block {
+	return []Block{{Best: n}}
+}
+
+func split(firstRounds, firstBest, restBest int) []Block {
+	return []Block{{Rounds: firstRounds, Best: firstBest}, {Best: restBest}}
+}
+
+// splitSeasons are the seasons from 1967 to 1980, counting the best results
+// of each half of the season separately
+var splitSeasons = map[int][]Block{
+	1967: split(6, 5, 4),
+	1968: split(6, 5, 5),
+	1969: split(6, 5, 4),
+	1970: split(7, 6, 5),
+	1971: split(6, 5, 4),
+	1972: split(6, 5, 5),
+	1973: split(8, 7, 6),
+	1974: split(8, 7, 6),
+	1975: split(7, 6, 6),
+	1976: split(8, 7, 7),
+	1977: split(9, 8, 7),
+	1978: split(8, 7, 7),
+	1979: split(7, 4, 4),
+	1980: split(7, 5, 5),
+}
+
+// SystemForSeason returns the points system used in the season
+func SystemForSeason(year int) PointsSystem {
+	system := PointsSystem{ConstructorsFrom: 1958}
+
+	switch {
+	case year <= 1959:
+		system.Race = points1950
+		system.FastestLap = 1
+		switch {
+		case year <= 1953:
+			system.Blocks = best(4)
+		case year == 1958:
+			system.Blocks = best(6)
+		default:
+			system.Blocks = best(5)
+		}
+	case year == 1960:
+		system.Race = points1960
+		system.Blocks = best(6)
+	case year <= 1990:
+		system.Race = points1961
+		switch {
+		case year <= 1962 || year == 1966:
+			system.Blocks = best(5)
+		case year <= 1965:
+			system.Blocks = best(6)
+		case year <= 1980:
+			system.Blocks = splitSeasons[year]
+		default:
+			system.Blocks = best(11)
+		}
+	case year <= 2002:
+		system.Race = points1991
+	case year <= 2009:
+		system.Race = points2003
+	default:
+		system.Race = points2010
+	}
+
+	if year <= 1978 {
+		system.ConstructorBestCarOnly = true
+	}
+	if year >= 2019 && year <= 2024 {
+		system.FastestLap = 1
+		system.FastestLapTopN = 10
+		system.ConstructorFastestLap = true
+	}
+	switch {
+	case year == 2021:
+		system.Sprint = sprint2021
+	case year >= 2022:
+		system.Sprint = sprint2022
+	}
+
+	return system
+}
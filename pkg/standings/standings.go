@@ -0,0 +1,305 @@
+// Package standings computes the drivers' and constructors' championship
+// tables from stored results with the points system of the season.
+package standings
+
+import (
+	"sort"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+// Score is what a driver scored in a session, race scores include the
+// fastest lap bonus
+type Score struct {
+	Round      int
+	Session    string
+	Position   *int
+	Points     float64
+	FastestLap bool
+	Dropped    bool
+}
+
+type DriverStanding struct {
+	Position     int
+	Driver       m.Driver
+	Constructors []m.Constructor
+	// Points counts towards the championship, GrossPoints includes dropped
+	// scores
+	Points      float64
+	GrossPoints float64
+	Wins        int
+	// SprintPoints are included in Points, sprint wins do not count as wins
+	SprintPoints float64
+	SprintWins   int
+	Scores       []Score
+	finishes     map[int]int
+}
+
+type ConstructorStanding struct {
+	Position     int
+	Constructor  m.Constructor
+	Points       float64
+	Wins         int
+	SprintPoints float64
+	SprintWins   int
+	finishes     map[int]int
+}
+
+type Table struct {
+	Year       int
+	AfterRound int
+	Drivers    []DriverStanding
+	// Constructors is empty before the constructors' championship existed
+	Constructors []ConstructorStanding
+}
+
+// racePoints returns the points of a race result and whether the fastest
+// lap bonus was awarded
+func (system PointsSystem) racePoints(result m.Result, scale float64) (float64, bool) {
+	points := 0.0
+	if result.Position != nil && *result.Position <= len(system.Race) {
+		points = system.Race[*result.Position-1] * scale
+	}
+
+	fastestLap := system.FastestLap > 0 && result.FastestLapRank != nil && *result.FastestLapRank == 1
+	if fastestLap && system.FastestLapTopN > 0 {
+		fastestLap = result.Position != nil && *result.Position <= system.FastestLapTopN
+	}
+	if fastestLap {
+		points += system.FastestLap
+	}
+	return points, fastestLap
+}
+
+func (system PointsSystem) sprintPoints(result m.Result) float64 {
+	if result.Position != nil && *result.Position <= len(system.Sprint) {
+		return system.Sprint[*result.Position-1]
+	}
+	return 0
+}
+
+// Points returns the points a result of the race or sprint session scores,
+// including the fastest lap bonus, with the race points multiplied by scale
+func (system PointsSystem) Points(result m.Result, session string, scale float64) float64 {
+	if session == m.SessionSprint {
+		return system.sprintPoints(result)
+	}
+	if scale == 0 {
+		scale = 1
+	}
+	points, _ := system.racePoints(result, scale)
+	return points
+}
+
+// dropScores marks the race scores which do not count in their block, ties
+// keep the earlier round
+func (system PointsSystem) dropScores(scores []Score) {
+	if len(system.Blocks) == 0 {
+		return
+	}
+
+	first := 1
+	for _, block := range system.Blocks {
+		last := first + block.Rounds - 1
+		inBlock := []*Score{}
+		for i := range scores {
+			score := &scores[i]
+			if score.Session == m.SessionRace && score.Round >= first && (block.Rounds == 0 || score.Round <= last) {
+				inBlock = append(inBlock, score)
+			}
+		}
+
+		if block.Best > 0 && len(inBlock) > block.Best {
+			sort.SliceStable(inBlock, func(i, j int) bool {
+				return inBlock[i].Points > inBlock[j].Points
+			})
+			for _, score := range inBlock[block.Best:] {
+				score.Dropped = true
+			}
+		}
+
+		if block.Rounds == 0 {
+			return
+		}
+		first = last + 1
+	}
+}
+
+// ahead compares finishing positions by countback, the holder of more first
+// places is ahead, then of more second places and so on
+func ahead(a, b map[int]int) (bool, bool) {
+	last := 0
+	for position := range a {
+		if position > last {
+			last = position
+		}
+	}
+	for position := range b {
+		if position > last {
+			last = position
+		}
+	}
+
+	for position := 1; position <= last; position++ {
+		if a[position] != b[position] {
+			return a[position] > b[position], true
+		}
+	}
+	return false, false
+}
+
+// Compute builds the tables from the results of the race and sprint sessions
+// of the season's races up to and including the after round, 0 for the
+// whole season. Results need their session, driver and constructor loaded
+func Compute(year int, system PointsSystem, races []m.Race, results []m.Result, afterRound int) Table {
+	table := Table{Year: year, AfterRound: afterRound, Drivers: []DriverStanding{}, Constructors: []ConstructorStanding{}}
+	results = append([]m.Result{}, results...)
+
+	byID := make(map[uuid.UUID]m.Race)
+	for _, race := range races {
+		if afterRound == 0 || race.Round <= afterRound {
+			byID[race.ID] = race
+		}
+		if afterRound == 0 && race.Round > table.AfterRound {
+			table.AfterRound = race.Round
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return byID[results[i].RaceID].Round < byID[results[j].RaceID].Round
+	})
+
+	drivers := make(map[uuid.UUID]*DriverStanding)
+	driverOrder := []uuid.UUID{}
+	constructors := make(map[uuid.UUID]*ConstructorStanding)
+	constructorOrder := []uuid.UUID{}
+	// constructor points of each race, for championships where only the best
+	// placed car scores
+	bestCar := make(map[uuid.UUID]map[uuid.UUID]float64)
+
+	for _, result := range results {
+		race, ok := byID[result.RaceID]
+		if !ok {
+			continue
+		}
+		session := result.Session.Type
+		if session != m.SessionRace && session != m.SessionSprint {
+			continue
+		}
+
+		driver, ok := drivers[result.DriverID]
+		if !ok {
+			driver = &DriverStanding{Driver: result.Driver, finishes: make(map[int]int)}
+			drivers[result.DriverID] = driver
+			driverOrder = append(driverOrder, result.DriverID)
+		}
+		known := false
+		for _, c := range driver.Constructors {
+			known = known || c.ID == result.ConstructorID
+		}
+		if !known {
+			driver.Constructors = append(driver.Constructors, result.Constructor)
+		}
+
+		constructor, ok := constructors[result.ConstructorID]
+		if !ok {
+			constructor = &ConstructorStanding{Constructor: result.Constructor, finishes: make(map[int]int)}
+			constructors[result.ConstructorID] = constructor
+			constructorOrder = append(constructorOrder, result.ConstructorID)
+		}
+
+		score := Score{Round: race.Round, Session: session, Position: result.Position}
+		constructorPoints := 0.0
+		if session == m.SessionRace {
+			scale := race.PointsScale
+			if scale == 0 {
+				scale = 1
+			}
+			score.Points, score.FastestLap = system.racePoints(result, scale)
+			constructorPoints = score.Points
+			if score.FastestLap && !system.ConstructorFastestLap {
+				constructorPoints -= system.FastestLap
+			}
+
+			if result.Position != nil {
+				driver.finishes[*result.Position]++
+				constructor.finishes[*result.Position]++
+				if *result.Position == 1 {
+					driver.Wins++
+					constructor.Wins++
+				}
+			}
+		} else {
+			score.Points = system.sprintPoints(result)
+			constructorPoints = score.Points
+			driver.SprintPoints += score.Points
+			constructor.SprintPoints += score.Points
+			if result.Position != nil && *result.Position == 1 {
+				driver.SprintWins++
+				constructor.SprintWins++
+			}
+		}
+		driver.Scores = append(driver.Scores, score)
+
+		if system.ConstructorBestCarOnly && session == m.SessionRace {
+			if bestCar[race.ID] == nil {
+				bestCar[race.ID] = make(map[uuid.UUID]float64)
+			}
+			if constructorPoints > bestCar[race.ID][result.ConstructorID] {
+				constructor.Points += constructorPoints - bestCar[race.ID][result.ConstructorID]
+				bestCar[race.ID][result.ConstructorID] = constructorPoints
+			}
+		} else {
+			constructor.Points += constructorPoints
+		}
+	}
+
+	for _, id := range driverOrder {
+		driver := drivers[id]
+		system.dropScores(driver.Scores)
+		for _, score := range driver.Scores {
+			driver.GrossPoints += score.Points
+			if !score.Dropped {
+				driver.Points += score.Points
+			}
+		}
+		table.Drivers = append(table.Drivers, *driver)
+	}
+
+	sort.SliceStable(table.Drivers, func(i, j int) bool {
+		a, b := table.Drivers[i], table.Drivers[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if better, decided := ahead(a.finishes, b.finishes); decided {
+			return better
+		}
+		return a.Driver.LastName < b.Driver.LastName
+	})
+	for i := range table.Drivers {
+		table.Drivers[i].Position = i + 1
+	}
+
+	if year < system.ConstructorsFrom {
+		return table
+	}
+	for _, id := range constructorOrder {
+		table.Constructors = append(table.Constructors, *constructors[id])
+	}
+	sort.SliceStable(table.Constructors, func(i, j int) bool {
+		a, b := table.Constructors[i], table.Constructors[j]
+		if a.Points != b.Points {
+			return a.Points > b.Points
+		}
+		if better, decided := ahead(a.finishes, b.finishes); decided {
+			return better
+		}
+		return a.Constructor.Name < b.Constructor.Name
+	})
+	for i := range table.Constructors {
+		table.Constructors[i].Position = i + 1
+	}
+
+	return table
+}
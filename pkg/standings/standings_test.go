@@ -0,0 +1,218 @@
+package standings
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+// entry holds a driver's season as one token per round: the finishing
+// position, R for a retirement, D for a disqualification or - when the driver
+// did not take part, followed by F when they set the fastest lap
+type entry struct {
+	ref         string
+	constructor string
+	races       string
+	sprints     map[int]int
+}
+
+type season struct {
+	year    int
+	rounds  int
+	scales  map[int]float64
+	entries []entry
+}
+
+func (s season) build(t *testing.T) ([]m.Race, []m.Result) {
+	races := []m.Race{}
+	for round := 1; round <= s.rounds; round++ {
+		races = append(races, m.Race{Model: m.Model{ID: uuid.New()}, Year: s.year, Round: round, PointsScale: s.scales[round]})
+	}
+
+	constructors := make(map[string]m.Constructor)
+	results := []m.Result{}
+	for _, e := range s.entries {
+		driver := m.Driver{Model: m.Model{ID: uuid.New()}, Ref: e.ref, LastName: e.ref}
+		constructor, ok := constructors[e.constructor]
+		if !ok {
+			constructor = m.Constructor{Model: m.Model{ID: uuid.New()}, Ref: e.constructor, Name: e.constructor}
+			constructors[e.constructor] = constructor
+		}
+
+		tokens := strings.Fields(e.races)
+		if len(tokens) != s.rounds {
+			t.Fatalf("%s has %d results in a season of %d rounds", e.ref, len(tokens), s.rounds)
+		}
+		for i, token := range tokens {
+			if token == "-" {
+				continue
+			}
+			result := m.Result{
+				RaceID:        races[i].ID,
+				Session:       m.Session{Type: m.SessionRace},
+				DriverID:      driver.ID,
+				Driver:        driver,
+				ConstructorID: constructor.ID,
+				Constructor:   constructor,
+			}
+			if strings.HasSuffix(token, "F") {
+				rank := 1
+				result.FastestLapRank = &rank
+				token = strings.TrimSuffix(token, "F")
+			}
+			if position, err := strconv.Atoi(token); err == nil {
+				result.Position = &position
+			}
+			results = append(results, result)
+		}
+
+		for round, position := range e.sprints {
+			position := position
+			results = append(results, m.Result{
+				RaceID:        races[round-1].ID,
+				Session:       m.Session{Type: m.SessionSprint},
+				DriverID:      driver.ID,
+				Driver:        driver,
+				ConstructorID: constructor.ID,
+				Constructor:   constructor,
+				Position:      &position,
+			})
+		}
+	}
+
+	return races, results
+}
+
+type want struct {
+	ref    string
+	points float64
+	gross  float64
+}
+
+func checkDrivers(t *testing.T, table Table, expected []want) {
+	t.Helper()
+	if len(table.Drivers) < len(expected) {
+		t.Fatalf("got %d drivers, want at least %d", len(table.Drivers), len(expected))
+	}
+	for i, w := range expected {
+		got := table.Drivers[i]
+		if got.Driver.Ref != w.ref || got.Points != w.points || got.GrossPoints != w.gross {
+			t.Errorf("P%d: got %s with %v (%v) points, want %s with %v (%v)",
+				i+1, got.Driver.Ref, got.Points, got.GrossPoints, w.ref, w.points, w.gross)
+		}
+		if got.Position != i+1 {
+			t.Errorf("%s has position %d, want %d", got.Driver.Ref, got.Position, i+1)
+		}
+	}
+}
+
+// 1950: 8-6-4-3-2 with a point for the fastest lap and the best four results
+// counting, Fagioli scored 28 points of which 24 counted
+func TestCompute1950(t *testing.T) {
+	s := season{year: 1950, rounds: 7, entries: []entry{
+		{ref: "farina", constructor: "alfa", races: "1F R - 1F 4F R 1"},
+		{ref: "fangio", constructor: "alfa", races: "R 1F - R 1 1F RF"},
+		{ref: "fagioli", constructor: "alfa", races: "2 R - 2 2 2 3"},
+	}}
+	races, results := s.build(t)
+
+	table := Compute(1950, SystemForSeason(1950), races, results, 0)
+
+	checkDrivers(t, table, []want{
+		{"farina", 30, 30},
+		{"fangio", 27, 27},
+		{"fagioli", 24, 28},
+	})
+	if len(table.Constructors) != 0 {
+		t.Errorf("got %d constructors before the constructors' championship", len(table.Constructors))
+	}
+}
+
+// 1988: best eleven of sixteen results, Senna won with 90 points to Prost's
+// 87 although Prost scored more in total
+func TestCompute1988(t *testing.T) {
+	s := season{year: 1988, rounds: 16, entries: []entry{
+		{ref: "senna", constructor: "mclaren", races: "D 1 R 2 1 1 2 1 1 1 1 10 6 4 1 2"},
+		{ref: "prost", constructor: "mclaren", races: "1 2 1 1 2 2 1 R 2 2 2 R 1 1 2 1"},
+	}}
+	races, results := s.build(t)
+
+	table := Compute(1988, SystemForSeason(1988), races, results, 0)
+
+	checkDrivers(t, table, []want{
+		{"senna", 90, 94},
+		{"prost", 87, 105},
+	})
+	if table.Drivers[0].Wins != 8 || table.Drivers[1].Wins != 7 {
+		t.Errorf("got %d and %d wins, want 8 and 7", table.Drivers[0].Wins, table.Drivers[1].Wins)
+	}
+	if len(table.Constructors) != 1 || table.Constructors[0].Points != 199 {
+		t.Errorf("got constructors %+v, want mclaren with 199 points", table.Constructors)
+	}
+}
+
+// 2021: sprint points, half points at Spa and the fastest lap bonus. The two
+// were level before the final round, Verstappen ahead on countback
+func TestCompute2021(t *testing.T) {
+	s := season{year: 2021, rounds: 22, scales: map[int]float64{12: 0.5}, entries: []entry{
+		{
+			ref: "max_verstappen", constructor: "red_bull",
+			races:   "2 1 2 2F 1 R 1F 1 1F R 9 1 1 R 2 2 1 1 2 2F 2 1F",
+			sprints: map[int]int{10: 1, 14: 2, 19: 2},
+		},
+		{
+			ref: "hamilton", constructor: "mercedes",
+			races:   "1 2F 1 1 7F 15 2 2F 4 1 2 3 2F R 1 5 2F 2 1 1 1F 2",
+			sprints: map[int]int{10: 2, 14: 5, 19: 5},
+		},
+	}}
+	races, results := s.build(t)
+	system := SystemForSeason(2021)
+
+	table := Compute(2021, system, races, results, 0)
+	checkDrivers(t, table, []want{
+		{"max_verstappen", 395.5, 395.5},
+		{"hamilton", 387.5, 387.5},
+	})
+	verstappen, hamilton := table.Drivers[0], table.Drivers[1]
+	if verstappen.SprintPoints != 7 || verstappen.SprintWins != 1 || hamilton.SprintPoints != 2 || hamilton.SprintWins != 0 {
+		t.Errorf("got sprint points %v and %v with %d and %d sprint wins, want 7 and 2 with 1 and 0",
+			verstappen.SprintPoints, hamilton.SprintPoints, verstappen.SprintWins, hamilton.SprintWins)
+	}
+	if verstappen.Wins != 10 {
+		t.Errorf("got %d wins for verstappen, want 10 without the sprint win", verstappen.Wins)
+	}
+
+	checkDrivers(t, Compute(2021, system, races, results, 21), []want{
+		{"max_verstappen", 369.5, 369.5},
+		{"hamilton", 369.5, 369.5},
+	})
+
+	checkDrivers(t, Compute(2021, system, races, results, 12), []want{
+		{"hamilton", 202.5, 202.5},
+		{"max_verstappen", 199.5, 199.5},
+	})
+}
+
+// 2019 Singapore: Magnussen set the fastest lap finishing 17th and, outside
+// the top ten, scored no bonus point
+func TestComputeFastestLapTopTen(t *testing.T) {
+	s := season{year: 2019, rounds: 1, entries: []entry{
+		{ref: "vettel", constructor: "ferrari", races: "1"},
+		{ref: "kevin_magnussen", constructor: "haas", races: "17F"},
+	}}
+	races, results := s.build(t)
+
+	table := Compute(2019, SystemForSeason(2019), races, results, 0)
+
+	checkDrivers(t, table, []want{
+		{"vettel", 25, 25},
+		{"kevin_magnussen", 0, 0},
+	})
+	if table.Drivers[1].Scores[0].FastestLap {
+		t.Errorf("fastest lap bonus awarded outside the top ten")
+	}
+}
@@ -0,0 +1,225 @@
+// Package stewards follows steward decisions through appeals and amends the
+// classification of a session with the penalties in force.
+package stewards
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	st "github.com/dewciu/f1_api/pkg/standings"
+	"github.com/google/uuid"
+)
+
+const StatusDisqualified = "Disqualified"
+
+// StatusAt returns the status of the decision at the time, false before it
+// was issued
+func StatusAt(decision m.StewardDecision, at time.Time) (string, bool) {
+	if at.Before(decision.IssuedAt) {
+		return "", false
+	}
+	if decision.ResolvedAt != nil && !at.Before(*decision.ResolvedAt) {
+		return decision.Status, true
+	}
+	if decision.AppealedAt != nil && !at.Before(*decision.AppealedAt) {
+		return m.DecisionAppealed, true
+	}
+	return m.DecisionIssued, true
+}
+
+// Amends reports whether the penalty changes the classification
+func Amends(penalty string) bool {
+	return penalty == m.PenaltyTime || penalty == m.PenaltyDisqualification
+}
+
+// InForce returns the decisions issued after since whose penalty stands at
+// the time, a penalty stands while it is under appeal
+func InForce(decisions []m.StewardDecision, since, at time.Time) []m.StewardDecision {
+	inForce := []m.StewardDecision{}
+	for _, decision := range decisions {
+		if !decision.IssuedAt.After(since) {
+			continue
+		}
+		if status, ok := StatusAt(decision, at); ok && status != m.DecisionOverturned {
+			inForce = append(inForce, decision)
+		}
+	}
+	return inForce
+}
+
+// Amend applies the time penalties and disqualifications of the decisions to
+// the classification. Time penalties are added to the race time and the
+// classified drivers are ranked again by laps and race time, drivers without
+// a race time keep their place among the drivers on the same lap.
+// Disqualified drivers follow the unclassified ones. Gaps to the winner and
+// points are derived again, points with the scale of the race
+func Amend(results []m.Result, decisions []m.StewardDecision, system st.PointsSystem, session string, scale float64) []m.Result {
+	amended := append([]m.Result{}, results...)
+	sort.SliceStable(amended, func(i, j int) bool {
+		return amended[i].PositionOrder < amended[j].PositionOrder
+	})
+
+	penalties := make(map[uuid.UUID]int64)
+	disqualified := make(map[uuid.UUID]bool)
+	changed := false
+	for _, decision := range decisions {
+		switch decision.Penalty {
+		case m.PenaltyTime:
+			penalties[decision.DriverID] += int64(decision.Value * 1000)
+			changed = true
+		case m.PenaltyDisqualification:
+			disqualified[decision.DriverID] = true
+			changed = true
+		}
+	}
+	if !changed {
+		return amended
+	}
+
+	original := make(map[uuid.UUID]*int)
+	classified, unclassified, excluded := []m.Result{}, []m.Result{}, []m.Result{}
+	for _, result := range amended {
+		original[result.DriverID] = result.Position
+		if penalty, ok := penalties[result.DriverID]; ok && result.TimeMillis != nil {
+			total := *result.TimeMillis + penalty
+			result.TimeMillis = &total
+		}
+
+		switch {
+		case disqualified[result.DriverID]:
+			result.Position = nil
+			result.PositionText = m.PositionDisqualified
+			result.Classified = false
+			result.Gap = ""
+			result.Status = StatusDisqualified
+			excluded = append(excluded, result)
+		case result.Position != nil:
+			classified = append(classified, result)
+		default:
+			unclassified = append(unclassified, result)
+		}
+	}
+
+	sort.SliceStable(classified, func(i, j int) bool {
+		a, b := classified[i], classified[j]
+		if a.Laps != b.Laps {
+			return a.Laps > b.Laps
+		}
+		if (a.TimeMillis != nil) != (b.TimeMillis != nil) {
+			return a.TimeMillis != nil
+		}
+		if a.TimeMillis != nil && *a.TimeMillis != *b.TimeMillis {
+			return *a.TimeMillis < *b.TimeMillis
+		}
+		return *a.Position < *b.Position
+	})
+
+	for i := range classified {
+		result := &classified[i]
+		position := i + 1
+		result.Position = &position
+		result.PositionText = strconv.Itoa(position)
+
+		winner := classified[0]
+		if i == 0 {
+			result.Gap = ""
+		} else if result.TimeMillis != nil && winner.TimeMillis != nil {
+			result.Gap = "+" + m.LapTimeFromMillis(*result.TimeMillis-*winner.TimeMillis).String()
+		}
+	}
+
+	amended = append(append(classified, unclassified...), excluded...)
+	for i := range amended {
+		result := &amended[i]
+		result.PositionOrder = i + 1
+		before := original[result.DriverID]
+		if before == nil && result.Position == nil {
+			continue
+		}
+		if before == nil || result.Position == nil || *before != *result.Position {
+			result.Points = system.Points(*result, session, scale)
+			if result.Position == nil {
+				result.Points = 0
+			}
+		}
+	}
+	return amended
+}
+
+// Snapshot decodes the classification stored with the version
+func Snapshot(version m.ResultVersion) ([]m.Result, error) {
+	results := []m.Result{}
+	if err := json.Unmarshal(version.Results, &results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Base returns the latest submitted classification in effect at the time,
+// false when none was submitted by then
+func Base(versions []m.ResultVersion, at time.Time) (m.ResultVersion, bool) {
+	var base m.ResultVersion
+	found := false
+	for _, version := range versions {
+		if version.DecisionID != nil || version.EffectiveAt.After(at) {
+			continue
+		}
+		if !found || version.EffectiveAt.After(base.EffectiveAt) ||
+			version.EffectiveAt.Equal(base.EffectiveAt) && version.Version > base.Version {
+			base = version
+			found = true
+		}
+	}
+	return base, found
+}
+
+// NeedsReview reports whether a time penalty of the decisions falls on a
+// classified driver without a race time. Amend keeps such a lapped car in its
+// place among the drivers on the same lap, the stewards may have meant
+// otherwise
+func NeedsReview(results []m.Result, decisions []m.StewardDecision) bool {
+	penalized := make(map[uuid.UUID]bool)
+	for _, decision := range decisions {
+		if decision.Penalty == m.PenaltyTime {
+			penalized[decision.DriverID] = true
+		}
+	}
+	for _, result := range results {
+		if penalized[result.DriverID] && result.Position != nil && result.TimeMillis == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsReviewAt reports whether the classification at the time needs a
+// review, see NeedsReview
+func NeedsReviewAt(versions []m.ResultVersion, decisions []m.StewardDecision, at time.Time) (bool, error) {
+	base, ok := Base(versions, at)
+	if !ok {
+		return false, nil
+	}
+	results, err := Snapshot(base)
+	if err != nil {
+		return false, err
+	}
+	return NeedsReview(results, InForce(decisions, base.EffectiveAt, at)), nil
+}
+
+// ClassificationAt amends the latest submitted classification at the time
+// with the decisions issued after it and in force at the time, false when no
+// classification was submitted by then
+func ClassificationAt(versions []m.ResultVersion, decisions []m.StewardDecision, at time.Time, system st.PointsSystem, session string, scale float64) ([]m.Result, bool, error) {
+	base, ok := Base(versions, at)
+	if !ok {
+		return nil, false, nil
+	}
+	results, err := Snapshot(base)
+	if err != nil {
+		return nil, false, err
+	}
+	return Amend(results, InForce(decisions, base.EffectiveAt, at), system, session, scale), true, nil
+}
@@ -0,0 +1,237 @@
+package stewards
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	st "github.com/dewciu/f1_api/pkg/standings"
+	"github.com/google/uuid"
+)
+
+var (
+	system  = st.SystemForSeason(2023)
+	raceEnd = time.Date(2023, 3, 5, 17, 0, 0, 0, time.UTC)
+)
+
+type driverResult struct {
+	ref    string
+	laps   int
+	millis int64
+}
+
+// classification builds the results of a race from drivers in finishing
+// order, millis is 0 for drivers without a race time
+func classification(drivers ...driverResult) ([]m.Result, map[string]uuid.UUID) {
+	ids := make(map[string]uuid.UUID)
+	results := []m.Result{}
+	for i, d := range drivers {
+		id := uuid.New()
+		ids[d.ref] = id
+		position := i + 1
+		result := m.Result{
+			DriverID:      id,
+			Driver:        m.Driver{Model: m.Model{ID: id}, Ref: d.ref},
+			Position:      &position,
+			PositionOrder: position,
+			Classified:    true,
+			Laps:          d.laps,
+			Status:        m.StatusFinished,
+		}
+		if d.millis > 0 {
+			millis := d.millis
+			result.TimeMillis = &millis
+		}
+		result.Points = system.Points(result, m.SessionRace, 1)
+		results = append(results, result)
+	}
+	return results, ids
+}
+
+func order(results []m.Result) []uuid.UUID {
+	drivers := []uuid.UUID{}
+	for _, result := range results {
+		drivers = append(drivers, result.DriverID)
+	}
+	return drivers
+}
+
+func decision(driver uuid.UUID, penalty string, value float64, issued time.Time) m.StewardDecision {
+	return m.StewardDecision{DriverID: driver, Penalty: penalty, Value: value, Status: m.DecisionIssued, IssuedAt: issued}
+}
+
+func TestStatusAt(t *testing.T) {
+	appealed, resolved := raceEnd.Add(24*time.Hour), raceEnd.Add(72*time.Hour)
+	d := decision(uuid.New(), m.PenaltyTime, 5, raceEnd.Add(time.Hour))
+	d.Status, d.AppealedAt, d.ResolvedAt = m.DecisionOverturned, &appealed, &resolved
+
+	cases := []struct {
+		at     time.Time
+		status string
+		ok     bool
+	}{
+		{raceEnd, "", false},
+		{raceEnd.Add(time.Hour), m.DecisionIssued, true},
+		{appealed, m.DecisionAppealed, true},
+		{resolved.Add(-time.Second), m.DecisionAppealed, true},
+		{resolved, m.DecisionOverturned, true},
+	}
+	for _, c := range cases {
+		if status, ok := StatusAt(d, c.at); status != c.status || ok != c.ok {
+			t.Errorf("at %v got %q %v, want %q %v", c.at, status, ok, c.status, c.ok)
+		}
+	}
+
+	if n := len(InForce([]m.StewardDecision{d}, raceEnd, appealed)); n != 1 {
+		t.Errorf("appealed penalty in force %d times, want once", n)
+	}
+	if n := len(InForce([]m.StewardDecision{d}, raceEnd, resolved)); n != 0 {
+		t.Errorf("overturned penalty in force %d times", n)
+	}
+	if n := len(InForce([]m.StewardDecision{d}, appealed, appealed)); n != 0 {
+		t.Errorf("penalty issued before the classification in force %d times", n)
+	}
+}
+
+func TestAmendTimePenalty(t *testing.T) {
+	results, ids := classification(
+		driverResult{"alpha", 57, 5_400_000},
+		driverResult{"beta", 57, 5_403_000},
+		driverResult{"gamma", 57, 5_406_500},
+		driverResult{"delta", 56, 0},
+	)
+
+	amended := Amend(results, []m.StewardDecision{decision(ids["alpha"], m.PenaltyTime, 5, raceEnd)}, system, m.SessionRace, 1)
+	want := []uuid.UUID{ids["beta"], ids["alpha"], ids["gamma"], ids["delta"]}
+	for i, driver := range order(amended) {
+		if driver != want[i] {
+			t.Fatalf("got order %v, want %v", order(amended), want)
+		}
+	}
+
+	beta, alpha, gamma, delta := amended[0], amended[1], amended[2], amended[3]
+	if *beta.Position != 1 || beta.PositionText != "1" || beta.Gap != "" || beta.Points != 25 {
+		t.Errorf("winner beta is %v %q with gap %q and %v points", *beta.Position, beta.PositionText, beta.Gap, beta.Points)
+	}
+	if *alpha.TimeMillis != 5_405_000 || alpha.Gap != "+2.000" || alpha.Points != 18 {
+		t.Errorf("alpha has time %v, gap %q and %v points", *alpha.TimeMillis, alpha.Gap, alpha.Points)
+	}
+	if *gamma.Position != 3 || gamma.Gap != "+3.500" || gamma.Points != 15 {
+		t.Errorf("gamma is %v with gap %q and %v points", *gamma.Position, gamma.Gap, gamma.Points)
+	}
+	if *delta.Position != 4 || delta.PositionOrder != 4 {
+		t.Errorf("lapped delta is %v", *delta.Position)
+	}
+	if *results[0].Position != 1 || *results[0].TimeMillis != 5_400_000 {
+		t.Error("amending changed the submitted results")
+	}
+}
+
+func TestAmendDisqualification(t *testing.T) {
+	results, ids := classification(
+		driverResult{"alpha", 57, 5_400_000},
+		driverResult{"beta", 57, 5_403_000},
+		driverResult{"gamma", 57, 5_406_500},
+	)
+	retired := m.Result{DriverID: uuid.New(), PositionText: m.PositionRetired, PositionOrder: 4, Laps: 20, Status: "Engine"}
+	results = append(results, retired)
+
+	amended := Amend(results, []m.StewardDecision{decision(ids["beta"], m.PenaltyDisqualification, 0, raceEnd)}, system, m.SessionRace, 1)
+	want := []uuid.UUID{ids["alpha"], ids["gamma"], retired.DriverID, ids["beta"]}
+	for i, driver := range order(amended) {
+		if driver != want[i] {
+			t.Fatalf("got order %v, want %v", order(amended), want)
+		}
+	}
+
+	beta, gamma := amended[3], amended[1]
+	if beta.Position != nil || beta.PositionText != m.PositionDisqualified || beta.Classified || beta.Points != 0 || beta.PositionOrder != 4 {
+		t.Errorf("disqualified beta is %+v", beta)
+	}
+	if *gamma.Position != 2 || gamma.Points != 18 || gamma.Gap != "+6.500" {
+		t.Errorf("gamma is %v with %v points and gap %q", *gamma.Position, gamma.Points, gamma.Gap)
+	}
+}
+
+func TestAmendWithoutClassificationPenalties(t *testing.T) {
+	results, ids := classification(driverResult{"alpha", 57, 5_400_000}, driverResult{"beta", 57, 5_403_000})
+	results[0].Points = 26
+
+	amended := Amend(results, []m.StewardDecision{decision(ids["alpha"], m.PenaltyReprimand, 0, raceEnd)}, system, m.SessionRace, 1)
+	if amended[0].DriverID != ids["alpha"] || amended[0].Points != 26 {
+		t.Error("a reprimand changed the classification")
+	}
+}
+
+func TestNeedsReview(t *testing.T) {
+	results, ids := classification(
+		driverResult{"alpha", 57, 5_400_000},
+		driverResult{"beta", 56, 0},
+		driverResult{"gamma", 56, 0},
+	)
+	data, _ := json.Marshal(results)
+	versions := []m.ResultVersion{{Version: 1, EffectiveAt: raceEnd, Results: data}}
+	lapped := []m.StewardDecision{decision(ids["beta"], m.PenaltyTime, 10, raceEnd.Add(time.Hour))}
+
+	amended := Amend(results, lapped, system, m.SessionRace, 1)
+	if *amended[1].Position != 2 || amended[1].DriverID != ids["beta"] {
+		t.Errorf("lapped beta with a time penalty is %v, want it kept second", *amended[1].Position)
+	}
+	if needsReview, err := NeedsReviewAt(versions, lapped, raceEnd.Add(time.Hour)); err != nil || !needsReview {
+		t.Errorf("time penalty on a lapped car needs no review: %v", err)
+	}
+	if needsReview, _ := NeedsReviewAt(versions, lapped, raceEnd); needsReview {
+		t.Error("classification before the penalty needs a review")
+	}
+	leader := []m.StewardDecision{decision(ids["alpha"], m.PenaltyTime, 10, raceEnd.Add(time.Hour))}
+	if NeedsReview(results, leader) {
+		t.Error("time penalty on a driver with a race time needs a review")
+	}
+}
+
+func TestClassificationAt(t *testing.T) {
+	results, ids := classification(
+		driverResult{"alpha", 57, 5_400_000},
+		driverResult{"beta", 57, 5_403_000},
+	)
+	data, _ := json.Marshal(results)
+	base := m.ResultVersion{Version: 1, EffectiveAt: raceEnd, Results: data}
+
+	appealed, resolved := raceEnd.Add(24*time.Hour), raceEnd.Add(72*time.Hour)
+	penalty := decision(ids["alpha"], m.PenaltyTime, 10, raceEnd.Add(time.Hour))
+	penalty.Status, penalty.AppealedAt, penalty.ResolvedAt = m.DecisionOverturned, &appealed, &resolved
+	decisions := []m.StewardDecision{penalty}
+	versions := []m.ResultVersion{base}
+
+	if _, ok, _ := ClassificationAt(versions, decisions, raceEnd.Add(-time.Minute), system, m.SessionRace, 1); ok {
+		t.Error("found a classification before the race ended")
+	}
+
+	cases := []struct {
+		at     time.Time
+		winner uuid.UUID
+	}{
+		{raceEnd, ids["alpha"]},
+		{raceEnd.Add(time.Hour), ids["beta"]},
+		{appealed, ids["beta"]},
+		{resolved, ids["alpha"]},
+	}
+	for _, c := range cases {
+		classified, ok, err := ClassificationAt(versions, decisions, c.at, system, m.SessionRace, 1)
+		if err != nil || !ok {
+			t.Fatalf("no classification at %v: %v", c.at, err)
+		}
+		if classified[0].DriverID != c.winner {
+			t.Errorf("at %v the winner is %s", c.at, classified[0].Driver.Ref)
+		}
+	}
+
+	// a later submission includes the penalties issued before it
+	resubmitted := m.ResultVersion{Version: 3, EffectiveAt: raceEnd.Add(2 * time.Hour), Results: data}
+	versions = append(versions, resubmitted)
+	classified, _, _ := ClassificationAt(versions, decisions, appealed, system, m.SessionRace, 1)
+	if classified[0].DriverID != ids["alpha"] {
+		t.Error("penalty applied again to a later submission")
+	}
+}
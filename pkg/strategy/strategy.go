@@ -0,0 +1,236 @@
+// Package strategy builds the stint timelines of a race and analyses how
+// drivers gained or lost time to their rivals around pit stops.
+package strategy
+
+import (
+	"sort"
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/laps"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+const (
+	// NearbyGap is the largest gap between two drivers before the first of
+	// them stops for their stops to be compared
+	NearbyGap = m.LapTime(5 * time.Second)
+	// ResponseLaps is the most laps after which a rival's stop still counts
+	// as a response to a driver's stop
+	ResponseLaps = 5
+)
+
+const (
+	RankedByStationary = "stationary"
+	RankedByPitLane    = "pit_lane"
+)
+
+const (
+	OutcomeUndercut = "undercut"
+	OutcomeOvercut  = "overcut"
+	OutcomeHeld     = "held"
+)
+
+// DriverStrategy is the stint timeline of a driver, Stops counts the pit
+// stops or the tyre changes when no stops are stored
+type DriverStrategy struct {
+	DriverID uuid.UUID
+	Stints   []m.Stint
+	PitStops []m.PitStop
+	Stops    int
+}
+
+type RankedStop struct {
+	Rank int
+	m.PitStop
+	Time m.LapTime
+}
+
+// Exchange compares two nearby drivers who stopped on different laps within
+// ResponseLaps of each other. Gaps are the early stopper's race time minus
+// the late stopper's, measured at the end of the lap before the early stop
+// and at the end of the late stopper's out lap. Gain is what the early
+// stopper made up
+type Exchange struct {
+	Early     uuid.UUID
+	Late      uuid.UUID
+	EarlyLap  int
+	LateLap   int
+	GapBefore m.LapTime
+	GapAfter  m.LapTime
+	Gain      m.LapTime
+	// Outcome is undercut when the early stopper got ahead, overcut when the
+	// late stopper did and held otherwise
+	Outcome string
+}
+
+type Strategy struct {
+	Drivers  []DriverStrategy
+	Fastest  []RankedStop
+	RankedBy string
+	// Analysed is false when there are no laps to compare the stops with
+	Analysed  bool
+	Exchanges []Exchange
+}
+
+// Analyse builds the strategy of the race. Drivers are ordered by their
+// classification, followed by drivers without a result
+func Analyse(stints []m.Stint, stops []m.PitStop, raceLaps []m.Lap, results []m.Result) Strategy {
+	strategy := Strategy{
+		Drivers:   timelines(stints, stops, results),
+		Exchanges: []Exchange{},
+	}
+	strategy.Fastest, strategy.RankedBy = fastest(stops)
+	if len(raceLaps) > 0 {
+		strategy.Analysed = true
+		strategy.Exchanges = exchanges(stops, laps.Totals(raceLaps))
+	}
+	return strategy
+}
+
+func timelines(stints []m.Stint, stops []m.PitStop, results []m.Result) []DriverStrategy {
+	index := make(map[uuid.UUID]int)
+	drivers := []DriverStrategy{}
+	driver := func(id uuid.UUID) *DriverStrategy {
+		i, ok := index[id]
+		if !ok {
+			i = len(drivers)
+			index[id] = i
+			drivers = append(drivers, DriverStrategy{DriverID: id, Stints: []m.Stint{}, PitStops: []m.PitStop{}})
+		}
+		return &drivers[i]
+	}
+
+	for _, stint := range stints {
+		d := driver(stint.DriverID)
+		d.Stints = append(d.Stints, stint)
+	}
+	for _, stop := range stops {
+		d := driver(stop.DriverID)
+		d.PitStops = append(d.PitStops, stop)
+	}
+
+	for i := range drivers {
+		d := &drivers[i]
+		sort.Slice(d.Stints, func(a, b int) bool { return d.Stints[a].Number < d.Stints[b].Number })
+		sort.Slice(d.PitStops, func(a, b int) bool { return d.PitStops[a].Stop < d.PitStops[b].Stop })
+		d.Stops = len(d.PitStops)
+		if d.Stops == 0 && len(d.Stints) > 1 {
+			d.Stops = len(d.Stints) - 1
+		}
+	}
+
+	order := make(map[uuid.UUID]int)
+	for _, result := range results {
+		order[result.DriverID] = result.PositionOrder
+	}
+	sort.SliceStable(drivers, func(i, j int) bool {
+		a, aok := order[drivers[i].DriverID]
+		b, bok := order[drivers[j].DriverID]
+		if aok != bok {
+			return aok
+		}
+		if a != b {
+			return a < b
+		}
+		return drivers[i].DriverID.String() < drivers[j].DriverID.String()
+	})
+	return drivers
+}
+
+// fastest ranks the stops by stationary time when every stop has one and by
+// pit lane time otherwise, stops without that time are left out
+func fastest(stops []m.PitStop) ([]RankedStop, string) {
+	rankedBy := RankedByStationary
+	for _, stop := range stops {
+		if stop.Stationary == nil {
+			rankedBy = RankedByPitLane
+		}
+	}
+
+	ranking := []RankedStop{}
+	for _, stop := range stops {
+		t := stop.PitLane
+		if rankedBy == RankedByStationary {
+			t = stop.Stationary
+		}
+		if t != nil {
+			ranking = append(ranking, RankedStop{PitStop: stop, Time: *t})
+		}
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		if ranking[i].Time != ranking[j].Time {
+			return ranking[i].Time < ranking[j].Time
+		}
+		return ranking[i].Lap < ranking[j].Lap
+	})
+	for i := range ranking {
+		ranking[i].Rank = i + 1
+	}
+	return ranking, rankedBy
+}
+
+// elapsed returns the race time of the driver at the end of the lap
+func elapsed(totals map[uuid.UUID][]m.LapTime, driver uuid.UUID, lap int) (m.LapTime, bool) {
+	times := totals[driver]
+	if lap < 1 || lap > len(times) {
+		return 0, false
+	}
+	return times[lap-1], true
+}
+
+func exchanges(stops []m.PitStop, totals map[uuid.UUID][]m.LapTime) []Exchange {
+	sorted := append([]m.PitStop{}, stops...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Lap != sorted[j].Lap {
+			return sorted[i].Lap < sorted[j].Lap
+		}
+		return sorted[i].DriverID.String() < sorted[j].DriverID.String()
+	})
+
+	result := []Exchange{}
+	for i, early := range sorted {
+		for _, late := range sorted[i+1:] {
+			if late.Lap-early.Lap > ResponseLaps {
+				break
+			}
+			if late.Lap == early.Lap || late.DriverID == early.DriverID {
+				continue
+			}
+
+			earlyBefore, ok1 := elapsed(totals, early.DriverID, early.Lap-1)
+			lateBefore, ok2 := elapsed(totals, late.DriverID, early.Lap-1)
+			earlyAfter, ok3 := elapsed(totals, early.DriverID, late.Lap+1)
+			lateAfter, ok4 := elapsed(totals, late.DriverID, late.Lap+1)
+			if !ok1 || !ok2 || !ok3 || !ok4 {
+				continue
+			}
+
+			before := earlyBefore - lateBefore
+			if before > NearbyGap || before < -NearbyGap {
+				continue
+			}
+			after := earlyAfter - lateAfter
+
+			exchange := Exchange{
+				Early:     early.DriverID,
+				Late:      late.DriverID,
+				EarlyLap:  early.Lap,
+				LateLap:   late.Lap,
+				GapBefore: before,
+				GapAfter:  after,
+				Gain:      before - after,
+				Outcome:   OutcomeHeld,
+			}
+			switch {
+			case before > 0 && after < 0:
+				exchange.Outcome = OutcomeUndercut
+			case before < 0 && after > 0:
+				exchange.Outcome = OutcomeOvercut
+			}
+			result = append(result, exchange)
+		}
+	}
+	return result
+}
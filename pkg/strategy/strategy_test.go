@@ -0,0 +1,122 @@
+package strategy
+
+import (
+	"testing"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+var (
+	alpha = uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	beta  = uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+	gamma = uuid.MustParse("00000000-0000-0000-0000-00000000000c")
+)
+
+func millis(value int64) *m.LapTime {
+	t := m.LapTimeFromMillis(value)
+	return &t
+}
+
+// race builds laps from lap times in seconds, lap n of each driver being the
+// n-th element
+func race(times map[uuid.UUID][]float64) []m.Lap {
+	laps := []m.Lap{}
+	for driver, seconds := range times {
+		for i, s := range seconds {
+			laps = append(laps, m.Lap{DriverID: driver, Number: i + 1, Position: 1, Time: *millis(int64(s * 1000))})
+		}
+	}
+	return laps
+}
+
+func stop(driver uuid.UUID, number, lap int, stationary *m.LapTime, pitLane int64) m.PitStop {
+	return m.PitStop{DriverID: driver, Stop: number, Lap: lap, Stationary: stationary, PitLane: millis(pitLane)}
+}
+
+func TestTimelines(t *testing.T) {
+	stints := []m.Stint{
+		{DriverID: beta, Number: 2, Compound: m.CompoundHard, StartLap: 21, EndLap: 50},
+		{DriverID: beta, Number: 1, Compound: m.CompoundMedium, New: true, StartLap: 1, EndLap: 20},
+		{DriverID: gamma, Number: 1, Compound: m.CompoundSoft, StartLap: 1, EndLap: 15},
+		{DriverID: gamma, Number: 2, Compound: m.CompoundHard, StartLap: 16, EndLap: 50},
+	}
+	stops := []m.PitStop{stop(beta, 1, 20, nil, 21000)}
+	results := []m.Result{{DriverID: gamma, PositionOrder: 1}, {DriverID: beta, PositionOrder: 2}}
+
+	strategy := Analyse(stints, stops, nil, results)
+	if len(strategy.Drivers) != 2 || strategy.Drivers[0].DriverID != gamma {
+		t.Fatalf("got %+v, want gamma first", strategy.Drivers)
+	}
+	if beta := strategy.Drivers[1]; beta.Stops != 1 || beta.Stints[0].Number != 1 || beta.Stints[1].Laps() != 30 {
+		t.Errorf("beta timeline %+v", beta)
+	}
+	// gamma has no stored stop, the tyre change counts
+	if strategy.Drivers[0].Stops != 1 {
+		t.Errorf("gamma has %d stops, want 1", strategy.Drivers[0].Stops)
+	}
+	if strategy.Analysed || len(strategy.Exchanges) != 0 {
+		t.Error("stops are analysed without laps")
+	}
+}
+
+func TestFastestStops(t *testing.T) {
+	stops := []m.PitStop{
+		stop(alpha, 1, 20, millis(2400), 22000),
+		stop(beta, 1, 18, millis(2100), 23000),
+		stop(gamma, 1, 25, millis(3000), 21500),
+	}
+	strategy := Analyse(nil, stops, nil, nil)
+	if strategy.RankedBy != RankedByStationary || strategy.Fastest[0].DriverID != beta || strategy.Fastest[2].DriverID != gamma {
+		t.Errorf("ranked by %s: %+v", strategy.RankedBy, strategy.Fastest)
+	}
+
+	stops[0].Stationary = nil
+	strategy = Analyse(nil, stops, nil, nil)
+	if strategy.RankedBy != RankedByPitLane || strategy.Fastest[0].DriverID != gamma || strategy.Fastest[0].Rank != 1 {
+		t.Errorf("ranked by %s: %+v", strategy.RankedBy, strategy.Fastest)
+	}
+}
+
+func TestUndercut(t *testing.T) {
+	// beta runs a second behind alpha, pits on lap 3 and laps two seconds
+	// quicker on new tyres while alpha pits a lap later
+	laps := race(map[uuid.UUID][]float64{
+		alpha: {90, 90, 90, 112, 92, 90},
+		beta:  {91, 90, 110, 88, 90, 90},
+	})
+	stops := []m.PitStop{stop(alpha, 1, 4, nil, 21000), stop(beta, 1, 3, nil, 21000)}
+
+	strategy := Analyse(nil, stops, laps, nil)
+	if !strategy.Analysed || len(strategy.Exchanges) != 1 {
+		t.Fatalf("got %+v", strategy.Exchanges)
+	}
+	e := strategy.Exchanges[0]
+	if e.Early != beta || e.Late != alpha || e.Outcome != OutcomeUndercut {
+		t.Errorf("got %+v, want beta undercutting alpha", e)
+	}
+	// 1.000 behind after lap 2, 5.000 ahead after lap 5
+	if e.GapBefore.String() != "1.000" || e.GapAfter.String() != "-5.000" || e.Gain.String() != "6.000" {
+		t.Errorf("gaps %s %s gain %s", e.GapBefore, e.GapAfter, e.Gain)
+	}
+}
+
+func TestOvercutAndDistantRivals(t *testing.T) {
+	// alpha pits first from ahead and loses the place to beta, who stays out
+	// and gets through lap 5 quicker; gamma is far behind
+	laps := race(map[uuid.UUID][]float64{
+		alpha: {90, 90, 115, 91, 91, 90},
+		beta:  {91, 90, 89, 110, 89, 90},
+		gamma: {100, 100, 100, 100, 100, 100},
+	})
+	stops := []m.PitStop{stop(alpha, 1, 3, nil, 21000), stop(beta, 1, 4, nil, 21000), stop(gamma, 1, 5, nil, 21000)}
+
+	strategy := Analyse(nil, stops, laps, nil)
+	if len(strategy.Exchanges) != 1 {
+		t.Fatalf("got %+v, want only alpha and beta compared", strategy.Exchanges)
+	}
+	e := strategy.Exchanges[0]
+	if e.Early != alpha || e.Outcome != OutcomeOvercut || e.Gain >= 0 {
+		t.Errorf("got %+v, want beta overcutting alpha", e)
+	}
+}
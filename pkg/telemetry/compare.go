@@ -0,0 +1,195 @@
+package telemetry
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+)
+
+// Defaults of the comparison options
+const (
+	DefaultStep        = 10.0
+	DefaultMiniSectors = 25
+)
+
+// Laps of a comparison, naming the winner of a mini-sector
+const (
+	LapA = "a"
+	LapB = "b"
+)
+
+var ErrTooFewSamples = errors.New("lap has too few samples to be compared")
+
+type CompareOptions struct {
+	// Step is the spacing of the distance grid in metres
+	Step float64
+	// MiniSectors is the number of equal parts the lap is split into
+	MiniSectors int
+}
+
+// ComparisonPoint holds both laps at a distance of the grid. Delta is the
+// time lap B has lost to lap A up to the distance, in seconds, negative
+// where lap B is ahead
+type ComparisonPoint struct {
+	Distance  float64
+	SpeedA    float64
+	SpeedB    float64
+	ThrottleA float64
+	ThrottleB float64
+	Delta     float64
+}
+
+// MiniSector holds the time both laps took between two distances, Winner is
+// the faster lap or empty when both took the same time
+type MiniSector struct {
+	Number int
+	Start  float64
+	End    float64
+	TimeA  float64
+	TimeB  float64
+	Winner string
+}
+
+type Comparison struct {
+	// Length is the common length the laps are aligned on in metres
+	Length      float64
+	Points      []ComparisonPoint
+	MiniSectors []MiniSector
+	// Delta is the time lap B lost to lap A over the whole lap
+	Delta float64
+}
+
+// trace is a lap by distance, elapsed seconds, speed and throttle per sample
+type trace struct {
+	distance []float64
+	elapsed  []float64
+	speed    []float64
+	throttle []float64
+}
+
+// newTrace builds the trace of the samples of a lap in time order. The
+// recorded distance is used when every sample has one, otherwise it is
+// integrated from the speed. Distances never decrease, so that noise in a
+// recorded distance cannot fold the trace back on itself
+func newTrace(samples []m.TelemetrySample) (trace, error) {
+	if len(samples) < 2 {
+		return trace{}, ErrTooFewSamples
+	}
+	recorded := true
+	for _, sample := range samples {
+		recorded = recorded && sample.Distance != nil
+	}
+
+	n := len(samples)
+	t := trace{distance: make([]float64, n), elapsed: make([]float64, n), speed: make([]float64, n), throttle: make([]float64, n)}
+	start := samples[0].Time
+	for i, sample := range samples {
+		t.elapsed[i] = sample.Time.Sub(start).Seconds()
+		t.speed[i] = float64(sample.Speed)
+		t.throttle[i] = float64(sample.Throttle)
+		switch {
+		case recorded:
+			t.distance[i] = *sample.Distance - *samples[0].Distance
+		case i > 0:
+			// trapezoidal integration of the speed in km/h
+			t.distance[i] = t.distance[i-1] + (t.speed[i-1]+t.speed[i])/2/3.6*(t.elapsed[i]-t.elapsed[i-1])
+		}
+		if i > 0 {
+			t.distance[i] = math.Max(t.distance[i], t.distance[i-1])
+		}
+	}
+	if t.length() <= 0 {
+		return trace{}, ErrTooFewSamples
+	}
+	return t, nil
+}
+
+func (t trace) length() float64 {
+	return t.distance[len(t.distance)-1]
+}
+
+// scale stretches the trace to the length
+func (t trace) scale(length float64) {
+	factor := length / t.length()
+	for i := range t.distance {
+		t.distance[i] *= factor
+	}
+}
+
+// at interpolates the values linearly at the distance, samples at the same
+// distance resolve to the last of them
+func (t trace) at(values []float64, distance float64) float64 {
+	i := sort.Search(len(t.distance), func(i int) bool { return t.distance[i] > distance })
+	switch {
+	case i == 0:
+		return values[0]
+	case i == len(t.distance):
+		return values[len(values)-1]
+	}
+	from, to := t.distance[i-1], t.distance[i]
+	ratio := (distance - from) / (to - from)
+	return values[i-1] + ratio*(values[i]-values[i-1])
+}
+
+// Compare aligns two laps by distance. Both laps are scaled to the mean of
+// their lengths, which absorbs the difference in racing lines and in the
+// integrated distance, so that the final delta is the difference of the lap
+// times. Speed, throttle and the delta are interpolated on a grid of the
+// given step and the lap is split into mini-sectors won by the faster lap
+func Compare(a, b []m.TelemetrySample, options CompareOptions) (Comparison, error) {
+	if options.Step <= 0 {
+		options.Step = DefaultStep
+	}
+	if options.MiniSectors <= 0 {
+		options.MiniSectors = DefaultMiniSectors
+	}
+
+	traceA, err := newTrace(a)
+	if err != nil {
+		return Comparison{}, err
+	}
+	traceB, err := newTrace(b)
+	if err != nil {
+		return Comparison{}, err
+	}
+	length := (traceA.length() + traceB.length()) / 2
+	traceA.scale(length)
+	traceB.scale(length)
+
+	comparison := Comparison{Length: length}
+	for i := 0; ; i++ {
+		distance := math.Min(float64(i)*options.Step, length)
+		comparison.Points = append(comparison.Points, ComparisonPoint{
+			Distance:  distance,
+			SpeedA:    traceA.at(traceA.speed, distance),
+			SpeedB:    traceB.at(traceB.speed, distance),
+			ThrottleA: traceA.at(traceA.throttle, distance),
+			ThrottleB: traceB.at(traceB.throttle, distance),
+			Delta:     traceB.at(traceB.elapsed, distance) - traceA.at(traceA.elapsed, distance),
+		})
+		if distance == length {
+			break
+		}
+	}
+	comparison.Delta = comparison.Points[len(comparison.Points)-1].Delta
+
+	sectorLength := length / float64(options.MiniSectors)
+	for i := 0; i < options.MiniSectors; i++ {
+		sector := MiniSector{Number: i + 1, Start: float64(i) * sectorLength, End: float64(i+1) * sectorLength}
+		if i == options.MiniSectors-1 {
+			sector.End = length
+		}
+		sector.TimeA = traceA.at(traceA.elapsed, sector.End) - traceA.at(traceA.elapsed, sector.Start)
+		sector.TimeB = traceB.at(traceB.elapsed, sector.End) - traceB.at(traceB.elapsed, sector.Start)
+		switch {
+		case sector.TimeA < sector.TimeB:
+			sector.Winner = LapA
+		case sector.TimeB < sector.TimeA:
+			sector.Winner = LapB
+		}
+		comparison.MiniSectors = append(comparison.MiniSectors, sector)
+	}
+	return comparison, nil
+}
@@ -0,0 +1,144 @@
+package telemetry
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+)
+
+// lap builds the samples of a 1000 m lap driven four times a second at the
+// speeds in m/s, the first speed held for the first half of the lap and the
+// second for the rest. Distances are recorded when asked to
+func lap(first, second float64, recorded bool) []m.TelemetrySample {
+	samples := []m.TelemetrySample{}
+	distance := 0.0
+	for i := 0; ; i++ {
+		speed := first
+		if distance >= 500 {
+			speed = second
+		}
+		sample := m.TelemetrySample{
+			Time:     start.Add(time.Duration(i) * 250 * time.Millisecond),
+			Lap:      1,
+			Speed:    int(math.Round(speed * 3.6)),
+			Throttle: int(speed),
+		}
+		if recorded {
+			d := distance
+			sample.Distance = &d
+		}
+		samples = append(samples, sample)
+		if distance >= 1000 {
+			return samples
+		}
+		distance += speed / 4
+	}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-6
+}
+
+func TestCompareIdenticalLaps(t *testing.T) {
+	comparison, err := Compare(lap(50, 50, true), lap(50, 50, true), CompareOptions{Step: 100, MiniSectors: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comparison.Length != 1000 || len(comparison.Points) != 11 || len(comparison.MiniSectors) != 4 {
+		t.Fatalf("length %v with %d points and %d mini-sectors, want 1000 m, 11 points and 4", comparison.Length, len(comparison.Points), len(comparison.MiniSectors))
+	}
+	for _, point := range comparison.Points {
+		if point.Delta != 0 || point.SpeedA != 180 || point.SpeedB != 180 {
+			t.Errorf("point = %+v, want no delta at 180 km/h", point)
+		}
+	}
+	for _, sector := range comparison.MiniSectors {
+		if sector.Winner != "" || !near(sector.TimeA, 5) {
+			t.Errorf("mini-sector = %+v, want a tie in 5 s", sector)
+		}
+	}
+}
+
+func TestCompareDelta(t *testing.T) {
+	// lap B drops to 40 m/s for the second half, 12.5 s instead of 10 s
+	comparison, err := Compare(lap(50, 50, true), lap(50, 40, true), CompareOptions{Step: 250, MiniSectors: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !near(comparison.Delta, 2.5) {
+		t.Errorf("delta = %v, want 2.5 s", comparison.Delta)
+	}
+
+	want := []float64{0, 0, 0, 1.25, 2.5}
+	for i, point := range comparison.Points {
+		if point.Distance != float64(i)*250 || !near(point.Delta, want[i]) {
+			t.Errorf("point %d = %+v, want a delta of %v at %v m", i, point, want[i], float64(i)*250)
+		}
+	}
+	if point := comparison.Points[3]; point.SpeedB != 144 || point.ThrottleB != 40 || point.SpeedA != 180 {
+		t.Errorf("point at 750 m = %+v, want lap B at 144 km/h and lap A at 180 km/h", point)
+	}
+
+	first, second := comparison.MiniSectors[0], comparison.MiniSectors[1]
+	if first.Winner != "" || !near(first.TimeB, 10) {
+		t.Errorf("first mini-sector = %+v, want a tie in 10 s", first)
+	}
+	if second.Winner != LapA || !near(second.TimeA, 10) || !near(second.TimeB, 12.5) || second.End != 1000 {
+		t.Errorf("second mini-sector = %+v, want lap A in 10 s against 12.5 s", second)
+	}
+
+	reversed, _ := Compare(lap(50, 40, true), lap(50, 50, true), CompareOptions{Step: 250, MiniSectors: 2})
+	if !near(reversed.Delta, -2.5) || reversed.MiniSectors[1].Winner != LapB {
+		t.Errorf("reversed delta %v won by %q, want -2.5 s won by lap B", reversed.Delta, reversed.MiniSectors[1].Winner)
+	}
+}
+
+func TestCompareIntegratedDistance(t *testing.T) {
+	// without recorded distances the lengths are integrated from the speed
+	comparison, err := Compare(lap(50, 50, false), lap(50, 40, false), CompareOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(comparison.Length-1000) > 10 {
+		t.Errorf("length = %v, want about 1000 m", comparison.Length)
+	}
+	if !near(comparison.Delta, 2.5) {
+		t.Errorf("delta = %v, want the difference of the lap times", comparison.Delta)
+	}
+	if len(comparison.Points) != int(math.Ceil(comparison.Length/DefaultStep))+1 || len(comparison.MiniSectors) != DefaultMiniSectors {
+		t.Errorf("%d points and %d mini-sectors with the default options", len(comparison.Points), len(comparison.MiniSectors))
+	}
+}
+
+func TestCompareNoisyDistance(t *testing.T) {
+	samples := lap(50, 50, true)
+	back := *samples[10].Distance - 30
+	samples[11].Distance = &back
+
+	tr, err := newTrace(samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < len(tr.distance); i++ {
+		if tr.distance[i] < tr.distance[i-1] {
+			t.Fatalf("distance falls from %v to %v at sample %d", tr.distance[i-1], tr.distance[i], i)
+		}
+	}
+}
+
+func TestCompareTooFewSamples(t *testing.T) {
+	if _, err := Compare(lap(50, 50, true)[:1], lap(50, 50, true), CompareOptions{}); !errors.Is(err, ErrTooFewSamples) {
+		t.Errorf("err = %v, want ErrTooFewSamples", err)
+	}
+	stationary := lap(50, 50, true)[:3]
+	for i := range stationary {
+		zero := 0.0
+		stationary[i].Distance = &zero
+	}
+	if _, err := Compare(lap(50, 50, true), stationary, CompareOptions{}); !errors.Is(err, ErrTooFewSamples) {
+		t.Errorf("err = %v, want ErrTooFewSamples for a lap without distance", err)
+	}
+}
@@ -0,0 +1,70 @@
+// Package telemetry downsamples and compares the car telemetry of drivers.
+package telemetry
+
+import (
+	"math"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+)
+
+// LTTB picks threshold of the n points of a series with the Largest
+// Triangle Three Buckets algorithm and returns their indices in order. The
+// first and last points are always kept and every bucket in between keeps
+// the point forming the largest triangle with its neighbours, so peaks and
+// troughs survive. All points are kept when there are no more than threshold
+func LTTB(n, threshold int, x, y func(int) float64) []int {
+	if threshold >= n || threshold < 3 {
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+
+	indices := make([]int, 0, threshold)
+	indices = append(indices, 0)
+	// the points between the first and the last are split into buckets
+	every := float64(n-2) / float64(threshold-2)
+	a := 0
+	for bucket := 0; bucket < threshold-2; bucket++ {
+		next := int(float64(bucket+1)*every) + 1
+		end := min(int(float64(bucket+2)*every)+1, n)
+		avgX, avgY := 0.0, 0.0
+		for i := next; i < end; i++ {
+			avgX += x(i)
+			avgY += y(i)
+		}
+		avgX /= float64(end - next)
+		avgY /= float64(end - next)
+
+		best, largest := -1, -1.0
+		for i := int(float64(bucket)*every) + 1; i < next; i++ {
+			area := math.Abs((x(a)-avgX)*(y(i)-y(a)) - (x(a)-x(i))*(avgY-y(a)))
+			if area > largest {
+				best, largest = i, area
+			}
+		}
+		indices = append(indices, best)
+		a = best
+	}
+	return append(indices, n-1)
+}
+
+// Downsample reduces the samples, in time order, to the given number of
+// points. The points are picked by the speed trace, which follows the
+// corners of the lap most closely, and keep all the channels of the sample
+func Downsample(samples []m.TelemetrySample, points int) []m.TelemetrySample {
+	if points <= 0 || len(samples) <= points {
+		return samples
+	}
+	start := samples[0].Time
+	indices := LTTB(len(samples), points,
+		func(i int) float64 { return samples[i].Time.Sub(start).Seconds() },
+		func(i int) float64 { return float64(samples[i].Speed) },
+	)
+	downsampled := make([]m.TelemetrySample, len(indices))
+	for i, index := range indices {
+		downsampled[i] = samples[index]
+	}
+	return downsampled
+}
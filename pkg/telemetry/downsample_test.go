@@ -0,0 +1,86 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+)
+
+var start = time.Date(2023, 9, 3, 13, 4, 0, 0, time.UTC)
+
+// speedTrace builds samples four times a second from speeds
+func speedTrace(speeds ...int) []m.TelemetrySample {
+	samples := make([]m.TelemetrySample, len(speeds))
+	for i, speed := range speeds {
+		samples[i] = m.TelemetrySample{Time: start.Add(time.Duration(i) * 250 * time.Millisecond), Lap: 1, Speed: speed}
+	}
+	return samples
+}
+
+func TestLTTB(t *testing.T) {
+	ys := []float64{0, 1, 0, 1, 0, 9, 0, 1, 0, 1, 0}
+	x := func(i int) float64 { return float64(i) }
+	y := func(i int) float64 { return ys[i] }
+
+	indices := LTTB(len(ys), 4, x, y)
+	if len(indices) != 4 {
+		t.Fatalf("kept %d points, want 4", len(indices))
+	}
+	if indices[0] != 0 || indices[3] != len(ys)-1 {
+		t.Errorf("indices = %v, want the first and last point kept", indices)
+	}
+	spike := false
+	for i, index := range indices {
+		if i > 0 && index <= indices[i-1] {
+			t.Errorf("indices = %v, want them in order", indices)
+		}
+		spike = spike || index == 5
+	}
+	if !spike {
+		t.Errorf("indices = %v, want the spike at 5 kept", indices)
+	}
+
+	if all := LTTB(len(ys), len(ys), x, y); len(all) != len(ys) {
+		t.Errorf("kept %d of %d points with a threshold of all points", len(all), len(ys))
+	}
+}
+
+func TestDownsample(t *testing.T) {
+	// a straight at 300 km/h with a corner taken at 80 km/h, braking from
+	// sample 180 to the apex at 203 and accelerating out of it until 247
+	speeds := make([]int, 400)
+	for i := range speeds {
+		switch {
+		case i >= 180 && i <= 203:
+			speeds[i] = 300 - (i-180)*220/23
+		case i > 203 && i < 247:
+			speeds[i] = 80 + (i-203)*5
+		default:
+			speeds[i] = 300
+		}
+	}
+	samples := speedTrace(speeds...)
+
+	points := Downsample(samples, 50)
+	if len(points) != 50 {
+		t.Fatalf("got %d points, want 50", len(points))
+	}
+	if !points[0].Time.Equal(samples[0].Time) || !points[49].Time.Equal(samples[399].Time) {
+		t.Error("first and last samples were not kept")
+	}
+	slowest := 300
+	for _, point := range points {
+		slowest = min(slowest, point.Speed)
+	}
+	if slowest != 80 {
+		t.Errorf("slowest point is %d km/h, want the apex at 80 km/h kept", slowest)
+	}
+
+	if got := Downsample(samples[:10], 50); len(got) != 10 {
+		t.Errorf("got %d points from 10 samples, want all of them", len(got))
+	}
+	if got := Downsample(samples, 0); len(got) != len(samples) {
+		t.Errorf("got %d points without a limit, want all %d", len(got), len(samples))
+	}
+}
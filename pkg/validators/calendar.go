@@ -0,0 +1,139 @@
+package validators
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/dewciu/f1_api/pkg/sprint"
+)
+
+const (
+	// Sessions may start from the Thursday before the race date and end
+	// before the Tuesday after it, the margin covers sessions whose UTC
+	// date differs from the local one
+	WeekendDaysBefore = 3
+	WeekendDaysAfter  = 2
+)
+
+type CalendarError struct {
+	Message string
+}
+
+func (e CalendarError) Error() string {
+	return e.Message
+}
+
+func calendarError(format string, args ...interface{}) error {
+	return &CalendarError{Message: fmt.Sprintf(format, args...)}
+}
+
+// WeekendWindow returns the period in which every session of the race has to
+// take place
+func WeekendWindow(race m.Race) (time.Time, time.Time) {
+	date := time.Date(race.Date.Year(), race.Date.Month(), race.Date.Day(), 0, 0, 0, 0, time.UTC)
+	return date.AddDate(0, 0, -WeekendDaysBefore), date.AddDate(0, 0, WeekendDaysAfter)
+}
+
+// weekendSpan returns the period from the start of the first session to the
+// end of the last one, or the race day when no sessions are scheduled
+func weekendSpan(race m.Race) (time.Time, time.Time) {
+	var start, end time.Time
+	for _, session := range race.Sessions {
+		if session.Status == m.SessionCancelled {
+			continue
+		}
+		if start.IsZero() || session.StartTime.Before(start) {
+			start = session.StartTime
+		}
+		if end.IsZero() || session.EndTime.After(end) {
+			end = session.EndTime
+		}
+	}
+
+	if start.IsZero() {
+		date := time.Date(race.Date.Year(), race.Date.Month(), race.Date.Day(), 0, 0, 0, 0, time.UTC)
+		return date, date.AddDate(0, 0, 1)
+	}
+	return start, end
+}
+
+// ValidateRaceWeekend checks that the sessions of the race are within its
+// weekend and do not overlap each other, cancelled sessions are not checked
+// for overlaps, and that sprint sessions follow the format of the season
+func ValidateRaceWeekend(race m.Race) error {
+	windowStart, windowEnd := WeekendWindow(race)
+	types := make(map[string]bool)
+
+	for i, session := range race.Sessions {
+		if types[session.Type] {
+			return calendarError("%s has more than one %s session", race.Name, session.Type)
+		}
+		types[session.Type] = true
+
+		if !session.EndTime.After(session.StartTime) {
+			return calendarError("%s %s session must end after it starts", race.Name, session.Type)
+		}
+		if session.StartTime.Before(windowStart) || session.EndTime.After(windowEnd) {
+			return calendarError("%s %s session is outside of the race weekend", race.Name, session.Type)
+		}
+
+		if session.Status == m.SessionCancelled {
+			continue
+		}
+		for _, other := range race.Sessions[i+1:] {
+			if other.Status != m.SessionCancelled && session.Overlaps(other) {
+				return calendarError("%s %s session overlaps with %s session", race.Name, session.Type, other.Type)
+			}
+		}
+	}
+
+	if err := sprint.Check(race); err != nil {
+		return calendarError("%s", err)
+	}
+
+	return nil
+}
+
+// ValidateCalendar checks every race weekend of a season and that the
+// weekends follow each other without overlapping
+func ValidateCalendar(races []m.Race) error {
+	type span struct {
+		race       m.Race
+		start, end time.Time
+	}
+
+	rounds := make(map[int]bool)
+	spans := make([]span, 0, len(races))
+
+	for _, race := range races {
+		if rounds[race.Round] {
+			return calendarError("round %d is scheduled more than once", race.Round)
+		}
+		rounds[race.Round] = true
+
+		if err := ValidateRaceWeekend(race); err != nil {
+			return err
+		}
+
+		start, end := weekendSpan(race)
+		spans = append(spans, span{race: race, start: start, end: end})
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start.Before(spans[j].start)
+	})
+
+	for i := 1; i < len(spans); i++ {
+		previous, current := spans[i-1], spans[i]
+		if current.start.Before(previous.end) {
+			return calendarError("%s weekend overlaps with %s weekend", current.race.Name, previous.race.Name)
+		}
+		if current.race.Round < previous.race.Round {
+			return calendarError("round %d takes place after round %d", current.race.Round, previous.race.Round)
+		}
+	}
+
+	return nil
+}
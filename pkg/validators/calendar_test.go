@@ -0,0 +1,151 @@
+package validators
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+)
+
+// slot is a session of a test weekend starting the given number of hours
+// before the race
+type slot struct {
+	session     string
+	hoursBefore int
+}
+
+var (
+	conventional   = []slot{{m.SessionFP1, 52}, {m.SessionFP2, 48}, {m.SessionFP3, 28}, {m.SessionQualifying, 24}}
+	sprintShootout = []slot{{m.SessionFP1, 50}, {m.SessionQualifying, 46}, {m.SessionSprintShootout, 27}, {m.SessionSprint, 23}}
+	sprintFirst    = []slot{{m.SessionFP1, 52}, {m.SessionSprintShootout, 48}, {m.SessionSprint, 28}, {m.SessionQualifying, 24}}
+)
+
+// race builds a scheduled race starting at the given time, followed by the
+// sessions of the schedule and lasting an hour each
+func race(name string, round int, start time.Time, schedule []slot) m.Race {
+	r := m.Race{Name: name, Round: round, Year: start.Year(), Date: start}
+	for _, s := range append(schedule, slot{m.SessionRace, 0}) {
+		sessionStart := start.Add(-time.Duration(s.hoursBefore) * time.Hour)
+		r.Sessions = append(r.Sessions, m.Session{
+			Type:      s.session,
+			StartTime: sessionStart,
+			EndTime:   sessionStart.Add(time.Hour),
+			Status:    m.SessionScheduled,
+		})
+	}
+	return r
+}
+
+func saudiArabia(round int) m.Race {
+	return race("Saudi Arabian Grand Prix", round, time.Date(2024, time.March, 9, 17, 0, 0, 0, time.UTC), conventional)
+}
+
+func australia(round int) m.Race {
+	return race("Australian Grand Prix", round, time.Date(2024, time.March, 24, 4, 0, 0, 0, time.UTC), conventional)
+}
+
+func china(round int) m.Race {
+	return race("Chinese Grand Prix", round, time.Date(2024, time.April, 21, 7, 0, 0, 0, time.UTC), sprintFirst)
+}
+
+// expectCalendarError fails unless err is a calendar error containing want,
+// an empty want expecting no error
+func expectCalendarError(t *testing.T, name string, err error, want string) {
+	t.Helper()
+	if want == "" {
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		return
+	}
+	var calendarErr *CalendarError
+	if !errors.As(err, &calendarErr) {
+		t.Errorf("%s: got %v, want a calendar error", name, err)
+		return
+	}
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("%s: got %q, want it to contain %q", name, err, want)
+	}
+}
+
+func TestValidateRaceWeekend(t *testing.T) {
+	overlapping := saudiArabia(1)
+	overlapping.Sessions[1].StartTime = overlapping.Sessions[0].StartTime.Add(30 * time.Minute)
+	overlapping.Sessions[1].EndTime = overlapping.Sessions[1].StartTime.Add(time.Hour)
+
+	cancelledOverlap := saudiArabia(1)
+	cancelledOverlap.Sessions[1] = overlapping.Sessions[1]
+	cancelledOverlap.Sessions[1].Status = m.SessionCancelled
+
+	early := saudiArabia(1)
+	early.Sessions[0].StartTime = time.Date(2024, time.March, 5, 13, 0, 0, 0, time.UTC)
+	early.Sessions[0].EndTime = early.Sessions[0].StartTime.Add(time.Hour)
+
+	late := saudiArabia(1)
+	late.Sessions[4].EndTime = time.Date(2024, time.March, 11, 0, 30, 0, 0, time.UTC)
+
+	backwards := saudiArabia(1)
+	backwards.Sessions[2].EndTime = backwards.Sessions[2].StartTime
+
+	duplicate := saudiArabia(1)
+	duplicate.Sessions[1].Type = m.SessionFP1
+
+	sprintBefore2021 := race("British Grand Prix", 10, time.Date(2020, time.August, 9, 13, 0, 0, 0, time.UTC),
+		[]slot{{m.SessionFP1, 50}, {m.SessionQualifying, 23}, {m.SessionSprint, 20}})
+
+	shootoutIn2022 := race("Austrian Grand Prix", 11, time.Date(2022, time.July, 10, 13, 0, 0, 0, time.UTC), sprintShootout)
+
+	// in the sprint first format of 2024 the sprint runs before qualifying
+	qualifyingFirst := china(5)
+	qualifyingFirst.Sessions[2].StartTime, qualifyingFirst.Sessions[3].StartTime =
+		qualifyingFirst.Sessions[3].StartTime, qualifyingFirst.Sessions[2].StartTime
+	qualifyingFirst.Sessions[2].EndTime = qualifyingFirst.Sessions[2].StartTime.Add(time.Hour)
+	qualifyingFirst.Sessions[3].EndTime = qualifyingFirst.Sessions[3].StartTime.Add(time.Hour)
+
+	tests := []struct {
+		name string
+		race m.Race
+		want string
+	}{
+		{"conventional weekend", saudiArabia(1), ""},
+		{"sprint weekend", china(5), ""},
+		{"overlapping sessions", overlapping, m.SessionFP1 + " session overlaps with " + m.SessionFP2 + " session"},
+		{"overlap with a cancelled session", cancelledOverlap, ""},
+		{"session before the weekend", early, m.SessionFP1 + " session is outside of the race weekend"},
+		{"session after the weekend", late, m.SessionRace + " session is outside of the race weekend"},
+		{"session ending when it starts", backwards, m.SessionFP3 + " session must end after it starts"},
+		{"duplicate session", duplicate, "more than one " + m.SessionFP1 + " session"},
+		{"sprint before sprints existed", sprintBefore2021, "sprints were introduced in 2021"},
+		{"shootout in a season without one", shootoutIn2022, "the 2022 sprint grid is set by"},
+		{"sprint after qualifying in a sprint first season", qualifyingFirst, m.SessionSprint + " session has to run before the " + m.SessionQualifying + " session"},
+	}
+	for _, tt := range tests {
+		expectCalendarError(t, tt.name, ValidateRaceWeekend(tt.race), tt.want)
+	}
+}
+
+func TestValidateCalendar(t *testing.T) {
+	// starts on the Friday of the Saudi Arabian weekend
+	overlapping := race("Australian Grand Prix", 2, time.Date(2024, time.March, 10, 4, 0, 0, 0, time.UTC), conventional)
+
+	invalidWeekend := australia(2)
+	invalidWeekend.Sessions[4].StartTime = invalidWeekend.Sessions[3].StartTime
+
+	tests := []struct {
+		name  string
+		races []m.Race
+		want  string
+	}{
+		{"season", []m.Race{china(5), saudiArabia(1), australia(2)}, ""},
+		{"empty season", nil, ""},
+		{"overlapping weekends", []m.Race{saudiArabia(1), overlapping}, "Australian Grand Prix weekend overlaps with Saudi Arabian Grand Prix weekend"},
+		{"round scheduled twice", []m.Race{saudiArabia(1), australia(1)}, "round 1 is scheduled more than once"},
+		{"rounds out of order", []m.Race{saudiArabia(2), australia(1)}, "round 1 takes place after round 2"},
+		{"invalid weekend", []m.Race{saudiArabia(1), invalidWeekend}, m.SessionQualifying + " session overlaps with " + m.SessionRace + " session"},
+	}
+	for _, tt := range tests {
+		expectCalendarError(t, tt.name, ValidateCalendar(tt.races), tt.want)
+	}
+}
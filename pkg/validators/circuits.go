@@ -0,0 +1,165 @@
+package validators
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+var geometryTypes = map[string]bool{
+	"Point":              true,
+	"MultiPoint":         true,
+	"LineString":         true,
+	"MultiLineString":    true,
+	"Polygon":            true,
+	"MultiPolygon":       true,
+	"GeometryCollection": true,
+}
+
+type CircuitCreateModelValidator struct {
+	Ref       string    `form:"ref" json:"ref" binding:"required,max=255"`
+	ErgastID  *int      `form:"ergast_id" json:"ergast_id" binding:"omitempty,min=1"`
+	Name      string    `form:"name" json:"name" binding:"required,max=255"`
+	Location  string    `form:"location" json:"location" binding:"required,max=255"`
+	Country   string    `form:"country" json:"country" binding:"required,iso3166_1_alpha2"`
+	Latitude  *float64  `form:"latitude" json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude *float64  `form:"longitude" json:"longitude" binding:"required,min=-180,max=180"`
+	URL       string    `form:"url" json:"url" binding:"omitempty,url"`
+	Circuit   m.Circuit `json:"-"`
+} // @name CircuitCreateModelValidator
+
+func (s *CircuitCreateModelValidator) Bind(c *gin.Context) interface{} {
+	err := common.Bind(c, s)
+	customizer := g.Validator(CircuitCreateModelValidator{})
+
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	s.Circuit.ID = uuid.New()
+	s.Circuit.Ref = s.Ref
+	s.Circuit.ErgastID = s.ErgastID
+	s.Circuit.Name = s.Name
+	s.Circuit.Location = s.Location
+	s.Circuit.Country = s.Country
+	s.Circuit.Latitude = *s.Latitude
+	s.Circuit.Longitude = *s.Longitude
+	s.Circuit.URL = s.URL
+
+	return nil
+}
+
+type CircuitUpdateModelValidator struct {
+	Name      string   `json:"name" binding:"omitempty,max=255"`
+	Location  string   `json:"location" binding:"omitempty,max=255"`
+	Country   string   `json:"country" binding:"omitempty,iso3166_1_alpha2"`
+	Latitude  *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	URL       string   `json:"url" binding:"omitempty,url"`
+} // @name CircuitUpdateModelValidator
+
+func (s *CircuitUpdateModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(CircuitUpdateModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	return nil
+}
+
+type CircuitLayoutModelValidator struct {
+	Name          string          `json:"name" binding:"required,max=255"`
+	FirstYear     *int            `json:"first_year" binding:"omitempty,min=1950"`
+	LastYear      *int            `json:"last_year" binding:"omitempty,min=1950"`
+	LengthMeters  int             `json:"length_meters" binding:"required,min=1"`
+	Turns         int             `json:"turns" binding:"required,min=1"`
+	Direction     string          `json:"direction" binding:"required,oneof=clockwise anticlockwise"`
+	Geometry      json.RawMessage `json:"geometry" swaggertype:"object"`
+	CircuitLayout m.CircuitLayout `json:"-"`
+} // @name CircuitLayoutModelValidator
+
+// Bind validates the layout and builds its model, the circuit and the version
+// are assigned when the layout is stored.
+func (s *CircuitLayoutModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(CircuitLayoutModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	if s.FirstYear != nil && s.LastYear != nil && *s.LastYear < *s.FirstYear {
+		return errors.New("last_year must not be before first_year").Error()
+	}
+
+	if len(s.Geometry) > 0 && string(s.Geometry) != "null" {
+		if err := validateGeometry(s.Geometry); err != nil {
+			return err.Error()
+		}
+		s.CircuitLayout.Geometry = m.GeoJSON(s.Geometry)
+	}
+
+	s.CircuitLayout.ID = uuid.New()
+	s.CircuitLayout.Name = s.Name
+	s.CircuitLayout.FirstYear = s.FirstYear
+	s.CircuitLayout.LastYear = s.LastYear
+	s.CircuitLayout.LengthMeters = s.LengthMeters
+	s.CircuitLayout.Turns = s.Turns
+	s.CircuitLayout.Direction = s.Direction
+
+	return nil
+}
+
+func validateGeometry(raw json.RawMessage) error {
+	var geometry struct {
+		Type        string            `json:"type"`
+		Coordinates json.RawMessage   `json:"coordinates"`
+		Geometries  []json.RawMessage `json:"geometries"`
+	}
+
+	if err := json.Unmarshal(raw, &geometry); err != nil {
+		return errors.New("geometry must be a GeoJSON object")
+	}
+	if !geometryTypes[geometry.Type] {
+		return errors.New("geometry must be a GeoJSON geometry object")
+	}
+
+	if geometry.Type == "GeometryCollection" {
+		for _, g := range geometry.Geometries {
+			if err := validateGeometry(g); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var coordinates []interface{}
+	if err := json.Unmarshal(geometry.Coordinates, &coordinates); err != nil || len(coordinates) == 0 {
+		return errors.New("geometry coordinates must be a non empty array")
+	}
+
+	return nil
+}
+
+type NearbyCircuitsValidator struct {
+	Lat      *float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Lng      *float64 `form:"lng" binding:"required,min=-180,max=180"`
+	RadiusKm float64  `form:"radius_km" binding:"required,gt=0,max=20040"`
+} // @name NearbyCircuitsValidator
+
+func (s *NearbyCircuitsValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(NearbyCircuitsValidator{})
+
+	err := c.ShouldBindQuery(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ConstructorCreateModelValidator struct {
+	Ref         string        `form:"ref" json:"ref" binding:"required,max=255"`
+	ErgastID    *int          `form:"ergast_id" json:"ergast_id" binding:"omitempty,min=1"`
+	Name        string        `form:"name" json:"name" binding:"required,max=255"`
+	Nationality string        `form:"nationality" json:"nationality" binding:"required,iso3166_1_alpha2"`
+	URL         string        `form:"url" json:"url" binding:"omitempty,url"`
+	Constructor m.Constructor `json:"-"`
+} // @name ConstructorCreateModelValidator
+
+func (s *ConstructorCreateModelValidator) Bind(c *gin.Context) interface{} {
+	err := common.Bind(c, s)
+	customizer := g.Validator(ConstructorCreateModelValidator{})
+
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	s.Constructor.ID = uuid.New()
+	s.Constructor.Ref = s.Ref
+	s.Constructor.ErgastID = s.ErgastID
+	s.Constructor.Name = s.Name
+	s.Constructor.Nationality = s.Nationality
+	s.Constructor.URL = s.URL
+
+	return nil
+}
+
+type ConstructorUpdateModelValidator struct {
+	Name        string `json:"name" binding:"omitempty,max=255"`
+	Nationality string `json:"nationality" binding:"omitempty,iso3166_1_alpha2"`
+	URL         string `json:"url" binding:"omitempty,url"`
+} // @name ConstructorUpdateModelValidator
+
+func (s *ConstructorUpdateModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(ConstructorUpdateModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	return nil
+}
+
+type LineUpValidator struct {
+	DriverID   string `json:"driver_id" binding:"required,uuid"`
+	CarNumber  int    `json:"car_number" binding:"min=0,max=99"`
+	StartRound int    `json:"start_round" binding:"required,min=1"`
+	EndRound   *int   `json:"end_round" binding:"omitempty,min=1"`
+} // @name LineUpValidator
+
+type SeasonEntryModelValidator struct {
+	EntrantName string            `json:"entrant_name" binding:"required,max=255"`
+	ChassisName string            `json:"chassis_name" binding:"required,max=255"`
+	PowerUnit   string            `json:"power_unit" binding:"required,max=255"`
+	LineUps     []LineUpValidator `json:"line_ups" binding:"required,min=1,dive"`
+	SeasonEntry m.SeasonEntry     `json:"-"`
+} // @name SeasonEntryModelValidator
+
+// Bind validates the entry and builds its model, the constructor and year are
+// taken from the request path and have to be set by the caller.
+func (s *SeasonEntryModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(SeasonEntryModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	s.SeasonEntry.ID = uuid.New()
+	s.SeasonEntry.EntrantName = s.EntrantName
+	s.SeasonEntry.ChassisName = s.ChassisName
+	s.SeasonEntry.PowerUnit = s.PowerUnit
+
+	for _, l := range s.LineUps {
+		if l.EndRound != nil && *l.EndRound < l.StartRound {
+			return errors.New("end_round must not be before start_round").Error()
+		}
+		s.SeasonEntry.LineUps = append(s.SeasonEntry.LineUps, m.LineUp{
+			DriverID:   uuid.MustParse(l.DriverID),
+			CarNumber:  l.CarNumber,
+			StartRound: l.StartRound,
+			EndRound:   l.EndRound,
+		})
+	}
+
+	lineUps := s.SeasonEntry.LineUps
+	for i := range lineUps {
+		for _, other := range lineUps[i+1:] {
+			if lineUps[i].DriverID == other.DriverID && lineUps[i].Overlaps(other) {
+				return fmt.Sprintf("line-ups of driver %s overlap", other.DriverID)
+			}
+		}
+	}
+
+	return nil
+}
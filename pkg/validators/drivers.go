@@ -0,0 +1,106 @@
+package validators
+
+import (
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type DriverCreateModelValidator struct {
+	Ref             string   `form:"ref" json:"ref" binding:"required,max=255"`
+	ErgastID        *int     `form:"ergast_id" json:"ergast_id" binding:"omitempty,min=1"`
+	PermanentNumber *int     `form:"permanent_number" json:"permanent_number" binding:"omitempty,min=1,max=99"`
+	Code            string   `form:"code" json:"code" binding:"omitempty,len=3,alpha,uppercase"`
+	FirstName       string   `form:"first_name" json:"first_name" binding:"required,max=255"`
+	LastName        string   `form:"last_name" json:"last_name" binding:"required,max=255"`
+	DateOfBirth     string   `form:"date_of_birth" json:"date_of_birth" binding:"required,datetime=2006-01-02"`
+	Nationality     string   `form:"nationality" json:"nationality" binding:"required,iso3166_1_alpha2"`
+	URL             string   `form:"url" json:"url" binding:"omitempty,url"`
+	Seasons         []int    `form:"seasons" json:"seasons" binding:"omitempty,dive,min=1950"`
+	Driver          m.Driver `json:"-"`
+} // @name DriverCreateModelValidator
+
+func (s *DriverCreateModelValidator) Bind(c *gin.Context) interface{} {
+	err := common.Bind(c, s)
+	customizer := g.Validator(DriverCreateModelValidator{})
+
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	dob, err := time.Parse(common.DateLayout, s.DateOfBirth)
+	if err != nil {
+		return err.Error()
+	}
+
+	s.Driver.ID = uuid.New()
+	s.Driver.Ref = s.Ref
+	s.Driver.ErgastID = s.ErgastID
+	s.Driver.PermanentNumber = s.PermanentNumber
+	s.Driver.Code = s.Code
+	s.Driver.FirstName = s.FirstName
+	s.Driver.LastName = s.LastName
+	s.Driver.DateOfBirth = dob
+	s.Driver.Nationality = s.Nationality
+	s.Driver.URL = s.URL
+	s.Driver.Seasons = driverSeasons(s.Driver.ID, s.Seasons)
+
+	return nil
+}
+
+type DriverUpdateModelValidator struct {
+	PermanentNumber *int   `json:"permanent_number" binding:"omitempty,min=1,max=99"`
+	Code            string `json:"code" binding:"omitempty,len=3,alpha,uppercase"`
+	FirstName       string `json:"first_name" binding:"omitempty,max=255"`
+	LastName        string `json:"last_name" binding:"omitempty,max=255"`
+	DateOfBirth     string `json:"date_of_birth" binding:"omitempty,datetime=2006-01-02"`
+	Nationality     string `json:"nationality" binding:"omitempty,iso3166_1_alpha2"`
+	URL             string `json:"url" binding:"omitempty,url"`
+	// Seasons replace the seasons of the driver when given
+	Seasons []int `json:"seasons" gorm:"-" binding:"omitempty,dive,min=1950"`
+} // @name DriverUpdateModelValidator
+
+func (s *DriverUpdateModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(DriverUpdateModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	return nil
+}
+
+// driverSeasons returns the seasons of the driver without duplicates
+func driverSeasons(driverID uuid.UUID, years []int) []m.DriverSeason {
+	seasons := []m.DriverSeason{}
+	seen := make(map[int]bool)
+	for _, year := range years {
+		if !seen[year] {
+			seen[year] = true
+			seasons = append(seasons, m.DriverSeason{DriverID: driverID, Year: year})
+		}
+	}
+	return seasons
+}
+
+type DriverFilterValidator struct {
+	Nationality string `form:"nationality"`
+	Code        string `form:"code"`
+	Ref         string `form:"ref"`
+	Season      string `form:"season" binding:"omitempty,numeric"`
+} // @name DriverFilterValidator
+
+func (s *DriverFilterValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(DriverFilterValidator{})
+
+	err := c.ShouldBindQuery(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	return nil
+}
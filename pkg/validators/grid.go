@@ -0,0 +1,50 @@
+package validators
+
+import (
+	"errors"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type GridPenaltyModelValidator struct {
+	DriverID    string        `json:"driver_id" binding:"required,uuid"`
+	Type        string        `json:"type" binding:"required,oneof=grid_drop back_of_grid pit_lane"`
+	Places      int           `json:"places" binding:"min=0,max=99"`
+	Component   string        `json:"component" binding:"omitempty,oneof=power_unit gearbox other"`
+	Reason      string        `json:"reason" binding:"max=255"`
+	InfringedAt time.Time     `json:"infringed_at" binding:"required"`
+	GridPenalty m.GridPenalty `json:"-"`
+} // @name GridPenaltyModelValidator
+
+func (s *GridPenaltyModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(GridPenaltyModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	if s.Type == m.GridPenaltyDrop && s.Places == 0 {
+		return errors.New("grid drops need the number of places").Error()
+	}
+	if s.Type != m.GridPenaltyDrop && s.Places != 0 {
+		return errors.New("places can only be given for grid drops").Error()
+	}
+	if s.Component == "" {
+		s.Component = m.ComponentOther
+	}
+
+	s.GridPenalty = m.GridPenalty{
+		DriverID:    uuid.MustParse(s.DriverID),
+		Type:        s.Type,
+		Places:      s.Places,
+		Component:   s.Component,
+		Reason:      s.Reason,
+		InfringedAt: s.InfringedAt.UTC(),
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package validators
+
+import (
+	"mime/multipart"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ImportUploadValidator struct {
+	Files []*multipart.FileHeader `form:"files" binding:"required,min=1"`
+} // @name ImportUploadValidator
+
+func (s *ImportUploadValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(ImportUploadValidator{})
+
+	err := c.ShouldBind(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	return nil
+}
+
+type ImportReviewFilterValidator struct {
+	Source string `form:"source" binding:"omitempty,oneof=openf1"`
+} // @name ImportReviewFilterValidator
+
+func (s *ImportReviewFilterValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(ImportReviewFilterValidator{})
+
+	err := c.ShouldBindQuery(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	return nil
+}
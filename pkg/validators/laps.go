@@ -0,0 +1,87 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type LapValidator struct {
+	DriverID string     `json:"driver_id" binding:"required,uuid"`
+	Lap      int        `json:"lap" binding:"required,min=1,max=500"`
+	Position int        `json:"position" binding:"required,min=1"`
+	Time     *m.LapTime `json:"time" binding:"required" swaggertype:"string" example:"1:32.238"`
+} // @name LapValidator
+
+type LapsModelValidator struct {
+	Laps   []LapValidator `json:"laps" binding:"required,min=1,dive"`
+	Models []m.Lap        `json:"-"`
+} // @name LapsModelValidator
+
+// Bind validates the submitted laps and builds their models, a driver can
+// only have one time per lap
+func (s *LapsModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(LapsModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	type key struct {
+		driver uuid.UUID
+		lap    int
+	}
+	seen := make(map[key]bool)
+	s.Models = make([]m.Lap, 0, len(s.Laps))
+	for _, l := range s.Laps {
+		if *l.Time <= 0 {
+			return errors.New("lap times must be positive").Error()
+		}
+		lap := m.Lap{
+			DriverID: uuid.MustParse(l.DriverID),
+			Number:   l.Lap,
+			Position: l.Position,
+			Time:     *l.Time,
+		}
+		k := key{lap.DriverID, lap.Number}
+		if seen[k] {
+			return fmt.Sprintf("driver %s has more than one time for lap %d", l.DriverID, l.Lap)
+		}
+		seen[k] = true
+		s.Models = append(s.Models, lap)
+	}
+
+	return nil
+}
+
+type LapsFilterValidator struct {
+	Driver  string `form:"driver" binding:"omitempty,uuid"`
+	FromLap int    `form:"from_lap" binding:"omitempty,min=1"`
+	ToLap   int    `form:"to_lap" binding:"omitempty,min=1"`
+	// ExcludeNeutralized leaves out laps run under the Safety Car, the Virtual
+	// Safety Car or a red flag
+	ExcludeNeutralized bool `form:"exclude_neutralized"`
+	// Weather annotates the laps with the weather observation nearest to
+	// them, ExcludeWet leaves out the laps run in the rain
+	Weather    bool `form:"weather"`
+	ExcludeWet bool `form:"exclude_wet"`
+} // @name LapsFilterValidator
+
+func (s *LapsFilterValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(LapsFilterValidator{})
+
+	err := c.ShouldBindQuery(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	if s.FromLap > 0 && s.ToLap > 0 && s.ToLap < s.FromLap {
+		return errors.New("to_lap cannot be before from_lap").Error()
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package validators
+
+import (
+	"github.com/dewciu/f1_api/pkg/pace"
+	"github.com/gin-gonic/gin"
+)
+
+type PaceFilterValidator struct {
+	// FuelCorrection is the time gained for every lap of fuel burnt, in
+	// seconds
+	FuelCorrection   *float64     `form:"fuel_correction" binding:"omitempty,min=0,max=1"`
+	Model            string       `form:"model" binding:"omitempty,oneof=linear quadratic"`
+	OutlierThreshold *float64     `form:"outlier_threshold" binding:"omitempty,gt=0,max=10"`
+	ExcludeWet       bool         `form:"exclude_wet"`
+	Options          pace.Options `form:"-"`
+} // @name PaceFilterValidator
+
+// Bind validates the query, filling in the defaults of the analysis
+func (s *PaceFilterValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(PaceFilterValidator{})
+
+	err := c.ShouldBindQuery(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	s.Options = pace.DefaultOptions()
+	if s.FuelCorrection != nil {
+		s.Options.FuelCorrection = *s.FuelCorrection
+	}
+	if s.Model != "" {
+		s.Options.Model = s.Model
+	}
+	if s.OutlierThreshold != nil {
+		s.Options.OutlierThreshold = *s.OutlierThreshold
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package validators
+
+import (
+	"errors"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type QualifyingResultValidator struct {
+	DriverID     string     `json:"driver_id" binding:"required,uuid"`
+	CarNumber    int        `json:"car_number" binding:"min=0,max=99"`
+	Position     int        `json:"position" binding:"required,min=1"`
+	Q1           *m.LapTime `json:"q1" swaggertype:"string" example:"1:30.503"`
+	Q2           *m.LapTime `json:"q2" swaggertype:"string" example:"1:30.282"`
+	Q3           *m.LapTime `json:"q3" swaggertype:"string" example:"1:29.708"`
+	Q1Deleted    bool       `json:"q1_deleted"`
+	Q2Deleted    bool       `json:"q2_deleted"`
+	Q3Deleted    bool       `json:"q3_deleted"`
+	EliminatedIn string     `json:"eliminated_in" binding:"omitempty,oneof=Q1 Q2"`
+	Explanation  string     `json:"explanation" binding:"max=255"`
+	Admitted     bool       `json:"admitted"`
+} // @name QualifyingResultValidator
+
+type QualifyingResultsModelValidator struct {
+	Results []QualifyingResultValidator `json:"results" binding:"required,min=1,dive"`
+	Models  []m.QualifyingResult        `json:"-"`
+} // @name QualifyingResultsModelValidator
+
+// Bind validates the submitted qualifying results and builds their models,
+// drivers cannot have times in segments after the one they were eliminated
+// in
+func (s *QualifyingResultsModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(QualifyingResultsModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	for _, r := range s.Results {
+		switch {
+		case r.EliminatedIn == m.SegmentQ1 && (r.Q2 != nil || r.Q3 != nil || r.Q2Deleted || r.Q3Deleted):
+			return errors.New("drivers eliminated in Q1 cannot have Q2 or Q3 laps").Error()
+		case r.EliminatedIn == m.SegmentQ2 && (r.Q3 != nil || r.Q3Deleted):
+			return errors.New("drivers eliminated in Q2 cannot have Q3 laps").Error()
+		}
+
+		s.Models = append(s.Models, m.QualifyingResult{
+			DriverID:     uuid.MustParse(r.DriverID),
+			CarNumber:    r.CarNumber,
+			Position:     r.Position,
+			Q1:           r.Q1,
+			Q2:           r.Q2,
+			Q3:           r.Q3,
+			Q1Deleted:    r.Q1Deleted,
+			Q2Deleted:    r.Q2Deleted,
+			Q3Deleted:    r.Q3Deleted,
+			EliminatedIn: r.EliminatedIn,
+			Explanation:  r.Explanation,
+			Admitted:     r.Admitted,
+		})
+	}
+
+	return nil
+}
+
+// ValidateQualifying checks that every driver was entered for the race and
+// appears once, and that the positions run from 1 without duplicates or gaps
+func ValidateQualifying(results []m.QualifyingResult, entered map[uuid.UUID]bool) error {
+	drivers := make([]uuid.UUID, 0, len(results))
+	positions := make([]int, 0, len(results))
+	for _, result := range results {
+		drivers = append(drivers, result.DriverID)
+		positions = append(positions, result.Position)
+	}
+
+	if err := validateEntries(drivers, entered); err != nil {
+		return err
+	}
+	return validatePositions(positions)
+}
+
+type QualifyingFilterValidator struct {
+	Segment string `form:"segment" binding:"omitempty,oneof=Q1 Q2 Q3"`
+	MaxTime string `form:"max_time"`
+	// ParsedMaxTime is set from MaxTime by Bind
+	ParsedMaxTime *m.LapTime `form:"-" json:"-"`
+} // @name QualifyingFilterValidator
+
+func (s *QualifyingFilterValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(QualifyingFilterValidator{})
+
+	err := c.ShouldBindQuery(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	if s.MaxTime != "" {
+		maxTime, err := m.ParseLapTime(s.MaxTime)
+		if err != nil {
+			return err.Error()
+		}
+		s.ParsedMaxTime = &maxTime
+	}
+
+	return nil
+}
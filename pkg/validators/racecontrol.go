@@ -0,0 +1,65 @@
+package validators
+
+import (
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type RaceControlEventValidator struct {
+	Time     time.Time `json:"time" binding:"required"`
+	Type     string    `json:"type" binding:"required,oneof=green_flag yellow_flag double_yellow_flag red_flag chequered_flag safety_car_deployed safety_car_ending vsc_deployed vsc_ending track_limits_deletion investigation penalty"`
+	Lap      *int      `json:"lap" binding:"omitempty,min=1,max=500"`
+	Sector   *int      `json:"sector" binding:"omitempty,min=1,max=3"`
+	DriverID string    `json:"driver_id" binding:"omitempty,uuid"`
+	Message  string    `json:"message" binding:"max=255"`
+} // @name RaceControlEventValidator
+
+type RaceControlEventsModelValidator struct {
+	Events []RaceControlEventValidator `json:"events" binding:"required,min=1,dive"`
+	Models []m.RaceControlEvent        `json:"-"`
+} // @name RaceControlEventsModelValidator
+
+func (s *RaceControlEventsModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(RaceControlEventsModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	for _, e := range s.Events {
+		event := m.RaceControlEvent{
+			Time:    e.Time.UTC(),
+			Type:    e.Type,
+			Lap:     e.Lap,
+			Sector:  e.Sector,
+			Message: e.Message,
+		}
+		if e.DriverID != "" {
+			driver := uuid.MustParse(e.DriverID)
+			event.DriverID = &driver
+		}
+		s.Models = append(s.Models, event)
+	}
+
+	return nil
+}
+
+type RaceControlFilterValidator struct {
+	Type   string `form:"type" binding:"omitempty,oneof=green_flag yellow_flag double_yellow_flag red_flag chequered_flag safety_car_deployed safety_car_ending vsc_deployed vsc_ending track_limits_deletion investigation penalty"`
+	Driver string `form:"driver" binding:"omitempty,uuid"`
+} // @name RaceControlFilterValidator
+
+func (s *RaceControlFilterValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(RaceControlFilterValidator{})
+
+	err := c.ShouldBindQuery(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	return nil
+}
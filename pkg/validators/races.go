@@ -0,0 +1,166 @@
+package validators
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/common"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SeasonCreateModelValidator struct {
+	Year   int      `form:"year" json:"year" binding:"required,min=1950,max=2100"`
+	URL    string   `form:"url" json:"url" binding:"omitempty,url"`
+	Season m.Season `json:"-"`
+} // @name SeasonCreateModelValidator
+
+func (s *SeasonCreateModelValidator) Bind(c *gin.Context) interface{} {
+	err := common.Bind(c, s)
+	customizer := g.Validator(SeasonCreateModelValidator{})
+
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	s.Season.ID = uuid.New()
+	s.Season.Year = s.Year
+	s.Season.URL = s.URL
+
+	return nil
+}
+
+type RaceCreateModelValidator struct {
+	Round       int      `form:"round" json:"round" binding:"required,min=1"`
+	Name        string   `form:"name" json:"name" binding:"required,max=255"`
+	CircuitID   string   `form:"circuit_id" json:"circuit_id" binding:"required,uuid"`
+	Date        string   `form:"date" json:"date" binding:"required,datetime=2006-01-02"`
+	URL         string   `form:"url" json:"url" binding:"omitempty,url"`
+	PointsScale *float64 `form:"points_scale" json:"points_scale" binding:"omitempty,gt=0,max=2"`
+	Race        m.Race   `json:"-"`
+} // @name RaceCreateModelValidator
+
+// Bind validates the race and builds its model, the year is taken from the
+// request path and has to be set by the caller.
+func (s *RaceCreateModelValidator) Bind(c *gin.Context) interface{} {
+	err := common.Bind(c, s)
+	customizer := g.Validator(RaceCreateModelValidator{})
+
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	date, err := time.Parse(common.DateLayout, s.Date)
+	if err != nil {
+		return err.Error()
+	}
+
+	s.Race.ID = uuid.New()
+	s.Race.Round = s.Round
+	s.Race.Name = s.Name
+	s.Race.CircuitID = uuid.MustParse(s.CircuitID)
+	s.Race.Date = date
+	s.Race.URL = s.URL
+	s.Race.PointsScale = 1
+	if s.PointsScale != nil {
+		s.Race.PointsScale = *s.PointsScale
+	}
+
+	return nil
+}
+
+type RaceUpdateModelValidator struct {
+	Name        string   `json:"name" binding:"omitempty,max=255"`
+	URL         string   `json:"url" binding:"omitempty,url"`
+	PointsScale *float64 `json:"points_scale" binding:"omitempty,gt=0,max=2"`
+} // @name RaceUpdateModelValidator
+
+func (s *RaceUpdateModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(RaceUpdateModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	if s.Name == "" && s.URL == "" && s.PointsScale == nil {
+		return errors.New("nothing to update").Error()
+	}
+
+	return nil
+}
+
+type SessionCreateModelValidator struct {
+	Type      string     `json:"type" binding:"required,oneof=FP1 FP2 FP3 SPRINT_SHOOTOUT SPRINT QUALIFYING RACE"`
+	StartTime time.Time  `json:"start_time" binding:"required"`
+	EndTime   *time.Time `json:"end_time"`
+	Status    string     `json:"status" binding:"omitempty,oneof=scheduled live finished cancelled"`
+	Session   m.Session  `json:"-"`
+} // @name SessionCreateModelValidator
+
+// Bind validates the session and builds its model, when no end time is given
+// the usual length of the session type is assumed.
+func (s *SessionCreateModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(SessionCreateModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	s.Session.ID = uuid.New()
+	s.Session.Type = s.Type
+	s.Session.StartTime = s.StartTime.UTC()
+	if s.EndTime != nil {
+		s.Session.EndTime = s.EndTime.UTC()
+	} else {
+		s.Session.EndTime = s.Session.StartTime.Add(m.SessionDurations[s.Type])
+	}
+	s.Session.Status = s.Status
+	if s.Session.Status == "" {
+		s.Session.Status = m.SessionScheduled
+	}
+
+	return nil
+}
+
+type SessionUpdateModelValidator struct {
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+	Status    string     `json:"status" binding:"omitempty,oneof=scheduled live finished cancelled"`
+} // @name SessionUpdateModelValidator
+
+func (s *SessionUpdateModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(SessionUpdateModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	if s.StartTime == nil && s.EndTime == nil && s.Status == "" {
+		return errors.New("nothing to update").Error()
+	}
+
+	return nil
+}
+
+// Apply returns the session with the requested changes, moving a session
+// without a new end time keeps its length.
+func (s *SessionUpdateModelValidator) Apply(session m.Session) m.Session {
+	length := session.EndTime.Sub(session.StartTime)
+
+	if s.StartTime != nil {
+		session.StartTime = s.StartTime.UTC()
+		session.EndTime = session.StartTime.Add(length)
+	}
+	if s.EndTime != nil {
+		session.EndTime = s.EndTime.UTC()
+	}
+	if s.Status != "" {
+		session.Status = s.Status
+	}
+
+	return session
+}
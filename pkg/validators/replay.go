@@ -0,0 +1,89 @@
+package validators
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/replay"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type ReplayStreamValidator struct {
+	Speed float64 `form:"speed" binding:"omitempty,gt=0,max=100"`
+	// From is the offset into the race in seconds the replay starts at
+	From   float64 `form:"from" binding:"omitempty,min=0"`
+	Paused bool    `form:"paused"`
+} // @name ReplayStreamValidator
+
+func (s *ReplayStreamValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(ReplayStreamValidator{})
+
+	err := c.ShouldBindQuery(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	return nil
+}
+
+// Start returns the playback the replay starts with
+func (s *ReplayStreamValidator) Start() replay.Control {
+	start := replay.Control{Offset: seconds(s.From), Speed: s.Speed}
+	if s.Paused {
+		start.Action = replay.ActionPause
+	}
+	return start
+}
+
+type ReplayControlValidator struct {
+	Action string `json:"action" binding:"required,oneof=pause resume seek speed" example:"seek"`
+	// Offset is the offset into the race in seconds to seek to
+	Offset *float64 `json:"offset" binding:"omitempty,min=0" example:"1800"`
+	Speed  float64  `json:"speed" binding:"omitempty,gt=0,max=100" example:"4"`
+} // @name ReplayControlValidator
+
+func (s *ReplayControlValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(ReplayControlValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	return s.validate()
+}
+
+// Validate checks a control received over a WebSocket
+func (s *ReplayControlValidator) Validate() interface{} {
+	customizer := g.Validator(ReplayControlValidator{})
+
+	if err := binding.Validator.ValidateStruct(s); err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	return s.validate()
+}
+
+func (s *ReplayControlValidator) validate() interface{} {
+	if s.Action == replay.ActionSeek && s.Offset == nil {
+		return errors.New("seek needs an offset").Error()
+	}
+	if s.Action == replay.ActionSpeed && s.Speed == 0 {
+		return errors.New("speed needs a speed").Error()
+	}
+	return nil
+}
+
+// Control returns the validated control
+func (s *ReplayControlValidator) Control() replay.Control {
+	control := replay.Control{Action: s.Action, Speed: s.Speed}
+	if s.Offset != nil {
+		control.Offset = seconds(*s.Offset)
+	}
+	return control
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
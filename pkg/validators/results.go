@@ -0,0 +1,145 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ClassificationError struct {
+	Message string
+}
+
+func (e ClassificationError) Error() string {
+	return e.Message
+}
+
+func classificationError(format string, args ...interface{}) error {
+	return &ClassificationError{Message: fmt.Sprintf(format, args...)}
+}
+
+type ResultValidator struct {
+	DriverID       string     `json:"driver_id" binding:"required,uuid"`
+	CarNumber      int        `json:"car_number" binding:"min=0,max=99"`
+	Grid           int        `json:"grid" binding:"min=0"`
+	Position       *int       `json:"position" binding:"omitempty,min=1"`
+	PositionText   string     `json:"position_text" binding:"omitempty,oneof=R D W N E F"`
+	Laps           int        `json:"laps" binding:"min=0"`
+	TimeMillis     *int64     `json:"time_millis" binding:"omitempty,min=1"`
+	Gap            string     `json:"gap" binding:"max=32"`
+	Status         string     `json:"status" binding:"required,max=64"`
+	Points         float64    `json:"points" binding:"min=0"`
+	FastestLap     *int       `json:"fastest_lap" binding:"omitempty,min=1"`
+	FastestLapTime *m.LapTime `json:"fastest_lap_time" swaggertype:"string" example:"1:23.456"`
+	FastestLapRank *int       `json:"fastest_lap_rank" binding:"omitempty,min=1"`
+} // @name ResultValidator
+
+type SessionResultsModelValidator struct {
+	Results []ResultValidator `json:"results" binding:"required,min=1,dive"`
+	Models  []m.Result        `json:"-"`
+} // @name SessionResultsModelValidator
+
+// Bind validates the submitted results and builds their models, each result
+// needs either a classified position or the position text explaining why the
+// driver was not classified. Race, session and constructor are assigned when
+// the results are stored.
+func (s *SessionResultsModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(SessionResultsModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	for _, r := range s.Results {
+		if (r.Position == nil) == (r.PositionText == "") {
+			return errors.New("each result needs either position or position_text").Error()
+		}
+
+		result := m.Result{
+			DriverID:       uuid.MustParse(r.DriverID),
+			CarNumber:      r.CarNumber,
+			Grid:           r.Grid,
+			Position:       r.Position,
+			PositionText:   r.PositionText,
+			Classified:     r.Position != nil,
+			Laps:           r.Laps,
+			TimeMillis:     r.TimeMillis,
+			Gap:            r.Gap,
+			Status:         r.Status,
+			Points:         r.Points,
+			FastestLap:     r.FastestLap,
+			FastestLapTime: r.FastestLapTime,
+			FastestLapRank: r.FastestLapRank,
+		}
+		if r.Position != nil {
+			result.PositionText = strconv.Itoa(*r.Position)
+		}
+		s.Models = append(s.Models, result)
+	}
+
+	return nil
+}
+
+// ValidateEntrant checks that the driver was entered for the race
+func ValidateEntrant(driver uuid.UUID, entered map[uuid.UUID]bool) error {
+	if !entered[driver] {
+		return classificationError("driver %s is not entered for the race", driver)
+	}
+	return nil
+}
+
+// validateEntries checks that every driver was entered for the race and
+// appears once
+func validateEntries(drivers []uuid.UUID, entered map[uuid.UUID]bool) error {
+	seen := make(map[uuid.UUID]bool)
+	for _, driver := range drivers {
+		if err := ValidateEntrant(driver, entered); err != nil {
+			return err
+		}
+		if seen[driver] {
+			return classificationError("driver %s is classified more than once", driver)
+		}
+		seen[driver] = true
+	}
+	return nil
+}
+
+// validatePositions checks that the positions run from 1 without duplicates
+// or gaps
+func validatePositions(positions []int) error {
+	sort.Ints(positions)
+	for i, position := range positions {
+		if i > 0 && position == positions[i-1] {
+			return classificationError("position %d is given more than once", position)
+		}
+		if position != i+1 {
+			return classificationError("classification is not contiguous, position %d is missing", i+1)
+		}
+	}
+	return nil
+}
+
+// ValidateClassification checks that every driver was entered for the race
+// and appears once, and that the classified positions run from 1 without
+// duplicates or gaps
+func ValidateClassification(results []m.Result, entered map[uuid.UUID]bool) error {
+	drivers := make([]uuid.UUID, 0, len(results))
+	positions := []int{}
+	for _, result := range results {
+		drivers = append(drivers, result.DriverID)
+		if result.Position != nil {
+			positions = append(positions, *result.Position)
+		}
+	}
+
+	if err := validateEntries(drivers, entered); err != nil {
+		return err
+	}
+	return validatePositions(positions)
+}
@@ -0,0 +1,70 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+func TestValidateClassification(t *testing.T) {
+	drivers := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+	entered := map[uuid.UUID]bool{}
+	for _, driver := range drivers {
+		entered[driver] = true
+	}
+	outsider := uuid.New()
+
+	// classification returns a result per driver, a zero position leaving the
+	// driver unclassified
+	classification := func(drivers []uuid.UUID, positions ...int) []m.Result {
+		results := make([]m.Result, len(drivers))
+		for i, driver := range drivers {
+			results[i] = m.Result{DriverID: driver}
+			if positions[i] > 0 {
+				position := positions[i]
+				results[i].Position = &position
+			}
+		}
+		return results
+	}
+
+	tests := []struct {
+		name    string
+		results []m.Result
+		want    string
+	}{
+		{"full classification", classification(drivers, 1, 2, 3, 4), ""},
+		{"positions out of order", classification(drivers, 3, 1, 4, 2), ""},
+		{"unclassified drivers", classification(drivers, 1, 2, 0, 0), ""},
+		{"empty classification", nil, ""},
+		{"duplicate driver", classification([]uuid.UUID{drivers[0], drivers[1], drivers[0]}, 1, 2, 3),
+			fmt.Sprintf("driver %s is classified more than once", drivers[0])},
+		{"unclassified duplicate driver", classification([]uuid.UUID{drivers[0], drivers[1], drivers[1]}, 1, 2, 0),
+			fmt.Sprintf("driver %s is classified more than once", drivers[1])},
+		{"missing position", classification(drivers, 1, 2, 4, 5), "position 3 is missing"},
+		{"not starting from first", classification(drivers, 2, 3, 4, 5), "position 1 is missing"},
+		{"gap behind unclassified drivers", classification(drivers, 1, 3, 0, 0), "position 2 is missing"},
+		{"duplicate position", classification(drivers, 1, 2, 2, 3), "position 2 is given more than once"},
+		{"non-entrant", classification([]uuid.UUID{drivers[0], outsider}, 1, 2),
+			fmt.Sprintf("driver %s is not entered for the race", outsider)},
+		{"unclassified non-entrant", classification([]uuid.UUID{drivers[0], outsider}, 1, 0),
+			fmt.Sprintf("driver %s is not entered for the race", outsider)},
+	}
+	for _, tt := range tests {
+		err := ValidateClassification(tt.results, entered)
+		if tt.want == "" {
+			if err != nil {
+				t.Errorf("%s: %v", tt.name, err)
+			}
+			continue
+		}
+		var classificationErr *ClassificationError
+		if !errors.As(err, &classificationErr) || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got %v, want a classification error containing %q", tt.name, err, tt.want)
+		}
+	}
+}
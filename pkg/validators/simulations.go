@@ -0,0 +1,82 @@
+package validators
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const DefaultSimulationRuns = 10000
+
+type SimulationOverrideValidator struct {
+	DriverID       string   `json:"driver_id" binding:"required,uuid"`
+	StrengthFactor *float64 `json:"strength_factor" binding:"omitempty,gt=0,max=100"`
+	DNFProbability *float64 `json:"dnf_probability" binding:"omitempty,min=0,max=1"`
+} // @name SimulationOverrideValidator
+
+type SimulationCreateModelValidator struct {
+	Runs          int                           `json:"runs" binding:"omitempty,min=1,max=100000"`
+	Seed          *int64                        `json:"seed"`
+	Decay         *float64                      `json:"decay" binding:"omitempty,gt=0,max=1"`
+	StrengthPrior *float64                      `json:"strength_prior" binding:"omitempty,gt=0,max=100"`
+	DNFPrior      *float64                      `json:"dnf_prior" binding:"omitempty,gt=0,max=100"`
+	Overrides     []SimulationOverrideValidator `json:"overrides" binding:"omitempty,dive"`
+	Simulation    m.Simulation                  `json:"-"`
+} // @name SimulationCreateModelValidator
+
+// Bind validates the simulation settings, model settings left out stay zero.
+// A seed is drawn when none is given so the run can be repeated
+func (s *SimulationCreateModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(SimulationCreateModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	s.Simulation = m.Simulation{
+		Runs:   DefaultSimulationRuns,
+		Seed:   time.Now().UnixNano(),
+		Status: m.SimulationPending,
+	}
+	if s.Runs > 0 {
+		s.Simulation.Runs = s.Runs
+	}
+	if s.Seed != nil {
+		s.Simulation.Seed = *s.Seed
+	}
+	if s.Decay != nil {
+		s.Simulation.Decay = *s.Decay
+	}
+	if s.StrengthPrior != nil {
+		s.Simulation.StrengthPrior = *s.StrengthPrior
+	}
+	if s.DNFPrior != nil {
+		s.Simulation.DNFPrior = *s.DNFPrior
+	}
+
+	overrides := []m.SimulationOverride{}
+	seen := make(map[uuid.UUID]bool)
+	for _, o := range s.Overrides {
+		override := m.SimulationOverride{
+			DriverID:       uuid.MustParse(o.DriverID),
+			StrengthFactor: o.StrengthFactor,
+			DNFProbability: o.DNFProbability,
+		}
+		if seen[override.DriverID] {
+			return errors.New("driver " + o.DriverID + " is overridden more than once").Error()
+		}
+		seen[override.DriverID] = true
+		overrides = append(overrides, override)
+	}
+	s.Simulation.Overrides, err = json.Marshal(overrides)
+	if err != nil {
+		return err.Error()
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package validators
+
+import (
+	"errors"
+
+	st "github.com/dewciu/f1_api/pkg/standings"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StandingsFilterValidator struct {
+	AfterRound int `form:"after_round" binding:"omitempty,min=1"`
+} // @name StandingsFilterValidator
+
+func (s *StandingsFilterValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(StandingsFilterValidator{})
+
+	err := c.ShouldBindQuery(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	return nil
+}
+
+type ScenarioValidator struct {
+	Round      int      `json:"round" binding:"required,min=1"`
+	Race       []string `json:"race" binding:"required,min=1,dive,uuid"`
+	Sprint     []string `json:"sprint" binding:"omitempty,dive,uuid"`
+	FastestLap string   `json:"fastest_lap" binding:"omitempty,uuid"`
+} // @name ScenarioValidator
+
+type ContendersWhatIfValidator struct {
+	Races     []ScenarioValidator `json:"races" binding:"required,min=1,dive"`
+	Scenarios []st.Scenario       `json:"-"`
+} // @name ContendersWhatIfValidator
+
+// Bind validates the hypothetical classifications of upcoming races, drivers
+// are listed in finishing order
+func (s *ContendersWhatIfValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(ContendersWhatIfValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	parse := func(ids []string) []uuid.UUID {
+		parsed := []uuid.UUID{}
+		for _, id := range ids {
+			parsed = append(parsed, uuid.MustParse(id))
+		}
+		return parsed
+	}
+
+	for _, race := range s.Races {
+		scenario := st.Scenario{Round: race.Round, Race: parse(race.Race), Sprint: parse(race.Sprint)}
+		if race.FastestLap != "" {
+			fastestLap := uuid.MustParse(race.FastestLap)
+			scenario.FastestLap = &fastestLap
+		}
+		s.Scenarios = append(s.Scenarios, scenario)
+	}
+
+	return nil
+}
+
+type HeadToHeadFilterValidator struct {
+	DriverA string `form:"driver_a" binding:"required,uuid"`
+	DriverB string `form:"driver_b" binding:"required,uuid"`
+	// AnyPair compares the drivers in every round they both took part in,
+	// not only those in which they were teammates
+	AnyPair bool `form:"any_pair"`
+} // @name HeadToHeadFilterValidator
+
+func (s *HeadToHeadFilterValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(HeadToHeadFilterValidator{})
+
+	err := c.ShouldBindQuery(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	if uuid.MustParse(s.DriverA) == uuid.MustParse(s.DriverB) {
+		return errors.New("driver_a and driver_b must be different drivers").Error()
+	}
+
+	return nil
+}
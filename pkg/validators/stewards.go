@@ -0,0 +1,160 @@
+package validators
+
+import (
+	"errors"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type StewardDecisionModelValidator struct {
+	Session      string     `json:"session" binding:"omitempty,oneof=RACE SPRINT"`
+	DriverID     string     `json:"driver_id" binding:"required,uuid"`
+	Document     string     `json:"document" binding:"required,max=16"`
+	Infringement string     `json:"infringement" binding:"required,max=255"`
+	Penalty      string     `json:"penalty" binding:"required,oneof=time disqualification reprimand warning fine"`
+	Value        float64    `json:"value" binding:"min=0"`
+	IssuedAt     *time.Time `json:"issued_at"`
+	// SessionType is set from Session by Bind, the race by default
+	SessionType string            `json:"-"`
+	Decision    m.StewardDecision `json:"-"`
+} // @name StewardDecisionModelValidator
+
+// Bind validates the decision and builds its model, time penalties are given
+// in seconds and fines in euros. Decisions are issued now unless issued_at
+// says otherwise. Session and race are assigned when the decision is stored
+func (s *StewardDecisionModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(StewardDecisionModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	valued := s.Penalty == m.PenaltyTime || s.Penalty == m.PenaltyFine
+	if valued && s.Value == 0 {
+		return errors.New("time penalties and fines need a value").Error()
+	}
+	if !valued && s.Value != 0 {
+		return errors.New("value can only be given for time penalties and fines").Error()
+	}
+
+	issuedAt := time.Now().UTC()
+	if s.IssuedAt != nil {
+		if s.IssuedAt.After(issuedAt) {
+			return errors.New("issued_at cannot be in the future").Error()
+		}
+		issuedAt = s.IssuedAt.UTC()
+	}
+
+	s.SessionType = s.Session
+	if s.SessionType == "" {
+		s.SessionType = m.SessionRace
+	}
+
+	s.Decision = m.StewardDecision{
+		DriverID:     uuid.MustParse(s.DriverID),
+		Document:     s.Document,
+		Infringement: s.Infringement,
+		Penalty:      s.Penalty,
+		Value:        s.Value,
+		Status:       m.DecisionIssued,
+		IssuedAt:     issuedAt,
+	}
+
+	return nil
+}
+
+type StewardDecisionStatusValidator struct {
+	Status string     `json:"status" binding:"required,oneof=appealed upheld overturned"`
+	At     *time.Time `json:"at"`
+	// ParsedAt is set from At by Bind, now by default
+	ParsedAt time.Time `json:"-"`
+} // @name StewardDecisionStatusValidator
+
+func (s *StewardDecisionStatusValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(StewardDecisionStatusValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	s.ParsedAt = time.Now().UTC()
+	if s.At != nil {
+		if s.At.After(s.ParsedAt) {
+			return errors.New("at cannot be in the future").Error()
+		}
+		s.ParsedAt = s.At.UTC()
+	}
+
+	return nil
+}
+
+// ValidateDecisionStatus checks that the decision can move to the status at
+// the time. Issued decisions can be appealed or overturned on review,
+// appealed ones are upheld or overturned
+func ValidateDecisionStatus(decision m.StewardDecision, status string, at time.Time) error {
+	allowed := map[string][]string{
+		m.DecisionIssued:   {m.DecisionAppealed, m.DecisionOverturned},
+		m.DecisionAppealed: {m.DecisionUpheld, m.DecisionOverturned},
+	}
+
+	valid := false
+	for _, next := range allowed[decision.Status] {
+		valid = valid || next == status
+	}
+	if !valid {
+		return classificationError("a decision %s cannot be %s", decision.Status, status)
+	}
+
+	last := decision.IssuedAt
+	if decision.AppealedAt != nil {
+		last = *decision.AppealedAt
+	}
+	if at.Before(last) {
+		return classificationError("decision cannot be %s before it was %s", status, decision.Status)
+	}
+	return nil
+}
+
+type ResultsFilterValidator struct {
+	AsOf string `form:"as_of"`
+	// ParsedAsOf is set from AsOf by Bind
+	ParsedAsOf *time.Time `form:"-" json:"-"`
+} // @name ResultsFilterValidator
+
+func (s *ResultsFilterValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(ResultsFilterValidator{})
+
+	err := c.ShouldBindQuery(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	if s.AsOf != "" {
+		asOf, err := time.Parse(time.RFC3339, s.AsOf)
+		if err != nil {
+			return errors.New("as_of must be an RFC 3339 time, e.g. 2023-03-05T18:00:00Z").Error()
+		}
+		asOf = asOf.UTC()
+		s.ParsedAsOf = &asOf
+	}
+
+	return nil
+}
+
+// ValidateAmendment checks that a decision changing the classification names
+// a driver of the classification and was issued after the classification was
+// submitted
+func ValidateAmendment(decision m.StewardDecision, classified map[uuid.UUID]bool, submittedAt time.Time) error {
+	if !classified[decision.DriverID] {
+		return classificationError("driver %s has no result in the session", decision.DriverID)
+	}
+	if !decision.IssuedAt.After(submittedAt) {
+		return classificationError("decision has to be issued after the classification it amends, submitted at %s", submittedAt.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type PitStopValidator struct {
+	DriverID   string     `json:"driver_id" binding:"required,uuid"`
+	Stop       int        `json:"stop" binding:"required,min=1"`
+	Lap        int        `json:"lap" binding:"required,min=1,max=500"`
+	TimeOfDay  string     `json:"time_of_day" example:"14:35:12"`
+	Stationary *m.LapTime `json:"stationary" swaggertype:"string" example:"2.4"`
+	PitLane    *m.LapTime `json:"pit_lane" swaggertype:"string" example:"21.873"`
+} // @name PitStopValidator
+
+type PitStopsModelValidator struct {
+	PitStops []PitStopValidator `json:"pit_stops" binding:"required,dive"`
+	Models   []m.PitStop        `json:"-"`
+} // @name PitStopsModelValidator
+
+// Bind validates the pit stops of a race, every driver's stops are numbered
+// from 1 in lap order
+func (s *PitStopsModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(PitStopsModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	byDriver := make(map[uuid.UUID][]m.PitStop)
+	for _, p := range s.PitStops {
+		if p.TimeOfDay != "" {
+			if _, err := time.Parse("15:04:05", p.TimeOfDay); err != nil {
+				return errors.New("time_of_day must be given as HH:MM:SS").Error()
+			}
+		}
+		if (p.Stationary != nil && *p.Stationary <= 0) || (p.PitLane != nil && *p.PitLane <= 0) {
+			return errors.New("pit stop times must be positive").Error()
+		}
+
+		stop := m.PitStop{
+			DriverID:   uuid.MustParse(p.DriverID),
+			Stop:       p.Stop,
+			Lap:        p.Lap,
+			TimeOfDay:  p.TimeOfDay,
+			Stationary: p.Stationary,
+			PitLane:    p.PitLane,
+		}
+		byDriver[stop.DriverID] = append(byDriver[stop.DriverID], stop)
+		s.Models = append(s.Models, stop)
+	}
+
+	for driver, stops := range byDriver {
+		sort.Slice(stops, func(i, j int) bool { return stops[i].Stop < stops[j].Stop })
+		for i, stop := range stops {
+			if stop.Stop != i+1 {
+				return fmt.Sprintf("stops of driver %s must be numbered from 1 without gaps", driver)
+			}
+			if i > 0 && stop.Lap < stops[i-1].Lap {
+				return fmt.Sprintf("stops of driver %s must be numbered in lap order", driver)
+			}
+		}
+	}
+
+	return nil
+}
+
+type StintValidator struct {
+	DriverID string `json:"driver_id" binding:"required,uuid"`
+	Number   int    `json:"number" binding:"required,min=1"`
+	Compound string `json:"compound" binding:"required,oneof=HYPERSOFT ULTRASOFT SUPERSOFT SOFT MEDIUM HARD SUPERHARD INTERMEDIATE WET UNKNOWN"`
+	New      bool   `json:"new"`
+	StartLap int    `json:"start_lap" binding:"required,min=1,max=500"`
+	EndLap   int    `json:"end_lap" binding:"required,min=1,max=500"`
+} // @name StintValidator
+
+type StintsModelValidator struct {
+	Stints []StintValidator `json:"stints" binding:"required,dive"`
+	Models []m.Stint        `json:"-"`
+} // @name StintsModelValidator
+
+// Bind validates the stints of a race, every driver's stints are numbered
+// from 1 and follow each other without overlapping
+func (s *StintsModelValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(StintsModelValidator{})
+
+	err := c.ShouldBindJSON(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	byDriver := make(map[uuid.UUID][]m.Stint)
+	for _, st := range s.Stints {
+		if st.EndLap < st.StartLap {
+			return errors.New("stints cannot end before they start").Error()
+		}
+
+		stint := m.Stint{
+			DriverID: uuid.MustParse(st.DriverID),
+			Number:   st.Number,
+			Compound: st.Compound,
+			New:      st.New,
+			StartLap: st.StartLap,
+			EndLap:   st.EndLap,
+		}
+		byDriver[stint.DriverID] = append(byDriver[stint.DriverID], stint)
+		s.Models = append(s.Models, stint)
+	}
+
+	for driver, stints := range byDriver {
+		sort.Slice(stints, func(i, j int) bool { return stints[i].Number < stints[j].Number })
+		for i, stint := range stints {
+			if stint.Number != i+1 {
+				return fmt.Sprintf("stints of driver %s must be numbered from 1 without gaps", driver)
+			}
+			if i > 0 && stint.StartLap <= stints[i-1].EndLap {
+				return fmt.Sprintf("stints of driver %s overlap", driver)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package validators
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/golodash/galidator"
+	"github.com/google/uuid"
+)
+
+// maxTelemetryLine bounds the length of a line of a telemetry stream
+const maxTelemetryLine = 64 * 1024
+
+type TelemetrySampleValidator struct {
+	DriverID string     `json:"driver_id" binding:"required,uuid"`
+	Time     *time.Time `json:"time" binding:"required" example:"2023-09-03T13:04:12.250Z"`
+	Lap      int        `json:"lap" binding:"required,min=1,max=500"`
+	Distance *float64   `json:"distance" binding:"omitempty,min=0"`
+	Speed    int        `json:"speed" binding:"min=0,max=400"`
+	RPM      int        `json:"rpm" binding:"min=0,max=20000"`
+	Gear     int        `json:"gear" binding:"min=0,max=8"`
+	Throttle int        `json:"throttle" binding:"min=0,max=100"`
+	Brake    bool       `json:"brake"`
+	DRS      bool       `json:"drs"`
+} // @name TelemetrySampleValidator
+
+// TelemetryLineError rejects a line of a telemetry stream, Reason holds the
+// validation errors of the sample
+type TelemetryLineError struct {
+	Line   int         `json:"line"`
+	Reason interface{} `json:"reason"`
+}
+
+func (e *TelemetryLineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Reason)
+}
+
+// TelemetryReader reads the samples of a stream of newline delimited JSON
+// objects one at a time, so that streams of any length are validated and
+// stored without being held in memory
+type TelemetryReader struct {
+	scanner    *bufio.Scanner
+	line       int
+	customizer galidator.Validator
+	// Entered rejects the samples of drivers not entered for the race when set
+	Entered map[uuid.UUID]bool
+	// Err is the error which ended the stream, a *TelemetryLineError when a
+	// line was rejected
+	Err error
+}
+
+func NewTelemetryReader(r io.Reader) *TelemetryReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxTelemetryLine)
+	return &TelemetryReader{scanner: scanner, customizer: g.Validator(TelemetrySampleValidator{})}
+}
+
+// Next returns the sample of the next line, blank lines are skipped. It
+// returns false at the end of the stream or when a line is rejected
+func (tr *TelemetryReader) Next() (m.TelemetrySample, bool) {
+	for tr.Err == nil && tr.scanner.Scan() {
+		tr.line++
+		line := bytes.TrimSpace(tr.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		sample, err := tr.sample(line)
+		if err != nil {
+			tr.Err = &TelemetryLineError{Line: tr.line, Reason: err}
+			return m.TelemetrySample{}, false
+		}
+		return sample, true
+	}
+	if tr.Err == nil {
+		tr.Err = tr.scanner.Err()
+		if errors.Is(tr.Err, bufio.ErrTooLong) {
+			tr.Err = &TelemetryLineError{Line: tr.line + 1, Reason: fmt.Sprintf("line is longer than %d bytes", maxTelemetryLine)}
+		}
+	}
+	return m.TelemetrySample{}, false
+}
+
+func (tr *TelemetryReader) sample(line []byte) (m.TelemetrySample, interface{}) {
+	var s TelemetrySampleValidator
+	if err := json.Unmarshal(line, &s); err != nil {
+		return m.TelemetrySample{}, "line is not a telemetry sample: " + err.Error()
+	}
+	if err := binding.Validator.ValidateStruct(&s); err != nil {
+		return m.TelemetrySample{}, tr.customizer.DecryptErrors(err)
+	}
+
+	sample := m.TelemetrySample{
+		DriverID: uuid.MustParse(s.DriverID),
+		Time:     s.Time.UTC(),
+		Lap:      s.Lap,
+		Distance: s.Distance,
+		Speed:    s.Speed,
+		RPM:      s.RPM,
+		Gear:     s.Gear,
+		Throttle: s.Throttle,
+		Brake:    s.Brake,
+		DRS:      s.DRS,
+	}
+	if tr.Entered != nil {
+		if err := ValidateEntrant(sample.DriverID, tr.Entered); err != nil {
+			return m.TelemetrySample{}, err.Error()
+		}
+	}
+	return sample, nil
+}
+
+type TelemetryFilterValidator struct {
+	Driver string `form:"driver" binding:"required,uuid"`
+	Lap    int    `form:"lap" binding:"omitempty,min=1"`
+	From   string `form:"from"`
+	To     string `form:"to"`
+	// Points downsamples the samples to at most this many points
+	Points int `form:"points" binding:"omitempty,min=3,max=10000"`
+	// ParsedFrom and ParsedTo are set from From and To by Bind
+	ParsedFrom *time.Time `form:"-" json:"-"`
+	ParsedTo   *time.Time `form:"-" json:"-"`
+} // @name TelemetryFilterValidator
+
+func (s *TelemetryFilterValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(TelemetryFilterValidator{})
+
+	err := c.ShouldBindQuery(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	if s.ParsedFrom, err = parseBound("from", s.From); err != nil {
+		return err.Error()
+	}
+	if s.ParsedTo, err = parseBound("to", s.To); err != nil {
+		return err.Error()
+	}
+
+	if s.Lap > 0 && (s.ParsedFrom != nil || s.ParsedTo != nil) {
+		return errors.New("lap cannot be combined with from and to").Error()
+	}
+	if s.ParsedFrom != nil && s.ParsedTo != nil && s.ParsedTo.Before(*s.ParsedFrom) {
+		return errors.New("to cannot be before from").Error()
+	}
+
+	return nil
+}
+
+// parseBound parses an optional RFC 3339 time of a query parameter
+func parseBound(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 time, e.g. 2023-09-03T13:04:00Z", name)
+	}
+	t = t.UTC()
+	return &t, nil
+}
+
+type TelemetryCompareValidator struct {
+	DriverA string `form:"driver_a" binding:"required,uuid"`
+	LapA    int    `form:"lap_a" binding:"required,min=1"`
+	DriverB string `form:"driver_b" binding:"required,uuid"`
+	LapB    int    `form:"lap_b" binding:"required,min=1"`
+	// Step is the spacing of the distance grid in metres
+	Step        float64 `form:"step" binding:"omitempty,min=1,max=500"`
+	MiniSectors int     `form:"mini_sectors" binding:"omitempty,min=1,max=100"`
+} // @name TelemetryCompareValidator
+
+func (s *TelemetryCompareValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(TelemetryCompareValidator{})
+
+	err := c.ShouldBindQuery(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	return nil
+}
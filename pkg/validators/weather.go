@@ -0,0 +1,37 @@
+package validators
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WeatherFilterValidator struct {
+	From string `form:"from"`
+	To   string `form:"to"`
+	// ParsedFrom and ParsedTo are set from From and To by Bind
+	ParsedFrom *time.Time `form:"-" json:"-"`
+	ParsedTo   *time.Time `form:"-" json:"-"`
+} // @name WeatherFilterValidator
+
+func (s *WeatherFilterValidator) Bind(c *gin.Context) interface{} {
+	customizer := g.Validator(WeatherFilterValidator{})
+
+	err := c.ShouldBindQuery(s)
+	if err != nil {
+		return customizer.DecryptErrors(err)
+	}
+
+	if s.ParsedFrom, err = parseBound("from", s.From); err != nil {
+		return err.Error()
+	}
+	if s.ParsedTo, err = parseBound("to", s.To); err != nil {
+		return err.Error()
+	}
+	if s.ParsedFrom != nil && s.ParsedTo != nil && s.ParsedTo.Before(*s.ParsedFrom) {
+		return errors.New("to cannot be before from").Error()
+	}
+
+	return nil
+}
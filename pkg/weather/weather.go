@@ -0,0 +1,142 @@
+// Package weather summarizes the conditions of a session and matches laps to
+// the weather they were run in.
+package weather
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/laps"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+// Range is the spread of a measure over the observations
+type Range struct {
+	Min  float64
+	Max  float64
+	Mean float64
+}
+
+type Summary struct {
+	Observations     int
+	From             time.Time
+	To               time.Time
+	AirTemperature   Range
+	TrackTemperature Range
+	Humidity         Range
+	Pressure         Range
+	WindSpeed        Range
+	// WindDirection is the mean direction of the wind weighted by its speed,
+	// nil when the winds cancel out
+	WindDirection *int
+	Rainfall      bool
+	// WetShare is the share of the observations reporting rainfall
+	WetShare float64
+}
+
+// Summarize returns the spread of the conditions over the observations,
+// which are in time order
+func Summarize(observations []m.WeatherObservation) Summary {
+	summary := Summary{Observations: len(observations)}
+	if len(observations) == 0 {
+		return summary
+	}
+	summary.From = observations[0].Time
+	summary.To = observations[len(observations)-1].Time
+
+	measure := func(value func(m.WeatherObservation) float64) Range {
+		r := Range{Min: math.Inf(1), Max: math.Inf(-1)}
+		for _, observation := range observations {
+			v := value(observation)
+			r.Min = math.Min(r.Min, v)
+			r.Max = math.Max(r.Max, v)
+			r.Mean += v
+		}
+		r.Mean /= float64(len(observations))
+		return r
+	}
+	summary.AirTemperature = measure(func(o m.WeatherObservation) float64 { return o.AirTemperature })
+	summary.TrackTemperature = measure(func(o m.WeatherObservation) float64 { return o.TrackTemperature })
+	summary.Humidity = measure(func(o m.WeatherObservation) float64 { return o.Humidity })
+	summary.Pressure = measure(func(o m.WeatherObservation) float64 { return o.Pressure })
+	summary.WindSpeed = measure(func(o m.WeatherObservation) float64 { return o.WindSpeed })
+
+	// directions are averaged as vectors, so that 350 and 10 degrees give 0
+	var north, east float64
+	wet := 0
+	for _, observation := range observations {
+		angle := float64(observation.WindDirection) * math.Pi / 180
+		north += observation.WindSpeed * math.Cos(angle)
+		east += observation.WindSpeed * math.Sin(angle)
+		if observation.Rainfall {
+			wet++
+		}
+	}
+	if math.Hypot(north, east) > 1e-9 {
+		direction := int(math.Round(math.Atan2(east, north)*180/math.Pi+360)) % 360
+		summary.WindDirection = &direction
+	}
+	summary.Rainfall = wet > 0
+	summary.WetShare = float64(wet) / float64(len(observations))
+	return summary
+}
+
+// Nearest returns the observation closest to the time, the observations
+// being in time order
+func Nearest(observations []m.WeatherObservation, at time.Time) (m.WeatherObservation, bool) {
+	if len(observations) == 0 {
+		return m.WeatherObservation{}, false
+	}
+	i := sort.Search(len(observations), func(i int) bool { return !observations[i].Time.Before(at) })
+	switch {
+	case i == 0:
+		return observations[0], true
+	case i == len(observations):
+		return observations[i-1], true
+	}
+	if at.Sub(observations[i-1].Time) <= observations[i].Time.Sub(at) {
+		return observations[i-1], true
+	}
+	return observations[i], true
+}
+
+// LapKey identifies the lap of a driver
+type LapKey struct {
+	DriverID uuid.UUID
+	Lap      int
+}
+
+// LapConditions returns the observation nearest to the middle of every lap.
+// Laps are placed from the start of the session by the cumulative lap times
+// of their driver, so the laps given have to start from lap 1 and laps after
+// a missing one have no observation
+func LapConditions(start time.Time, raceLaps []m.Lap, observations []m.WeatherObservation) map[LapKey]m.WeatherObservation {
+	conditions := make(map[LapKey]m.WeatherObservation)
+	if len(observations) == 0 {
+		return conditions
+	}
+	for driver, totals := range laps.Totals(raceLaps) {
+		var previous m.LapTime
+		for i, total := range totals {
+			middle := start.Add((previous + (total-previous)/2).Duration())
+			observation, _ := Nearest(observations, middle)
+			conditions[LapKey{DriverID: driver, Lap: i + 1}] = observation
+			previous = total
+		}
+	}
+	return conditions
+}
+
+// ExcludeWet returns the laps not run in the rain, laps without an
+// observation are kept
+func ExcludeWet(raceLaps []m.Lap, conditions map[LapKey]m.WeatherObservation) []m.Lap {
+	kept := []m.Lap{}
+	for _, lap := range raceLaps {
+		if !conditions[LapKey{DriverID: lap.DriverID, Lap: lap.Number}].Rainfall {
+			kept = append(kept, lap)
+		}
+	}
+	return kept
+}
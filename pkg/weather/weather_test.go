@@ -0,0 +1,99 @@
+package weather
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/google/uuid"
+)
+
+var start = time.Date(2024, 7, 7, 14, 0, 0, 0, time.UTC)
+
+// observations returns a sample a minute from the start, raining from the
+// given minute on
+func observations(minutes, rainFrom int) []m.WeatherObservation {
+	list := []m.WeatherObservation{}
+	for i := 0; i < minutes; i++ {
+		list = append(list, m.WeatherObservation{
+			Time:             start.Add(time.Duration(i) * time.Minute),
+			AirTemperature:   20 + float64(i),
+			TrackTemperature: 30,
+			WindSpeed:        2,
+			WindDirection:    []int{350, 10}[i%2],
+			Rainfall:         i >= rainFrom,
+		})
+	}
+	return list
+}
+
+func TestSummarize(t *testing.T) {
+	summary := Summarize(observations(4, 3))
+	if summary.Observations != 4 || !summary.From.Equal(start) || !summary.To.Equal(start.Add(3*time.Minute)) {
+		t.Errorf("summary covers %d observations from %v to %v", summary.Observations, summary.From, summary.To)
+	}
+	if air := summary.AirTemperature; air.Min != 20 || air.Max != 23 || air.Mean != 21.5 {
+		t.Errorf("air temperature is %+v", air)
+	}
+	if summary.WindDirection == nil || *summary.WindDirection != 0 {
+		t.Errorf("wind direction is %v, want 0 between 350 and 10 degrees", summary.WindDirection)
+	}
+	if !summary.Rainfall || math.Abs(summary.WetShare-0.25) > 1e-9 {
+		t.Errorf("rainfall %v with wet share %v, want one wet sample of four", summary.Rainfall, summary.WetShare)
+	}
+
+	if empty := Summarize(nil); empty.Observations != 0 || empty.Rainfall {
+		t.Errorf("summary of no observations is %+v", empty)
+	}
+}
+
+func TestNearest(t *testing.T) {
+	list := observations(3, 3)
+	cases := []struct {
+		at   time.Duration
+		want int
+	}{
+		{-time.Minute, 0},
+		{29 * time.Second, 0},
+		{31 * time.Second, 1},
+		{2 * time.Minute, 2},
+		{time.Hour, 2},
+	}
+	for _, tc := range cases {
+		got, ok := Nearest(list, start.Add(tc.at))
+		if !ok || !got.Time.Equal(list[tc.want].Time) {
+			t.Errorf("nearest to %v is %v, want observation %d", tc.at, got.Time, tc.want)
+		}
+	}
+	if _, ok := Nearest(nil, start); ok {
+		t.Error("found an observation in none")
+	}
+}
+
+func TestLapConditions(t *testing.T) {
+	driver := uuid.New()
+	raceLaps := []m.Lap{}
+	for lap := 1; lap <= 5; lap++ {
+		raceLaps = append(raceLaps, m.Lap{DriverID: driver, Number: lap, Time: m.LapTime(90 * time.Second)})
+	}
+	// the laps end after 1:30, 3:00, 4:30, 6:00 and 7:30, it rains from 5:00
+	conditions := LapConditions(start, raceLaps, observations(10, 5))
+
+	if len(conditions) != 5 {
+		t.Fatalf("got conditions of %d laps, want 5", len(conditions))
+	}
+	// lap 3 runs from 3:00 to 4:30, its middle 3:45 is nearest to 4:00
+	if got := conditions[LapKey{driver, 3}]; !got.Time.Equal(start.Add(4 * time.Minute)) {
+		t.Errorf("lap 3 matched %v", got.Time)
+	}
+
+	dry := ExcludeWet(raceLaps, conditions)
+	if len(dry) != 3 || dry[len(dry)-1].Number != 3 {
+		t.Errorf("kept %d dry laps, want laps 1 to 3", len(dry))
+	}
+
+	if got := LapConditions(start, raceLaps, nil); len(got) != 0 {
+		t.Errorf("matched laps without observations: %v", got)
+	}
+}
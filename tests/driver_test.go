@@ -0,0 +1,237 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/config"
+	"github.com/dewciu/f1_api/pkg/database"
+	"github.com/dewciu/f1_api/pkg/live"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/dewciu/f1_api/pkg/routes"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	tc "github.com/testcontainers/testcontainers-go"
+	"gorm.io/gorm"
+)
+
+type DriverTestSuite struct {
+	suite.Suite
+	db           *gorm.DB
+	pgContainter tc.Container
+	ctx          context.Context
+	router       *gin.Engine
+	baseHeader   http.Header
+}
+
+func (suite *DriverTestSuite) SetupSuite() {
+	suite.db, suite.pgContainter, suite.ctx = SetupDB([]string{"drivers"})
+	// the seeded season is removed so that every test creates its own drivers
+	suite.db.Exec("TRUNCATE TABLE drivers CASCADE")
+	suite.router = routes.SetupRouter(suite.db, live.NewHub(database.NewLiveRepository(suite.db), live.Options{}), &config.Config{})
+	suite.baseHeader = http.Header{
+		"Authorization": []string{Authenticate(suite.router)},
+		"Content-Type":  []string{"application/json"},
+	}
+}
+
+func (suite *DriverTestSuite) request(method, url string, body interface{}) *httptest.ResponseRecorder {
+	jsonData, _ := json.Marshal(body)
+	req, _ := http.NewRequest(method, url, bytes.NewBuffer(jsonData))
+	req.Header = suite.baseHeader
+
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *DriverTestSuite) TestSuccessfulDriverCreation() {
+	var created s.DriverResponse
+	var dbDriver m.Driver
+	driver := map[string]interface{}{
+		"ref":              "hamilton",
+		"permanent_number": 44,
+		"code":             "HAM",
+		"first_name":       "Lewis",
+		"last_name":        "Hamilton",
+		"date_of_birth":    "1985-01-07",
+		"nationality":      "GB",
+	}
+
+	w := suite.request(http.MethodPost, "/api/v1/drivers/", driver)
+
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Nil(json.Unmarshal(w.Body.Bytes(), &created))
+	suite.Equal("HAM", created.Code)
+	suite.Equal("1985-01-07", created.DateOfBirth)
+
+	err := suite.db.Where("id = ?", created.ID).First(&dbDriver).Error
+	suite.Nil(err)
+	suite.Equal("hamilton", dbDriver.Ref)
+	suite.Equal(44, *dbDriver.PermanentNumber)
+}
+
+func (suite *DriverTestSuite) TestFailedDriverCreationBadRequest() {
+	testCases := []map[string]interface{}{
+		{
+			"ref":           "verstappen",
+			"code":          "VERS",
+			"first_name":    "Max",
+			"last_name":     "Verstappen",
+			"date_of_birth": "1997-09-30",
+			"nationality":   "NL",
+		},
+		{
+			"ref":           "verstappen",
+			"code":          "VER",
+			"first_name":    "Max",
+			"last_name":     "Verstappen",
+			"date_of_birth": "1997-09-30",
+			"nationality":   "Dutch",
+		},
+	}
+
+	for _, driver := range testCases {
+		w := suite.request(http.MethodPost, "/api/v1/drivers/", driver)
+		suite.Equal(http.StatusBadRequest, w.Code)
+	}
+}
+
+func (suite *DriverTestSuite) TestFilterDriversByNationality() {
+	dob, _ := time.Parse("2006-01-02", "1981-07-29")
+	suite.db.Create(&m.Driver{Ref: "alonso", FirstName: "Fernando", LastName: "Alonso", DateOfBirth: dob, Nationality: "ES"})
+	suite.db.Create(&m.Driver{Ref: "sainz", FirstName: "Carlos", LastName: "Sainz", DateOfBirth: dob, Nationality: "ES"})
+	suite.db.Create(&m.Driver{Ref: "norris", FirstName: "Lando", LastName: "Norris", DateOfBirth: dob, Nationality: "GB"})
+
+	var drivers []s.DriverResponse
+	w := suite.request(http.MethodGet, "/api/v1/drivers/?nationality=ES&limit=1", nil)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Nil(json.Unmarshal(w.Body.Bytes(), &drivers))
+	suite.Len(drivers, 1)
+	suite.Equal("alonso", drivers[0].Ref)
+}
+
+func (suite *DriverTestSuite) TestFilterDriversBySeason() {
+	dob, _ := time.Parse("2006-01-02", "1981-07-29")
+	suite.db.Create(&m.Driver{Ref: "alonso", FirstName: "Fernando", LastName: "Alonso", DateOfBirth: dob, Nationality: "ES",
+		Seasons: []m.DriverSeason{{Year: 2005}, {Year: 2023}}})
+	suite.db.Create(&m.Driver{Ref: "button", FirstName: "Jenson", LastName: "Button", DateOfBirth: dob, Nationality: "GB",
+		Seasons: []m.DriverSeason{{Year: 2005}}})
+
+	var drivers []s.DriverResponse
+	w := suite.request(http.MethodGet, "/api/v1/drivers/?season=2023", nil)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Nil(json.Unmarshal(w.Body.Bytes(), &drivers))
+	suite.Len(drivers, 1)
+	suite.Equal("alonso", drivers[0].Ref)
+	suite.Equal([]int{2005, 2023}, drivers[0].Seasons)
+}
+
+func (suite *DriverTestSuite) TestFilterDriversBySeasonBadRequest() {
+	for _, season := range []string{"twenty", "2023;", "2023a"} {
+		w := suite.request(http.MethodGet, "/api/v1/drivers/?season="+season, nil)
+		suite.Equal(http.StatusBadRequest, w.Code)
+	}
+}
+
+// enter creates a season entry of a new constructor with a line-up for each
+// of the drivers
+func (suite *DriverTestSuite) enter(year int, drivers ...m.Driver) {
+	constructor := m.Constructor{Ref: uuid.NewString(), Name: "Constructor"}
+	suite.Nil(suite.db.Create(&constructor).Error)
+	entry := m.SeasonEntry{ConstructorID: constructor.ID, Year: year}
+	for _, driver := range drivers {
+		entry.LineUps = append(entry.LineUps, m.LineUp{DriverID: driver.ID, StartRound: 1})
+	}
+	suite.Nil(suite.db.Create(&entry).Error)
+}
+
+func (suite *DriverTestSuite) TestFilterDriversBySeasonLineUps() {
+	dob, _ := time.Parse("2006-01-02", "1997-09-30")
+	verstappen := m.Driver{Ref: "max_verstappen", FirstName: "Max", LastName: "Verstappen", DateOfBirth: dob, Nationality: "NL"}
+	deVries := m.Driver{Ref: "de_vries", FirstName: "Nyck", LastName: "de Vries", DateOfBirth: dob, Nationality: "NL"}
+	vanDerGarde := m.Driver{Ref: "van_der_garde", FirstName: "Giedo", LastName: "van der Garde", DateOfBirth: dob, Nationality: "NL"}
+	norris := m.Driver{Ref: "norris", FirstName: "Lando", LastName: "Norris", DateOfBirth: dob, Nationality: "GB"}
+	for _, driver := range []*m.Driver{&verstappen, &deVries, &vanDerGarde, &norris} {
+		suite.Nil(suite.db.Create(driver).Error)
+	}
+	suite.enter(2023, verstappen, norris)
+	suite.enter(2023, deVries)
+	suite.enter(2013, vanDerGarde)
+
+	var drivers []s.DriverResponse
+	w := suite.request(http.MethodGet, "/api/v1/drivers/?season=2023&nationality=NL", nil)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Nil(json.Unmarshal(w.Body.Bytes(), &drivers))
+	suite.Len(drivers, 2)
+	suite.Equal("de_vries", drivers[0].Ref)
+	suite.Equal("max_verstappen", drivers[1].Ref)
+
+	w = suite.request(http.MethodGet, "/api/v1/drivers/?season=2013", nil)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Nil(json.Unmarshal(w.Body.Bytes(), &drivers))
+	suite.Len(drivers, 1)
+	suite.Equal("van_der_garde", drivers[0].Ref)
+
+	w = suite.request(http.MethodGet, "/api/v1/drivers/?season=1949", nil)
+
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Nil(json.Unmarshal(w.Body.Bytes(), &drivers))
+	suite.Len(drivers, 0)
+}
+
+func (suite *DriverTestSuite) TestOverlappingLineUpsBadRequest() {
+	dob, _ := time.Parse("2006-01-02", "1989-07-01")
+	driver := m.Driver{Ref: "ricciardo", FirstName: "Daniel", LastName: "Ricciardo", DateOfBirth: dob, Nationality: "AU"}
+	suite.Nil(suite.db.Create(&driver).Error)
+	constructor := m.Constructor{Ref: "alphatauri", Name: "AlphaTauri"}
+	suite.Nil(suite.db.Create(&constructor).Error)
+
+	lineUp := func(start int, end interface{}) map[string]interface{} {
+		return map[string]interface{}{"driver_id": driver.ID, "car_number": 3, "start_round": start, "end_round": end}
+	}
+	entry := func(lineUps ...map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"entrant_name": "Scuderia AlphaTauri",
+			"chassis_name": "AT04",
+			"power_unit":   "Honda RBPT",
+			"line_ups":     lineUps,
+		}
+	}
+	url := "/api/v1/constructors/" + constructor.ID.String() + "/seasons/2023"
+
+	for _, lineUps := range [][]map[string]interface{}{
+		{lineUp(1, 10), lineUp(10, nil)},
+		{lineUp(5, nil), lineUp(1, 8)},
+		{lineUp(3, 6), lineUp(4, 5)},
+	} {
+		w := suite.request(http.MethodPut, url, entry(lineUps...))
+		suite.Equal(http.StatusBadRequest, w.Code, lineUps)
+	}
+
+	w := suite.request(http.MethodPut, url, entry(lineUp(1, 4), lineUp(11, nil)))
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *DriverTestSuite) TearDownTest() {
+	suite.db.Exec("TRUNCATE TABLE drivers, constructors CASCADE")
+}
+
+func (suite *DriverTestSuite) TearDownSuite() {
+	suite.pgContainter.Terminate(suite.ctx)
+}
+
+func TestDriverTestSuite(t *testing.T) {
+	suite.Run(t, new(DriverTestSuite))
+}
@@ -1,13 +1,18 @@
 package tests
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"time"
 
 	"github.com/dewciu/f1_api/pkg/config"
 	"github.com/dewciu/f1_api/pkg/migrations"
 	"github.com/dewciu/f1_api/pkg/seeding"
+	"github.com/gin-gonic/gin"
 	tc "github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 	"gorm.io/driver/postgres"
@@ -63,3 +68,22 @@ func SetupDB(tablesAffected []string) (*gorm.DB, tc.Container, context.Context)
 
 	return db, pg, ctx
 }
+
+// Authenticate logs in as the seeded admin user and returns its token
+func Authenticate(router *gin.Engine) string {
+	loginData := map[string]string{
+		"password": "admin",
+		"username": "admin",
+	}
+	loginJson, _ := json.Marshal(loginData)
+	loginReq, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewBuffer(loginJson))
+	loginReq.Header.Set("Content-Type", "application/json")
+
+	loginW := httptest.NewRecorder()
+	router.ServeHTTP(loginW, loginReq)
+
+	var response map[string]string
+	json.Unmarshal(loginW.Body.Bytes(), &response)
+
+	return response["token"]
+}
@@ -0,0 +1,149 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dewciu/f1_api/pkg/config"
+	"github.com/dewciu/f1_api/pkg/database"
+	"github.com/dewciu/f1_api/pkg/live"
+	m "github.com/dewciu/f1_api/pkg/models"
+	"github.com/dewciu/f1_api/pkg/routes"
+	s "github.com/dewciu/f1_api/pkg/serializers"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	tc "github.com/testcontainers/testcontainers-go"
+	"gorm.io/gorm"
+)
+
+type TelemetryTestSuite struct {
+	suite.Suite
+	db           *gorm.DB
+	pgContainter tc.Container
+	ctx          context.Context
+	router       *gin.Engine
+	token        string
+	driver       m.Driver
+}
+
+func (suite *TelemetryTestSuite) SetupSuite() {
+	suite.db, suite.pgContainter, suite.ctx = SetupDB([]string{"telemetry_samples"})
+	suite.router = routes.SetupRouter(suite.db, live.NewHub(database.NewLiveRepository(suite.db), live.Options{}), &config.Config{})
+	suite.token = Authenticate(suite.router)
+	suite.Nil(suite.db.Where("ref = ?", "max_verstappen").First(&suite.driver).Error)
+}
+
+// session returns the race session of the round of the seeded season
+func (suite *TelemetryTestSuite) session(round int) m.Session {
+	var session m.Session
+	err := suite.db.Joins("JOIN races ON races.id = sessions.race_id").
+		Where("races.year = 2023 AND races.round = ? AND sessions.type = ?", round, m.SessionRace).
+		First(&session).Error
+	suite.Nil(err)
+	return session
+}
+
+// samples returns a line of the stream for each of the seconds after the
+// start of the session
+func (suite *TelemetryTestSuite) samples(session m.Session, seconds ...int) []string {
+	lines := []string{}
+	for _, second := range seconds {
+		line, _ := json.Marshal(map[string]interface{}{
+			"driver_id": suite.driver.ID,
+			"time":      session.StartTime.Add(time.Duration(second) * time.Second),
+			"lap":       1,
+			"speed":     250 + second,
+			"gear":      7,
+			"throttle":  100,
+		})
+		lines = append(lines, string(line))
+	}
+	return lines
+}
+
+func (suite *TelemetryTestSuite) stream(session m.Session, lines []string) *httptest.ResponseRecorder {
+	body := bytes.NewBufferString(strings.Join(lines, "\n") + "\n")
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/sessions/"+session.ID.String()+"/telemetry", body)
+	req.Header.Set("Authorization", suite.token)
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *TelemetryTestSuite) stored(session m.Session) int64 {
+	var count int64
+	suite.Nil(suite.db.Model(&m.TelemetrySample{}).Where("session_id = ?", session.ID).Count(&count).Error)
+	return count
+}
+
+func (suite *TelemetryTestSuite) TestStreamSkipsStoredSamples() {
+	session := suite.session(1)
+	var copied s.TelemetryCopyResponse
+
+	w := suite.stream(session, suite.samples(session, 1, 2, 3))
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Nil(json.Unmarshal(w.Body.Bytes(), &copied))
+	suite.Equal(int64(3), copied.Received)
+	suite.Equal(int64(3), copied.Stored)
+
+	w = suite.stream(session, suite.samples(session, 1, 2, 3, 4))
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Nil(json.Unmarshal(w.Body.Bytes(), &copied))
+	suite.Equal(int64(4), copied.Received)
+	suite.Equal(int64(1), copied.Stored)
+	suite.Equal(int64(3), copied.Duplicates)
+	suite.Equal(int64(4), suite.stored(session))
+}
+
+func (suite *TelemetryTestSuite) TestRejectedStreamLeavesNoPartition() {
+	session := suite.session(2)
+	lines := append(suite.samples(session, 1, 2), `{"driver_id": "not a driver"}`)
+
+	w := suite.stream(session, lines)
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Equal(int64(0), suite.stored(session))
+
+	var partition *string
+	suite.Nil(suite.db.Raw("SELECT to_regclass(?)::text", fmt.Sprintf("telemetry_samples_%s", strings.ReplaceAll(session.ID.String(), "-", ""))).Scan(&partition).Error)
+	suite.Nil(partition)
+}
+
+func (suite *TelemetryTestSuite) TestConcurrentStreamsOfNewSession() {
+	session := suite.session(3)
+	streams := 4
+
+	var wg sync.WaitGroup
+	codes := make(chan int, streams)
+	for i := 0; i < streams; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			codes <- suite.stream(session, suite.samples(session, i*10+1, i*10+2)).Code
+		}(i)
+	}
+	wg.Wait()
+	close(codes)
+
+	for code := range codes {
+		suite.Equal(http.StatusCreated, code)
+	}
+	suite.Equal(int64(streams*2), suite.stored(session))
+}
+
+func (suite *TelemetryTestSuite) TearDownSuite() {
+	suite.pgContainter.Terminate(suite.ctx)
+}
+
+func TestTelemetryTestSuite(t *testing.T) {
+	suite.Run(t, new(TelemetryTestSuite))
+}
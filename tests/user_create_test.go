@@ -9,6 +9,9 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/dewciu/f1_api/pkg/config"
+	"github.com/dewciu/f1_api/pkg/database"
+	"github.com/dewciu/f1_api/pkg/live"
 	m "github.com/dewciu/f1_api/pkg/models"
 	"github.com/dewciu/f1_api/pkg/routes"
 	"github.com/gin-gonic/gin"
@@ -29,7 +32,7 @@ type UserCreateTestSuite struct {
 func (suite *UserCreateTestSuite) SetupSuite() {
 	// Setup the test environment
 	suite.db, suite.pgContainter, suite.ctx = SetupDB([]string{"users"})
-	suite.router = routes.SetupRouter(suite.db)
+	suite.router = routes.SetupRouter(suite.db, live.NewHub(database.NewLiveRepository(suite.db), live.Options{}), &config.Config{})
 	fmt.Println(suite.db)
 	token := suite.authenticate()
 	fmt.Println("tokee")